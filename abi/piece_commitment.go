@@ -0,0 +1,340 @@
+package abi
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"io"
+	"math/bits"
+
+	cid "github.com/ipfs/go-cid"
+	mh "github.com/multiformats/go-multihash"
+	"golang.org/x/xerrors"
+)
+
+// Multihash code of the FRC-0069 fr32-sha2-256-trunc254-padded-binary-tree hash, used by v2 piece CIDs.
+const PieceMultihashCode = 0x1011
+
+// Size in bytes of a node of the piece merkle tree.
+const pieceNodeSize = 32
+
+// Height of the largest supported piece tree, whose padded size of 2^63 bytes is the largest
+// power of two representable as a PaddedPieceSize.
+const maxPieceTreeHeight = 58
+
+// Size of the payload of the largest supported piece.
+const maxPiecePayloadSize = uint64(pieceNodeSize) << maxPieceTreeHeight / 128 * 127
+
+type pieceNode [pieceNodeSize]byte
+
+// Commitments to the all-zero subtree at each height.
+var zeroPieceNodes = func() [maxPieceTreeHeight + 1]pieceNode {
+	var out [maxPieceTreeHeight + 1]pieceNode
+	for i := 1; i < len(out); i++ {
+		out[i] = hashPieceNodes(&out[i-1], &out[i-1])
+	}
+	return out
+}()
+
+func hashPieceNodes(left, right *pieceNode) pieceNode {
+	var buf [2 * pieceNodeSize]byte
+	copy(buf[:pieceNodeSize], left[:])
+	copy(buf[pieceNodeSize:], right[:])
+	out := pieceNode(sha256.Sum256(buf[:]))
+	out[pieceNodeSize-1] &= 0x3f // truncate to 254 bits
+	return out
+}
+
+// Inserts 2 zero bits after every 254 bits of a 127 byte chunk, producing four 32 byte nodes.
+func fr32Pad(in *[127]byte, out *[4]pieceNode) {
+	copy(out[0][:31], in[:31])
+	out[0][31] = in[31] & 0x3f
+	t := in[31] >> 6
+
+	var v byte
+	for i := 32; i < 64; i++ {
+		v = in[i]
+		out[1][i-32] = (v << 2) | t
+		t = v >> 6
+	}
+	out[1][31] &= 0x3f
+	t = v >> 4
+
+	for i := 64; i < 96; i++ {
+		v = in[i]
+		out[2][i-64] = (v << 4) | t
+		t = v >> 4
+	}
+	out[2][31] &= 0x3f
+	t = v >> 2
+
+	for i := 96; i < 127; i++ {
+		v = in[i]
+		out[3][i-96] = (v << 6) | t
+		t = v >> 2
+	}
+	out[3][31] = t & 0x3f
+}
+
+// PieceWriter computes the commitment (CommP) of the data written to it, streaming the data through
+// fr32 padding and a SHA2-256-trunc254 binary merkle tree.
+// The zero value is ready to use.
+type PieceWriter struct {
+	// Buffered bytes of an incomplete 127 byte chunk.
+	buf    [127]byte
+	bufLen int
+	// Number of bytes written.
+	size uint64
+	// Number of leaf nodes added to the tree.
+	leaves uint64
+	// Pending left nodes at each height of the tree.
+	layers [maxPieceTreeHeight + 1]pieceNode
+	// Bit i is set when layers[i] holds a pending node.
+	pending uint64
+}
+
+var _ io.Writer = (*PieceWriter)(nil)
+
+func (w *PieceWriter) Write(p []byte) (int, error) {
+	if w.size+uint64(len(p)) > maxPiecePayloadSize {
+		return 0, xerrors.Errorf("writing %d bytes would exceed the maximum piece size", len(p))
+	}
+	n := len(p)
+	w.size += uint64(n)
+	for len(p) > 0 {
+		copied := copy(w.buf[w.bufLen:], p)
+		w.bufLen += copied
+		p = p[copied:]
+		if w.bufLen == len(w.buf) {
+			w.addChunk()
+		}
+	}
+	return n, nil
+}
+
+// Size returns the number of bytes written, i.e. the payload size of the piece.
+func (w *PieceWriter) Size() uint64 {
+	return w.size
+}
+
+func (w *PieceWriter) addChunk() {
+	var nodes [4]pieceNode
+	fr32Pad(&w.buf, &nodes)
+	for i := range nodes {
+		w.addNode(0, &nodes[i])
+	}
+	w.leaves += 4
+	w.bufLen = 0
+}
+
+func (w *PieceWriter) addNode(height int, node *pieceNode) {
+	for w.pending&(1<<height) != 0 {
+		*node = hashPieceNodes(&w.layers[height], node)
+		w.pending &^= 1 << height
+		height++
+	}
+	w.layers[height] = *node
+	w.pending |= 1 << height
+}
+
+// Sum returns the piece of the data written so far, zero-padded to the smallest valid piece size.
+// Further writes may follow.
+func (w *PieceWriter) Sum() (PieceInfo, error) {
+	c := *w
+	if c.bufLen > 0 || c.leaves == 0 {
+		for i := c.bufLen; i < len(c.buf); i++ {
+			c.buf[i] = 0
+		}
+		c.addChunk()
+	}
+
+	height := bits.Len64(c.leaves - 1) // smallest tree holding all leaves
+	for h := 0; h < height; h++ {
+		if c.pending&(1<<h) != 0 {
+			node := hashPieceNodes(&c.layers[h], &zeroPieceNodes[h])
+			c.pending &^= 1 << h
+			c.addNode(h+1, &node)
+		}
+	}
+
+	pieceCID, err := PieceCIDFromCommitment(c.layers[height][:])
+	if err != nil {
+		return PieceInfo{}, err
+	}
+	return PieceInfo{
+		Size:     PaddedPieceSize(pieceNodeSize << height),
+		PieceCID: pieceCID,
+	}, nil
+}
+
+// GeneratePieceInfo computes the v1 piece CID and padded size of the data read from r.
+// The data is zero-padded to the smallest valid piece size.
+func GeneratePieceInfo(r io.Reader) (PieceInfo, error) {
+	var w PieceWriter
+	if _, err := io.Copy(&w, r); err != nil {
+		return PieceInfo{}, xerrors.Errorf("failed to read piece data: %w", err)
+	}
+	return w.Sum()
+}
+
+// ZeroPieceCommitment returns the v1 piece CID of a piece of zeros of the given size.
+func ZeroPieceCommitment(size PaddedPieceSize) (cid.Cid, error) {
+	if err := size.Validate(); err != nil {
+		return cid.Undef, err
+	}
+	height := bits.TrailingZeros64(uint64(size) / pieceNodeSize)
+	if height > maxPieceTreeHeight {
+		return cid.Undef, xerrors.Errorf("piece size %d too large", size)
+	}
+	return PieceCIDFromCommitment(zeroPieceNodes[height][:])
+}
+
+// PieceCIDFromCommitment wraps a 32 byte piece commitment in a v1 piece CID.
+func PieceCIDFromCommitment(commP []byte) (cid.Cid, error) {
+	if len(commP) != pieceNodeSize {
+		return cid.Undef, xerrors.Errorf("piece commitment must be %d bytes, got %d", pieceNodeSize, len(commP))
+	}
+	h, err := mh.Encode(commP, mh.SHA2_256_TRUNC254_PADDED)
+	if err != nil {
+		return cid.Undef, err
+	}
+	return cid.NewCidV1(cid.FilCommitmentUnsealed, h), nil
+}
+
+// PieceCommitmentFromCID extracts the 32 byte piece commitment from a v1 piece CID.
+func PieceCommitmentFromCID(c cid.Cid) ([]byte, error) {
+	if c.Prefix().Codec != cid.FilCommitmentUnsealed {
+		return nil, xerrors.Errorf("piece CID %s has codec %x, expected %x", c, c.Prefix().Codec, cid.FilCommitmentUnsealed)
+	}
+	decoded, err := mh.Decode(c.Hash())
+	if err != nil {
+		return nil, xerrors.Errorf("failed to decode piece CID %s multihash: %w", c, err)
+	}
+	if decoded.Code != mh.SHA2_256_TRUNC254_PADDED || len(decoded.Digest) != pieceNodeSize {
+		return nil, xerrors.Errorf("piece CID %s has an invalid multihash", c)
+	}
+	return decoded.Digest, nil
+}
+
+// PieceCIDV2 returns the FRC-0069 v2 piece CID of a piece, which records the size of its tree and
+// of the payload it was computed from, in addition to the commitment.
+func PieceCIDV2(piece PieceInfo, payloadSize uint64) (cid.Cid, error) {
+	if err := piece.Size.Validate(); err != nil {
+		return cid.Undef, err
+	}
+	unpadded := uint64(piece.Size.Unpadded())
+	if payloadSize > unpadded {
+		return cid.Undef, xerrors.Errorf("payload size %d exceeds piece capacity %d", payloadSize, unpadded)
+	}
+	commP, err := PieceCommitmentFromCID(piece.PieceCID)
+	if err != nil {
+		return cid.Undef, err
+	}
+
+	height := bits.TrailingZeros64(uint64(piece.Size) / pieceNodeSize)
+	digest := binary.AppendUvarint(nil, unpadded-payloadSize)
+	digest = append(digest, byte(height))
+	digest = append(digest, commP...)
+
+	h, err := mh.Encode(digest, PieceMultihashCode)
+	if err != nil {
+		return cid.Undef, err
+	}
+	return cid.NewCidV1(cid.Raw, h), nil
+}
+
+// PieceInfoFromV2 decodes an FRC-0069 v2 piece CID into the piece, with its v1 piece CID, and the
+// payload size.
+func PieceInfoFromV2(c cid.Cid) (PieceInfo, uint64, error) {
+	if c.Prefix().Codec != cid.Raw {
+		return PieceInfo{}, 0, xerrors.Errorf("piece CID %s has codec %x, expected %x", c, c.Prefix().Codec, cid.Raw)
+	}
+	decoded, err := mh.Decode(c.Hash())
+	if err != nil {
+		return PieceInfo{}, 0, xerrors.Errorf("failed to decode piece CID %s multihash: %w", c, err)
+	}
+	if decoded.Code != PieceMultihashCode {
+		return PieceInfo{}, 0, xerrors.Errorf("piece CID %s has multihash code %x, expected %x", c, decoded.Code, PieceMultihashCode)
+	}
+
+	padding, n := binary.Uvarint(decoded.Digest)
+	if n <= 0 || len(decoded.Digest) != n+1+pieceNodeSize {
+		return PieceInfo{}, 0, xerrors.Errorf("piece CID %s has a malformed digest", c)
+	}
+	height := int(decoded.Digest[n])
+	if height < 2 || height > maxPieceTreeHeight {
+		return PieceInfo{}, 0, xerrors.Errorf("piece CID %s has invalid tree height %d", c, height)
+	}
+	size := PaddedPieceSize(pieceNodeSize << height)
+	if padding > uint64(size.Unpadded()) {
+		return PieceInfo{}, 0, xerrors.Errorf("piece CID %s padding %d exceeds piece capacity", c, padding)
+	}
+
+	pieceCID, err := PieceCIDFromCommitment(decoded.Digest[n+1:])
+	if err != nil {
+		return PieceInfo{}, 0, err
+	}
+	return PieceInfo{Size: size, PieceCID: pieceCID}, uint64(size.Unpadded()) - padding, nil
+}
+
+// GenerateUnsealedCID computes the unsealed sector commitment (CommD) of a sector holding the given
+// pieces, in order.
+// Each piece is aligned to a multiple of its size, and the space between pieces and after the last
+// one is filled with zero pieces.
+func GenerateUnsealedCID(proofType RegisteredSealProof, pieces []PieceInfo) (cid.Cid, error) {
+	sectorSize, err := proofType.SectorSize()
+	if err != nil {
+		return cid.Undef, err
+	}
+	sectorPieceSize := PaddedPieceSize(sectorSize)
+
+	type stackEntry struct {
+		size PaddedPieceSize
+		node pieceNode
+	}
+	var stack []stackEntry
+	push := func(size PaddedPieceSize, node pieceNode) {
+		for len(stack) > 0 && stack[len(stack)-1].size == size {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			node = hashPieceNodes(&top.node, &node)
+			size *= 2
+		}
+		stack = append(stack, stackEntry{size: size, node: node})
+	}
+	var offset PaddedPieceSize
+	// Fills with zero pieces up to the next multiple of align.
+	padTo := func(align PaddedPieceSize) {
+		for offset%align != 0 {
+			size := PaddedPieceSize(1) << bits.TrailingZeros64(uint64(offset))
+			push(size, zeroPieceNodes[bits.TrailingZeros64(uint64(size/pieceNodeSize))])
+			offset += size
+		}
+	}
+
+	for i, p := range pieces {
+		if err := p.Size.Validate(); err != nil {
+			return cid.Undef, xerrors.Errorf("piece %d: %w", i, err)
+		}
+		commP, err := PieceCommitmentFromCID(p.PieceCID)
+		if err != nil {
+			return cid.Undef, xerrors.Errorf("piece %d: %w", i, err)
+		}
+		padTo(p.Size)
+		if offset+p.Size > sectorPieceSize {
+			return cid.Undef, xerrors.Errorf("pieces exceed sector size %d", sectorSize)
+		}
+		var node pieceNode
+		copy(node[:], commP)
+		push(p.Size, node)
+		offset += p.Size
+	}
+
+	if offset == 0 {
+		return ZeroPieceCommitment(sectorPieceSize)
+	}
+	padTo(sectorPieceSize)
+	if len(stack) != 1 {
+		return cid.Undef, xerrors.Errorf("unexpected piece stack depth %d", len(stack))
+	}
+	return PieceCIDFromCommitment(stack[0].node[:])
+}
@@ -0,0 +1,112 @@
+package abi
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeneratePieceInfo(t *testing.T) {
+	for _, tc := range []struct {
+		data  []byte
+		size  PaddedPieceSize
+		commP string
+	}{
+		{make([]byte, 127), 128, "3731bb99ac689f66eef5973e4a94da188f4ddcae580724fc6f3fd60dfd488333"},
+		{func() []byte {
+			data := make([]byte, 1000)
+			for i := range data {
+				data[i] = byte(i)
+			}
+			return data
+		}(), 1024, "9538fc126da56ccaab174a907dfb040f5fc3b79fc73423c557921454ce507e2b"},
+	} {
+		piece, err := GeneratePieceInfo(bytes.NewReader(tc.data))
+		require.NoError(t, err)
+		require.Equal(t, tc.size, piece.Size)
+		commP, err := PieceCommitmentFromCID(piece.PieceCID)
+		require.NoError(t, err)
+		require.Equal(t, tc.commP, hex.EncodeToString(commP))
+	}
+
+	// Streaming in small writes gives the same result.
+	var w PieceWriter
+	for i := 0; i < 1000; i++ {
+		_, err := w.Write([]byte{byte(i)})
+		require.NoError(t, err)
+	}
+	piece, err := w.Sum()
+	require.NoError(t, err)
+	commP, err := PieceCommitmentFromCID(piece.PieceCID)
+	require.NoError(t, err)
+	require.Equal(t, "9538fc126da56ccaab174a907dfb040f5fc3b79fc73423c557921454ce507e2b", hex.EncodeToString(commP))
+
+	// Zero-padding the data doesn't change the commitment.
+	zero, err := ZeroPieceCommitment(128)
+	require.NoError(t, err)
+	piece, err = GeneratePieceInfo(bytes.NewReader(make([]byte, 10)))
+	require.NoError(t, err)
+	require.Equal(t, zero, piece.PieceCID)
+}
+
+func TestPieceCIDV2(t *testing.T) {
+	var w PieceWriter
+	_, err := w.Write(make([]byte, 1000))
+	require.NoError(t, err)
+	piece, err := w.Sum()
+	require.NoError(t, err)
+
+	v2, err := PieceCIDV2(piece, w.Size())
+	require.NoError(t, err)
+	decoded, payloadSize, err := PieceInfoFromV2(v2)
+	require.NoError(t, err)
+	require.Equal(t, piece, decoded)
+	require.Equal(t, uint64(1000), payloadSize)
+
+	_, err = PieceCIDV2(piece, 1017)
+	require.Error(t, err)
+	_, _, err = PieceInfoFromV2(piece.PieceCID)
+	require.Error(t, err)
+}
+
+func TestGenerateUnsealedCID(t *testing.T) {
+	// The well-known CommD of an empty 32GiB sector.
+	commD, err := GenerateUnsealedCID(RegisteredSealProof_StackedDrg32GiBV1_1, nil)
+	require.NoError(t, err)
+	require.Equal(t, "baga6ea4seaqao7s73y24kcutaosvacpdjgfe5pw76ooefnyqw4ynr3d2y6x2mpq", commD.String())
+
+	// Pieces of zeros are indistinguishable from padding.
+	zero128, err := ZeroPieceCommitment(128)
+	require.NoError(t, err)
+	zero512, err := ZeroPieceCommitment(512)
+	require.NoError(t, err)
+	commD, err = GenerateUnsealedCID(RegisteredSealProof_StackedDrg2KiBV1_1, []PieceInfo{
+		{Size: 128, PieceCID: zero128},
+		{Size: 512, PieceCID: zero512},
+	})
+	require.NoError(t, err)
+	expected, err := ZeroPieceCommitment(2048)
+	require.NoError(t, err)
+	require.Equal(t, expected, commD)
+
+	// A data piece aligned after padding matches the tree computed from the data.
+	data := make([]byte, 1016)
+	for i := range data {
+		data[i] = byte(i)
+	}
+	piece, err := GeneratePieceInfo(bytes.NewReader(data))
+	require.NoError(t, err)
+	commD, err = GenerateUnsealedCID(RegisteredSealProof_StackedDrg2KiBV1_1, []PieceInfo{
+		{Size: 128, PieceCID: zero128},
+		piece,
+	})
+	require.NoError(t, err)
+	sector, err := GeneratePieceInfo(bytes.NewReader(append(make([]byte, 1016), data...)))
+	require.NoError(t, err)
+	require.Equal(t, sector.PieceCID, commD)
+
+	_, err = GenerateUnsealedCID(RegisteredSealProof_StackedDrg2KiBV1_1, []PieceInfo{piece, piece, piece})
+	require.Error(t, err)
+}
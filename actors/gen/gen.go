@@ -0,0 +1,110 @@
+// Command gen writes actors/manifests_gen.go, the manifest CIDs of the builtin actors bundles of the
+// networks with built-in upgrade schedules, from the build/builtin_actors_gen.go file of lotus:
+//
+//	go run ./actors/gen/gen.go ../lotus/build/builtin_actors_gen.go
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/ipfs/go-cid"
+)
+
+const output = "./actors/manifests_gen.go"
+
+// The networks of the built-in schedules, by their names in lotus.
+var networks = map[string]bool{"mainnet": true, "calibrationnet": true}
+
+// The first actors version deployed as a bundle with a manifest.
+const firstBundleVersion = 8
+
+// An entry of lotus's EmbeddedBuiltinActorsMetadata, up to its manifest CID.
+var entryRe = regexp.MustCompile(`(?s)Network:\s*"([^"]+)",\s*Version:\s*(\d+),.*?ManifestCid:\s*cid\.MustParse\("([^"]+)"\)`)
+
+func main() {
+	if len(os.Args) != 2 {
+		fmt.Fprintln(os.Stderr, "usage: gen path/to/lotus/build/builtin_actors_gen.go")
+		os.Exit(2)
+	}
+	src, err := os.ReadFile(os.Args[1])
+	if err != nil {
+		panic(err)
+	}
+	out, err := generate(src)
+	if err != nil {
+		panic(err)
+	}
+	if err := os.WriteFile(output, out, 0644); err != nil {
+		panic(err)
+	}
+}
+
+type manifest struct {
+	network string
+	version int
+	cid     cid.Cid
+}
+
+func parse(src []byte) ([]manifest, error) {
+	var out []manifest
+	seen := make(map[string]bool)
+	for _, m := range entryRe.FindAllSubmatch(src, -1) {
+		network := string(m[1])
+		version, err := strconv.Atoi(string(m[2]))
+		if err != nil {
+			return nil, err
+		}
+		if !networks[network] || version < firstBundleVersion {
+			continue
+		}
+		c, err := cid.Decode(string(m[3]))
+		if err != nil {
+			return nil, fmt.Errorf("manifest of %s version %d: %w", network, version, err)
+		}
+		key := fmt.Sprintf("%s/%d", network, version)
+		if seen[key] {
+			return nil, fmt.Errorf("duplicate manifest of %s version %d", network, version)
+		}
+		seen[key] = true
+		out = append(out, manifest{network: network, version: version, cid: c})
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("no manifests found")
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].network != out[j].network {
+			return out[i].network < out[j].network
+		}
+		return out[i].version < out[j].version
+	})
+	return out, nil
+}
+
+func generate(src []byte) ([]byte, error) {
+	manifests, err := parse(src)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by github.com/filecoin-project/go-state-types/actors/gen. DO NOT EDIT.\n\n")
+	buf.WriteString("package actors\n\n")
+	buf.WriteString("// The manifest CIDs of the latest builtin actors bundle of each actors version, by network.\n")
+	buf.WriteString("var builtinManifests = map[string]map[Version]string{\n")
+	for i, m := range manifests {
+		if i == 0 || manifests[i-1].network != m.network {
+			fmt.Fprintf(&buf, "%q: {\n", m.network)
+		}
+		fmt.Fprintf(&buf, "Version%d: %q,\n", m.version, m.cid)
+		if i == len(manifests)-1 || manifests[i+1].network != m.network {
+			buf.WriteString("},\n")
+		}
+	}
+	buf.WriteString("}\n")
+	return format.Source(buf.Bytes())
+}
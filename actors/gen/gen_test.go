@@ -0,0 +1,67 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// An excerpt in the format of lotus's build/builtin_actors_gen.go.
+const lotusExcerpt = `
+var EmbeddedBuiltinActorsMetadata = []*BuiltinActorsMetadata{{
+	Network:      "butterflynet",
+	Version:      8,
+	BundleGitTag: "v8.0.0",
+	ManifestCid:  cid.MustParse("bafy2bzacecu7n7wbtogznrtuuvf73dsz7wasgyneqasksdblxupnyovmtwxxu"),
+	Actors: map[string]cid.Cid{
+		"account": cid.MustParse("bafy2bzacecu7n7wbtogznrtuuvf73dsz7wasgyneqasksdblxupnyovmtwxxu"),
+	},
+}, {
+	Network:      "calibrationnet",
+	Version:      12,
+	BundleGitTag: "v12.0.0",
+	ManifestCid:  cid.MustParse("bafy2bzaceaxxqkgsdhadu4agahitbuzqtmx3y6ew2itc7zvmmd5wvzwfzunza"),
+	Actors: map[string]cid.Cid{
+		"account": cid.MustParse("bafy2bzacecu7n7wbtogznrtuuvf73dsz7wasgyneqasksdblxupnyovmtwxxu"),
+	},
+}, {
+	Network:      "mainnet",
+	Version:      9,
+	BundleGitTag: "v9.0.3",
+	ManifestCid:  cid.MustParse("bafy2bzacecu7n7wbtogznrtuuvf73dsz7wasgyneqasksdblxupnyovmtwxxu"),
+	Actors: map[string]cid.Cid{
+		"account": cid.MustParse("bafy2bzaceaxxqkgsdhadu4agahitbuzqtmx3y6ew2itc7zvmmd5wvzwfzunza"),
+	},
+}, {
+	Network:      "mainnet",
+	Version:      8,
+	BundleGitTag: "v8.0.0",
+	ManifestCid:  cid.MustParse("bafy2bzaceaxxqkgsdhadu4agahitbuzqtmx3y6ew2itc7zvmmd5wvzwfzunza"),
+	Actors: map[string]cid.Cid{
+		"account": cid.MustParse("bafy2bzacecu7n7wbtogznrtuuvf73dsz7wasgyneqasksdblxupnyovmtwxxu"),
+	},
+}}
+`
+
+func TestGenerate(t *testing.T) {
+	out, err := generate([]byte(lotusExcerpt))
+	require.NoError(t, err)
+	require.Equal(t, `// Code generated by github.com/filecoin-project/go-state-types/actors/gen. DO NOT EDIT.
+
+package actors
+
+// The manifest CIDs of the latest builtin actors bundle of each actors version, by network.
+var builtinManifests = map[string]map[Version]string{
+	"calibrationnet": {
+		Version12: "bafy2bzaceaxxqkgsdhadu4agahitbuzqtmx3y6ew2itc7zvmmd5wvzwfzunza",
+	},
+	"mainnet": {
+		Version8: "bafy2bzaceaxxqkgsdhadu4agahitbuzqtmx3y6ew2itc7zvmmd5wvzwfzunza",
+		Version9: "bafy2bzacecu7n7wbtogznrtuuvf73dsz7wasgyneqasksdblxupnyovmtwxxu",
+	},
+}
+`, string(out))
+
+	_, err = generate([]byte("package build"))
+	require.Error(t, err)
+}
@@ -0,0 +1,200 @@
+package actors
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/ipfs/go-cid"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/network"
+)
+
+// Upgrade is a network upgrade, activating a network version after an epoch.
+// A fix upgrade keeps the network version of the previous upgrade and only deploys new builtin actors code.
+type Upgrade struct {
+	Name string `json:"name"`
+	// The last epoch of the previous network version. The upgrade's network version is in effect
+	// from the following epoch.
+	Height  abi.ChainEpoch  `json:"height"`
+	Network network.Version `json:"networkVersion"`
+	// The CID of the builtin actors manifest deployed by the upgrade, if known. Undefined for
+	// upgrades that don't deploy new actors code.
+	// The built-in schedules take the manifests of actors version 8 and later from the bundles
+	// embedded in lotus, which only holds the latest bundle of each actors version; the bundles
+	// replaced by a fix upgrade are left undefined. Run make gen-manifests to update them.
+	Manifest cid.Cid `json:"manifest"`
+}
+
+// Schedule is the sequence of network upgrades of a chain.
+type Schedule struct {
+	Name string `json:"name"`
+	// The network version at genesis, in effect until the first upgrade.
+	GenesisNetwork network.Version `json:"genesisNetworkVersion"`
+	// Upgrades in increasing height and network version order.
+	Upgrades []Upgrade `json:"upgrades"`
+}
+
+// The upgrade schedule of the Filecoin mainnet.
+var MainnetSchedule = withBuiltinManifests(Schedule{
+	Name:           "mainnet",
+	GenesisNetwork: network.Version0,
+	Upgrades: []Upgrade{
+		{Name: "Breeze", Height: 41280, Network: network.Version1},
+		{Name: "Smoke", Height: 51000, Network: network.Version2},
+		{Name: "Ignition", Height: 94000, Network: network.Version3},
+		{Name: "Assembly", Height: 138720, Network: network.Version4},
+		{Name: "Tape", Height: 140760, Network: network.Version5},
+		{Name: "Kumquat", Height: 170000, Network: network.Version6},
+		{Name: "Calico", Height: 265200, Network: network.Version7},
+		{Name: "Persian", Height: 272400, Network: network.Version8},
+		{Name: "Orange", Height: 336458, Network: network.Version9},
+		{Name: "Trust", Height: 550321, Network: network.Version10},
+		{Name: "Norwegian", Height: 665280, Network: network.Version11},
+		{Name: "Turbo", Height: 712320, Network: network.Version12},
+		{Name: "Hyperdrive", Height: 892800, Network: network.Version13},
+		{Name: "Chocolate", Height: 1231620, Network: network.Version14},
+		{Name: "OhSnap", Height: 1594680, Network: network.Version15},
+		{Name: "Skyr", Height: 1960320, Network: network.Version16},
+		{Name: "Shark", Height: 2383680, Network: network.Version17},
+		{Name: "Hygge", Height: 2683348, Network: network.Version18},
+		{Name: "Lightning", Height: 2809800, Network: network.Version19},
+		{Name: "Thunder", Height: 2870280, Network: network.Version20},
+		{Name: "Watermelon", Height: 3469380, Network: network.Version21},
+		{Name: "Dragon", Height: 3855360, Network: network.Version22},
+		{Name: "Waffle", Height: 4154640, Network: network.Version23},
+		{Name: "TukTuk", Height: 4461240, Network: network.Version24},
+		{Name: "Teep", Height: 4878840, Network: network.Version25},
+		{Name: "Tock", Height: 5138040, Network: network.Version26},
+		{Name: "GoldenWeek", Height: 5348280, Network: network.Version27},
+	},
+})
+
+// The upgrade schedule of the calibration test network.
+// The first network upgrades were applied before genesis, and several upgrades were followed by fix
+// upgrades redeploying the same actors version.
+var CalibnetSchedule = withBuiltinManifests(Schedule{
+	Name:           "calibrationnet",
+	GenesisNetwork: network.Version3,
+	Upgrades: []Upgrade{
+		{Name: "Assembly", Height: 30, Network: network.Version4},
+		{Name: "Tape", Height: 60, Network: network.Version5},
+		{Name: "Kumquat", Height: 90, Network: network.Version6},
+		{Name: "Calico", Height: 120, Network: network.Version7},
+		{Name: "Persian", Height: 240, Network: network.Version8},
+		{Name: "Orange", Height: 300, Network: network.Version9},
+		{Name: "Trust", Height: 330, Network: network.Version10},
+		{Name: "Norwegian", Height: 360, Network: network.Version11},
+		{Name: "Turbo", Height: 390, Network: network.Version12},
+		{Name: "Hyperdrive", Height: 420, Network: network.Version13},
+		{Name: "Chocolate", Height: 450, Network: network.Version14},
+		{Name: "OhSnap", Height: 480, Network: network.Version15},
+		{Name: "Skyr", Height: 510, Network: network.Version16},
+		{Name: "Shark", Height: 16800, Network: network.Version17},
+		{Name: "Hygge", Height: 322354, Network: network.Version18},
+		{Name: "Lightning", Height: 489094, Network: network.Version19},
+		{Name: "Thunder", Height: 492214, Network: network.Version20},
+		{Name: "Watermelon", Height: 1013134, Network: network.Version21},
+		{Name: "WatermelonFix", Height: 1070494, Network: network.Version21},
+		{Name: "WatermelonFix2", Height: 1108174, Network: network.Version21},
+		{Name: "Dragon", Height: 1427974, Network: network.Version22},
+		{Name: "DragonFix", Height: 1493854, Network: network.Version22},
+		{Name: "Waffle", Height: 1779094, Network: network.Version23},
+		{Name: "TukTuk", Height: 2078794, Network: network.Version24},
+		{Name: "Teep", Height: 2523454, Network: network.Version25},
+		{Name: "Tock", Height: 2558014, Network: network.Version26},
+		{Name: "GoldenWeek", Height: 3007294, Network: network.Version27},
+	},
+})
+
+// withBuiltinManifests sets the manifest of the last upgrade deploying each actors version of a
+// built-in schedule to the latest bundle of that version. An upgrade deploys actors if it changes the
+// actors version or is a fix upgrade, keeping the network version.
+func withBuiltinManifests(s Schedule) Schedule {
+	manifests := builtinManifests[s.Name]
+	last := make(map[Version]int)
+	prevNetwork := s.GenesisNetwork
+	prevVersion, _ := VersionForNetwork(prevNetwork)
+	for i, u := range s.Upgrades {
+		v, _ := VersionForNetwork(u.Network)
+		if v != prevVersion || u.Network == prevNetwork {
+			last[v] = i
+		}
+		prevNetwork, prevVersion = u.Network, v
+	}
+	for v, i := range last { // nolint:nomaprange
+		if m, ok := manifests[v]; ok {
+			s.Upgrades[i].Manifest = cid.MustParse(m)
+		}
+	}
+	return s
+}
+
+// LoadSchedule reads a schedule from its JSON encoding, such as the schedule of a development
+// network, and validates it.
+func LoadSchedule(r io.Reader) (*Schedule, error) {
+	var s Schedule
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&s); err != nil {
+		return nil, fmt.Errorf("failed to decode schedule: %w", err)
+	}
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// Validate checks that upgrades are ordered by strictly increasing height and non-decreasing network
+// version, and that every network version maps to an actors version.
+func (s *Schedule) Validate() error {
+	if _, err := VersionForNetwork(s.GenesisNetwork); err != nil {
+		return fmt.Errorf("schedule %s genesis: %w", s.Name, err)
+	}
+	prevHeight, prevNetwork := abi.ChainEpoch(-1), s.GenesisNetwork
+	for _, u := range s.Upgrades {
+		if u.Height <= prevHeight {
+			return fmt.Errorf("schedule %s upgrade %s at height %d is not after the previous upgrade at %d",
+				s.Name, u.Name, u.Height, prevHeight)
+		}
+		if u.Network < prevNetwork {
+			return fmt.Errorf("schedule %s upgrade %s to network version %d precedes network version %d",
+				s.Name, u.Name, u.Network, prevNetwork)
+		}
+		if _, err := VersionForNetwork(u.Network); err != nil {
+			return fmt.Errorf("schedule %s upgrade %s: %w", s.Name, u.Name, err)
+		}
+		prevHeight, prevNetwork = u.Height, u.Network
+	}
+	return nil
+}
+
+// NetworkVersionAt returns the network version in effect at an epoch.
+func (s *Schedule) NetworkVersionAt(epoch abi.ChainEpoch) network.Version {
+	// Index of the first upgrade not yet in effect.
+	i := sort.Search(len(s.Upgrades), func(i int) bool {
+		return s.Upgrades[i].Height >= epoch
+	})
+	if i == 0 {
+		return s.GenesisNetwork
+	}
+	return s.Upgrades[i-1].Network
+}
+
+// ActorsVersionAt returns the actors version in effect at an epoch.
+func (s *Schedule) ActorsVersionAt(epoch abi.ChainEpoch) (Version, error) {
+	return VersionForNetwork(s.NetworkVersionAt(epoch))
+}
+
+// UpgradeTo returns the upgrade that activated a network version, if it is in the schedule.
+// Fix upgrades redeploying actors for the same network version are not returned.
+func (s *Schedule) UpgradeTo(version network.Version) (Upgrade, bool) {
+	for _, u := range s.Upgrades {
+		if u.Network == version {
+			return u, true
+		}
+	}
+	return Upgrade{}, false
+}
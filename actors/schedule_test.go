@@ -0,0 +1,98 @@
+package actors
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/network"
+)
+
+func TestScheduleLookups(t *testing.T) {
+	require.NoError(t, MainnetSchedule.Validate())
+	require.NoError(t, CalibnetSchedule.Validate())
+
+	assert.Equal(t, network.Version0, MainnetSchedule.NetworkVersionAt(0))
+	// The upgrade epoch is the last epoch of the previous version.
+	assert.Equal(t, network.Version15, MainnetSchedule.NetworkVersionAt(1960320))
+	assert.Equal(t, network.Version16, MainnetSchedule.NetworkVersionAt(1960321))
+	av, err := MainnetSchedule.ActorsVersionAt(1960321)
+	require.NoError(t, err)
+	assert.Equal(t, Version8, av)
+
+	assert.Equal(t, network.Version3, CalibnetSchedule.NetworkVersionAt(0))
+	u, ok := CalibnetSchedule.UpgradeTo(network.Version17)
+	require.True(t, ok)
+	assert.Equal(t, "Shark", u.Name)
+
+	// Fix upgrades keep the network version.
+	assert.Equal(t, network.Version21, CalibnetSchedule.NetworkVersionAt(1070495))
+	u, ok = CalibnetSchedule.UpgradeTo(network.Version21)
+	require.True(t, ok)
+	assert.Equal(t, "Watermelon", u.Name)
+}
+
+func TestBuiltinManifests(t *testing.T) {
+	saved := builtinManifests
+	defer func() { builtinManifests = saved }()
+	const v16 = "bafy2bzaceaxxqkgsdhadu4agahitbuzqtmx3y6ew2itc7zvmmd5wvzwfzunza"
+	const v12 = "bafy2bzacecu7n7wbtogznrtuuvf73dsz7wasgyneqasksdblxupnyovmtwxxu"
+	builtinManifests = map[string]map[Version]string{
+		"mainnet":        {Version16: v16},
+		"calibrationnet": {Version12: v12},
+	}
+
+	// Teep deployed actors version 16, which Tock kept.
+	mainnet := withBuiltinManifests(cloneSchedule(MainnetSchedule))
+	for _, u := range mainnet.Upgrades {
+		assert.Equal(t, u.Name == "Teep", u.Manifest.Defined(), u.Name)
+	}
+	teep, ok := mainnet.UpgradeTo(network.Version25)
+	require.True(t, ok)
+	assert.Equal(t, v16, teep.Manifest.String())
+
+	// The latest bundle of actors version 12 was deployed by the last fix upgrade.
+	calibnet := withBuiltinManifests(cloneSchedule(CalibnetSchedule))
+	for _, u := range calibnet.Upgrades {
+		assert.Equal(t, u.Name == "WatermelonFix2", u.Manifest.Defined(), u.Name)
+	}
+}
+
+func cloneSchedule(s Schedule) Schedule {
+	s.Upgrades = append([]Upgrade(nil), s.Upgrades...)
+	for i := range s.Upgrades {
+		s.Upgrades[i].Manifest = cid.Undef
+	}
+	return s
+}
+
+func TestLoadSchedule(t *testing.T) {
+	s, err := LoadSchedule(strings.NewReader(`{
+		"name": "devnet",
+		"genesisNetworkVersion": 27,
+		"upgrades": [{"name": "FireHorse", "height": 20, "networkVersion": 28, "manifest": {"/": "bafy2bzaceaxxqkgsdhadu4agahitbuzqtmx3y6ew2itc7zvmmd5wvzwfzunza"}}]
+	}`))
+	require.NoError(t, err)
+	assert.Equal(t, network.Version27, s.NetworkVersionAt(20))
+	av, err := s.ActorsVersionAt(21)
+	require.NoError(t, err)
+	assert.Equal(t, Version18, av)
+	assert.True(t, s.Upgrades[0].Manifest.Defined())
+
+	_, err = LoadSchedule(strings.NewReader(`{
+		"name": "bad",
+		"genesisNetworkVersion": 27,
+		"upgrades": [{"name": "A", "height": 20, "networkVersion": 28}, {"name": "B", "height": 10, "networkVersion": 29}]
+	}`))
+	assert.Error(t, err)
+
+	_, err = LoadSchedule(strings.NewReader(`{
+		"name": "bad",
+		"genesisNetworkVersion": 27,
+		"upgrades": [{"name": "A", "height": 20, "networkVersion": 28}, {"name": "B", "height": 30, "networkVersion": 27}]
+	}`))
+	assert.Error(t, err)
+}
@@ -28,6 +28,17 @@ type ActorV5 struct {
 	DelegatedAddress *address.Address // Delegated (f4) actor address
 }
 
+// Version of the state tree, which determines the type of its actor entries.
+type StateTreeVersion uint64
+
+// The root of a state tree, as referenced by a block header: the version of the tree, the root of
+// the map of ID-addresses to actors, and the CID of the state info.
+type StateRoot struct {
+	Version StateTreeVersion
+	Actors  cid.Cid
+	Info    cid.Cid
+}
+
 // A specialization of a map of ID-addresses to actor heads.
 type ActorTree struct {
 	Map   *adt.Map
@@ -0,0 +1,255 @@
+// Package aggregate estimates the size and network fee of aggregated seal proofs, as submitted with
+// ProveCommitAggregate and ProveCommitSectorsNI, to help decide between aggregating proofs and
+// submitting them individually.
+package aggregate
+
+import (
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/actors"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin"
+	miner10 "github.com/filecoin-project/go-state-types/builtin/v10/miner"
+	miner11 "github.com/filecoin-project/go-state-types/builtin/v11/miner"
+	miner12 "github.com/filecoin-project/go-state-types/builtin/v12/miner"
+	miner13 "github.com/filecoin-project/go-state-types/builtin/v13/miner"
+	miner14 "github.com/filecoin-project/go-state-types/builtin/v14/miner"
+	miner15 "github.com/filecoin-project/go-state-types/builtin/v15/miner"
+	miner16 "github.com/filecoin-project/go-state-types/builtin/v16/miner"
+	miner17 "github.com/filecoin-project/go-state-types/builtin/v17/miner"
+	miner18 "github.com/filecoin-project/go-state-types/builtin/v18/miner"
+	miner19 "github.com/filecoin-project/go-state-types/builtin/v19/miner"
+	miner8 "github.com/filecoin-project/go-state-types/builtin/v8/miner"
+	miner9 "github.com/filecoin-project/go-state-types/builtin/v9/miner"
+	"github.com/filecoin-project/go-state-types/network"
+)
+
+// The maximum number of non-interactive PoRep proofs in an aggregate.
+// https://github.com/filecoin-project/FIPs/blob/master/FIPS/fip-0090.md
+const MaxAggregatedSectorsNI = 65
+
+// Serialized sizes of the SnarkPack proof elements: BLS12-381 target group elements are serialized
+// uncompressed, G1 and G2 points compressed.
+const (
+	gtSize = 576
+	g1Size = 48
+	g2Size = 96
+	// The size of a single Groth16 proof, of one partition of a seal proof.
+	groth16ProofSize = 192
+)
+
+const (
+	// Commitments to A·B and C, their inner product, the aggregated C, the proof count, the final
+	// GIPA values and keys, and the KZG openings of the verification and commitment keys.
+	snarkPackFixedSize = 2*2*gtSize + gtSize + g1Size + 4 +
+		(g1Size + g2Size + g1Size) + 2*g2Size + 2*g1Size +
+		2*g2Size + 2*g1Size
+	// The commitments and cross terms of a GIPA round, which halves the number of proofs.
+	snarkPackRoundSize = 2*2*gtSize + 2*2*gtSize + 2*gtSize + 2*g1Size
+)
+
+// ProofSize returns the expected size in bytes of an aggregate of the seal proofs of a number of sectors.
+// Each partition proof of a sector is aggregated, and the number of proofs is padded to a power of two.
+// Both SnarkPack versions produce proofs of the same size.
+// The size is derived from the layout of the SnarkPack proof, and bounded by the policy's
+// MaxAggregateProofSize for the largest aggregates.
+func ProofSize(aggregateProof abi.RegisteredAggregationProof, sealProof abi.RegisteredSealProof, sectors int) (uint64, error) {
+	if _, ok := builtin.AggregationProofNetworkVersions[aggregateProof]; !ok {
+		return 0, xerrors.Errorf("unsupported aggregation proof type: %v", aggregateProof)
+	}
+	if sectors <= 0 {
+		return 0, xerrors.Errorf("cannot aggregate %d sectors", sectors)
+	}
+	sealProofSize, err := sealProof.ProofSize()
+	if err != nil {
+		return 0, err
+	}
+	partitions := sealProofSize / groth16ProofSize
+	proofs := uint64(sectors) * partitions
+
+	// SnarkPack aggregates at least two proofs.
+	var rounds uint64
+	for n := uint64(1); n < proofs || n < 2; n *= 2 {
+		rounds++
+	}
+	return snarkPackFixedSize + rounds*snarkPackRoundSize, nil
+}
+
+// Estimate is the expected cost of aggregating the proofs of a number of sectors.
+type Estimate struct {
+	Sectors          int
+	AggregationProof abi.RegisteredAggregationProof
+	ProofSize        uint64
+	// The network fee burnt for the aggregate, in addition to the message's gas fee.
+	// No network fee is charged from network version 25.
+	NetworkFee abi.TokenAmount
+}
+
+// EstimateProveCommitAggregate estimates the cost of proving a number of sectors with ProveCommitAggregate,
+// or with ProveCommitSectorsNI for non-interactive seal proofs.
+func EstimateProveCommitAggregate(nv network.Version, sealProof abi.RegisteredSealProof, sectors int, baseFee abi.TokenAmount) (*Estimate, error) {
+	minSectors, maxSectors, err := aggregatedSectorsRange(nv)
+	if err != nil {
+		return nil, err
+	}
+	if sealProof.IsNonInteractive() {
+		maxSectors = MaxAggregatedSectorsNI
+	}
+	if sectors < minSectors || sectors > maxSectors {
+		return nil, xerrors.Errorf("aggregate of %d sectors is outside the range [%d, %d]",
+			sectors, minSectors, maxSectors)
+	}
+
+	entry, err := builtin.SealProof(sealProof)
+	if err != nil {
+		return nil, err
+	}
+	sealVersions := entry.NetworkVersions.ProveCommit
+	if !sealVersions.Contains(nv) {
+		return nil, xerrors.Errorf("seal proof type %d is not accepted in network version %d", sealProof, nv)
+	}
+
+	aggregateProof, err := builtin.AggregationProofForNetwork(nv)
+	if err != nil {
+		return nil, err
+	}
+	size, err := ProofSize(aggregateProof, sealProof, sectors)
+	if err != nil {
+		return nil, err
+	}
+	fee, err := ProveCommitNetworkFee(nv, sectors, baseFee)
+	if err != nil {
+		return nil, err
+	}
+	return &Estimate{
+		Sectors:          sectors,
+		AggregationProof: aggregateProof,
+		ProofSize:        size,
+		NetworkFee:       fee,
+	}, nil
+}
+
+// aggregatedSectorsRange returns the policy's minimum and maximum number of sectors in a
+// ProveCommitAggregate.
+func aggregatedSectorsRange(nv network.Version) (int, int, error) {
+	av, err := actors.VersionForNetwork(nv)
+	if err != nil {
+		return 0, 0, err
+	}
+	switch av {
+	case actors.Version8:
+		return miner8.MinAggregatedSectors, miner8.MaxAggregatedSectors, nil
+	case actors.Version9:
+		return miner9.MinAggregatedSectors, miner9.MaxAggregatedSectors, nil
+	case actors.Version10:
+		return miner10.MinAggregatedSectors, miner10.MaxAggregatedSectors, nil
+	case actors.Version11:
+		return miner11.MinAggregatedSectors, miner11.MaxAggregatedSectors, nil
+	case actors.Version12:
+		return miner12.MinAggregatedSectors, miner12.MaxAggregatedSectors, nil
+	case actors.Version13:
+		return miner13.MinAggregatedSectors, miner13.MaxAggregatedSectors, nil
+	case actors.Version14:
+		return miner14.MinAggregatedSectors, miner14.MaxAggregatedSectors, nil
+	case actors.Version15:
+		return miner15.MinAggregatedSectors, miner15.MaxAggregatedSectors, nil
+	case actors.Version16:
+		return miner16.MinAggregatedSectors, miner16.MaxAggregatedSectors, nil
+	case actors.Version17:
+		return miner17.MinAggregatedSectors, miner17.MaxAggregatedSectors, nil
+	case actors.Version18:
+		return miner18.MinAggregatedSectors, miner18.MaxAggregatedSectors, nil
+	case actors.Version19:
+		return miner19.MinAggregatedSectors, miner19.MaxAggregatedSectors, nil
+	}
+	return 0, 0, xerrors.Errorf("unsupported actors version %d", av)
+}
+
+// ProveCommitNetworkFee returns the network fee burnt for an aggregate prove-commitment of a number
+// of sectors in a network version, given the base fee.
+func ProveCommitNetworkFee(nv network.Version, sectors int, baseFee abi.TokenAmount) (abi.TokenAmount, error) {
+	av, err := actors.VersionForNetwork(nv)
+	if err != nil {
+		return big.Zero(), err
+	}
+	switch av {
+	case actors.Version8:
+		return miner8.AggregateProveCommitNetworkFee(sectors, baseFee), nil
+	case actors.Version9:
+		return miner9.AggregateProveCommitNetworkFee(sectors, baseFee), nil
+	case actors.Version10:
+		return miner10.AggregateProveCommitNetworkFee(sectors, baseFee), nil
+	case actors.Version11:
+		return miner11.AggregateProveCommitNetworkFee(sectors, baseFee), nil
+	case actors.Version12:
+		return miner12.AggregateProveCommitNetworkFee(sectors, baseFee), nil
+	case actors.Version13:
+		return miner13.AggregateProveCommitNetworkFee(sectors, baseFee), nil
+	case actors.Version14:
+		return miner14.AggregateProveCommitNetworkFee(sectors, baseFee), nil
+	case actors.Version15:
+		return miner15.AggregateProveCommitNetworkFee(sectors, baseFee), nil
+	}
+	if av > actors.Version15 {
+		// The batch balancer was removed by FIP-0100.
+		return big.Zero(), nil
+	}
+	return big.Zero(), xerrors.Errorf("unsupported actors version %d", av)
+}
+
+// singleProveCommitGasUsage returns the policy's estimate of the gas used by a single prove-commitment.
+func singleProveCommitGasUsage(nv network.Version) (big.Int, error) {
+	av, err := actors.VersionForNetwork(nv)
+	if err != nil {
+		return big.Zero(), err
+	}
+	switch av {
+	case actors.Version8:
+		return miner8.EstimatedSingleProveCommitGasUsage, nil
+	case actors.Version9:
+		return miner9.EstimatedSingleProveCommitGasUsage, nil
+	case actors.Version10:
+		return miner10.EstimatedSingleProveCommitGasUsage, nil
+	case actors.Version11:
+		return miner11.EstimatedSingleProveCommitGasUsage, nil
+	case actors.Version12:
+		return miner12.EstimatedSingleProveCommitGasUsage, nil
+	case actors.Version13:
+		return miner13.EstimatedSingleProveCommitGasUsage, nil
+	case actors.Version14:
+		return miner14.EstimatedSingleProveCommitGasUsage, nil
+	case actors.Version15, actors.Version16, actors.Version17, actors.Version18, actors.Version19:
+		// Later versions dropped the estimate along with the batch balancer.
+		return miner15.EstimatedSingleProveCommitGasUsage, nil
+	}
+	return big.Zero(), xerrors.Errorf("unsupported actors version %d", av)
+}
+
+// BreakEven returns the smallest number of sectors for which aggregating their proofs costs less than
+// proving them individually, given the base fee and the gas used by an aggregate of a number of sectors,
+// which depends on the implementation's gas schedule.
+// Individual proofs are assumed to use the policy's estimated gas of a single prove-commitment.
+// It returns false if aggregation is not cheaper for any allowed aggregate size.
+func BreakEven(nv network.Version, baseFee abi.TokenAmount, aggregateGasUsage func(sectors int) int64) (int, bool, error) {
+	singleGas, err := singleProveCommitGasUsage(nv)
+	if err != nil {
+		return 0, false, err
+	}
+	minSectors, maxSectors, err := aggregatedSectorsRange(nv)
+	if err != nil {
+		return 0, false, err
+	}
+	for n := minSectors; n <= maxSectors; n++ {
+		fee, err := ProveCommitNetworkFee(nv, n, baseFee)
+		if err != nil {
+			return 0, false, err
+		}
+		aggregateCost := big.Add(big.Mul(big.NewInt(aggregateGasUsage(n)), baseFee), fee)
+		individualCost := big.Product(singleGas, big.NewInt(int64(n)), baseFee)
+		if aggregateCost.LessThan(individualCost) {
+			return n, true, nil
+		}
+	}
+	return 0, false, nil
+}
@@ -0,0 +1,84 @@
+package aggregate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin"
+	miner19 "github.com/filecoin-project/go-state-types/builtin/v19/miner"
+	"github.com/filecoin-project/go-state-types/network"
+)
+
+func TestProofSize(t *testing.T) {
+	// The largest aggregate of 32GiB sectors fits the maximum aggregate proof size.
+	size, err := ProofSize(abi.RegisteredAggregationProof_SnarkPackV2, abi.RegisteredSealProof_StackedDrg32GiBV1_1, miner19.MaxAggregatedSectors)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, size, uint64(miner19.MaxAggregateProofSize))
+
+	// 4 sectors of 10 partitions are padded to 64 proofs.
+	small, err := ProofSize(abi.RegisteredAggregationProof_SnarkPackV2, abi.RegisteredSealProof_StackedDrg32GiBV1_1, 4)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(snarkPackFixedSize+6*snarkPackRoundSize), small)
+
+	_, err = ProofSize(abi.RegisteredAggregationProof(7), abi.RegisteredSealProof_StackedDrg32GiBV1_1, 4)
+	assert.Error(t, err)
+}
+
+func TestEstimateProveCommitAggregate(t *testing.T) {
+	baseFee := abi.NewTokenAmount(100)
+
+	// Below the batch balancer, the fee is charged at the balancer's price.
+	est, err := EstimateProveCommitAggregate(network.Version21, abi.RegisteredSealProof_StackedDrg32GiBV1_1, 20, baseFee)
+	require.NoError(t, err)
+	assert.Equal(t, abi.RegisteredAggregationProof_SnarkPackV2, est.AggregationProof)
+	expected := big.Div(big.Product(big.Mul(big.NewInt(5), builtin.OneNanoFIL), big.NewInt(49299973), big.NewInt(20)), big.NewInt(20))
+	assert.Equal(t, expected, est.NetworkFee)
+
+	est, err = EstimateProveCommitAggregate(network.Version25, abi.RegisteredSealProof_StackedDrg32GiBV1_2_Feat_NiPoRep, 20, baseFee)
+	require.NoError(t, err)
+	assert.True(t, est.NetworkFee.IsZero())
+
+	_, err = EstimateProveCommitAggregate(network.Version25, abi.RegisteredSealProof_StackedDrg32GiBV1_2_Feat_NiPoRep, 100, baseFee)
+	assert.Error(t, err)
+	_, err = EstimateProveCommitAggregate(network.Version21, abi.RegisteredSealProof_StackedDrg32GiBV1_2_Feat_NiPoRep, 20, baseFee)
+	assert.Error(t, err)
+}
+
+func TestAggregatedSectorsRange(t *testing.T) {
+	// Every actors version from 8 bounds aggregates, and its largest aggregate fits the maximum proof size.
+	for nv := network.Version16; nv <= network.Version27; nv++ {
+		minSectors, maxSectors, err := aggregatedSectorsRange(nv)
+		require.NoError(t, err)
+		assert.Equal(t, 4, minSectors)
+		assert.Equal(t, 819, maxSectors)
+		size, err := ProofSize(abi.RegisteredAggregationProof_SnarkPackV2, abi.RegisteredSealProof_StackedDrg32GiBV1_1, maxSectors)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, size, uint64(miner19.MaxAggregateProofSize))
+	}
+
+	// Aggregation predates actors version 8, whose policies are not in this module.
+	_, _, err := aggregatedSectorsRange(network.Version13)
+	assert.Error(t, err)
+	_, err = EstimateProveCommitAggregate(network.Version13, abi.RegisteredSealProof_StackedDrg32GiBV1_1, 20, abi.NewTokenAmount(100))
+	assert.Error(t, err)
+}
+
+func TestBreakEven(t *testing.T) {
+	// An aggregate costs a fixed 200M gas plus 5M per sector.
+	gas := func(n int) int64 { return 200_000_000 + 5_000_000*int64(n) }
+
+	// Without a network fee, aggregation pays off once the fixed cost is amortized.
+	n, ok, err := BreakEven(network.Version25, abi.NewTokenAmount(100), gas)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, 5, n)
+
+	// At a low base fee, the batch balancer makes aggregation uneconomic.
+	_, ok, err = BreakEven(network.Version21, abi.NewTokenAmount(100), gas)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
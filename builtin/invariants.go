@@ -0,0 +1,295 @@
+package builtin
+
+// Identifiers of invariants checked by CheckStateInvariants, stable across actors versions.
+// Violations of an identified invariant carry its ID, so that monitoring can match on the ID rather than
+// on the rendered message.
+
+// Invariants checked across actors.
+const (
+	// Addresses and actor IDs referenced across actors could be converted into each other.
+	InvariantIDAddress    = "state.id_address"
+	InvariantTotalBalance = "state.total_balance"
+	// Every actor in the state tree is keyed by an ID address.
+	InvariantActorAddress          = "state.actor_address"
+	InvariantActorDelegatedAddress = "state.actor_delegated_address"
+	// Placeholder, EthAccount and EAM actors have the empty object as their state.
+	InvariantEmptyActorHead         = "state.empty_actor_head"
+	InvariantDelegatedAddressMapped = "init.delegated_address_mapped"
+	InvariantMinerPowerClaim        = "power.miner_claim_exists"
+	InvariantMinerActivePower       = "power.miner_claim_matches_active_power"
+	InvariantMinerClaimProofType    = "power.miner_claim_proof_type"
+	InvariantMinerCronPayload       = "power.miner_cron_payload"
+	InvariantMinerCronEventType     = "power.miner_cron_event_type"
+	InvariantMinerCronDuplicate     = "power.miner_cron_duplicate"
+	InvariantMinerDeadlineCron      = "power.miner_deadline_cron"
+	InvariantMinerProvingPeriodCron = "power.miner_proving_period_cron"
+	InvariantDealProvider           = "market.deal_provider_exists"
+	InvariantDealSector             = "market.deal_sector_exists"
+	InvariantDealSectorStart        = "market.deal_sector_start"
+	InvariantDealActivation         = "market.deal_activation"
+	InvariantDealLastUpdated        = "market.deal_last_updated"
+	InvariantDealSlashEpoch         = "market.deal_slash_epoch"
+	InvariantDealSectorNumber       = "market.deal_sector_number"
+	InvariantDealSectorUnique       = "market.deal_sector_unique"
+	InvariantDealExists             = "market.deal_exists"
+	InvariantDealClaim              = "market.deal_claim_exists"
+	InvariantDealClaimProvider      = "market.deal_claim_provider"
+	InvariantDealClaimPiece         = "market.deal_claim_piece"
+	InvariantDealAllocation         = "market.deal_allocation_exists"
+	InvariantDealAllocationProvider = "market.deal_allocation_provider"
+	InvariantDealAllocationPiece    = "market.deal_allocation_piece"
+	InvariantClaimProvider          = "verifreg.claim_provider_exists"
+	InvariantClaimSector            = "verifreg.claim_sector_has_deals"
+	// The verified registry holds exactly the datacap of its pending allocations.
+	InvariantVerifregDatacapBalanceExists = "datacap.verifreg_balance_exists"
+	InvariantVerifregDatacapBalance       = "datacap.verifreg_balance"
+)
+
+// Reported for violations recorded without an ID and without a message template.
+const InvariantUnclassified = "unclassified"
+
+// Miner actor invariants.
+const (
+	// The state and its collections could be loaded and decoded.
+	InvariantMinerStateLoadable                = "miner.state_loadable"
+	InvariantMinerSectorPowerBaseEpoch         = "miner.sector_power_base_epoch"
+	InvariantMinerSectorAllocated              = "miner.sector_allocated"
+	InvariantMinerCurrentDeadline              = "miner.current_deadline"
+	InvariantMinerPartitionSequence            = "miner.partition_sequence"
+	InvariantMinerPartitionDuplicateSector     = "miner.partition_duplicate_sector"
+	InvariantMinerProvenPartitions             = "miner.proven_partitions"
+	InvariantMinerProvenLiveSectors            = "miner.proven_live_sectors"
+	InvariantMinerSnapshotRecoveringPower      = "miner.snapshot_recovering_power"
+	InvariantMinerSnapshotRecoveries           = "miner.snapshot_recoveries"
+	InvariantMinerSnapshotUnprovenPower        = "miner.snapshot_unproven_power"
+	InvariantMinerSnapshotUnproven             = "miner.snapshot_unproven"
+	InvariantMinerSnapshotProofPartition       = "miner.snapshot_proof_partition"
+	InvariantMinerDeadlineLiveSectors          = "miner.deadline_live_sectors"
+	InvariantMinerDeadlineTotalSectors         = "miner.deadline_total_sectors"
+	InvariantMinerDeadlineFaultyPower          = "miner.deadline_faulty_power"
+	InvariantMinerDeadlineLivePower            = "miner.deadline_live_power"
+	InvariantMinerDeadlineDailyFee             = "miner.deadline_daily_fee"
+	InvariantMinerDeadlineExpirationEntry      = "miner.deadline_expiration_entry"
+	InvariantMinerDeadlineExpirationPartition  = "miner.deadline_expiration_partition"
+	InvariantMinerPartitionLivePower           = "miner.partition_live_power"
+	InvariantMinerPartitionUnprovenPower       = "miner.partition_unproven_power"
+	InvariantMinerPartitionFaultyPower         = "miner.partition_faulty_power"
+	InvariantMinerPartitionRecoveringPower     = "miner.partition_recovering_power"
+	InvariantMinerPartitionActivePower         = "miner.partition_active_power"
+	InvariantMinerPartitionSectorsExist        = "miner.partition_sectors_exist"
+	InvariantMinerExpirationQueueDuplicate     = "miner.expiration_queue_duplicate"
+	InvariantMinerExpirationQueueEpoch         = "miner.expiration_queue_epoch"
+	InvariantMinerExpirationQueueSectorLive    = "miner.expiration_queue_sector_live"
+	InvariantMinerEarlyExpirationFaulty        = "miner.early_expiration_faulty"
+	InvariantMinerEarlyExpirationEpoch         = "miner.early_expiration_epoch"
+	InvariantMinerExpirationQueueSectorsLive   = "miner.expiration_queue_sectors_live"
+	InvariantMinerExpirationQueueActivePower   = "miner.expiration_queue_active_power"
+	InvariantMinerExpirationQueueFaultyPower   = "miner.expiration_queue_faulty_power"
+	InvariantMinerExpirationQueuePledge        = "miner.expiration_queue_pledge"
+	InvariantMinerExpirationQueueDailyFee      = "miner.expiration_queue_daily_fee"
+	InvariantMinerExpirationQueueQuantized     = "miner.expiration_queue_quantized"
+	InvariantMinerEarlyTerminationDuplicate    = "miner.early_termination_duplicate"
+	InvariantMinerOwnerAddress                 = "miner.owner_address"
+	InvariantMinerWorkerAddress                = "miner.worker_address"
+	InvariantMinerControlAddress               = "miner.control_address"
+	InvariantMinerPendingWorkerAddress         = "miner.pending_worker_address"
+	InvariantMinerPendingWorkerChange          = "miner.pending_worker_change"
+	InvariantMinerPendingOwnerAddress          = "miner.pending_owner_address"
+	InvariantMinerPendingOwnerChange           = "miner.pending_owner_change"
+	InvariantMinerWindowPoStProofType          = "miner.window_post_proof_type"
+	InvariantMinerSectorSize                   = "miner.sector_size"
+	InvariantMinerPoStProofPolicy              = "miner.post_proof_policy"
+	InvariantMinerPartitionSectors             = "miner.partition_sectors"
+	InvariantMinerBalanceNonNegative           = "miner.balance_non_negative"
+	InvariantMinerLockedFundsNonNegative       = "miner.locked_funds_non_negative"
+	InvariantMinerPreCommitDepositsNonNegative = "miner.pre_commit_deposits_non_negative"
+	InvariantMinerInitialPledgeNonNegative     = "miner.initial_pledge_non_negative"
+	InvariantMinerFeeDebtNonNegative           = "miner.fee_debt_non_negative"
+	InvariantMinerBalanceCoversRequirements    = "miner.balance_covers_requirements"
+	InvariantMinerVestingAmountPositive        = "miner.vesting_amount_positive"
+	InvariantMinerVestingEpochQuantized        = "miner.vesting_epoch_quantized"
+	InvariantMinerVestingLockedFunds           = "miner.vesting_locked_funds"
+	InvariantMinerDeadlineCronActive           = "miner.deadline_cron_active"
+	InvariantMinerPreCommitExpirationQuantized = "miner.pre_commit_expiration_quantized"
+	InvariantMinerPreCommitAllocated           = "miner.pre_commit_allocated"
+	InvariantMinerPreCommitCleanUp             = "miner.pre_commit_clean_up"
+	InvariantMinerPreCommitDeposits            = "miner.pre_commit_deposits"
+	InvariantMinerPartitionLiveActive          = "miner.partition_live_active"
+	InvariantMinerPartitionLiveFaults          = "miner.partition_live_faults"
+	InvariantMinerPartitionLiveUnproven        = "miner.partition_live_unproven"
+	InvariantMinerPartitionFaultsRecoveries    = "miner.partition_faults_recoveries"
+	InvariantMinerPartitionSectorsTerminated   = "miner.partition_sectors_terminated"
+	InvariantMinerPartitionActiveFaults        = "miner.partition_active_faults"
+	InvariantMinerPartitionActiveUnproven      = "miner.partition_active_unproven"
+	InvariantMinerPartitionUnprovenFaults      = "miner.partition_unproven_faults"
+	InvariantMinerPartitionLiveTerminated      = "miner.partition_live_terminated"
+	InvariantMinerPartitionExpirations         = "miner.partition_expirations"
+	InvariantMinerPartitionEarlyTerminations   = "miner.partition_early_terminations"
+	InvariantMinerDeadlineEarlyTerminations    = "miner.deadline_early_terminations"
+)
+
+// Storage market actor invariants.
+const (
+	// The state and its collections could be loaded and decoded.
+	InvariantMarketStateLoadable                 = "market.state_loadable"
+	InvariantMarketClientCollateralNonNegative   = "market.client_collateral_non_negative"
+	InvariantMarketProviderCollateralNonNegative = "market.provider_collateral_non_negative"
+	InvariantMarketClientStorageFeeNonNegative   = "market.client_storage_fee_non_negative"
+	InvariantMarketDealClientAddress             = "market.deal_client_address"
+	InvariantMarketDealProviderAddress           = "market.deal_provider_address"
+	InvariantMarketNextID                        = "market.next_id"
+	InvariantMarketPendingAllocationProposal     = "market.pending_allocation_proposal"
+	InvariantMarketDealStateSectorStart          = "market.deal_state_sector_start"
+	InvariantMarketDealStateLastUpdated          = "market.deal_state_last_updated"
+	InvariantMarketDealStateLastUpdatedCurrent   = "market.deal_state_last_updated_current"
+	InvariantMarketDealStateSlashEpoch           = "market.deal_state_slash_epoch"
+	InvariantMarketDealStateSlashEpochCurrent    = "market.deal_state_slash_epoch_current"
+	InvariantMarketDealStateProposal             = "market.deal_state_proposal"
+	InvariantMarketDealStatePendingAllocation    = "market.deal_state_pending_allocation"
+	InvariantMarketPendingProposal               = "market.pending_proposal"
+	InvariantMarketLockedWithinEscrow            = "market.locked_within_escrow"
+	InvariantMarketLockedTotal                   = "market.locked_total"
+	InvariantMarketEscrowWithinBalance           = "market.escrow_within_balance"
+	InvariantMarketEscrowCoversCollateral        = "market.escrow_covers_collateral"
+	InvariantMarketDealOpProposal                = "market.deal_op_proposal"
+	InvariantMarketProposalDealOps               = "market.proposal_deal_ops"
+	InvariantMarketProviderSectorDeals           = "market.provider_sector_deals"
+	InvariantMarketProviderSectorNumber          = "market.provider_sector_number"
+	InvariantMarketProviderSectorUnexpectedDeal  = "market.provider_sector_unexpected_deal"
+	InvariantMarketProviderSectorProvider        = "market.provider_sector_provider"
+	InvariantMarketProviderSectorsMissing        = "market.provider_sectors_missing"
+)
+
+// Storage power actor invariants.
+const (
+	// The state and its collections could be loaded and decoded.
+	InvariantPowerStateLoadable            = "power.state_loadable"
+	InvariantPowerTotalRawNonNegative      = "power.total_raw_non_negative"
+	InvariantPowerTotalQANonNegative       = "power.total_qa_non_negative"
+	InvariantPowerCommittedRawNonNegative  = "power.committed_raw_non_negative"
+	InvariantPowerCommittedQANonNegative   = "power.committed_qa_non_negative"
+	InvariantPowerTotalRawWithinQA         = "power.total_raw_within_qa"
+	InvariantPowerCommittedRawWithinQA     = "power.committed_raw_within_qa"
+	InvariantPowerTotalRawWithinCommitted  = "power.total_raw_within_committed"
+	InvariantPowerTotalQAWithinCommitted   = "power.total_qa_within_committed"
+	InvariantPowerCronKey                  = "power.cron_key"
+	InvariantPowerCronEpoch                = "power.cron_epoch"
+	InvariantPowerConsensusMinerMinPower   = "power.consensus_miner_min_power"
+	InvariantPowerClaimsRawCommitted       = "power.claims_raw_committed"
+	InvariantPowerClaimsQACommitted        = "power.claims_qa_committed"
+	InvariantPowerMinerAboveMinPowerCount  = "power.miner_above_min_power_count"
+	InvariantPowerClaimsRaw                = "power.claims_raw"
+	InvariantPowerClaimsQA                 = "power.claims_qa"
+	InvariantPowerProofValidationClaim     = "power.proof_validation_claim"
+	InvariantPowerProofValidationProofType = "power.proof_validation_proof_type"
+	InvariantPowerProofValidationBatchSize = "power.proof_validation_batch_size"
+)
+
+// Verified registry actor invariants.
+const (
+	// The state and its collections could be loaded and decoded.
+	InvariantVerifregStateLoadable        = "verifreg.state_loadable"
+	InvariantVerifregRootKeyAddress       = "verifreg.root_key_address"
+	InvariantVerifregVerifierAddress      = "verifreg.verifier_address"
+	InvariantVerifregVerifierCap          = "verifreg.verifier_cap"
+	InvariantVerifregClientAddress        = "verifreg.client_address"
+	InvariantVerifregClientCap            = "verifreg.client_cap"
+	InvariantVerifregVerifierClient       = "verifreg.verifier_client"
+	InvariantVerifregAllocationID         = "verifreg.allocation_id"
+	InvariantVerifregAllocationClient     = "verifreg.allocation_client"
+	InvariantVerifregAllocationSize       = "verifreg.allocation_size"
+	InvariantVerifregAllocationTermMin    = "verifreg.allocation_term_min"
+	InvariantVerifregAllocationTermMax    = "verifreg.allocation_term_max"
+	InvariantVerifregAllocationTerm       = "verifreg.allocation_term"
+	InvariantVerifregAllocationExpiration = "verifreg.allocation_expiration"
+	InvariantVerifregClaimID              = "verifreg.claim_id"
+	InvariantVerifregClaimProvider        = "verifreg.claim_provider"
+	InvariantVerifregClaimSize            = "verifreg.claim_size"
+	InvariantVerifregClaimTermMin         = "verifreg.claim_term_min"
+	InvariantVerifregClaimTerm            = "verifreg.claim_term"
+	InvariantVerifregClaimTermStart       = "verifreg.claim_term_start"
+)
+
+// Datacap actor invariants.
+const (
+	// The state and its collections could be loaded and decoded.
+	InvariantDatacapStateLoadable     = "datacap.state_loadable"
+	InvariantDatacapGovernorAddress   = "datacap.governor_address"
+	InvariantDatacapSupplyNonNegative = "datacap.supply_non_negative"
+	InvariantDatacapBalancePositive   = "datacap.balance_positive"
+	InvariantDatacapSupply            = "datacap.supply"
+	InvariantDatacapSelfAllowance     = "datacap.self_allowance"
+	InvariantDatacapAllowancePositive = "datacap.allowance_positive"
+)
+
+// Init actor invariants.
+const (
+	// The state and its collections could be loaded and decoded.
+	InvariantInitStateLoadable      = "init.state_loadable"
+	InvariantInitNetworkName        = "init.network_name"
+	InvariantInitNextID             = "init.next_id"
+	InvariantInitKeyNotID           = "init.key_not_id"
+	InvariantInitKeyProtocol        = "init.key_protocol"
+	InvariantInitMappedNonSingleton = "init.mapped_non_singleton"
+	InvariantInitDuplicateMapping   = "init.duplicate_mapping"
+	// The actor an address is mapped to could be addressed and loaded from the state tree.
+	InvariantInitMappedActorLoadable   = "init.mapped_actor_loadable"
+	InvariantInitMappedActorExists     = "init.mapped_actor_exists"
+	InvariantInitDelegatedAddressActor = "init.delegated_address_actor"
+	InvariantInitDelegatedAddress      = "init.delegated_address"
+)
+
+// Cron actor invariants.
+const (
+	InvariantCronEntryReceiver = "cron.entry_receiver"
+	InvariantCronEntryMethod   = "cron.entry_method"
+)
+
+// Account actor invariants.
+const (
+	// The actor's key in the state tree is an ID address.
+	InvariantAccountIDAddress       = "account.id_address"
+	InvariantAccountAddressProtocol = "account.address_protocol"
+)
+
+// Multisig actor invariants.
+const (
+	// The state and its collections could be loaded and decoded.
+	InvariantMultisigStateLoadable     = "multisig.state_loadable"
+	InvariantMultisigSignersMax        = "multisig.signers_max"
+	InvariantMultisigThreshold         = "multisig.threshold"
+	InvariantMultisigStartEpoch        = "multisig.start_epoch"
+	InvariantMultisigInitialBalance    = "multisig.initial_balance"
+	InvariantMultisigApprovalSigner    = "multisig.approval_signer"
+	InvariantMultisigApprovalDuplicate = "multisig.approval_duplicate"
+	InvariantMultisigNextTxnID         = "multisig.next_txn_id"
+)
+
+// Payment channel actor invariants.
+const (
+	// The state and its collections could be loaded and decoded.
+	InvariantPaychStateLoadable       = "paych.state_loadable"
+	InvariantPaychFromAddress         = "paych.from_address"
+	InvariantPaychToAddress           = "paych.to_address"
+	InvariantPaychSettlingAt          = "paych.settling_at"
+	InvariantPaychLaneRedeemed        = "paych.lane_redeemed"
+	InvariantPaychBalanceCoversToSend = "paych.balance_covers_to_send"
+)
+
+// Reward actor invariants.
+const (
+	InvariantRewardStorageMiningAllocation   = "reward.storage_mining_allocation"
+	InvariantRewardEpoch                     = "reward.epoch"
+	InvariantRewardEffectiveNetworkTime      = "reward.effective_network_time"
+	InvariantRewardCumsumRealized            = "reward.cumsum_realized"
+	InvariantRewardCumsumRealizedNonNegative = "reward.cumsum_realized_non_negative"
+	InvariantRewardEffectiveBaselinePower    = "reward.effective_baseline_power"
+)
+
+// EVM actor invariants.
+const (
+	// The state and its bytecode could be loaded and decoded.
+	InvariantEVMStateLoadable = "evm.state_loadable"
+	InvariantEVMNonce         = "evm.nonce"
+	InvariantEVMBytecodeHash  = "evm.bytecode_hash"
+)
@@ -1,17 +1,97 @@
 package builtin
 
 import (
+	"encoding/json"
 	"fmt"
+
+	addr "github.com/filecoin-project/go-address"
+)
+
+// Severity of an invariant violation.
+type Severity uint8
+
+const (
+	// The state is inconsistent.
+	SeverityError Severity = iota
+	// The state is unusual but not necessarily inconsistent.
+	SeverityWarning
 )
 
+func (s Severity) String() string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	default:
+		return fmt.Sprintf("severity(%d)", uint8(s))
+	}
+}
+
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// Structured values attached to a violation, e.g. "expected" and "actual".
+type ViolationFields map[string]interface{}
+
+// A single invariant violation recorded by a MessageAccumulator.
+type Violation struct {
+	// Identifier of the violated invariant, stable across actors versions.
+	// Violations recorded without an explicit ID use the unformatted message template, or
+	// InvariantUnclassified if there is none.
+	ID       string
+	Severity Severity
+	// Actor the violation relates to, or address.Undef.
+	Actor addr.Address
+	// Prefixes describing higher level context, as accumulated by WithPrefix.
+	Context string
+	// Formatted message, excluding the context.
+	Message string
+	Fields  ViolationFields
+	// Unformatted arguments of the message, for violations recorded with a message template.
+	Args []interface{}
+}
+
+// Renders the violation as a message line, as returned by MessageAccumulator.Messages.
+func (v Violation) String() string {
+	return v.Context + v.Message
+}
+
+func (v Violation) MarshalJSON() ([]byte, error) {
+	type violationJSON struct {
+		ID       string          `json:"id"`
+		Severity Severity        `json:"severity"`
+		Actor    string          `json:"actor,omitempty"`
+		Context  string          `json:"context,omitempty"`
+		Message  string          `json:"message"`
+		Fields   ViolationFields `json:"fields,omitempty"`
+		Args     []interface{}   `json:"args,omitempty"`
+	}
+	out := violationJSON{
+		ID:       v.ID,
+		Severity: v.Severity,
+		Context:  v.Context,
+		Message:  v.Message,
+		Fields:   v.Fields,
+		Args:     v.Args,
+	}
+	if v.Actor != addr.Undef {
+		out.Actor = v.Actor.String()
+	}
+	return json.Marshal(out)
+}
+
 // Accumulates a sequence of messages (e.g. validation failures).
 type MessageAccumulator struct {
-	// Accumulated messages.
+	// Accumulated violations.
 	// This is a pointer to support accumulators derived from `WithPrefix()` accumulating to
 	// the same underlying collection.
-	msgs *[]string
+	msgs *[]Violation
 	// Optional prefix to all new messages, e.g. describing higher level context.
 	prefix string
+	// Optional actor to which all new messages relate.
+	actor addr.Address
 }
 
 // Returns a new accumulator backed by the same collection, that will prefix each new message with
@@ -21,14 +101,36 @@ func (ma *MessageAccumulator) WithPrefix(format string, args ...interface{}) *Me
 	return &MessageAccumulator{
 		msgs:   ma.msgs,
 		prefix: ma.prefix + fmt.Sprintf(format, args...),
+		actor:  ma.actor,
 	}
 }
 
+// Returns a new accumulator backed by the same collection, that will attribute each new message
+// to an actor and prefix it with the actor's address.
+func (ma *MessageAccumulator) WithActor(a addr.Address) *MessageAccumulator {
+	derived := ma.WithPrefix("%v ", a)
+	derived.actor = a
+	return derived
+}
+
 func (ma *MessageAccumulator) IsEmpty() bool {
 	return ma.msgs == nil || len(*ma.msgs) == 0
 }
 
+// Returns the accumulated violations rendered as strings.
 func (ma *MessageAccumulator) Messages() []string {
+	if ma.msgs == nil {
+		return nil
+	}
+	msgs := make([]string, len(*ma.msgs))
+	for i, v := range *ma.msgs {
+		msgs[i] = v.String()
+	}
+	return msgs
+}
+
+// Returns the accumulated violations.
+func (ma *MessageAccumulator) Violations() []Violation {
 	if ma.msgs == nil {
 		return nil
 	}
@@ -37,13 +139,31 @@ func (ma *MessageAccumulator) Messages() []string {
 
 // Adds messages to the accumulator.
 func (ma *MessageAccumulator) Add(msg string) {
-	ma.initialize()
-	*ma.msgs = append(*ma.msgs, ma.prefix+msg)
+	ma.Report(Violation{ID: InvariantUnclassified, Message: msg})
 }
 
 // Adds a message to the accumulator
 func (ma *MessageAccumulator) Addf(format string, args ...interface{}) {
-	ma.Add(fmt.Sprintf(format, args...))
+	ma.Report(Violation{ID: format, Message: fmt.Sprintf(format, args...), Args: args})
+}
+
+// Adds a violation of the identified invariant, with structured fields, to the accumulator.
+func (ma *MessageAccumulator) AddInvariantf(id string, fields ViolationFields, format string, args ...interface{}) {
+	ma.Report(Violation{ID: id, Message: fmt.Sprintf(format, args...), Fields: fields, Args: args})
+}
+
+// Adds a violation to the accumulator, applying the accumulator's prefix and actor.
+// An empty ID defaults to InvariantUnclassified.
+func (ma *MessageAccumulator) Report(v Violation) {
+	ma.initialize()
+	if v.ID == "" {
+		v.ID = InvariantUnclassified
+	}
+	if v.Actor == addr.Undef {
+		v.Actor = ma.actor
+	}
+	v.Context = ma.prefix + v.Context
+	*ma.msgs = append(*ma.msgs, v)
 }
 
 // Adds messages from another accumulator to this one.
@@ -51,28 +171,52 @@ func (ma *MessageAccumulator) AddAll(other *MessageAccumulator) {
 	if other.msgs == nil {
 		return
 	}
-	for _, msg := range *other.msgs {
-		ma.Add(msg)
+	for _, v := range *other.msgs {
+		ma.Report(v)
 	}
 }
 
 // Adds a message if predicate is false.
 func (ma *MessageAccumulator) Require(predicate bool, msg string, args ...interface{}) {
 	if !predicate {
-		ma.Add(fmt.Sprintf(msg, args...))
+		ma.Addf(msg, args...)
+	}
+}
+
+// Adds a violation of the identified invariant, with structured fields, if predicate is false.
+func (ma *MessageAccumulator) RequireInvariant(id string, predicate bool, fields ViolationFields, msg string, args ...interface{}) {
+	if !predicate {
+		ma.AddInvariantf(id, fields, msg, args...)
+	}
+}
+
+// Adds a warning for the identified invariant, with structured fields, if predicate is false.
+// Warnings flag state that is unusual but not necessarily inconsistent, such as values outside the
+// current policy that an earlier policy permitted.
+func (ma *MessageAccumulator) ExpectInvariant(id string, predicate bool, fields ViolationFields, msg string, args ...interface{}) {
+	if !predicate {
+		ma.Report(Violation{ID: id, Severity: SeverityWarning, Message: fmt.Sprintf(msg, args...), Fields: fields, Args: args})
 	}
 }
 
 func (ma *MessageAccumulator) RequireNoError(err error, msg string, args ...interface{}) {
+	ma.RequireInvariantNoError(msg, err, msg, args...)
+}
+
+// Adds a violation of the identified invariant if err is not nil, appending the error to the message.
+func (ma *MessageAccumulator) RequireInvariantNoError(id string, err error, msg string, args ...interface{}) {
 	if err != nil {
-		msg = msg + ": %v"
-		args = append(args, err)
-		ma.Addf(msg, args...)
+		ma.Report(Violation{
+			ID:      id,
+			Message: fmt.Sprintf(msg+": %v", append(args, err)...),
+			Fields:  ViolationFields{"error": err.Error()},
+			Args:    args,
+		})
 	}
 }
 
 func (ma *MessageAccumulator) initialize() {
 	if ma.msgs == nil {
-		ma.msgs = &[]string{}
+		ma.msgs = &[]Violation{}
 	}
 }
@@ -0,0 +1,71 @@
+package builtin
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/big"
+)
+
+func TestMessageAccumulatorViolations(t *testing.T) {
+	miner, err := addr.NewIDAddress(1000)
+	require.NoError(t, err)
+
+	acc := &MessageAccumulator{}
+	require.True(t, acc.IsEmpty())
+
+	minerAcc := &MessageAccumulator{}
+	minerAcc.WithPrefix("deadline %d: ", 3).Require(false, "sector %d missing", 7)
+	acc.WithActor(miner).AddAll(minerAcc)
+	acc.RequireInvariant(InvariantTotalBalance, false,
+		ViolationFields{"expected": big.NewInt(2), "actual": big.NewInt(1)},
+		"total token balance is %v, expected %v", big.NewInt(1), big.NewInt(2))
+	acc.RequireInvariant(InvariantTotalBalance, true, nil, "not reported")
+
+	require.Equal(t, []string{
+		"f01000 deadline 3: sector 7 missing",
+		"total token balance is 1, expected 2",
+	}, acc.Messages())
+
+	vs := acc.Violations()
+	require.Len(t, vs, 2)
+	require.Equal(t, "sector %d missing", vs[0].ID)
+	require.Equal(t, miner, vs[0].Actor)
+	require.Equal(t, "f01000 deadline 3: ", vs[0].Context)
+	require.Equal(t, []interface{}{7}, vs[0].Args)
+	require.Equal(t, InvariantTotalBalance, vs[1].ID)
+	require.Equal(t, addr.Undef, vs[1].Actor)
+	require.Equal(t, SeverityError, vs[1].Severity)
+
+	out, err := json.Marshal(vs)
+	require.NoError(t, err)
+	require.JSONEq(t, `[
+		{"id": "sector %d missing", "severity": "error", "actor": "f01000", "context": "f01000 deadline 3: ", "message": "sector 7 missing", "args": [7]},
+		{"id": "state.total_balance", "severity": "error", "message": "total token balance is 1, expected 2", "fields": {"expected": "2", "actual": "1"}, "args": ["1", "2"]}
+	]`, string(out))
+}
+
+func TestMessageAccumulatorSeverities(t *testing.T) {
+	acc := &MessageAccumulator{}
+	acc.ExpectInvariant(InvariantVerifregClaimSize, false, ViolationFields{"claim": 1, "actual": 64},
+		"claim %d size %d too small", 1, 64)
+	acc.ExpectInvariant(InvariantVerifregClaimSize, true, nil, "not reported")
+	acc.RequireInvariantNoError(InvariantMinerStateLoadable, errors.New("not found"), "error loading deadlines")
+	acc.RequireInvariantNoError(InvariantMinerStateLoadable, nil, "not reported")
+	acc.Add("free-form message")
+
+	vs := acc.Violations()
+	require.Len(t, vs, 3)
+	require.Equal(t, InvariantVerifregClaimSize, vs[0].ID)
+	require.Equal(t, SeverityWarning, vs[0].Severity)
+	require.Equal(t, InvariantMinerStateLoadable, vs[1].ID)
+	require.Equal(t, SeverityError, vs[1].Severity)
+	require.Equal(t, "error loading deadlines: not found", vs[1].Message)
+	require.Equal(t, ViolationFields{"error": "not found"}, vs[1].Fields)
+	require.Equal(t, InvariantUnclassified, vs[2].ID)
+	require.Equal(t, "free-form message", vs[2].Message)
+}
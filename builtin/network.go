@@ -3,6 +3,7 @@ package builtin
 import (
 	"fmt"
 
+	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
 )
 
@@ -14,6 +15,8 @@ import (
 // can override it at runtime. Doing so requires changing all the static references to it in this repo to go through
 // late-binding function calls, or they'll see the "wrong" value.
 // https://github.com/filecoin-project/specs-actors/issues/353
+// Networks with a different epoch duration apply a profile from the builtin/profile package, which sets the
+// NetworkEpochsIn* values below and the epoch-denominated policy values derived from them.
 // If EpochDurationSeconds is changed, update `BaselineExponent`, `lambda`, and // `expLamSubOne` in ./reward/reward_logic.go
 // You can re-calculate these constants by changing the epoch duration in ./reward/reward_calc.py and running it.
 const EpochDurationSeconds = 30
@@ -23,6 +26,43 @@ const EpochsInHour = SecondsInHour / EpochDurationSeconds
 const EpochsInDay = 24 * EpochsInHour
 const EpochsInYear = 365 * EpochsInDay
 
+// The epoch duration of the network and the epoch counts derived from it. They default to the constants
+// above and are set by a network profile.
+var (
+	networkEpochDurationSeconds int64          = EpochDurationSeconds
+	networkEpochsInHour         abi.ChainEpoch = EpochsInHour
+)
+
+// NetworkEpochDurationSeconds returns the duration of a chain epoch of the network.
+func NetworkEpochDurationSeconds() int64 {
+	return networkEpochDurationSeconds
+}
+
+// NetworkEpochsInHour returns the number of epochs in an hour of the network.
+func NetworkEpochsInHour() abi.ChainEpoch {
+	return networkEpochsInHour
+}
+
+// NetworkEpochsInDay returns the number of epochs in a day of the network.
+func NetworkEpochsInDay() abi.ChainEpoch {
+	return 24 * networkEpochsInHour
+}
+
+// NetworkEpochsInYear returns the number of epochs in a year of the network.
+func NetworkEpochsInYear() abi.ChainEpoch {
+	return 365 * NetworkEpochsInDay()
+}
+
+// SetNetworkEpochDuration sets the duration of a chain epoch of the network, which must be positive.
+// Epoch counts are rounded down to whole epochs per hour.
+func SetNetworkEpochDuration(seconds int64) {
+	if seconds <= 0 {
+		panic(fmt.Sprintf("epoch duration %d must be positive", seconds))
+	}
+	networkEpochDurationSeconds = seconds
+	networkEpochsInHour = abi.ChainEpoch(SecondsInHour / seconds)
+}
+
 // PARAM_SPEC
 // Expected number of block quality in an epoch (e.g. 1 block with block quality 5, or 5 blocks with quality 1)
 // Motivation: It ensures that there is enough on-chain throughput
@@ -0,0 +1,434 @@
+package profile
+
+import (
+	market10 "github.com/filecoin-project/go-state-types/builtin/v10/market"
+	miner10 "github.com/filecoin-project/go-state-types/builtin/v10/miner"
+	paych10 "github.com/filecoin-project/go-state-types/builtin/v10/paych"
+	reward10 "github.com/filecoin-project/go-state-types/builtin/v10/reward"
+	verifreg10 "github.com/filecoin-project/go-state-types/builtin/v10/verifreg"
+	market11 "github.com/filecoin-project/go-state-types/builtin/v11/market"
+	miner11 "github.com/filecoin-project/go-state-types/builtin/v11/miner"
+	paych11 "github.com/filecoin-project/go-state-types/builtin/v11/paych"
+	reward11 "github.com/filecoin-project/go-state-types/builtin/v11/reward"
+	verifreg11 "github.com/filecoin-project/go-state-types/builtin/v11/verifreg"
+	market12 "github.com/filecoin-project/go-state-types/builtin/v12/market"
+	miner12 "github.com/filecoin-project/go-state-types/builtin/v12/miner"
+	paych12 "github.com/filecoin-project/go-state-types/builtin/v12/paych"
+	reward12 "github.com/filecoin-project/go-state-types/builtin/v12/reward"
+	verifreg12 "github.com/filecoin-project/go-state-types/builtin/v12/verifreg"
+	market13 "github.com/filecoin-project/go-state-types/builtin/v13/market"
+	miner13 "github.com/filecoin-project/go-state-types/builtin/v13/miner"
+	paych13 "github.com/filecoin-project/go-state-types/builtin/v13/paych"
+	reward13 "github.com/filecoin-project/go-state-types/builtin/v13/reward"
+	verifreg13 "github.com/filecoin-project/go-state-types/builtin/v13/verifreg"
+	market14 "github.com/filecoin-project/go-state-types/builtin/v14/market"
+	miner14 "github.com/filecoin-project/go-state-types/builtin/v14/miner"
+	paych14 "github.com/filecoin-project/go-state-types/builtin/v14/paych"
+	reward14 "github.com/filecoin-project/go-state-types/builtin/v14/reward"
+	verifreg14 "github.com/filecoin-project/go-state-types/builtin/v14/verifreg"
+	market15 "github.com/filecoin-project/go-state-types/builtin/v15/market"
+	miner15 "github.com/filecoin-project/go-state-types/builtin/v15/miner"
+	paych15 "github.com/filecoin-project/go-state-types/builtin/v15/paych"
+	reward15 "github.com/filecoin-project/go-state-types/builtin/v15/reward"
+	verifreg15 "github.com/filecoin-project/go-state-types/builtin/v15/verifreg"
+	market16 "github.com/filecoin-project/go-state-types/builtin/v16/market"
+	miner16 "github.com/filecoin-project/go-state-types/builtin/v16/miner"
+	paych16 "github.com/filecoin-project/go-state-types/builtin/v16/paych"
+	reward16 "github.com/filecoin-project/go-state-types/builtin/v16/reward"
+	verifreg16 "github.com/filecoin-project/go-state-types/builtin/v16/verifreg"
+	market17 "github.com/filecoin-project/go-state-types/builtin/v17/market"
+	miner17 "github.com/filecoin-project/go-state-types/builtin/v17/miner"
+	paych17 "github.com/filecoin-project/go-state-types/builtin/v17/paych"
+	reward17 "github.com/filecoin-project/go-state-types/builtin/v17/reward"
+	verifreg17 "github.com/filecoin-project/go-state-types/builtin/v17/verifreg"
+	market18 "github.com/filecoin-project/go-state-types/builtin/v18/market"
+	miner18 "github.com/filecoin-project/go-state-types/builtin/v18/miner"
+	paych18 "github.com/filecoin-project/go-state-types/builtin/v18/paych"
+	reward18 "github.com/filecoin-project/go-state-types/builtin/v18/reward"
+	verifreg18 "github.com/filecoin-project/go-state-types/builtin/v18/verifreg"
+	market19 "github.com/filecoin-project/go-state-types/builtin/v19/market"
+	miner19 "github.com/filecoin-project/go-state-types/builtin/v19/miner"
+	paych19 "github.com/filecoin-project/go-state-types/builtin/v19/paych"
+	reward19 "github.com/filecoin-project/go-state-types/builtin/v19/reward"
+	verifreg19 "github.com/filecoin-project/go-state-types/builtin/v19/verifreg"
+	market8 "github.com/filecoin-project/go-state-types/builtin/v8/market"
+	miner8 "github.com/filecoin-project/go-state-types/builtin/v8/miner"
+	paych8 "github.com/filecoin-project/go-state-types/builtin/v8/paych"
+	reward8 "github.com/filecoin-project/go-state-types/builtin/v8/reward"
+	verifreg8 "github.com/filecoin-project/go-state-types/builtin/v8/verifreg"
+	market9 "github.com/filecoin-project/go-state-types/builtin/v9/market"
+	miner9 "github.com/filecoin-project/go-state-types/builtin/v9/miner"
+	paych9 "github.com/filecoin-project/go-state-types/builtin/v9/paych"
+	reward9 "github.com/filecoin-project/go-state-types/builtin/v9/reward"
+	verifreg9 "github.com/filecoin-project/go-state-types/builtin/v9/verifreg"
+)
+
+// Setters of the policy variables of each actors version, in version order.
+var versionAppliers = []func(p *Profile, r *rewardParams){
+	applyV8,
+	applyV9,
+	applyV10,
+	applyV11,
+	applyV12,
+	applyV13,
+	applyV14,
+	applyV15,
+	applyV16,
+	applyV17,
+	applyV18,
+	applyV19,
+}
+
+func applyV8(p *Profile, r *rewardParams) {
+	miner8.WPoStProvingPeriod = p.WPoStProvingPeriod
+	miner8.WPoStChallengeWindow = p.WPoStChallengeWindow
+	miner8.WPoStDisputeWindow = p.WPoStDisputeWindow
+	miner8.FaultMaxAge = p.FaultMaxAge
+	miner8.WindowPoStProofTypes = p.windowPoStProofTypes()
+	miner8.PreCommitChallengeDelay = p.PreCommitChallengeDelay
+	miner8.MaxPreCommitRandomnessLookback = p.MaxPreCommitRandomnessLookback
+	miner8.MaxProveCommitDuration = p.maxProveCommitDurations(miner8.MaxProveCommitDuration)
+	miner8.SetNetworkSectorExpiration(p.MinSectorExpiration, p.MaxSectorExpirationExtensionV8)
+	miner8.PreCommitDepositProjectionPeriod = p.PreCommitDepositProjectionPeriod
+	miner8.InitialPledgeProjectionPeriod = p.InitialPledgeProjectionPeriod
+
+	market8.DealMinDuration = p.DealMinDuration
+	market8.DealMaxDuration = p.DealMaxDurationV8
+
+	verifreg8.MinVerifiedDealSize = p.MinVerifiedDealSize.Copy()
+
+	paych8.SetNetworkSettleDelay(p.PaychSettleDelay)
+
+	reward8.BaselineExponent = r.baselineExponent.Copy()
+	reward8.Lambda = r.lambda.Copy()
+	reward8.ExpLamSubOne = r.expLamSubOne.Copy()
+}
+
+func applyV9(p *Profile, r *rewardParams) {
+	miner9.WPoStProvingPeriod = p.WPoStProvingPeriod
+	miner9.WPoStChallengeWindow = p.WPoStChallengeWindow
+	miner9.WPoStDisputeWindow = p.WPoStDisputeWindow
+	miner9.FaultMaxAge = p.FaultMaxAge
+	miner9.WindowPoStProofTypes = p.windowPoStProofTypes()
+	miner9.PreCommitChallengeDelay = p.PreCommitChallengeDelay
+	miner9.MaxPreCommitRandomnessLookback = p.MaxPreCommitRandomnessLookback
+	miner9.MaxProveCommitDuration = p.maxProveCommitDurations(miner9.MaxProveCommitDuration)
+	miner9.SetNetworkSectorExpiration(p.MinSectorExpiration, p.MaxSectorExpirationExtensionV8)
+	miner9.PreCommitDepositProjectionPeriod = p.PreCommitDepositProjectionPeriod
+	miner9.InitialPledgeProjectionPeriod = p.InitialPledgeProjectionPeriod
+
+	market9.DealMinDuration = p.DealMinDuration
+	market9.DealMaxDuration = p.DealMaxDurationV8
+	market9.MarketDefaultAllocationTermBuffer = p.MarketDefaultAllocationTermBuffer
+
+	verifreg9.MinVerifiedDealSize = p.MinVerifiedDealSize.Copy()
+	verifreg9.SetNetworkVerifiedAllocationTerms(p.MinimumVerifiedAllocationTerm, p.MaximumVerifiedAllocationTerm)
+	verifreg9.SetNetworkMaximumVerifiedAllocationExpiration(p.MaximumVerifiedAllocationExpiration)
+	verifreg9.SetNetworkEndOfLifeClaimDropPeriod(p.EndOfLifeClaimDropPeriod)
+
+	paych9.SetNetworkSettleDelay(p.PaychSettleDelay)
+
+	reward9.BaselineExponent = r.baselineExponent.Copy()
+	reward9.Lambda = r.lambda.Copy()
+	reward9.ExpLamSubOne = r.expLamSubOne.Copy()
+}
+
+func applyV10(p *Profile, r *rewardParams) {
+	miner10.WPoStProvingPeriod = p.WPoStProvingPeriod
+	miner10.WPoStChallengeWindow = p.WPoStChallengeWindow
+	miner10.WPoStDisputeWindow = p.WPoStDisputeWindow
+	miner10.FaultMaxAge = p.FaultMaxAge
+	miner10.WindowPoStProofTypes = p.windowPoStProofTypes()
+	miner10.PreCommitChallengeDelay = p.PreCommitChallengeDelay
+	miner10.MaxPreCommitRandomnessLookback = p.MaxPreCommitRandomnessLookback
+	miner10.MaxProveCommitDuration = p.maxProveCommitDurations(miner10.MaxProveCommitDuration)
+	miner10.SetNetworkSectorExpiration(p.MinSectorExpiration, p.MaxSectorExpirationExtensionV8)
+	miner10.PreCommitDepositProjectionPeriod = p.PreCommitDepositProjectionPeriod
+	miner10.InitialPledgeProjectionPeriod = p.InitialPledgeProjectionPeriod
+
+	market10.DealMinDuration = p.DealMinDuration
+	market10.DealMaxDuration = p.DealMaxDurationV8
+	market10.MarketDefaultAllocationTermBuffer = p.MarketDefaultAllocationTermBuffer
+
+	verifreg10.MinVerifiedDealSize = p.MinVerifiedDealSize.Copy()
+	verifreg10.SetNetworkVerifiedAllocationTerms(p.MinimumVerifiedAllocationTerm, p.MaximumVerifiedAllocationTerm)
+	verifreg10.SetNetworkMaximumVerifiedAllocationExpiration(p.MaximumVerifiedAllocationExpiration)
+	verifreg10.SetNetworkEndOfLifeClaimDropPeriod(p.EndOfLifeClaimDropPeriod)
+
+	paych10.SetNetworkSettleDelay(p.PaychSettleDelay)
+
+	reward10.BaselineExponent = r.baselineExponent.Copy()
+	reward10.Lambda = r.lambda.Copy()
+	reward10.ExpLamSubOne = r.expLamSubOne.Copy()
+}
+
+func applyV11(p *Profile, r *rewardParams) {
+	miner11.WPoStProvingPeriod = p.WPoStProvingPeriod
+	miner11.WPoStChallengeWindow = p.WPoStChallengeWindow
+	miner11.WPoStDisputeWindow = p.WPoStDisputeWindow
+	miner11.FaultMaxAge = p.FaultMaxAge
+	miner11.WindowPoStProofTypes = p.windowPoStProofTypes()
+	miner11.PreCommitChallengeDelay = p.PreCommitChallengeDelay
+	miner11.MaxPreCommitRandomnessLookback = p.MaxPreCommitRandomnessLookback
+	miner11.MaxProveCommitDuration = p.maxProveCommitDurations(miner11.MaxProveCommitDuration)
+	miner11.SetNetworkSectorExpiration(p.MinSectorExpiration, p.MaxSectorExpirationExtensionV8)
+	miner11.PreCommitDepositProjectionPeriod = p.PreCommitDepositProjectionPeriod
+	miner11.InitialPledgeProjectionPeriod = p.InitialPledgeProjectionPeriod
+
+	market11.DealMinDuration = p.DealMinDuration
+	market11.DealMaxDuration = p.DealMaxDurationV8
+	market11.MarketDefaultAllocationTermBuffer = p.MarketDefaultAllocationTermBuffer
+
+	verifreg11.MinVerifiedDealSize = p.MinVerifiedDealSize.Copy()
+	verifreg11.SetNetworkVerifiedAllocationTerms(p.MinimumVerifiedAllocationTerm, p.MaximumVerifiedAllocationTerm)
+	verifreg11.SetNetworkMaximumVerifiedAllocationExpiration(p.MaximumVerifiedAllocationExpiration)
+	verifreg11.SetNetworkEndOfLifeClaimDropPeriod(p.EndOfLifeClaimDropPeriod)
+
+	paych11.SetNetworkSettleDelay(p.PaychSettleDelay)
+
+	reward11.BaselineExponent = r.baselineExponent.Copy()
+	reward11.Lambda = r.lambda.Copy()
+	reward11.ExpLamSubOne = r.expLamSubOne.Copy()
+}
+
+func applyV12(p *Profile, r *rewardParams) {
+	miner12.WPoStProvingPeriod = p.WPoStProvingPeriod
+	miner12.WPoStChallengeWindow = p.WPoStChallengeWindow
+	miner12.WPoStDisputeWindow = p.WPoStDisputeWindow
+	miner12.FaultMaxAge = p.FaultMaxAge
+	miner12.WindowPoStProofTypes = p.windowPoStProofTypes()
+	miner12.PreCommitChallengeDelay = p.PreCommitChallengeDelay
+	miner12.MaxPreCommitRandomnessLookback = p.MaxPreCommitRandomnessLookback
+	miner12.MaxProveCommitDuration = p.maxProveCommitDurations(miner12.MaxProveCommitDuration)
+	miner12.SetNetworkSectorExpiration(p.MinSectorExpiration, p.MaxSectorExpirationExtension)
+	miner12.PreCommitDepositProjectionPeriod = p.PreCommitDepositProjectionPeriod
+	miner12.InitialPledgeProjectionPeriod = p.InitialPledgeProjectionPeriod
+
+	market12.DealMinDuration = p.DealMinDuration
+	market12.DealMaxDuration = p.DealMaxDuration
+	market12.MarketDefaultAllocationTermBuffer = p.MarketDefaultAllocationTermBuffer
+
+	verifreg12.MinVerifiedDealSize = p.MinVerifiedDealSize.Copy()
+	verifreg12.SetNetworkVerifiedAllocationTerms(p.MinimumVerifiedAllocationTerm, p.MaximumVerifiedAllocationTerm)
+	verifreg12.SetNetworkMaximumVerifiedAllocationExpiration(p.MaximumVerifiedAllocationExpiration)
+	verifreg12.SetNetworkEndOfLifeClaimDropPeriod(p.EndOfLifeClaimDropPeriod)
+
+	paych12.SetNetworkSettleDelay(p.PaychSettleDelay)
+
+	reward12.BaselineExponent = r.baselineExponent.Copy()
+	reward12.Lambda = r.lambda.Copy()
+	reward12.ExpLamSubOne = r.expLamSubOne.Copy()
+}
+
+func applyV13(p *Profile, r *rewardParams) {
+	miner13.WPoStProvingPeriod = p.WPoStProvingPeriod
+	miner13.WPoStChallengeWindow = p.WPoStChallengeWindow
+	miner13.WPoStDisputeWindow = p.WPoStDisputeWindow
+	miner13.FaultMaxAge = p.FaultMaxAge
+	miner13.WindowPoStProofTypes = p.windowPoStProofTypes()
+	miner13.PreCommitChallengeDelay = p.PreCommitChallengeDelay
+	miner13.MaxPreCommitRandomnessLookback = p.MaxPreCommitRandomnessLookback
+	miner13.MaxProveCommitDuration = p.maxProveCommitDurations(miner13.MaxProveCommitDuration)
+	miner13.SetNetworkSectorExpiration(p.MinSectorExpiration, p.MaxSectorExpirationExtension)
+	miner13.PreCommitDepositProjectionPeriod = p.PreCommitDepositProjectionPeriod
+	miner13.InitialPledgeProjectionPeriod = p.InitialPledgeProjectionPeriod
+
+	market13.DealMinDuration = p.DealMinDuration
+	market13.DealMaxDuration = p.DealMaxDuration
+	market13.MarketDefaultAllocationTermBuffer = p.MarketDefaultAllocationTermBuffer
+
+	verifreg13.MinVerifiedDealSize = p.MinVerifiedDealSize.Copy()
+	verifreg13.SetNetworkVerifiedAllocationTerms(p.MinimumVerifiedAllocationTerm, p.MaximumVerifiedAllocationTerm)
+	verifreg13.SetNetworkMaximumVerifiedAllocationExpiration(p.MaximumVerifiedAllocationExpiration)
+	verifreg13.SetNetworkEndOfLifeClaimDropPeriod(p.EndOfLifeClaimDropPeriod)
+
+	paych13.SetNetworkSettleDelay(p.PaychSettleDelay)
+
+	reward13.BaselineExponent = r.baselineExponent.Copy()
+	reward13.Lambda = r.lambda.Copy()
+	reward13.ExpLamSubOne = r.expLamSubOne.Copy()
+}
+
+func applyV14(p *Profile, r *rewardParams) {
+	miner14.WPoStProvingPeriod = p.WPoStProvingPeriod
+	miner14.WPoStChallengeWindow = p.WPoStChallengeWindow
+	miner14.WPoStDisputeWindow = p.WPoStDisputeWindow
+	miner14.FaultMaxAge = p.FaultMaxAge
+	miner14.WindowPoStProofTypes = p.windowPoStProofTypes()
+	miner14.PreCommitChallengeDelay = p.PreCommitChallengeDelay
+	miner14.MaxPreCommitRandomnessLookback = p.MaxPreCommitRandomnessLookback
+	miner14.MaxProveCommitDuration = p.maxProveCommitDurations(miner14.MaxProveCommitDuration)
+	miner14.MaxProveCommitNiLookback = p.MaxProveCommitNiLookback
+	miner14.SetNetworkSectorExpiration(p.MinSectorExpiration, p.MaxSectorExpirationExtension)
+	miner14.PreCommitDepositProjectionPeriod = p.PreCommitDepositProjectionPeriod
+	miner14.InitialPledgeProjectionPeriod = p.InitialPledgeProjectionPeriod
+
+	market14.DealMinDuration = p.DealMinDuration
+	market14.DealMaxDuration = p.DealMaxDuration
+	market14.MarketDefaultAllocationTermBuffer = p.MarketDefaultAllocationTermBuffer
+
+	verifreg14.MinVerifiedDealSize = p.MinVerifiedDealSize.Copy()
+	verifreg14.SetNetworkVerifiedAllocationTerms(p.MinimumVerifiedAllocationTerm, p.MaximumVerifiedAllocationTerm)
+	verifreg14.SetNetworkMaximumVerifiedAllocationExpiration(p.MaximumVerifiedAllocationExpiration)
+	verifreg14.SetNetworkEndOfLifeClaimDropPeriod(p.EndOfLifeClaimDropPeriod)
+
+	paych14.SetNetworkSettleDelay(p.PaychSettleDelay)
+
+	reward14.BaselineExponent = r.baselineExponent.Copy()
+	reward14.Lambda = r.lambda.Copy()
+	reward14.ExpLamSubOne = r.expLamSubOne.Copy()
+}
+
+func applyV15(p *Profile, r *rewardParams) {
+	miner15.WPoStProvingPeriod = p.WPoStProvingPeriod
+	miner15.WPoStChallengeWindow = p.WPoStChallengeWindow
+	miner15.WPoStDisputeWindow = p.WPoStDisputeWindow
+	miner15.FaultMaxAge = p.FaultMaxAge
+	miner15.WindowPoStProofTypes = p.windowPoStProofTypes()
+	miner15.PreCommitChallengeDelay = p.PreCommitChallengeDelay
+	miner15.MaxPreCommitRandomnessLookback = p.MaxPreCommitRandomnessLookback
+	miner15.MaxProveCommitDuration = p.maxProveCommitDurations(miner15.MaxProveCommitDuration)
+	miner15.MaxProveCommitNiLookback = p.MaxProveCommitNiLookback
+	miner15.SetNetworkSectorExpiration(p.MinSectorExpiration, p.MaxSectorExpirationExtension)
+	miner15.PreCommitDepositProjectionPeriod = p.PreCommitDepositProjectionPeriod
+	miner15.InitialPledgeProjectionPeriod = p.InitialPledgeProjectionPeriod
+
+	market15.DealMinDuration = p.DealMinDuration
+	market15.DealMaxDuration = p.DealMaxDuration
+	market15.MarketDefaultAllocationTermBuffer = p.MarketDefaultAllocationTermBuffer
+
+	verifreg15.MinVerifiedDealSize = p.MinVerifiedDealSize.Copy()
+	verifreg15.SetNetworkVerifiedAllocationTerms(p.MinimumVerifiedAllocationTerm, p.MaximumVerifiedAllocationTerm)
+	verifreg15.SetNetworkMaximumVerifiedAllocationExpiration(p.MaximumVerifiedAllocationExpiration)
+	verifreg15.SetNetworkEndOfLifeClaimDropPeriod(p.EndOfLifeClaimDropPeriod)
+
+	paych15.SetNetworkSettleDelay(p.PaychSettleDelay)
+
+	reward15.BaselineExponent = r.baselineExponent.Copy()
+	reward15.Lambda = r.lambda.Copy()
+	reward15.ExpLamSubOne = r.expLamSubOne.Copy()
+}
+
+func applyV16(p *Profile, r *rewardParams) {
+	miner16.WPoStProvingPeriod = p.WPoStProvingPeriod
+	miner16.WPoStChallengeWindow = p.WPoStChallengeWindow
+	miner16.WPoStDisputeWindow = p.WPoStDisputeWindow
+	miner16.FaultMaxAge = p.FaultMaxAge
+	miner16.WindowPoStProofTypes = p.windowPoStProofTypes()
+	miner16.PreCommitChallengeDelay = p.PreCommitChallengeDelay
+	miner16.MaxPreCommitRandomnessLookback = p.MaxPreCommitRandomnessLookback
+	miner16.MaxProveCommitDuration = p.maxProveCommitDurations(miner16.MaxProveCommitDuration)
+	miner16.MaxProveCommitNiLookback = p.MaxProveCommitNiLookback
+	miner16.SetNetworkSectorExpiration(p.MinSectorExpiration, p.MaxSectorExpirationExtension)
+	miner16.PreCommitDepositProjectionPeriod = p.PreCommitDepositProjectionPeriod
+	miner16.InitialPledgeProjectionPeriod = p.InitialPledgeProjectionPeriod
+	miner16.SetNetworkContinuedFaultProjectionPeriod(p.ContinuedFaultProjectionPeriod)
+
+	market16.DealMinDuration = p.DealMinDuration
+	market16.DealMaxDuration = p.DealMaxDuration
+	market16.MarketDefaultAllocationTermBuffer = p.MarketDefaultAllocationTermBuffer
+
+	verifreg16.MinVerifiedDealSize = p.MinVerifiedDealSize.Copy()
+	verifreg16.SetNetworkVerifiedAllocationTerms(p.MinimumVerifiedAllocationTerm, p.MaximumVerifiedAllocationTerm)
+	verifreg16.SetNetworkMaximumVerifiedAllocationExpiration(p.MaximumVerifiedAllocationExpiration)
+	verifreg16.SetNetworkEndOfLifeClaimDropPeriod(p.EndOfLifeClaimDropPeriod)
+
+	paych16.SetNetworkSettleDelay(p.PaychSettleDelay)
+
+	reward16.BaselineExponent = r.baselineExponent.Copy()
+	reward16.Lambda = r.lambda.Copy()
+	reward16.ExpLamSubOne = r.expLamSubOne.Copy()
+}
+
+func applyV17(p *Profile, r *rewardParams) {
+	miner17.WPoStProvingPeriod = p.WPoStProvingPeriod
+	miner17.WPoStChallengeWindow = p.WPoStChallengeWindow
+	miner17.WPoStDisputeWindow = p.WPoStDisputeWindow
+	miner17.FaultMaxAge = p.FaultMaxAge
+	miner17.WindowPoStProofTypes = p.windowPoStProofTypes()
+	miner17.PreCommitChallengeDelay = p.PreCommitChallengeDelay
+	miner17.MaxPreCommitRandomnessLookback = p.MaxPreCommitRandomnessLookback
+	miner17.MaxProveCommitDuration = p.maxProveCommitDurations(miner17.MaxProveCommitDuration)
+	miner17.MaxProveCommitNiLookback = p.MaxProveCommitNiLookback
+	miner17.SetNetworkSectorExpiration(p.MinSectorExpiration, p.MaxSectorExpirationExtension)
+	miner17.PreCommitDepositProjectionPeriod = p.PreCommitDepositProjectionPeriod
+	miner17.InitialPledgeProjectionPeriod = p.InitialPledgeProjectionPeriod
+	miner17.SetNetworkContinuedFaultProjectionPeriod(p.ContinuedFaultProjectionPeriod)
+
+	market17.DealMinDuration = p.DealMinDuration
+	market17.DealMaxDuration = p.DealMaxDuration
+	market17.MarketDefaultAllocationTermBuffer = p.MarketDefaultAllocationTermBuffer
+
+	verifreg17.MinVerifiedDealSize = p.MinVerifiedDealSize.Copy()
+	verifreg17.SetNetworkVerifiedAllocationTerms(p.MinimumVerifiedAllocationTerm, p.MaximumVerifiedAllocationTerm)
+	verifreg17.SetNetworkMaximumVerifiedAllocationExpiration(p.MaximumVerifiedAllocationExpiration)
+	verifreg17.SetNetworkEndOfLifeClaimDropPeriod(p.EndOfLifeClaimDropPeriod)
+
+	paych17.SetNetworkSettleDelay(p.PaychSettleDelay)
+
+	reward17.BaselineExponent = r.baselineExponent.Copy()
+	reward17.Lambda = r.lambda.Copy()
+	reward17.ExpLamSubOne = r.expLamSubOne.Copy()
+}
+
+func applyV18(p *Profile, r *rewardParams) {
+	miner18.WPoStProvingPeriod = p.WPoStProvingPeriod
+	miner18.WPoStChallengeWindow = p.WPoStChallengeWindow
+	miner18.WPoStDisputeWindow = p.WPoStDisputeWindow
+	miner18.FaultMaxAge = p.FaultMaxAge
+	miner18.WindowPoStProofTypes = p.windowPoStProofTypes()
+	miner18.PreCommitChallengeDelay = p.PreCommitChallengeDelay
+	miner18.MaxPreCommitRandomnessLookback = p.MaxPreCommitRandomnessLookback
+	miner18.MaxProveCommitDuration = p.maxProveCommitDurations(miner18.MaxProveCommitDuration)
+	miner18.MaxProveCommitNiLookback = p.MaxProveCommitNiLookback
+	miner18.SetNetworkSectorExpiration(p.MinSectorExpiration, p.MaxSectorExpirationExtension)
+	miner18.PreCommitDepositProjectionPeriod = p.PreCommitDepositProjectionPeriod
+	miner18.InitialPledgeProjectionPeriod = p.InitialPledgeProjectionPeriod
+	miner18.SetNetworkContinuedFaultProjectionPeriod(p.ContinuedFaultProjectionPeriod)
+
+	market18.DealMinDuration = p.DealMinDuration
+	market18.DealMaxDuration = p.DealMaxDuration
+	market18.MarketDefaultAllocationTermBuffer = p.MarketDefaultAllocationTermBuffer
+
+	verifreg18.MinVerifiedDealSize = p.MinVerifiedDealSize.Copy()
+	verifreg18.SetNetworkVerifiedAllocationTerms(p.MinimumVerifiedAllocationTerm, p.MaximumVerifiedAllocationTerm)
+	verifreg18.SetNetworkMaximumVerifiedAllocationExpiration(p.MaximumVerifiedAllocationExpiration)
+	verifreg18.SetNetworkEndOfLifeClaimDropPeriod(p.EndOfLifeClaimDropPeriod)
+
+	paych18.SetNetworkSettleDelay(p.PaychSettleDelay)
+
+	reward18.BaselineExponent = r.baselineExponent.Copy()
+	reward18.Lambda = r.lambda.Copy()
+	reward18.ExpLamSubOne = r.expLamSubOne.Copy()
+}
+
+func applyV19(p *Profile, r *rewardParams) {
+	miner19.WPoStProvingPeriod = p.WPoStProvingPeriod
+	miner19.WPoStChallengeWindow = p.WPoStChallengeWindow
+	miner19.WPoStDisputeWindow = p.WPoStDisputeWindow
+	miner19.FaultMaxAge = p.FaultMaxAge
+	miner19.WindowPoStProofTypes = p.windowPoStProofTypes()
+	miner19.PreCommitChallengeDelay = p.PreCommitChallengeDelay
+	miner19.MaxPreCommitRandomnessLookback = p.MaxPreCommitRandomnessLookback
+	miner19.MaxProveCommitDuration = p.maxProveCommitDurations(miner19.MaxProveCommitDuration)
+	miner19.MaxProveCommitNiLookback = p.MaxProveCommitNiLookback
+	miner19.SetNetworkSectorExpiration(p.MinSectorExpiration, p.MaxSectorExpirationExtension)
+	miner19.PreCommitDepositProjectionPeriod = p.PreCommitDepositProjectionPeriod
+	miner19.InitialPledgeProjectionPeriod = p.InitialPledgeProjectionPeriod
+	miner19.SetNetworkContinuedFaultProjectionPeriod(p.ContinuedFaultProjectionPeriod)
+
+	market19.DealMinDuration = p.DealMinDuration
+	market19.DealMaxDuration = p.DealMaxDuration
+	market19.MarketDefaultAllocationTermBuffer = p.MarketDefaultAllocationTermBuffer
+
+	verifreg19.MinVerifiedDealSize = p.MinVerifiedDealSize.Copy()
+	verifreg19.SetNetworkVerifiedAllocationTerms(p.MinimumVerifiedAllocationTerm, p.MaximumVerifiedAllocationTerm)
+	verifreg19.SetNetworkMaximumVerifiedAllocationExpiration(p.MaximumVerifiedAllocationExpiration)
+	verifreg19.SetNetworkEndOfLifeClaimDropPeriod(p.EndOfLifeClaimDropPeriod)
+
+	paych19.SetNetworkSettleDelay(p.PaychSettleDelay)
+
+	reward19.BaselineExponent = r.baselineExponent.Copy()
+	reward19.Lambda = r.lambda.Copy()
+	reward19.ExpLamSubOne = r.expLamSubOne.Copy()
+}
@@ -0,0 +1,306 @@
+// Package profile defines network parameter profiles: the policy values that differ between
+// mainnet, test networks and development networks.
+//
+// The actors packages read their policy from package-level variables, initialized for mainnet.
+// Applying a profile sets those variables for every supported actors version, so that policy,
+// monies and deadline computations of any version see the network's values.
+// A profile also sets the epoch duration, from which builtin.NetworkEpochsInDay and the reward
+// actor's baseline exponent and minting rate are derived. The exported constants of the actors
+// packages, such as builtin.EpochsInDay and miner.MinSectorExpiration, keep their mainnet values;
+// the values of the network are read through the Network* accessors beside them.
+package profile
+
+import (
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin"
+)
+
+// Profile holds the policy values of a network.
+// Durations are in epochs.
+type Profile struct {
+	Name string
+
+	// The duration of a chain epoch. The reward actor's baseline exponent and minting rate and the
+	// miner termination fee lifetime cap are derived from it; the durations below are not, and are
+	// set from it by NewWithEpochDuration.
+	EpochDurationSeconds int64
+	// Expected number of blocks in an epoch.
+	ExpectedLeadersPerEpoch int64
+	// The minimum power of an individual miner to meet the threshold for leader election, for
+	// every PoSt proof type.
+	ConsensusMinerMinPower abi.StoragePower
+	// Window PoSt proof types which may be used when creating a new miner actor.
+	WindowPoStProofTypes []abi.RegisteredPoStProof
+	// Minimum size of a verified deal or DataCap allocation.
+	MinVerifiedDealSize abi.StoragePower
+
+	// Miner proving and sealing.
+	WPoStProvingPeriod             abi.ChainEpoch
+	WPoStChallengeWindow           abi.ChainEpoch
+	WPoStDisputeWindow             abi.ChainEpoch
+	FaultMaxAge                    abi.ChainEpoch
+	PreCommitChallengeDelay        abi.ChainEpoch
+	MaxPreCommitRandomnessLookback abi.ChainEpoch
+	// Maximum delay between pre-commit and prove-commit of sectors sealed with V1 seal proofs.
+	MaxProveCommitDurationV1 abi.ChainEpoch
+	// Maximum delay between pre-commit and prove-commit of sectors sealed with later seal proofs.
+	MaxProveCommitDuration       abi.ChainEpoch
+	MaxProveCommitNiLookback     abi.ChainEpoch
+	MinSectorExpiration          abi.ChainEpoch
+	MaxSectorExpirationExtension abi.ChainEpoch
+	// Maximum sector expiration extension of actors versions 8 through 11.
+	MaxSectorExpirationExtensionV8 abi.ChainEpoch
+	// Maximum lifetime of sectors sealed with V1 seal proofs.
+	SectorMaxLifetimeV1 abi.ChainEpoch
+	// Maximum lifetime of sectors sealed with later seal proofs.
+	SectorMaxLifetime abi.ChainEpoch
+
+	// Miner monies.
+	PreCommitDepositProjectionPeriod abi.ChainEpoch
+	InitialPledgeProjectionPeriod    abi.ChainEpoch
+	ContinuedFaultProjectionPeriod   abi.ChainEpoch
+
+	// Market.
+	DealMinDuration abi.ChainEpoch
+	DealMaxDuration abi.ChainEpoch
+	// Maximum deal duration of actors versions 8 through 11.
+	DealMaxDurationV8                 abi.ChainEpoch
+	MarketDefaultAllocationTermBuffer abi.ChainEpoch
+
+	// Verified registry.
+	MinimumVerifiedAllocationTerm       abi.ChainEpoch
+	MaximumVerifiedAllocationTerm       abi.ChainEpoch
+	MaximumVerifiedAllocationExpiration abi.ChainEpoch
+	EndOfLifeClaimDropPeriod            abi.ChainEpoch
+
+	// Payment channels.
+	PaychSettleDelay abi.ChainEpoch
+}
+
+// New returns a custom profile with mainnet's policy, to be adjusted for a network.
+func New(name string) *Profile {
+	return NewWithEpochDuration(name, builtin.EpochDurationSeconds)
+}
+
+// NewWithEpochDuration returns a custom profile with mainnet's policy for a network with epochs of
+// the given duration, which must be positive. Durations expressed in clock time on mainnet, such as
+// the proving period of a day, are converted to epochs of that duration; durations expressed in
+// epochs, such as the chain finality, are kept.
+func NewWithEpochDuration(name string, epochDurationSeconds int64) *Profile {
+	var epochsInHour abi.ChainEpoch
+	if epochDurationSeconds > 0 {
+		epochsInHour = abi.ChainEpoch(builtin.SecondsInHour / epochDurationSeconds)
+	}
+	epochsInDay := 24 * epochsInHour
+	epochsInYear := 365 * epochsInDay
+	const chainFinality = abi.ChainEpoch(900)
+	const preCommitChallengeDelay = abi.ChainEpoch(150)
+
+	return &Profile{
+		Name:                    name,
+		EpochDurationSeconds:    epochDurationSeconds,
+		ExpectedLeadersPerEpoch: 5,
+		ConsensusMinerMinPower:  abi.NewStoragePower(10 << 40),
+		WindowPoStProofTypes: []abi.RegisteredPoStProof{
+			abi.RegisteredPoStProof_StackedDrgWindow32GiBV1,
+			abi.RegisteredPoStProof_StackedDrgWindow64GiBV1,
+		},
+		MinVerifiedDealSize: abi.NewStoragePower(1 << 20),
+
+		WPoStProvingPeriod:             epochsInDay,
+		WPoStChallengeWindow:           epochsInHour / 2,
+		WPoStDisputeWindow:             2 * chainFinality,
+		FaultMaxAge:                    42 * epochsInDay,
+		PreCommitChallengeDelay:        preCommitChallengeDelay,
+		MaxPreCommitRandomnessLookback: epochsInDay + chainFinality,
+		MaxProveCommitDurationV1:       epochsInDay + preCommitChallengeDelay,
+		MaxProveCommitDuration:         30*epochsInDay + preCommitChallengeDelay,
+		MaxProveCommitNiLookback:       180 * epochsInDay,
+		MinSectorExpiration:            180 * epochsInDay,
+		MaxSectorExpirationExtension:   1278 * epochsInDay,
+		MaxSectorExpirationExtensionV8: 540 * epochsInDay,
+		SectorMaxLifetimeV1:            540 * epochsInDay,
+		SectorMaxLifetime:              5 * epochsInYear,
+
+		PreCommitDepositProjectionPeriod: 20 * epochsInDay,
+		InitialPledgeProjectionPeriod:    20 * epochsInDay,
+		ContinuedFaultProjectionPeriod:   epochsInDay * 351 / 100,
+
+		DealMinDuration:                   180 * epochsInDay,
+		DealMaxDuration:                   1278 * epochsInDay,
+		DealMaxDurationV8:                 540 * epochsInDay,
+		MarketDefaultAllocationTermBuffer: 90 * epochsInDay,
+
+		MinimumVerifiedAllocationTerm:       180 * epochsInDay,
+		MaximumVerifiedAllocationTerm:       5 * epochsInYear,
+		MaximumVerifiedAllocationExpiration: 60 * epochsInDay,
+		EndOfLifeClaimDropPeriod:            30 * epochsInDay,
+
+		PaychSettleDelay: 12 * epochsInHour,
+	}
+}
+
+// Mainnet returns the profile of the Filecoin mainnet, matching the initial values of the actors
+// packages' policy variables.
+func Mainnet() *Profile {
+	return New("mainnet")
+}
+
+// Calibnet returns the profile of the calibration test network.
+func Calibnet() *Profile {
+	p := New("calibnet")
+	p.ConsensusMinerMinPower = abi.NewStoragePower(32 << 30)
+	p.MinVerifiedDealSize = abi.NewStoragePower(256)
+	return p
+}
+
+// Butterfly returns the profile of the butterfly test network.
+func Butterfly() *Profile {
+	p := New("butterfly")
+	p.ConsensusMinerMinPower = abi.NewStoragePower(2 << 30)
+	p.WindowPoStProofTypes = []abi.RegisteredPoStProof{
+		abi.RegisteredPoStProof_StackedDrgWindow512MiBV1,
+		abi.RegisteredPoStProof_StackedDrgWindow32GiBV1,
+		abi.RegisteredPoStProof_StackedDrgWindow64GiBV1,
+	}
+	p.MinVerifiedDealSize = abi.NewStoragePower(256)
+	return p
+}
+
+// Devnet2K returns the profile of a local development network with 2KiB and 8MiB sectors.
+func Devnet2K() *Profile {
+	p := New("2k")
+	p.ConsensusMinerMinPower = abi.NewStoragePower(2048)
+	p.WindowPoStProofTypes = []abi.RegisteredPoStProof{
+		abi.RegisteredPoStProof_StackedDrgWindow2KiBV1,
+		abi.RegisteredPoStProof_StackedDrgWindow8MiBV1,
+	}
+	p.MinVerifiedDealSize = abi.NewStoragePower(256)
+	// Prove-commit durations are measured from the end of the challenge delay.
+	p.MaxProveCommitDurationV1 -= p.PreCommitChallengeDelay - 10
+	p.MaxProveCommitDuration -= p.PreCommitChallengeDelay - 10
+	p.PreCommitChallengeDelay = 10
+	return p
+}
+
+// Validate checks the consistency of a profile's values.
+func (p *Profile) Validate() error {
+	if p.EpochDurationSeconds <= 0 || p.EpochDurationSeconds > builtin.SecondsInHour {
+		return xerrors.Errorf("epoch duration %ds must be positive and at most an hour", p.EpochDurationSeconds)
+	}
+	if p.ExpectedLeadersPerEpoch <= 0 {
+		return xerrors.Errorf("expected leaders per epoch %d must be positive", p.ExpectedLeadersPerEpoch)
+	}
+	if p.ConsensusMinerMinPower.Nil() || p.ConsensusMinerMinPower.LessThan(big.Zero()) {
+		return xerrors.Errorf("consensus miner min power must not be negative")
+	}
+	if p.MinVerifiedDealSize.Nil() || p.MinVerifiedDealSize.LessThanEqual(big.Zero()) {
+		return xerrors.Errorf("min verified deal size must be positive")
+	}
+	if len(p.WindowPoStProofTypes) == 0 {
+		return xerrors.Errorf("no window PoSt proof types")
+	}
+	for _, proof := range p.WindowPoStProofTypes {
+		if _, ok := builtin.PoStProofPolicies[proof]; !ok {
+			return xerrors.Errorf("unsupported window PoSt proof type %d", proof)
+		}
+	}
+	if p.WPoStChallengeWindow <= 0 || p.WPoStProvingPeriod != p.WPoStChallengeWindow*abi.ChainEpoch(wPoStPeriodDeadlines) {
+		return xerrors.Errorf("proving period %d is not %d challenge windows of %d epochs",
+			p.WPoStProvingPeriod, wPoStPeriodDeadlines, p.WPoStChallengeWindow)
+	}
+	if p.PreCommitChallengeDelay <= 0 || p.MaxProveCommitDurationV1 <= p.PreCommitChallengeDelay || p.MaxProveCommitDuration <= p.PreCommitChallengeDelay {
+		return xerrors.Errorf("max prove commit durations must exceed the pre-commit challenge delay %d", p.PreCommitChallengeDelay)
+	}
+	if p.MinSectorExpiration > p.SectorMaxLifetime || p.DealMinDuration > p.DealMaxDuration || p.DealMinDuration > p.DealMaxDurationV8 ||
+		p.MinimumVerifiedAllocationTerm > p.MaximumVerifiedAllocationTerm {
+		return xerrors.Errorf("minimum durations must not exceed maximum durations")
+	}
+	return nil
+}
+
+// The number of Window PoSt deadlines in a proving period, which is fixed by the actors.
+const wPoStPeriodDeadlines = 48
+
+// The active profile, as last applied.
+var current = Mainnet()
+
+// Current returns a copy of the profile applied last, or the mainnet profile if none was.
+func Current() *Profile {
+	return current.clone()
+}
+
+// Apply sets the policy variables of every supported actors version to the profile's values.
+// It is not safe to apply a profile concurrently with the use of the actors packages; it is
+// intended to be called once during initialization.
+func Apply(p *Profile) error {
+	if err := p.Validate(); err != nil {
+		return xerrors.Errorf("invalid profile %s: %w", p.Name, err)
+	}
+	p = p.clone()
+
+	builtin.SetNetworkEpochDuration(p.EpochDurationSeconds)
+	builtin.ExpectedLeadersPerEpoch = p.ExpectedLeadersPerEpoch
+
+	postPolicies := make(map[abi.RegisteredPoStProof]*builtin.PoStProofPolicy, len(builtin.PoStProofPolicies))
+	for proof, policy := range builtin.PoStProofPolicies { // nolint:nomaprange
+		updated := *policy
+		updated.ConsensusMinerMinPower = p.ConsensusMinerMinPower.Copy()
+		postPolicies[proof] = &updated
+	}
+	builtin.PoStProofPolicies = postPolicies
+
+	sealPolicies := make(map[abi.RegisteredSealProof]*builtin.SealProofPolicy, len(builtin.SealProofPoliciesV11))
+	for proof := range builtin.SealProofPoliciesV11 { // nolint:nomaprange
+		lifetime := p.SectorMaxLifetime
+		if isV1SealProof(proof) {
+			lifetime = p.SectorMaxLifetimeV1
+		}
+		sealPolicies[proof] = &builtin.SealProofPolicy{SectorMaxLifetime: lifetime}
+	}
+	builtin.SealProofPoliciesV11 = sealPolicies
+
+	reward := newRewardParams(builtin.NetworkEpochsInYear())
+	for _, apply := range versionAppliers {
+		apply(p, reward)
+	}
+
+	current = p
+	return nil
+}
+
+func (p *Profile) clone() *Profile {
+	out := *p
+	out.ConsensusMinerMinPower = p.ConsensusMinerMinPower.Copy()
+	out.MinVerifiedDealSize = p.MinVerifiedDealSize.Copy()
+	out.WindowPoStProofTypes = append([]abi.RegisteredPoStProof(nil), p.WindowPoStProofTypes...)
+	return &out
+}
+
+func (p *Profile) windowPoStProofTypes() map[abi.RegisteredPoStProof]struct{} {
+	out := make(map[abi.RegisteredPoStProof]struct{}, len(p.WindowPoStProofTypes))
+	for _, proof := range p.WindowPoStProofTypes {
+		out[proof] = struct{}{}
+	}
+	return out
+}
+
+// Returns the max prove commit durations for the seal proofs keyed in a version's existing map.
+func (p *Profile) maxProveCommitDurations(existing map[abi.RegisteredSealProof]abi.ChainEpoch) map[abi.RegisteredSealProof]abi.ChainEpoch {
+	out := make(map[abi.RegisteredSealProof]abi.ChainEpoch, len(existing))
+	for proof := range existing { // nolint:nomaprange
+		if isV1SealProof(proof) {
+			out[proof] = p.MaxProveCommitDurationV1
+		} else {
+			out[proof] = p.MaxProveCommitDuration
+		}
+	}
+	return out
+}
+
+func isV1SealProof(proof abi.RegisteredSealProof) bool {
+	return proof <= abi.RegisteredSealProof_StackedDrg64GiBV1
+}
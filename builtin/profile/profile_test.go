@@ -0,0 +1,174 @@
+package profile
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin"
+	miner11 "github.com/filecoin-project/go-state-types/builtin/v11/miner"
+	miner12 "github.com/filecoin-project/go-state-types/builtin/v12/miner"
+	verifreg13 "github.com/filecoin-project/go-state-types/builtin/v13/verifreg"
+	paych15 "github.com/filecoin-project/go-state-types/builtin/v15/paych"
+	miner16 "github.com/filecoin-project/go-state-types/builtin/v16/miner"
+	verifreg18 "github.com/filecoin-project/go-state-types/builtin/v18/verifreg"
+	market19 "github.com/filecoin-project/go-state-types/builtin/v19/market"
+	miner19 "github.com/filecoin-project/go-state-types/builtin/v19/miner"
+	paych19 "github.com/filecoin-project/go-state-types/builtin/v19/paych"
+	reward19 "github.com/filecoin-project/go-state-types/builtin/v19/reward"
+	verifreg19 "github.com/filecoin-project/go-state-types/builtin/v19/verifreg"
+	market8 "github.com/filecoin-project/go-state-types/builtin/v8/market"
+	miner8 "github.com/filecoin-project/go-state-types/builtin/v8/miner"
+	paych8 "github.com/filecoin-project/go-state-types/builtin/v8/paych"
+	reward8 "github.com/filecoin-project/go-state-types/builtin/v8/reward"
+	verifreg9 "github.com/filecoin-project/go-state-types/builtin/v9/verifreg"
+)
+
+func TestMainnetMatchesDefaults(t *testing.T) {
+	wantV8Prove := miner8.MaxProveCommitDuration
+	wantV19Prove := miner19.MaxProveCommitDuration
+	wantV19Proofs := miner19.WindowPoStProofTypes
+	wantMinPower, err := builtin.ConsensusMinerMinPower(abi.RegisteredPoStProof_StackedDrgWindow32GiBV1)
+	require.NoError(t, err)
+	wantLifetime, err := builtin.SealProofSectorMaximumLifetime(abi.RegisteredSealProof_StackedDrg32GiBV1)
+	require.NoError(t, err)
+
+	p := Mainnet()
+	require.NoError(t, p.Validate())
+	assert.Equal(t, miner19.WPoStProvingPeriod, p.WPoStProvingPeriod)
+	assert.Equal(t, miner19.WPoStChallengeWindow, p.WPoStChallengeWindow)
+	assert.Equal(t, miner19.FaultMaxAge, p.FaultMaxAge)
+	assert.Equal(t, miner19.MaxProveCommitNiLookback, p.MaxProveCommitNiLookback)
+	assert.Equal(t, miner19.ContinuedFaultProjectionPeriod, p.ContinuedFaultProjectionPeriod)
+	assert.Equal(t, market8.DealMaxDuration, p.DealMaxDurationV8)
+	assert.Equal(t, abi.ChainEpoch(miner8.MaxSectorExpirationExtension), p.MaxSectorExpirationExtensionV8)
+	assert.Equal(t, market19.DealMaxDuration, p.DealMaxDuration)
+	assert.Equal(t, abi.ChainEpoch(verifreg19.MaximumVerifiedAllocationTerm), p.MaximumVerifiedAllocationTerm)
+	assert.Equal(t, abi.ChainEpoch(paych19.SettleDelay), p.PaychSettleDelay)
+
+	require.NoError(t, Apply(p))
+	assert.Equal(t, wantV8Prove, miner8.MaxProveCommitDuration)
+	assert.Equal(t, wantV19Prove, miner19.MaxProveCommitDuration)
+	assert.Equal(t, wantV19Proofs, miner19.WindowPoStProofTypes)
+	minPower, err := builtin.ConsensusMinerMinPower(abi.RegisteredPoStProof_StackedDrgWindow32GiBV1)
+	require.NoError(t, err)
+	assert.Equal(t, wantMinPower, minPower)
+	lifetime, err := builtin.SealProofSectorMaximumLifetime(abi.RegisteredSealProof_StackedDrg32GiBV1)
+	require.NoError(t, err)
+	assert.Equal(t, wantLifetime, lifetime)
+	assert.Equal(t, abi.ChainEpoch(builtin.EpochsInDay), builtin.NetworkEpochsInDay())
+	assert.Equal(t, abi.ChainEpoch(miner19.MinSectorExpiration), miner19.NetworkMinSectorExpiration())
+	assert.Equal(t, abi.PaddedPieceSize(verifreg19.MinimumVerifiedAllocationSize), verifreg19.NetworkMinimumVerifiedAllocationSize())
+}
+
+func TestMainnetRewardParams(t *testing.T) {
+	r := newRewardParams(builtin.EpochsInYear)
+	assert.Equal(t, reward19.BaselineExponent, r.baselineExponent)
+	assert.Equal(t, reward19.Lambda, r.lambda)
+	assert.Equal(t, reward19.ExpLamSubOne, r.expLamSubOne)
+}
+
+func TestApplyDevnet(t *testing.T) {
+	defer func() { require.NoError(t, Apply(Mainnet())) }()
+
+	for _, p := range []*Profile{Calibnet(), Butterfly(), Devnet2K()} {
+		require.NoError(t, p.Validate(), p.Name)
+	}
+
+	require.NoError(t, Apply(Devnet2K()))
+	assert.Equal(t, "2k", Current().Name)
+	assert.Equal(t, abi.ChainEpoch(2880), miner19.WPoStProvingPeriod)
+	assert.Equal(t, abi.ChainEpoch(60), miner8.WPoStChallengeWindow)
+	assert.Equal(t, abi.ChainEpoch(10), miner19.PreCommitChallengeDelay)
+	assert.Equal(t, abi.ChainEpoch(30*2880+10), miner19.MaxProveCommitDuration[abi.RegisteredSealProof_StackedDrg2KiBV1_1])
+	assert.Equal(t, abi.ChainEpoch(12*120), paych19.NetworkSettleDelay())
+	_, ok := miner19.WindowPoStProofTypes[abi.RegisteredPoStProof_StackedDrgWindow2KiBV1]
+	assert.True(t, ok)
+	minPower, err := builtin.ConsensusMinerMinPower(abi.RegisteredPoStProof_StackedDrgWindow2KiBV1)
+	require.NoError(t, err)
+	assert.Equal(t, abi.NewStoragePower(2048), minPower)
+
+	// The proving period must divide into the fixed number of deadlines.
+	p := Devnet2K()
+	p.WPoStChallengeWindow = 100
+	assert.Error(t, Apply(p))
+
+	p = Devnet2K()
+	p.EpochDurationSeconds = 0
+	assert.Error(t, Apply(p))
+}
+
+func TestApplyEpochDuration(t *testing.T) {
+	defer func() { require.NoError(t, Apply(Mainnet())) }()
+
+	p := NewWithEpochDuration("fast", 4)
+	require.NoError(t, Apply(p))
+
+	const epochsInDay = 24 * 60 * 60 / 4
+	assert.Equal(t, int64(4), builtin.NetworkEpochDurationSeconds())
+	assert.Equal(t, abi.ChainEpoch(epochsInDay), builtin.NetworkEpochsInDay())
+	assert.Equal(t, abi.ChainEpoch(365*epochsInDay), builtin.NetworkEpochsInYear())
+	assert.Equal(t, abi.ChainEpoch(epochsInDay), miner19.WPoStProvingPeriod)
+	assert.Equal(t, abi.ChainEpoch(epochsInDay/48), miner8.WPoStChallengeWindow)
+	assert.Equal(t, abi.ChainEpoch(180*epochsInDay), miner19.NetworkMinSectorExpiration())
+	assert.Equal(t, abi.ChainEpoch(epochsInDay*351/100), miner19.NetworkContinuedFaultProjectionPeriod())
+	assert.Equal(t, abi.ChainEpoch(5*365*epochsInDay), verifreg19.NetworkMaximumVerifiedAllocationTerm())
+	assert.Equal(t, abi.ChainEpoch(12*epochsInDay/24), paych19.NetworkSettleDelay())
+
+	// The baseline grows 100% in a year of the shorter epochs.
+	assert.Equal(t, newRewardParams(365*epochsInDay).baselineExponent, reward8.BaselineExponent)
+	assert.True(t, reward19.BaselineExponent.LessThan(newRewardParams(builtin.EpochsInYear).baselineExponent))
+	year := reward19.ProjectBaselinePower(reward19.BaselineInitialValue, 365*epochsInDay)
+	assert.InDelta(t, 2.0, float64(year.Int64())/float64(reward19.BaselineInitialValue.Int64()), 1e-6)
+
+	// A sector's termination fee is capped after the same number of days of its age.
+	initialPledge := abi.NewTokenAmount(1e18)
+	capped := miner19.PledgePenaltyForTermination(initialPledge, miner19.TerminationLifetimeCap*epochsInDay, big.Zero())
+	older := miner19.PledgePenaltyForTermination(initialPledge, 2*miner19.TerminationLifetimeCap*epochsInDay, big.Zero())
+	assert.Equal(t, older, capped)
+	younger := miner19.PledgePenaltyForTermination(initialPledge, miner19.TerminationLifetimeCap*epochsInDay/2, big.Zero())
+	assert.True(t, younger.LessThan(capped))
+}
+
+func TestApplyEveryVersion(t *testing.T) {
+	defer func() { require.NoError(t, Apply(Mainnet())) }()
+
+	p := New("custom")
+	p.MinSectorExpiration = 1000
+	p.MaxSectorExpirationExtensionV8 = 2000
+	p.MaxSectorExpirationExtension = 3000
+	p.ContinuedFaultProjectionPeriod = 40
+	p.MinimumVerifiedAllocationTerm = 5000
+	p.MaximumVerifiedAllocationTerm = 6000
+	p.MaximumVerifiedAllocationExpiration = 70
+	p.EndOfLifeClaimDropPeriod = 80
+	p.PaychSettleDelay = 90
+	require.NoError(t, Apply(p))
+
+	assert.Equal(t, abi.ChainEpoch(1000), miner8.NetworkMinSectorExpiration())
+	assert.Equal(t, abi.ChainEpoch(2000), miner8.NetworkMaxSectorExpirationExtension())
+	assert.Equal(t, abi.ChainEpoch(2000), miner11.NetworkMaxSectorExpirationExtension())
+	assert.Equal(t, abi.ChainEpoch(3000), miner12.NetworkMaxSectorExpirationExtension())
+	assert.Equal(t, abi.ChainEpoch(3000), miner19.NetworkMaxSectorExpirationExtension())
+	assert.Equal(t, abi.ChainEpoch(40), miner16.NetworkContinuedFaultProjectionPeriod())
+	assert.Equal(t, abi.ChainEpoch(5000), verifreg9.NetworkMinimumVerifiedAllocationTerm())
+	assert.Equal(t, abi.ChainEpoch(6000), verifreg13.NetworkMaximumVerifiedAllocationTerm())
+	assert.Equal(t, abi.ChainEpoch(70), verifreg18.NetworkMaximumVerifiedAllocationExpiration())
+	assert.Equal(t, abi.ChainEpoch(80), verifreg19.NetworkEndOfLifeClaimDropPeriod())
+	assert.Equal(t, abi.ChainEpoch(90), paych8.NetworkSettleDelay())
+	assert.Equal(t, abi.ChainEpoch(90), paych15.NetworkSettleDelay())
+
+	// The mainnet constants are unchanged.
+	assert.Equal(t, abi.ChainEpoch(180*builtin.EpochsInDay), abi.ChainEpoch(miner8.MinSectorExpiration))
+}
+
+func TestMinVerifiedDealSize(t *testing.T) {
+	defer func() { require.NoError(t, Apply(Mainnet())) }()
+
+	require.NoError(t, Apply(Calibnet()))
+	assert.Equal(t, abi.PaddedPieceSize(256), verifreg9.NetworkMinimumVerifiedAllocationSize())
+	assert.Equal(t, abi.PaddedPieceSize(256), verifreg19.NetworkMinimumVerifiedAllocationSize())
+}
@@ -0,0 +1,73 @@
+package profile
+
+import (
+	gobig "math/big"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+)
+
+// The reward actor's Q.128 constants, which depend on the number of epochs in a year.
+type rewardParams struct {
+	// e^(ln(2) / epochsInYear): the baseline grows 100% in a year.
+	baselineExponent big.Int
+	// ln(2) / (6 * epochsInYear): the simple minting half-life is six years.
+	lambda big.Int
+	// e^lambda - 1
+	expLamSubOne big.Int
+}
+
+// Binary digits of the intermediate values, well beyond the 128 fractional bits of the results.
+const rewardPrecision = 512
+
+// Computes the reward constants for a network with the given number of epochs in a year, as done by
+// reward_calc.py of the actors.
+func newRewardParams(epochsInYear abi.ChainEpoch) *rewardParams {
+	epochs := new(gobig.Float).SetPrec(rewardPrecision).SetInt64(int64(epochsInYear))
+	ln2 := ln2()
+
+	growth := new(gobig.Float).SetPrec(rewardPrecision).Quo(ln2, epochs)
+	lambda := new(gobig.Float).SetPrec(rewardPrecision).Quo(growth, new(gobig.Float).SetInt64(6))
+	expLamSubOne := expm1(lambda)
+	baselineExponent := expm1(growth)
+	baselineExponent.Add(baselineExponent, new(gobig.Float).SetInt64(1))
+
+	return &rewardParams{
+		baselineExponent: toQ128(baselineExponent),
+		lambda:           toQ128(lambda),
+		expLamSubOne:     toQ128(expLamSubOne),
+	}
+}
+
+// Returns ln(2) = sum over k >= 1 of 1 / (k * 2^k).
+func ln2() *gobig.Float {
+	sum := new(gobig.Float).SetPrec(rewardPrecision)
+	for k := int64(1); k <= rewardPrecision+8; k++ {
+		term := new(gobig.Float).SetPrec(rewardPrecision).SetInt64(k)
+		term.SetMantExp(term, int(k))
+		sum.Add(sum, term.Quo(new(gobig.Float).SetPrec(rewardPrecision).SetInt64(1), term))
+	}
+	return sum
+}
+
+// Returns e^x - 1 for 0 <= x < 1 by its Taylor series.
+func expm1(x *gobig.Float) *gobig.Float {
+	sum := new(gobig.Float).SetPrec(rewardPrecision)
+	term := new(gobig.Float).SetPrec(rewardPrecision).SetInt64(1)
+	limit := new(gobig.Float).SetMantExp(new(gobig.Float).SetInt64(1), -rewardPrecision)
+	for n := int64(1); ; n++ {
+		term.Mul(term, x)
+		term.Quo(term, new(gobig.Float).SetInt64(n))
+		if term.Cmp(limit) < 0 {
+			return sum
+		}
+		sum.Add(sum, term)
+	}
+}
+
+// Returns the integer part of x * 2^128.
+func toQ128(x *gobig.Float) big.Int {
+	scaled := new(gobig.Float).SetPrec(rewardPrecision).SetMantExp(x, 128)
+	out, _ := scaled.Int(nil)
+	return big.NewFromGo(out)
+}
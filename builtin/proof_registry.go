@@ -0,0 +1,287 @@
+package builtin
+
+import (
+	"sort"
+
+	stabi "github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/network"
+	"golang.org/x/xerrors"
+)
+
+// NetworkVersionRange is an inclusive range of network versions.
+type NetworkVersionRange struct {
+	First network.Version
+	Last  network.Version
+}
+
+// A range containing no network versions.
+var NoNetworkVersions = NetworkVersionRange{First: network.VersionMax, Last: network.Version0}
+
+// Returns the range of network versions from the given one onwards.
+func sinceNetworkVersion(v network.Version) NetworkVersionRange {
+	return NetworkVersionRange{First: v, Last: network.VersionMax}
+}
+
+func (r NetworkVersionRange) Contains(v network.Version) bool {
+	return r.First <= v && v <= r.Last
+}
+
+func (r NetworkVersionRange) Empty() bool {
+	return r.First > r.Last
+}
+
+// SealProofActivation holds the network versions in which new sectors may be sealed with a seal proof type.
+type SealProofActivation struct {
+	// Versions accepting pre-commitments of sectors sealed with the proof.
+	PreCommit NetworkVersionRange
+	// Versions accepting proofs of sectors sealed with the proof. Non-interactive proofs are committed
+	// without pre-commitment.
+	ProveCommit NetworkVersionRange
+}
+
+// Network versions accepting each seal proof type.
+// V1 proofs were replaced by V1_1 in network version 7, and could no longer be pre-committed from network
+// version 8. Synthetic PoRep was introduced in network version 21 and non-interactive PoRep in network
+// version 23.
+var SealProofNetworkVersions = map[stabi.RegisteredSealProof]SealProofActivation{}
+
+func init() {
+	v1 := SealProofActivation{
+		PreCommit:   NetworkVersionRange{First: network.Version0, Last: network.Version7},
+		ProveCommit: NetworkVersionRange{First: network.Version0, Last: network.Version8},
+	}
+	v1_1 := SealProofActivation{
+		PreCommit:   sinceNetworkVersion(network.Version7),
+		ProveCommit: sinceNetworkVersion(network.Version7),
+	}
+	synthetic := SealProofActivation{
+		PreCommit:   sinceNetworkVersion(network.Version21),
+		ProveCommit: sinceNetworkVersion(network.Version21),
+	}
+	nonInteractive := SealProofActivation{
+		PreCommit:   NoNetworkVersions,
+		ProveCommit: sinceNetworkVersion(network.Version23),
+	}
+	for p := range stabi.SealProofInfos { // nolint:nomaprange
+		switch {
+		case p.IsNonInteractive():
+			SealProofNetworkVersions[p] = nonInteractive
+		case p.IsSynthetic():
+			SealProofNetworkVersions[p] = synthetic
+		case p <= stabi.RegisteredSealProof_StackedDrg64GiBV1:
+			SealProofNetworkVersions[p] = v1
+		default:
+			SealProofNetworkVersions[p] = v1_1
+		}
+	}
+}
+
+// Network versions accepting each aggregation proof type.
+var AggregationProofNetworkVersions = map[stabi.RegisteredAggregationProof]NetworkVersionRange{
+	stabi.RegisteredAggregationProof_SnarkPackV1: {First: network.Version13, Last: network.Version15},
+	stabi.RegisteredAggregationProof_SnarkPackV2: sinceNetworkVersion(network.Version16),
+}
+
+// Network versions accepting sector updates (SnapDeals), for every update proof type.
+var UpdateProofNetworkVersions = sinceNetworkVersion(network.Version15)
+
+// Network versions accepting Window PoSt proofs of the V1 and V1_1 proof types.
+// https://github.com/filecoin-project/FIPs/blob/master/FIPS/fip-0061.md
+var (
+	WindowPoStV1NetworkVersions  = NetworkVersionRange{First: network.Version0, Last: network.Version18}
+	WindowPoStV11NetworkVersions = sinceNetworkVersion(network.Version19)
+)
+
+// Everything known about a seal proof type, collected from the abi and the builtin policies.
+type SealProofEntry struct {
+	Proof      stabi.RegisteredSealProof
+	SectorSize stabi.SectorSize
+	ProofSize  uint64
+
+	Synthetic      bool
+	NonInteractive bool
+
+	WinningPoStProof stabi.RegisteredPoStProof
+	WindowPoStProof  stabi.RegisteredPoStProof
+	UpdateProof      stabi.RegisteredUpdateProof
+
+	NetworkVersions   SealProofActivation
+	SectorMaxLifetime stabi.ChainEpoch
+}
+
+// Everything known about a PoSt proof type.
+type PoStProofEntry struct {
+	Proof      stabi.RegisteredPoStProof
+	SectorSize stabi.SectorSize
+	ProofSize  uint64
+	Window     bool
+
+	// Window PoSt proofs only.
+	WindowPoStPartitionSectors uint64
+	ConsensusMinerMinPower     stabi.StoragePower
+
+	NetworkVersions NetworkVersionRange
+}
+
+// Everything known about an aggregation proof type.
+type AggregationProofEntry struct {
+	Proof           stabi.RegisteredAggregationProof
+	NetworkVersions NetworkVersionRange
+}
+
+// Everything known about an update proof type.
+type UpdateProofEntry struct {
+	Proof           stabi.RegisteredUpdateProof
+	SectorSize      stabi.SectorSize
+	NetworkVersions NetworkVersionRange
+}
+
+// SealProof returns the registry entry of a seal proof type.
+func SealProof(p stabi.RegisteredSealProof) (SealProofEntry, error) {
+	info, ok := stabi.SealProofInfos[p]
+	if !ok {
+		return SealProofEntry{}, xerrors.Errorf("unsupported proof type: %v", p)
+	}
+	lifetime, err := SealProofSectorMaximumLifetime(p)
+	if err != nil {
+		return SealProofEntry{}, err
+	}
+	versions, ok := SealProofNetworkVersions[p]
+	if !ok {
+		versions = SealProofActivation{PreCommit: NoNetworkVersions, ProveCommit: NoNetworkVersions}
+	}
+	return SealProofEntry{
+		Proof:             p,
+		SectorSize:        info.SectorSize,
+		ProofSize:         info.ProofSize,
+		Synthetic:         p.IsSynthetic(),
+		NonInteractive:    p.IsNonInteractive(),
+		WinningPoStProof:  info.WinningPoStProof,
+		WindowPoStProof:   info.WindowPoStProof,
+		UpdateProof:       info.UpdateProof,
+		NetworkVersions:   versions,
+		SectorMaxLifetime: lifetime,
+	}, nil
+}
+
+// SealProofs returns the registry entries of all seal proof types, in proof type order.
+func SealProofs() []SealProofEntry {
+	proofs := make([]stabi.RegisteredSealProof, 0, len(stabi.SealProofInfos))
+	for p := range stabi.SealProofInfos { // nolint:nomaprange
+		proofs = append(proofs, p)
+	}
+	sort.Slice(proofs, func(i, j int) bool { return proofs[i] < proofs[j] })
+
+	out := make([]SealProofEntry, 0, len(proofs))
+	for _, p := range proofs {
+		entry, err := SealProof(p)
+		if err != nil {
+			continue
+		}
+		out = append(out, entry)
+	}
+	return out
+}
+
+// SealProofsForNetwork returns the seal proof types with which new sectors of a size may be sealed
+// in a network version, in proof type order.
+// Interactive proofs are included if they may be pre-committed, non-interactive ones if they may be committed.
+func SealProofsForNetwork(nv network.Version, size stabi.SectorSize) []SealProofEntry {
+	var out []SealProofEntry
+	for _, entry := range SealProofs() {
+		if entry.SectorSize != size {
+			continue
+		}
+		versions := entry.NetworkVersions.PreCommit
+		if entry.NonInteractive {
+			versions = entry.NetworkVersions.ProveCommit
+		}
+		if versions.Contains(nv) {
+			out = append(out, entry)
+		}
+	}
+	return out
+}
+
+// PoStProof returns the registry entry of a PoSt proof type.
+func PoStProof(p stabi.RegisteredPoStProof) (PoStProofEntry, error) {
+	info, ok := stabi.PoStProofInfos[p]
+	if !ok {
+		return PoStProofEntry{}, xerrors.Errorf("unsupported proof type: %v", p)
+	}
+	entry := PoStProofEntry{
+		Proof:           p,
+		SectorSize:      info.SectorSize,
+		ProofSize:       info.ProofSize,
+		NetworkVersions: sinceNetworkVersion(network.Version0),
+	}
+	if policy, ok := PoStProofPolicies[p]; ok {
+		entry.Window = true
+		entry.WindowPoStPartitionSectors = policy.WindowPoStPartitionSectors
+		entry.ConsensusMinerMinPower = policy.ConsensusMinerMinPower
+		if p >= stabi.RegisteredPoStProof_StackedDrgWindow2KiBV1_1 {
+			entry.NetworkVersions = WindowPoStV11NetworkVersions
+		} else {
+			entry.NetworkVersions = WindowPoStV1NetworkVersions
+		}
+	}
+	return entry, nil
+}
+
+// PoStProofs returns the registry entries of all PoSt proof types, in proof type order.
+func PoStProofs() []PoStProofEntry {
+	proofs := make([]stabi.RegisteredPoStProof, 0, len(stabi.PoStProofInfos))
+	for p := range stabi.PoStProofInfos { // nolint:nomaprange
+		proofs = append(proofs, p)
+	}
+	sort.Slice(proofs, func(i, j int) bool { return proofs[i] < proofs[j] })
+
+	out := make([]PoStProofEntry, 0, len(proofs))
+	for _, p := range proofs {
+		entry, err := PoStProof(p)
+		if err != nil {
+			continue
+		}
+		out = append(out, entry)
+	}
+	return out
+}
+
+// AggregationProofs returns the registry entries of all aggregation proof types, in proof type order.
+func AggregationProofs() []AggregationProofEntry {
+	out := make([]AggregationProofEntry, 0, len(AggregationProofNetworkVersions))
+	for p, versions := range AggregationProofNetworkVersions { // nolint:nomaprange
+		out = append(out, AggregationProofEntry{Proof: p, NetworkVersions: versions})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Proof < out[j].Proof })
+	return out
+}
+
+// AggregationProofForNetwork returns the aggregation proof type to use in a network version.
+func AggregationProofForNetwork(nv network.Version) (stabi.RegisteredAggregationProof, error) {
+	for _, entry := range AggregationProofs() {
+		if entry.NetworkVersions.Contains(nv) {
+			return entry.Proof, nil
+		}
+	}
+	return -1, xerrors.Errorf("no aggregation proof type for network version %d", nv)
+}
+
+// UpdateProofs returns the registry entries of all update proof types, in proof type order.
+func UpdateProofs() []UpdateProofEntry {
+	var out []UpdateProofEntry
+	seen := map[stabi.RegisteredUpdateProof]bool{}
+	for _, entry := range SealProofs() {
+		if seen[entry.UpdateProof] {
+			continue
+		}
+		seen[entry.UpdateProof] = true
+		out = append(out, UpdateProofEntry{
+			Proof:           entry.UpdateProof,
+			SectorSize:      entry.SectorSize,
+			NetworkVersions: UpdateProofNetworkVersions,
+		})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Proof < out[j].Proof })
+	return out
+}
@@ -0,0 +1,45 @@
+package builtin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	stabi "github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/network"
+)
+
+func TestProofRegistry(t *testing.T) {
+	assert.Len(t, SealProofs(), len(stabi.SealProofInfos))
+
+	entry, err := SealProof(stabi.RegisteredSealProof_StackedDrg32GiBV1_2_Feat_NiPoRep)
+	require.NoError(t, err)
+	assert.True(t, entry.NonInteractive)
+	assert.True(t, entry.NetworkVersions.PreCommit.Empty())
+	assert.Equal(t, EpochsInFiveYears, entry.SectorMaxLifetime)
+
+	var proofs []stabi.RegisteredSealProof
+	for _, e := range SealProofsForNetwork(network.Version25, 32<<30) {
+		proofs = append(proofs, e.Proof)
+	}
+	assert.Equal(t, []stabi.RegisteredSealProof{
+		stabi.RegisteredSealProof_StackedDrg32GiBV1_1,
+		stabi.RegisteredSealProof_StackedDrg32GiBV1_1_Feat_SyntheticPoRep,
+		stabi.RegisteredSealProof_StackedDrg32GiBV1_2_Feat_NiPoRep,
+	}, proofs)
+
+	post, err := PoStProof(stabi.RegisteredPoStProof_StackedDrgWindow32GiBV1)
+	require.NoError(t, err)
+	assert.True(t, post.Window)
+	assert.Equal(t, uint64(2349), post.WindowPoStPartitionSectors)
+	assert.False(t, post.NetworkVersions.Contains(network.Version19))
+
+	agg, err := AggregationProofForNetwork(network.Version21)
+	require.NoError(t, err)
+	assert.Equal(t, stabi.RegisteredAggregationProof_SnarkPackV2, agg)
+	_, err = AggregationProofForNetwork(network.Version12)
+	assert.Error(t, err)
+
+	assert.Len(t, UpdateProofs(), 5)
+}
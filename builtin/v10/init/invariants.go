@@ -21,8 +21,11 @@ func CheckStateInvariants(st *State, tree *builtin.ActorTree, actorCodes map[str
 	acc := &builtin.MessageAccumulator{}
 	store := tree.Store
 
-	acc.Require(len(st.NetworkName) > 0, "network name is empty")
-	acc.Require(st.NextID >= builtin.FirstNonSingletonActorId, "next id %d is too low", st.NextID)
+	acc.RequireInvariant(builtin.InvariantInitNetworkName, len(st.NetworkName) > 0, nil,
+		"network name is empty")
+	acc.RequireInvariant(builtin.InvariantInitNextID, st.NextID >= builtin.FirstNonSingletonActorId,
+		builtin.ViolationFields{"actual": st.NextID, "expected_min": builtin.FirstNonSingletonActorId},
+		"next id %d is too low", st.NextID)
 
 	initSummary := &StateSummary{
 		AddrIDs: nil,
@@ -31,7 +34,7 @@ func CheckStateInvariants(st *State, tree *builtin.ActorTree, actorCodes map[str
 
 	lut, err := adt.AsMap(store, st.AddressMap, builtin.DefaultHamtBitwidth)
 	if err != nil {
-		acc.Addf("error loading address map: %v", err)
+		acc.RequireInvariantNoError(builtin.InvariantInitStateLoadable, err, "error loading address map")
 		// Stop here, it's hard to make other useful checks.
 		return initSummary, acc
 	}
@@ -46,27 +49,39 @@ func CheckStateInvariants(st *State, tree *builtin.ActorTree, actorCodes map[str
 			return err
 		}
 
-		acc.Require(keyAddr.Protocol() != addr.ID, "key %v is an ID address", keyAddr)
-		acc.Require(keyAddr.Protocol() <= addr.Delegated, "unknown address protocol for key %v", keyAddr)
-		acc.Require(actorId >= builtin.FirstNonSingletonActorId, "unexpected singleton ID value %v", actorId)
+		acc.RequireInvariant(builtin.InvariantInitKeyNotID, keyAddr.Protocol() != addr.ID,
+			builtin.ViolationFields{"key": keyAddr},
+			"key %v is an ID address", keyAddr)
+		acc.RequireInvariant(builtin.InvariantInitKeyProtocol, keyAddr.Protocol() <= addr.Delegated,
+			builtin.ViolationFields{"key": keyAddr},
+			"unknown address protocol for key %v", keyAddr)
+		acc.RequireInvariant(builtin.InvariantInitMappedNonSingleton, actorId >= builtin.FirstNonSingletonActorId,
+			builtin.ViolationFields{"id": actorId},
+			"unexpected singleton ID value %v", actorId)
 
 		foundAddr, found := reverse[actorId]
 		isPair := (keyAddr.Protocol() == addr.Actor && foundAddr.Protocol() == addr.Delegated) ||
 			(keyAddr.Protocol() == addr.Delegated && foundAddr.Protocol() == addr.Actor)
 		dup := found && !isPair
-		acc.Require(!dup, "duplicate mapping to ID %v: %v, %v", actorId, keyAddr, foundAddr)
+		acc.RequireInvariant(builtin.InvariantInitDuplicateMapping, !dup,
+			builtin.ViolationFields{"id": actorId, "key": keyAddr, "existing_key": foundAddr},
+			"duplicate mapping to ID %v: %v, %v", actorId, keyAddr, foundAddr)
 		reverse[actorId] = keyAddr
 
 		initSummary.AddrIDs[keyAddr] = actorId
 
 		idaddr, err := addr.NewIDAddress(uint64(actorId))
-		acc.RequireNoError(err, "unable to convert actorId %v to id address", actorId)
+		acc.RequireInvariantNoError(builtin.InvariantInitMappedActorLoadable, err, "unable to convert actorId %v to id address", actorId)
 		actor, found, err := tree.GetActorV5(idaddr)
-		acc.RequireNoError(err, "unable to retrieve actor with idaddr %v", idaddr)
-		acc.Require(found, "actor not found idaddr %v", idaddr)
+		acc.RequireInvariantNoError(builtin.InvariantInitMappedActorLoadable, err, "unable to retrieve actor with idaddr %v", idaddr)
+		acc.RequireInvariant(builtin.InvariantInitMappedActorExists, found,
+			builtin.ViolationFields{"actor": idaddr},
+			"actor not found idaddr %v", idaddr)
 
 		if keyAddr.Protocol() == addr.Delegated {
-			acc.Require(canHaveDelegatedAddress(actor, actorCodes), "actor %v not supposed to have a delegated address", idaddr)
+			acc.RequireInvariant(builtin.InvariantInitDelegatedAddressActor, canHaveDelegatedAddress(actor, actorCodes),
+				builtin.ViolationFields{"actor": idaddr},
+				"actor %v not supposed to have a delegated address", idaddr)
 		}
 
 		// we expect the address field to be populated for the below actors
@@ -74,12 +89,14 @@ func CheckStateInvariants(st *State, tree *builtin.ActorTree, actorCodes map[str
 			actor.Code == actorCodes[manifest.EvmKey] ||
 			actor.Code == actorCodes[manifest.PlaceholderKey]) &&
 			keyAddr.Protocol() != addr.Actor {
-			acc.Require(keyAddr == *actor.DelegatedAddress, "address field in actor state differs from addr available in init actor map: actor=%v, init=%v", *actor.DelegatedAddress, keyAddr)
+			acc.RequireInvariant(builtin.InvariantInitDelegatedAddress, keyAddr == *actor.DelegatedAddress,
+				builtin.ViolationFields{"actual": *actor.DelegatedAddress, "expected": keyAddr},
+				"address field in actor state differs from addr available in init actor map: actor=%v, init=%v", *actor.DelegatedAddress, keyAddr)
 		}
 
 		return nil
 	})
-	acc.RequireNoError(err, "error iterating address map")
+	acc.RequireInvariantNoError(builtin.InvariantInitStateLoadable, err, "error iterating address map")
 	return initSummary, acc
 }
 
@@ -17,14 +17,26 @@ func CheckStateInvariants(st *State, _ adt.Store, priorEpoch abi.ChainEpoch, bal
 	acc := &builtin.MessageAccumulator{}
 
 	// Can't assert equality because anyone can send funds to reward actor (and already have on mainnet)
-	acc.Require(big.Add(st.TotalStoragePowerReward, balance).GreaterThanEqual(StorageMiningAllocationCheck), "reward given %v + reward left %v < storage mining allocation %v", st.TotalStoragePowerReward, balance, StorageMiningAllocationCheck)
-
-	acc.Require(st.Epoch == priorEpoch+1, "reward state epoch %d does not match priorEpoch+1 %d", st.Epoch, priorEpoch+1)
-	acc.Require(st.EffectiveNetworkTime <= st.Epoch, "effective network time greater than state epoch")
-
-	acc.Require(st.CumsumRealized.LessThanEqual(st.CumsumBaseline), "cumsum realized > cumsum baseline")
-	acc.Require(st.CumsumRealized.GreaterThanEqual(big.Zero()), "cumsum realized < 0")
-	acc.Require(st.EffectiveBaselinePower.LessThanEqual(st.ThisEpochBaselinePower), "effective baseline power > baseline power")
+	acc.RequireInvariant(builtin.InvariantRewardStorageMiningAllocation, big.Add(st.TotalStoragePowerReward, balance).GreaterThanEqual(StorageMiningAllocationCheck),
+		builtin.ViolationFields{"reward_given": st.TotalStoragePowerReward, "reward_left": balance, "expected_min": StorageMiningAllocationCheck},
+		"reward given %v + reward left %v < storage mining allocation %v", st.TotalStoragePowerReward, balance, StorageMiningAllocationCheck)
+
+	acc.RequireInvariant(builtin.InvariantRewardEpoch, st.Epoch == priorEpoch+1,
+		builtin.ViolationFields{"actual": st.Epoch, "expected": priorEpoch + 1},
+		"reward state epoch %d does not match priorEpoch+1 %d", st.Epoch, priorEpoch+1)
+	acc.RequireInvariant(builtin.InvariantRewardEffectiveNetworkTime, st.EffectiveNetworkTime <= st.Epoch,
+		builtin.ViolationFields{"actual": st.EffectiveNetworkTime, "epoch": st.Epoch},
+		"effective network time greater than state epoch")
+
+	acc.RequireInvariant(builtin.InvariantRewardCumsumRealized, st.CumsumRealized.LessThanEqual(st.CumsumBaseline),
+		builtin.ViolationFields{"actual": st.CumsumRealized, "baseline": st.CumsumBaseline},
+		"cumsum realized > cumsum baseline")
+	acc.RequireInvariant(builtin.InvariantRewardCumsumRealizedNonNegative, st.CumsumRealized.GreaterThanEqual(big.Zero()),
+		builtin.ViolationFields{"actual": st.CumsumRealized},
+		"cumsum realized < 0")
+	acc.RequireInvariant(builtin.InvariantRewardEffectiveBaselinePower, st.EffectiveBaselinePower.LessThanEqual(st.ThisEpochBaselinePower),
+		builtin.ViolationFields{"actual": st.EffectiveBaselinePower, "baseline": st.ThisEpochBaselinePower},
+		"effective baseline power > baseline power")
 
 	return &StateSummary{}, acc
 }
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"path/filepath"
+
 	"github.com/filecoin-project/go-state-types/builtin/v11/account"
 	"github.com/filecoin-project/go-state-types/builtin/v11/cron"
 	"github.com/filecoin-project/go-state-types/builtin/v11/datacap"
@@ -16,18 +18,19 @@ import (
 	"github.com/filecoin-project/go-state-types/builtin/v11/system"
 	"github.com/filecoin-project/go-state-types/builtin/v11/util/smoothing"
 	"github.com/filecoin-project/go-state-types/builtin/v11/verifreg"
+	"github.com/filecoin-project/go-state-types/ipld/schemagen"
 	gen "github.com/whyrusleeping/cbor-gen"
 )
 
 func main() {
-	if err := gen.WriteTupleEncodersToFile("./builtin/v11/system/cbor_gen.go", "system",
+	if err := writeTupleEncoders("./builtin/v11/system", "system",
 		// actor state
 		system.State{},
 	); err != nil {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v11/account/cbor_gen.go", "account",
+	if err := writeTupleEncoders("./builtin/v11/account", "account",
 		// actor state
 		account.State{},
 		// method params and returns
@@ -36,7 +39,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v11/cron/cbor_gen.go", "cron",
+	if err := writeTupleEncoders("./builtin/v11/cron", "cron",
 		// actor state
 		cron.State{},
 		cron.Entry{},
@@ -45,7 +48,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v11/reward/cbor_gen.go", "reward",
+	if err := writeTupleEncoders("./builtin/v11/reward", "reward",
 		// actor state
 		reward.State{},
 		// method params and returns
@@ -55,7 +58,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v11/multisig/cbor_gen.go", "multisig",
+	if err := writeTupleEncoders("./builtin/v11/multisig", "multisig",
 		// actor state
 		multisig.State{},
 		multisig.Transaction{},
@@ -75,7 +78,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v11/paych/cbor_gen.go", "paych",
+	if err := writeTupleEncoders("./builtin/v11/paych", "paych",
 		// actor state
 		paych.State{},
 		paych.LaneState{},
@@ -90,7 +93,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v11/power/cbor_gen.go", "power",
+	if err := writeTupleEncoders("./builtin/v11/power", "power",
 		// actors state
 		power.State{},
 		power.Claim{},
@@ -108,7 +111,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v11/market/cbor_gen.go", "market",
+	if err := writeTupleEncoders("./builtin/v11/market", "market",
 		// actor state
 		market.State{},
 		market.DealState{},
@@ -140,7 +143,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v11/miner/cbor_gen.go", "miner",
+	if err := writeTupleEncoders("./builtin/v11/miner", "miner",
 		// actor state
 		miner.State{},
 		miner.MinerInfo{},
@@ -208,7 +211,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v11/verifreg/cbor_gen.go", "verifreg",
+	if err := writeTupleEncoders("./builtin/v11/verifreg", "verifreg",
 		// actor state
 		verifreg.State{},
 
@@ -246,7 +249,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v11/datacap/cbor_gen.go", "datacap",
+	if err := writeTupleEncoders("./builtin/v11/datacap", "datacap",
 		// actor state
 		datacap.State{},
 		datacap.TokenState{},
@@ -271,13 +274,13 @@ func main() {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v11/util/smoothing/cbor_gen.go", "smoothing",
+	if err := writeTupleEncoders("./builtin/v11/util/smoothing", "smoothing",
 		smoothing.FilterEstimate{},
 	); err != nil {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v11/init/cbor_gen.go", "init",
+	if err := writeTupleEncoders("./builtin/v11/init", "init",
 		// actor state
 		init_.State{},
 		// method params and returns
@@ -289,7 +292,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v11/evm/cbor_gen.go", "evm",
+	if err := writeTupleEncoders("./builtin/v11/evm", "evm",
 		// actor state
 		evm.Tombstone{},
 		evm.State{},
@@ -301,7 +304,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v11/eam/cbor_gen.go", "eam",
+	if err := writeTupleEncoders("./builtin/v11/eam", "eam",
 		// method params and returns
 		eam.CreateParams{},
 		eam.CreateReturn{},
@@ -312,3 +315,12 @@ func main() {
 		panic(err)
 	}
 }
+
+// writeTupleEncoders generates the CBOR tuple encoders of types from the package in dir, along with
+// the IPLD schema describing them.
+func writeTupleEncoders(dir, pkg string, types ...interface{}) error {
+	if err := gen.WriteTupleEncodersToFile(filepath.Join(dir, "cbor_gen.go"), pkg, types...); err != nil {
+		return err
+	}
+	return schemagen.WriteSchemaToDir(dir, pkg, types...)
+}
@@ -20,13 +20,20 @@ func CheckStateInvariants(st *State, store adt.Store) (*StateSummary, *builtin.M
 	acc := &builtin.MessageAccumulator{}
 
 	// assert invariants involving signers
-	acc.Require(len(st.Signers) <= SignersMax, "multisig has too many signers: %d", len(st.Signers))
-	acc.Require(uint64(len(st.Signers)) >= st.NumApprovalsThreshold,
+	acc.RequireInvariant(builtin.InvariantMultisigSignersMax, len(st.Signers) <= SignersMax,
+		builtin.ViolationFields{"actual": len(st.Signers), "expected_max": SignersMax},
+		"multisig has too many signers: %d", len(st.Signers))
+	acc.RequireInvariant(builtin.InvariantMultisigThreshold, uint64(len(st.Signers)) >= st.NumApprovalsThreshold,
+		builtin.ViolationFields{"signers": len(st.Signers), "threshold": st.NumApprovalsThreshold},
 		"multisig has insufficient signers to meet threshold (%d < %d)", len(st.Signers), st.NumApprovalsThreshold)
 
 	if st.UnlockDuration == 0 { // See https://github.com/filecoin-project/specs-actors/issues/1185
-		acc.Require(st.StartEpoch == 0, "non-zero start epoch %d with zero unlock duration", st.StartEpoch)
-		acc.Require(st.InitialBalance.IsZero(), "non-zero locked balance %v with zero unlock duration", st.InitialBalance)
+		acc.RequireInvariant(builtin.InvariantMultisigStartEpoch, st.StartEpoch == 0,
+			builtin.ViolationFields{"actual": st.StartEpoch},
+			"non-zero start epoch %d with zero unlock duration", st.StartEpoch)
+		acc.RequireInvariant(builtin.InvariantMultisigInitialBalance, st.InitialBalance.IsZero(),
+			builtin.ViolationFields{"actual": st.InitialBalance},
+			"non-zero locked balance %v with zero unlock duration", st.InitialBalance)
 	}
 
 	// create lookup to test transaction approvals are multisig signers.
@@ -39,7 +46,7 @@ func CheckStateInvariants(st *State, store adt.Store) (*StateSummary, *builtin.M
 	maxTxnID := TxnID(-1)
 	numPending := uint64(0)
 	if transactions, err := adt.AsMap(store, st.PendingTxns, builtin.DefaultHamtBitwidth); err != nil {
-		acc.Addf("error loading transactions: %v", err)
+		acc.RequireInvariantNoError(builtin.InvariantMultisigStateLoadable, err, "error loading transactions")
 	} else {
 		var txn Transaction
 		err = transactions.ForEach(&txn, func(txnIDStr string) error {
@@ -54,10 +61,14 @@ func CheckStateInvariants(st *State, store adt.Store) (*StateSummary, *builtin.M
 			seenApprovals := make(map[address.Address]struct{})
 			for _, approval := range txn.Approved {
 				_, found := signers[approval]
-				acc.Require(found, "approval %v for transaction %d is not in signers list", approval, txnID)
+				acc.RequireInvariant(builtin.InvariantMultisigApprovalSigner, found,
+					builtin.ViolationFields{"approver": approval, "transaction": txnID},
+					"approval %v for transaction %d is not in signers list", approval, txnID)
 
 				_, seen := seenApprovals[approval]
-				acc.Require(!seen, "duplicate approval %v for transaction %d", approval, txnID)
+				acc.RequireInvariant(builtin.InvariantMultisigApprovalDuplicate, !seen,
+					builtin.ViolationFields{"approver": approval, "transaction": txnID},
+					"duplicate approval %v for transaction %d", approval, txnID)
 
 				seenApprovals[approval] = struct{}{}
 			}
@@ -65,10 +76,12 @@ func CheckStateInvariants(st *State, store adt.Store) (*StateSummary, *builtin.M
 			numPending++
 			return nil
 		})
-		acc.RequireNoError(err, "error iterating transactions")
+		acc.RequireInvariantNoError(builtin.InvariantMultisigStateLoadable, err, "error iterating transactions")
 	}
 
-	acc.Require(st.NextTxnID > maxTxnID, "next transaction id %d is not greater than pending ids", st.NextTxnID)
+	acc.RequireInvariant(builtin.InvariantMultisigNextTxnID, st.NextTxnID > maxTxnID,
+		builtin.ViolationFields{"actual": st.NextTxnID, "max_pending": maxTxnID},
+		"next transaction id %d is not greater than pending ids", st.NextTxnID)
 	return &StateSummary{
 		PendingTxnCount:       numPending,
 		NumApprovalsThreshold: st.NumApprovalsThreshold,
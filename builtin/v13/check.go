@@ -51,14 +51,16 @@ func CheckStateInvariants(tree *builtin.ActorTree, priorEpoch abi.ChainEpoch, ac
 	}
 
 	if err := tree.ForEachV5(func(key address.Address, actor *builtin.ActorV5) error {
-		acc := acc.WithPrefix("%v ", key) // Intentional shadow
+		acc := acc.WithActor(key) // Intentional shadow
 		if key.Protocol() != address.ID {
-			acc.Addf("unexpected address protocol in state tree root: %v", key)
+			acc.AddInvariantf(builtin.InvariantActorAddress, builtin.ViolationFields{"address": key}, "unexpected address protocol in state tree root: %v", key)
 		}
 		totalFIl = big.Add(totalFIl, actor.Balance)
 
 		if actor.DelegatedAddress != nil {
-			acc.Require(actor.DelegatedAddress.Protocol() == address.Delegated, "actor.Address %v is not a delegated address", *actor.DelegatedAddress)
+			acc.RequireInvariant(builtin.InvariantActorDelegatedAddress, actor.DelegatedAddress.Protocol() == address.Delegated,
+				builtin.ViolationFields{"delegated_address": *actor.DelegatedAddress},
+				"actor.Address %v is not a delegated address", *actor.DelegatedAddress)
 			if actor.DelegatedAddress.Protocol() == address.Delegated {
 				delegatedAddrs = append(delegatedAddrs, *actor.DelegatedAddress)
 			}
@@ -164,11 +166,17 @@ func CheckStateInvariants(tree *builtin.ActorTree, priorEpoch abi.ChainEpoch, ac
 			msgs := evm.CheckStateInvariants(&st, tree.Store)
 			acc.WithPrefix("evm: ").AddAll(msgs)
 		case actorCodes[manifest.PlaceholderKey]:
-			acc.Require(actor.Head == emptyObjectCid, "Placeholder actor head %v unequal to emptyObjectCid %v", actor.Head, emptyObjectCid)
+			acc.RequireInvariant(builtin.InvariantEmptyActorHead, actor.Head == emptyObjectCid,
+				builtin.ViolationFields{"actual": actor.Head, "expected": emptyObjectCid},
+				"Placeholder actor head %v unequal to emptyObjectCid %v", actor.Head, emptyObjectCid)
 		case actorCodes[manifest.EthAccountKey]:
-			acc.Require(actor.Head == emptyObjectCid, "EthAccount actor head %v unequal to emptyObjectCid %v", actor.Head, emptyObjectCid)
+			acc.RequireInvariant(builtin.InvariantEmptyActorHead, actor.Head == emptyObjectCid,
+				builtin.ViolationFields{"actual": actor.Head, "expected": emptyObjectCid},
+				"EthAccount actor head %v unequal to emptyObjectCid %v", actor.Head, emptyObjectCid)
 		case actorCodes[manifest.EamKey]:
-			acc.Require(actor.Head == emptyObjectCid, "Eam actor head %s unequal to emptyObjectCid %s", actor.Head, emptyObjectCid)
+			acc.RequireInvariant(builtin.InvariantEmptyActorHead, actor.Head == emptyObjectCid,
+				builtin.ViolationFields{"actual": actor.Head, "expected": emptyObjectCid},
+				"Eam actor head %s unequal to emptyObjectCid %s", actor.Head, emptyObjectCid)
 		default:
 			return xerrors.Errorf("unexpected actor code CID %v for address %v", actor.Code, key)
 		}
@@ -180,7 +188,9 @@ func CheckStateInvariants(tree *builtin.ActorTree, priorEpoch abi.ChainEpoch, ac
 	// Check if all delegated addresses are part of init actor
 	for _, addr := range delegatedAddrs {
 		_, found := initSummary.AddrIDs[addr]
-		acc.Require(found, "delegated address %v not found in init actor map", addr)
+		acc.RequireInvariant(builtin.InvariantDelegatedAddressMapped, found,
+			builtin.ViolationFields{"delegated_address": addr},
+			"delegated address %v not found in init actor map", addr)
 	}
 
 	//
@@ -200,9 +210,9 @@ func CheckStateInvariants(tree *builtin.ActorTree, priorEpoch abi.ChainEpoch, ac
 	_ = rewardSummary
 	_ = datacapSummary
 
-	if !totalFIl.Equals(builtin.TotalFilecoin) {
-		acc.Addf("total token balance is %v, expected %v", totalFIl, builtin.TotalFilecoin)
-	}
+	acc.RequireInvariant(builtin.InvariantTotalBalance, totalFIl.Equals(builtin.TotalFilecoin),
+		builtin.ViolationFields{"expected": builtin.TotalFilecoin, "actual": totalFIl},
+		"total token balance is %v, expected %v", totalFIl, builtin.TotalFilecoin)
 
 	return acc, nil
 }
@@ -211,12 +221,15 @@ func CheckMinersAgainstPower(acc *builtin.MessageAccumulator, minerSummaries map
 	for addr, minerSummary := range minerSummaries { // nolint:nomaprange
 		// check claim
 		claim, ok := powerSummary.Claims[addr]
-		acc.Require(ok, "miner %v has no power claim", addr)
+		acc.RequireInvariant(builtin.InvariantMinerPowerClaim, ok, builtin.ViolationFields{"miner": addr},
+			"miner %v has no power claim", addr)
 		if ok {
 			claimPower := miner.NewPowerPair(claim.RawBytePower, claim.QualityAdjPower)
-			acc.Require(minerSummary.ActivePower.Equals(claimPower),
+			acc.RequireInvariant(builtin.InvariantMinerActivePower, minerSummary.ActivePower.Equals(claimPower),
+				builtin.ViolationFields{"miner": addr, "expected": claimPower, "actual": minerSummary.ActivePower},
 				"miner %v computed active power %v does not match claim %v", addr, minerSummary.ActivePower, claimPower)
-			acc.Require(minerSummary.WindowPoStProofType == claim.WindowPoStProofType,
+			acc.RequireInvariant(builtin.InvariantMinerClaimProofType, minerSummary.WindowPoStProofType == claim.WindowPoStProofType,
+				builtin.ViolationFields{"actual": minerSummary.WindowPoStProofType, "expected": claim.WindowPoStProofType, "miner": addr},
 				"miner seal proof type %d does not match claim proof type %d", minerSummary.WindowPoStProofType, claim.WindowPoStProofType)
 		}
 
@@ -230,24 +243,33 @@ func CheckMinersAgainstPower(acc *builtin.MessageAccumulator, minerSummaries map
 		var provingPeriodCron *power.MinerCronEvent
 		for _, event := range crons {
 			err := payload.UnmarshalCBOR(bytes.NewReader(event.Payload))
-			acc.Require(err == nil, "miner %v registered cron at epoch %d with wrong or corrupt payload",
+			acc.RequireInvariant(builtin.InvariantMinerCronPayload, err == nil,
+				builtin.ViolationFields{"miner": addr, "epoch": event.Epoch},
+				"miner %v registered cron at epoch %d with wrong or corrupt payload",
 				addr, event.Epoch)
-			acc.Require(payload.EventType == miner.CronEventProcessEarlyTerminations || payload.EventType == miner.CronEventProvingDeadline,
+			acc.RequireInvariant(builtin.InvariantMinerCronEventType, payload.EventType == miner.CronEventProcessEarlyTerminations || payload.EventType == miner.CronEventProvingDeadline,
+				builtin.ViolationFields{"miner": addr, "actual": payload.EventType},
 				"miner %v has unexpected cron event type %v", addr, payload.EventType)
 
 			if payload.EventType == miner.CronEventProvingDeadline {
 				if provingPeriodCron != nil {
-					acc.Require(false, "miner %v has duplicate proving period crons at epoch %d and %d",
+					acc.RequireInvariant(builtin.InvariantMinerCronDuplicate, false,
+						builtin.ViolationFields{"miner": addr, "epoch": provingPeriodCron.Epoch, "duplicate_epoch": event.Epoch},
+						"miner %v has duplicate proving period crons at epoch %d and %d",
 						addr, provingPeriodCron.Epoch, event.Epoch)
 				}
 				provingPeriodCron = &event
 			}
 		}
 		hasProvingPeriodCron := provingPeriodCron != nil
-		acc.Require(hasProvingPeriodCron == minerSummary.DeadlineCronActive, "miner %v has invalid DeadlineCronActive (%t) for hasProvingPeriodCron status (%t)",
+		acc.RequireInvariant(builtin.InvariantMinerDeadlineCron, hasProvingPeriodCron == minerSummary.DeadlineCronActive,
+			builtin.ViolationFields{"miner": addr, "actual": minerSummary.DeadlineCronActive, "expected": hasProvingPeriodCron},
+			"miner %v has invalid DeadlineCronActive (%t) for hasProvingPeriodCron status (%t)",
 			addr, minerSummary.DeadlineCronActive, hasProvingPeriodCron)
 
-		acc.Require(provingPeriodCron != nil, "miner %v has no proving period cron", addr)
+		acc.RequireInvariant(builtin.InvariantMinerProvingPeriodCron, provingPeriodCron != nil,
+			builtin.ViolationFields{"miner": addr},
+			"miner %v has no proving period cron", addr)
 	}
 }
 
@@ -263,7 +285,7 @@ func CheckDealStatesAgainstSectors(acc *builtin.MessageAccumulator, minerSummari
 
 		minerSummary, found := minerSummaries[deal.Provider]
 		if !found {
-			acc.Addf("provider %v for deal %d not found among miners", deal.Provider, dealID)
+			acc.AddInvariantf(builtin.InvariantDealProvider, builtin.ViolationFields{"provider": deal.Provider, "deal": dealID}, "provider %v for deal %d not found among miners", deal.Provider, dealID)
 			continue
 		}
 
@@ -272,23 +294,28 @@ func CheckDealStatesAgainstSectors(acc *builtin.MessageAccumulator, minerSummari
 			continue
 		}
 
-		acc.Require(deal.SectorStartEpoch >= sectorDeal.SectorStart,
+		acc.RequireInvariant(builtin.InvariantDealSectorStart, deal.SectorStartEpoch >= sectorDeal.SectorStart,
+			builtin.ViolationFields{"actual": deal.SectorStartEpoch, "expected": sectorDeal.SectorStart, "miner": deal.Provider},
 			"deal state start %d does not match sector start %d for miner %v",
 			deal.SectorStartEpoch, sectorDeal.SectorStart, deal.Provider)
 
-		acc.Require(deal.SectorStartEpoch <= sectorDeal.SectorExpiration,
+		acc.RequireInvariant(builtin.InvariantDealActivation, deal.SectorStartEpoch <= sectorDeal.SectorExpiration,
+			builtin.ViolationFields{"actual": deal.SectorStartEpoch, "sector_expiration": sectorDeal.SectorExpiration, "miner": deal.Provider},
 			"deal state start %d activated after sector expiration %d for miner %v",
 			deal.SectorStartEpoch, sectorDeal.SectorExpiration, deal.Provider)
 
-		acc.Require(deal.LastUpdatedEpoch <= sectorDeal.SectorExpiration,
+		acc.RequireInvariant(builtin.InvariantDealLastUpdated, deal.LastUpdatedEpoch <= sectorDeal.SectorExpiration,
+			builtin.ViolationFields{"actual": deal.LastUpdatedEpoch, "sector_expiration": sectorDeal.SectorExpiration, "miner": deal.Provider},
 			"deal state update at %d after sector expiration %d for miner %v",
 			deal.LastUpdatedEpoch, sectorDeal.SectorExpiration, deal.Provider)
 
-		acc.Require(deal.SlashEpoch <= sectorDeal.SectorExpiration,
+		acc.RequireInvariant(builtin.InvariantDealSlashEpoch, deal.SlashEpoch <= sectorDeal.SectorExpiration,
+			builtin.ViolationFields{"actual": deal.SlashEpoch, "sector_expiration": sectorDeal.SectorExpiration, "miner": deal.Provider},
 			"deal state slashed at %d after sector expiration %d for miner %v",
 			deal.SlashEpoch, sectorDeal.SectorExpiration, deal.Provider)
 
-		acc.Require((deal.SectorNumber == sectorDeal.SectorNumber) || (deal.SectorNumber == 0 && deal.SlashEpoch != -1) || (deal.SectorNumber == 0 && deal.EndEpoch < currEpoch),
+		acc.RequireInvariant(builtin.InvariantDealSectorNumber, (deal.SectorNumber == sectorDeal.SectorNumber) || (deal.SectorNumber == 0 && deal.SlashEpoch != -1) || (deal.SectorNumber == 0 && deal.EndEpoch < currEpoch),
+			builtin.ViolationFields{"actual": deal.SectorNumber, "expected": sectorDeal.SectorNumber, "miner": deal.Provider},
 			"deal sector number %d does not match sector %d for miner %v (ds: %#v; ss %#v)",
 			deal.SectorNumber, sectorDeal.SectorNumber, deal.Provider, deal, sectorDeal)
 	}
@@ -299,13 +326,13 @@ func CheckDealStatesAgainstSectors(acc *builtin.MessageAccumulator, minerSummari
 	for sectorID, dealIDs := range marketSummary.ProviderSectors {
 		maddr, err := address.NewIDAddress(uint64(sectorID.Miner))
 		if err != nil {
-			acc.Addf("error creating ID address: %v", err)
+			acc.RequireInvariantNoError(builtin.InvariantIDAddress, err, "error creating ID address")
 			continue
 		}
 
 		minerSummary, found := minerSummaries[maddr]
 		if !found {
-			acc.Addf("provider %v for sector %v not found among miners", sectorID, sectorID)
+			acc.AddInvariantf(builtin.InvariantDealProvider, builtin.ViolationFields{"sector": sectorID}, "provider %v for sector %v not found among miners", sectorID, sectorID)
 			continue
 		}
 
@@ -316,7 +343,9 @@ func CheckDealStatesAgainstSectors(acc *builtin.MessageAccumulator, minerSummari
 			}
 
 			_, found = marketDealToSector[dealID]
-			acc.Require(!found, "deal %d found in multiple sectors", dealID)
+			acc.RequireInvariant(builtin.InvariantDealSectorUnique, !found,
+				builtin.ViolationFields{"deal": dealID},
+				"deal %d found in multiple sectors", dealID)
 
 			marketDealToSector[dealID] = sectorID
 		}
@@ -327,10 +356,12 @@ func CheckVerifregAgainstDatacap(acc *builtin.MessageAccumulator, verifregSummar
 	// Check verifiers and clients are disjoint.
 	for verifier := range verifregSummary.Verifiers {
 		actorId, err := address.IDFromAddress(verifier)
-		acc.RequireNoError(err, "error getting actor ID: %v", err)
+		acc.RequireInvariantNoError(builtin.InvariantIDAddress, err, "error getting actor ID: %v", err)
 
 		_, found := datacapSummary.Balances[abi.ActorID(actorId)]
-		acc.Require(!found, "verifier %v is also a client", verifier)
+		acc.RequireInvariant(builtin.InvariantVerifregVerifierClient, !found,
+			builtin.ViolationFields{"verifier": verifier},
+			"verifier %v is also a client", verifier)
 	}
 
 	// Check verifreg token balance matches unclaimed allocations
@@ -341,24 +372,30 @@ func CheckVerifregAgainstDatacap(acc *builtin.MessageAccumulator, verifregSummar
 
 	pendingAllocationsTotal = big.Mul(pendingAllocationsTotal, verifreg.DataCapGranularity)
 	verifregId, err := address.IDFromAddress(builtin.VerifiedRegistryActorAddr)
-	acc.RequireNoError(err, "could not get verifreg ID from address")
+	acc.RequireInvariantNoError(builtin.InvariantIDAddress, err, "could not get verifreg ID from address")
 	verifregBalance, found := datacapSummary.Balances[abi.ActorID(verifregId)]
 	if !found {
 		verifregBalance = big.Zero()
 	}
 
-	acc.Require(found, "verifreg not found in datacap actor balances map")
-	acc.Require(verifregBalance.Equals(pendingAllocationsTotal), "verifreg datacap balance %d does not match pending allocation size %d", verifregBalance, pendingAllocationsTotal)
+	// Token balances are positive, so verifreg only has a balance while allocations are pending.
+	acc.RequireInvariant(builtin.InvariantVerifregDatacapBalanceExists, found || pendingAllocationsTotal.IsZero(), nil,
+		"verifreg not found in datacap actor balances map")
+	acc.RequireInvariant(builtin.InvariantVerifregDatacapBalance, verifregBalance.Equals(pendingAllocationsTotal),
+		builtin.ViolationFields{"actual": verifregBalance, "expected": pendingAllocationsTotal},
+		"verifreg datacap balance %d does not match pending allocation size %d", verifregBalance, pendingAllocationsTotal)
 }
 
 func CheckVerifregAgainstMiners(acc *builtin.MessageAccumulator, verifregSummary *verifreg.StateSummary, minerSummaries map[address.Address]*miner.StateSummary) {
 	for _, claim := range verifregSummary.Claims {
 		// all claims are indexed by valid providers
 		maddr, err := address.NewIDAddress(uint64(claim.Provider))
-		acc.RequireNoError(err, "error creating ID address: %v", err)
+		acc.RequireInvariantNoError(builtin.InvariantIDAddress, err, "error creating ID address: %v", err)
 
 		_, ok := minerSummaries[maddr]
-		acc.Require(ok, "claim provider %s is not found in miner summaries", maddr)
+		acc.RequireInvariant(builtin.InvariantClaimProvider, ok,
+			builtin.ViolationFields{"provider": maddr},
+			"claim provider %s is not found in miner summaries", maddr)
 	}
 }
 
@@ -367,16 +404,24 @@ func CheckMarketAgainstVerifreg(acc *builtin.MessageAccumulator, verifregSummary
 	// note that it is possible for claims to exist with no matching deal if the deal expires
 	for claimId, dealId := range marketSummary.ClaimIdToDealId {
 		claim, found := verifregSummary.Claims[claimId]
-		acc.Require(found, "claim %d not found for activated deal %d", claimId, dealId)
+		acc.RequireInvariant(builtin.InvariantDealClaim, found,
+			builtin.ViolationFields{"claim": claimId, "deal": dealId},
+			"claim %d not found for activated deal %d", claimId, dealId)
 
 		info, found := marketSummary.Deals[dealId]
-		acc.Require(found, "internal invariant error invalid market state references missing deal %d", dealId)
+		acc.RequireInvariant(builtin.InvariantDealExists, found,
+			builtin.ViolationFields{"deal": dealId},
+			"internal invariant error invalid market state references missing deal %d", dealId)
 
 		providerId, err := address.IDFromAddress(info.Provider)
-		acc.RequireNoError(err, "error getting ID from provider address")
-		acc.Require(abi.ActorID(providerId) == claim.Provider, "mismatches providers %d %d on claim %d and deal %d", providerId, claim.Provider, claimId, dealId)
-
-		acc.Require(info.PieceCid == claim.Data, "mismatches piece cid %s %s on claim %d and deal %d", info.PieceCid, claim.Data, claimId, dealId)
+		acc.RequireInvariantNoError(builtin.InvariantIDAddress, err, "error getting ID from provider address")
+		acc.RequireInvariant(builtin.InvariantDealClaimProvider, abi.ActorID(providerId) == claim.Provider,
+			builtin.ViolationFields{"actual": providerId, "expected": claim.Provider, "claim": claimId, "deal": dealId},
+			"mismatches providers %d %d on claim %d and deal %d", providerId, claim.Provider, claimId, dealId)
+
+		acc.RequireInvariant(builtin.InvariantDealClaimPiece, info.PieceCid == claim.Data,
+			builtin.ViolationFields{"actual": info.PieceCid, "expected": claim.Data, "claim": claimId, "deal": dealId},
+			"mismatches piece cid %s %s on claim %d and deal %d", info.PieceCid, claim.Data, claimId, dealId)
 	}
 
 	// all pending deal allocation ids have an associated allocation
@@ -384,17 +429,25 @@ func CheckMarketAgainstVerifreg(acc *builtin.MessageAccumulator, verifregSummary
 	// if they are created from a direct DataCap transfer
 	for allocationId, dealId := range marketSummary.AllocIdToDealId {
 		alloc, found := verifregSummary.Allocations[allocationId]
-		acc.Require(found, "allocation %d not found for pending deal %d", allocationId, dealId)
+		acc.RequireInvariant(builtin.InvariantDealAllocation, found,
+			builtin.ViolationFields{"allocation": allocationId, "deal": dealId},
+			"allocation %d not found for pending deal %d", allocationId, dealId)
 		if !found {
 			continue
 		}
 		info, found := marketSummary.Deals[dealId]
-		acc.Require(found, "internal invariant error invalid market state references missing deal %d", dealId)
+		acc.RequireInvariant(builtin.InvariantDealExists, found,
+			builtin.ViolationFields{"deal": dealId},
+			"internal invariant error invalid market state references missing deal %d", dealId)
 
 		providerId, err := address.IDFromAddress(info.Provider)
-		acc.RequireNoError(err, "error getting ID from provider address")
-		acc.Require(abi.ActorID(providerId) == alloc.Provider, "mismatched providers %d %d on alloc %d and deal %d", providerId, alloc.Provider, allocationId, dealId)
-
-		acc.Require(info.PieceCid == alloc.Data, "mismatched piece cid %s %s on alloc %d and deal %d", info.PieceCid, alloc.Data, allocationId, dealId)
+		acc.RequireInvariantNoError(builtin.InvariantIDAddress, err, "error getting ID from provider address")
+		acc.RequireInvariant(builtin.InvariantDealAllocationProvider, abi.ActorID(providerId) == alloc.Provider,
+			builtin.ViolationFields{"actual": providerId, "expected": alloc.Provider, "allocation": allocationId, "deal": dealId},
+			"mismatched providers %d %d on alloc %d and deal %d", providerId, alloc.Provider, allocationId, dealId)
+
+		acc.RequireInvariant(builtin.InvariantDealAllocationPiece, info.PieceCid == alloc.Data,
+			builtin.ViolationFields{"actual": info.PieceCid, "expected": alloc.Data, "allocation": allocationId, "deal": dealId},
+			"mismatched piece cid %s %s on alloc %d and deal %d", info.PieceCid, alloc.Data, allocationId, dealId)
 	}
 }
@@ -1,6 +1,7 @@
 package verifreg
 
 import (
+	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/builtin"
 )
 
@@ -12,6 +13,58 @@ const MinimumVerifiedAllocationTerm = 180 * builtin.EpochsInDay
 
 const MaximumVerifiedAllocationTerm = 5 * builtin.EpochsInYear
 
+// The network's allocation and claim terms. They default to the constants above and are set by a network
+// profile.
+var (
+	networkEndOfLifeClaimDropPeriod            = abi.ChainEpoch(EndOfLifeClaimDropPeriod)
+	networkMaximumVerifiedAllocationExpiration = abi.ChainEpoch(MaximumVerifiedAllocationExpiration)
+	networkMinimumVerifiedAllocationTerm       = abi.ChainEpoch(MinimumVerifiedAllocationTerm)
+	networkMaximumVerifiedAllocationTerm       = abi.ChainEpoch(MaximumVerifiedAllocationTerm)
+)
+
+// NetworkEndOfLifeClaimDropPeriod returns the network's end of life claim drop period.
+func NetworkEndOfLifeClaimDropPeriod() abi.ChainEpoch {
+	return networkEndOfLifeClaimDropPeriod
+}
+
+// NetworkMaximumVerifiedAllocationExpiration returns the network's maximum number of epochs between
+// the creation and the expiration of an allocation.
+func NetworkMaximumVerifiedAllocationExpiration() abi.ChainEpoch {
+	return networkMaximumVerifiedAllocationExpiration
+}
+
+// NetworkMinimumVerifiedAllocationTerm returns the network's minimum term of an allocation.
+func NetworkMinimumVerifiedAllocationTerm() abi.ChainEpoch {
+	return networkMinimumVerifiedAllocationTerm
+}
+
+// NetworkMaximumVerifiedAllocationTerm returns the network's maximum term of an allocation.
+func NetworkMaximumVerifiedAllocationTerm() abi.ChainEpoch {
+	return networkMaximumVerifiedAllocationTerm
+}
+
+// SetNetworkEndOfLifeClaimDropPeriod sets the network's end of life claim drop period.
+func SetNetworkEndOfLifeClaimDropPeriod(period abi.ChainEpoch) {
+	networkEndOfLifeClaimDropPeriod = period
+}
+
+// SetNetworkMaximumVerifiedAllocationExpiration sets the network's maximum allocation expiration.
+func SetNetworkMaximumVerifiedAllocationExpiration(expiration abi.ChainEpoch) {
+	networkMaximumVerifiedAllocationExpiration = expiration
+}
+
+// SetNetworkVerifiedAllocationTerms sets the network's minimum and maximum allocation terms.
+func SetNetworkVerifiedAllocationTerms(minTerm, maxTerm abi.ChainEpoch) {
+	networkMinimumVerifiedAllocationTerm = minTerm
+	networkMaximumVerifiedAllocationTerm = maxTerm
+}
+
+// NetworkMinimumVerifiedAllocationSize returns the network's minimum size of an allocation or claim,
+// which is MinVerifiedDealSize.
+func NetworkMinimumVerifiedAllocationSize() abi.PaddedPieceSize {
+	return abi.PaddedPieceSize(MinVerifiedDealSize.Uint64())
+}
+
 const NoAllocationID = AllocationId(0)
 
 const MinimumVerifiedAllocationSize = 1 << 20
@@ -141,6 +141,31 @@ const MinSectorExpiration = 180 * builtin.EpochsInDay // PARAM_SPEC
 // the associated seal proof's maximum lifetime.
 const MaxSectorExpirationExtension = 1278 * builtin.EpochsInDay // PARAM_SPEC
 
+// The network's sector expiration bounds. They default to the constants above and are set by a network
+// profile.
+var (
+	networkMinSectorExpiration          = abi.ChainEpoch(MinSectorExpiration)
+	networkMaxSectorExpirationExtension = abi.ChainEpoch(MaxSectorExpirationExtension)
+)
+
+// NetworkMinSectorExpiration returns the minimum number of epochs past the current epoch a sector may be
+// set to expire on the network.
+func NetworkMinSectorExpiration() abi.ChainEpoch {
+	return networkMinSectorExpiration
+}
+
+// NetworkMaxSectorExpirationExtension returns the maximum number of epochs past the current epoch that
+// sector lifetime may be extended on the network.
+func NetworkMaxSectorExpirationExtension() abi.ChainEpoch {
+	return networkMaxSectorExpirationExtension
+}
+
+// SetNetworkSectorExpiration sets the network's sector expiration bounds.
+func SetNetworkSectorExpiration(minExpiration, maxExtension abi.ChainEpoch) {
+	networkMinSectorExpiration = minExpiration
+	networkMaxSectorExpirationExtension = maxExtension
+}
+
 // DealWeight and VerifiedDealWeight are spacetime occupied by regular deals and verified deals in a sector.
 // Sum of DealWeight and VerifiedDealWeight should be less than or equal to total SpaceTime of a sector.
 // Sectors full of VerifiedDeals will have a SectorQuality of VerifiedDealWeightMultiplier/QualityBaseMultiplier.
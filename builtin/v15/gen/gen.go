@@ -1,6 +1,8 @@
 package main
 
 import (
+	"path/filepath"
+
 	gen "github.com/whyrusleeping/cbor-gen"
 
 	"github.com/filecoin-project/go-state-types/builtin/v15/account"
@@ -18,17 +20,18 @@ import (
 	"github.com/filecoin-project/go-state-types/builtin/v15/system"
 	"github.com/filecoin-project/go-state-types/builtin/v15/util/smoothing"
 	"github.com/filecoin-project/go-state-types/builtin/v15/verifreg"
+	"github.com/filecoin-project/go-state-types/ipld/schemagen"
 )
 
 func main() {
-	if err := gen.WriteTupleEncodersToFile("./builtin/v15/system/cbor_gen.go", "system",
+	if err := writeTupleEncoders("./builtin/v15/system", "system",
 		// actor state
 		system.State{},
 	); err != nil {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v15/account/cbor_gen.go", "account",
+	if err := writeTupleEncoders("./builtin/v15/account", "account",
 		// actor state
 		account.State{},
 		// method params and returns
@@ -37,7 +40,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v15/cron/cbor_gen.go", "cron",
+	if err := writeTupleEncoders("./builtin/v15/cron", "cron",
 		// actor state
 		cron.State{},
 		cron.Entry{},
@@ -46,7 +49,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v15/reward/cbor_gen.go", "reward",
+	if err := writeTupleEncoders("./builtin/v15/reward", "reward",
 		// actor state
 		reward.State{},
 		// method params and returns
@@ -56,7 +59,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v15/multisig/cbor_gen.go", "multisig",
+	if err := writeTupleEncoders("./builtin/v15/multisig", "multisig",
 		// actor state
 		multisig.State{},
 		multisig.Transaction{},
@@ -76,7 +79,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v15/paych/cbor_gen.go", "paych",
+	if err := writeTupleEncoders("./builtin/v15/paych", "paych",
 		// actor state
 		paych.State{},
 		paych.LaneState{},
@@ -91,7 +94,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v15/power/cbor_gen.go", "power",
+	if err := writeTupleEncoders("./builtin/v15/power", "power",
 		// actors state
 		power.State{},
 		power.Claim{},
@@ -109,7 +112,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v15/market/cbor_gen.go", "market",
+	if err := writeTupleEncoders("./builtin/v15/market", "market",
 		// actor state
 		market.State{},
 		market.DealState{},
@@ -141,7 +144,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v15/miner/cbor_gen.go", "miner",
+	if err := writeTupleEncoders("./builtin/v15/miner", "miner",
 		// actor state
 		miner.State{},
 		miner.MinerInfo{},
@@ -221,7 +224,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v15/verifreg/cbor_gen.go", "verifreg",
+	if err := writeTupleEncoders("./builtin/v15/verifreg", "verifreg",
 		// actor state
 		verifreg.State{},
 
@@ -260,7 +263,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v15/datacap/cbor_gen.go", "datacap",
+	if err := writeTupleEncoders("./builtin/v15/datacap", "datacap",
 		// actor state
 		datacap.State{},
 		datacap.TokenState{},
@@ -285,13 +288,13 @@ func main() {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v15/util/smoothing/cbor_gen.go", "smoothing",
+	if err := writeTupleEncoders("./builtin/v15/util/smoothing", "smoothing",
 		smoothing.FilterEstimate{},
 	); err != nil {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v15/init/cbor_gen.go", "init",
+	if err := writeTupleEncoders("./builtin/v15/init", "init",
 		// actor state
 		init_.State{},
 		// method params and returns
@@ -303,7 +306,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v15/evm/cbor_gen.go", "evm",
+	if err := writeTupleEncoders("./builtin/v15/evm", "evm",
 		// actor state
 		evm.Tombstone{},
 		evm.State{},
@@ -315,7 +318,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v15/eam/cbor_gen.go", "eam",
+	if err := writeTupleEncoders("./builtin/v15/eam", "eam",
 		// method params and returns
 		eam.CreateParams{},
 		eam.CreateReturn{},
@@ -326,3 +329,12 @@ func main() {
 		panic(err)
 	}
 }
+
+// writeTupleEncoders generates the CBOR tuple encoders of types from the package in dir, along with
+// the IPLD schema describing them.
+func writeTupleEncoders(dir, pkg string, types ...interface{}) error {
+	if err := gen.WriteTupleEncodersToFile(filepath.Join(dir, "cbor_gen.go"), pkg, types...); err != nil {
+		return err
+	}
+	return schemagen.WriteSchemaToDir(dir, pkg, types...)
+}
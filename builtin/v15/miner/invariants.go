@@ -41,7 +41,7 @@ func CheckStateInvariants(st *State, store adt.Store, balance abi.TokenAmount) (
 
 	// Load data from linked structures.
 	if info, err := st.GetInfo(store); err != nil {
-		acc.Addf("error loading miner info: %v", err)
+		acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error loading miner info")
 		// Stop here, it's too hard to make other useful checks.
 		return minerSummary, acc
 	} else {
@@ -55,12 +55,12 @@ func CheckStateInvariants(st *State, store adt.Store, balance abi.TokenAmount) (
 	var allocatedSectors bitfield.BitField
 	var allocatedSectorsMap map[uint64]bool
 	if err := store.Get(store.Context(), st.AllocatedSectors, &allocatedSectors); err != nil {
-		acc.Addf("error loading allocated sector bitfield: %v", err)
+		acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error loading allocated sector bitfield")
 	} else {
 		allocatedSectorsMap, err = allocatedSectors.AllMap(1 << 30)
 		// if it's too big to expand, we'll fall back on the bitfield directly
 		if err != nil && !errors.Is(err, bitfield.ErrBitFieldTooMany) {
-			acc.Addf("error expanding allocated sector bitfield: %v", err)
+			acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error expanding allocated sector bitfield")
 			allocatedSectorsMap = nil
 		}
 	}
@@ -70,12 +70,14 @@ func CheckStateInvariants(st *State, store adt.Store, balance abi.TokenAmount) (
 	minerSummary.Deals = map[abi.DealID]DealSummary{}
 	var allSectors map[abi.SectorNumber]*SectorOnChainInfo
 	if sectorsArr, err := adt.AsArray(store, st.Sectors, SectorsAmtBitwidth); err != nil {
-		acc.Addf("error loading sectors")
+		acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error loading sectors")
 	} else {
 		allSectors = map[abi.SectorNumber]*SectorOnChainInfo{}
 		var sector SectorOnChainInfo
 		err = sectorsArr.ForEach(&sector, func(sno int64) error {
-			acc.Require(sector.PowerBaseEpoch >= sector.Activation, "sector %d has PBE %d < Activation %d",
+			acc.RequireInvariant(builtin.InvariantMinerSectorPowerBaseEpoch, sector.PowerBaseEpoch >= sector.Activation,
+				builtin.ViolationFields{"sector": sector.SectorNumber, "power_base_epoch": sector.PowerBaseEpoch, "activation": sector.Activation},
+				"sector %d has PBE %d < Activation %d",
 				sector.SectorNumber,
 				sector.PowerBaseEpoch,
 				sector.Activation)
@@ -89,12 +91,13 @@ func CheckStateInvariants(st *State, store adt.Store, balance abi.TokenAmount) (
 			} else {
 				allocated, err = allocatedSectors.IsSet(uint64(sno))
 				if err != nil {
-					acc.Addf("error checking allocated sectors: %v", err)
+					acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error checking allocated sectors")
 					return nil
 				}
 			}
 
-			acc.Require(allocated,
+			acc.RequireInvariant(builtin.InvariantMinerSectorAllocated, allocated,
+				builtin.ViolationFields{"sector": sno},
 				"on chain sector's sector number has not been allocated %d", sno)
 
 			for _, dealID := range sector.DealIDs {
@@ -107,16 +110,17 @@ func CheckStateInvariants(st *State, store adt.Store, balance abi.TokenAmount) (
 
 			return nil
 		})
-		acc.RequireNoError(err, "error iterating sectors")
+		acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error iterating sectors")
 	}
 
 	// Check deadlines
-	acc.Require(st.CurrentDeadline < WPoStPeriodDeadlines,
+	acc.RequireInvariant(builtin.InvariantMinerCurrentDeadline, st.CurrentDeadline < WPoStPeriodDeadlines,
+		builtin.ViolationFields{"deadlines": WPoStPeriodDeadlines, "current_deadline": st.CurrentDeadline},
 		"current deadline index is greater than deadlines per period(%d): %d", WPoStPeriodDeadlines, st.CurrentDeadline)
 
 	deadlines, err := st.LoadDeadlines(store)
 	if err != nil {
-		acc.Addf("error loading deadlines: %v", err)
+		acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error loading deadlines")
 		deadlines = nil
 	}
 
@@ -131,7 +135,7 @@ func CheckStateInvariants(st *State, store adt.Store, balance abi.TokenAmount) (
 			minerSummary.FaultyPower = minerSummary.FaultyPower.Add(dlSummary.FaultyPower)
 			return nil
 		})
-		acc.RequireNoError(err, "error iterating deadlines")
+		acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error iterating deadlines")
 	}
 
 	return minerSummary, acc
@@ -155,7 +159,7 @@ func CheckDeadlineStateInvariants(deadline *Deadline, store adt.Store, quant bui
 	// Load linked structures.
 	partitions, err := deadline.PartitionsArray(store)
 	if err != nil {
-		acc.Addf("error loading partitions: %v", err)
+		acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error loading partitions")
 		// Hard to do any useful checks.
 		return &DeadlineStateSummary{
 			AllSectors:        bitfield.New(),
@@ -188,16 +192,20 @@ func CheckDeadlineStateInvariants(deadline *Deadline, store adt.Store, quant bui
 	err = partitions.ForEach(&partition, func(i int64) error {
 		pIdx := uint64(i)
 		// Check sequential partitions.
-		acc.Require(pIdx == partitionCount, "Non-sequential partitions, expected index %d, found %d", partitionCount, pIdx)
+		acc.RequireInvariant(builtin.InvariantMinerPartitionSequence, pIdx == partitionCount,
+			builtin.ViolationFields{"expected": partitionCount, "actual": pIdx},
+			"Non-sequential partitions, expected index %d, found %d", partitionCount, pIdx)
 		partitionCount++
 
 		acc := acc.WithPrefix("partition %d: ", pIdx) // Shadow
 		summary := CheckPartitionStateInvariants(&partition, store, quant, ssize, sectors, acc)
 
 		if contains, err := util.BitFieldContainsAny(allSectors, summary.AllSectors); err != nil {
-			acc.Addf("error checking bitfield contains: %v", err)
+			acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error checking bitfield contains")
 		} else {
-			acc.Require(!contains, "duplicate sector in partition %d", pIdx)
+			acc.RequireInvariant(builtin.InvariantMinerPartitionDuplicateSector, !contains,
+				builtin.ViolationFields{"partition": pIdx},
+				"duplicate sector in partition %d", pIdx)
 		}
 
 		for _, e := range summary.ExpirationEpochs {
@@ -209,7 +217,7 @@ func CheckDeadlineStateInvariants(deadline *Deadline, store adt.Store, quant bui
 
 		allSectors, err = bitfield.MergeBitFields(allSectors, summary.AllSectors)
 		if err != nil {
-			acc.Addf("error merging partition sector numbers with all: %v", err)
+			acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error merging partition sector numbers with all")
 			allSectors = bitfield.New()
 		}
 		allLiveSectors = append(allLiveSectors, summary.LiveSectors)
@@ -222,122 +230,140 @@ func CheckDeadlineStateInvariants(deadline *Deadline, store adt.Store, quant bui
 		allFaultyPower = allFaultyPower.Add(summary.FaultyPower)
 		return nil
 	})
-	acc.RequireNoError(err, "error iterating partitions")
+	acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error iterating partitions")
 
 	// Check invariants on partitions proven.
 	{
 		if lastProof, err := deadline.PartitionsPoSted.Last(); err != nil {
 			if err != bitfield.ErrNoBitsSet {
-				acc.Addf("error determining the last partition proven: %v", err)
+				acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error determining the last partition proven")
 			}
 		} else {
-			acc.Require(partitionCount >= (lastProof+1), "expected at least %d partitions, found %d", lastProof+1, partitionCount)
-			acc.Require(deadline.LiveSectors > 0, "expected at least one live sector when partitions have been proven")
+			acc.RequireInvariant(builtin.InvariantMinerProvenPartitions, partitionCount >= (lastProof+1),
+				builtin.ViolationFields{"expected": lastProof + 1, "actual": partitionCount},
+				"expected at least %d partitions, found %d", lastProof+1, partitionCount)
+			acc.RequireInvariant(builtin.InvariantMinerProvenLiveSectors, deadline.LiveSectors > 0, nil,
+				"expected at least one live sector when partitions have been proven")
 		}
 	}
 
 	// Check partitions snapshot to make sure we take the snapshot after
 	// dealing with recovering power and unproven power.
 	partitionsSnapshot, err := deadline.PartitionsSnapshotArray(store)
-	acc.RequireNoError(err, "error loading partitions snapshot")
+	acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error loading partitions snapshot")
 	err = partitionsSnapshot.ForEach(&partition, func(i int64) error {
 		acc := acc.WithPrefix("partition snapshot %d: ", i) // Shadow
 
-		acc.Require(partition.RecoveringPower.IsZero(), "snapshot partition has recovering power")
+		acc.RequireInvariant(builtin.InvariantMinerSnapshotRecoveringPower, partition.RecoveringPower.IsZero(), nil,
+			"snapshot partition has recovering power")
 		if noRecoveries, err := partition.Recoveries.IsEmpty(); err != nil {
-			acc.Addf("error counting recoveries: %v", err)
+			acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error counting recoveries")
 		} else {
-			acc.Require(noRecoveries, "snapshot partition has pending recoveries")
+			acc.RequireInvariant(builtin.InvariantMinerSnapshotRecoveries, noRecoveries, nil,
+				"snapshot partition has pending recoveries")
 		}
 
-		acc.Require(partition.UnprovenPower.IsZero(), "snapshot partition has unproven power")
+		acc.RequireInvariant(builtin.InvariantMinerSnapshotUnprovenPower, partition.UnprovenPower.IsZero(), nil,
+			"snapshot partition has unproven power")
 		if noUnproven, err := partition.Unproven.IsEmpty(); err != nil {
-			acc.Addf("error counting unproven: %v", err)
+			acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error counting unproven")
 		} else {
-			acc.Require(noUnproven, "snapshot partition has unproven sectors")
+			acc.RequireInvariant(builtin.InvariantMinerSnapshotUnproven, noUnproven, nil,
+				"snapshot partition has unproven sectors")
 		}
 
 		return nil
 	})
-	acc.RequireNoError(err, "error iterating partitions snapshot")
+	acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error iterating partitions snapshot")
 
 	// Check that we don't have any proofs proving partitions that are not in the snapshot.
 	proofsSnapshot, err := deadline.OptimisticProofsSnapshotArray(store)
-	acc.RequireNoError(err, "error loading proofs snapshot")
+	acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error loading proofs snapshot")
 	var proof WindowedPoSt
 	err = proofsSnapshot.ForEach(&proof, func(_ int64) error {
 		err = proof.Partitions.ForEach(func(i uint64) error {
 			found, err := partitionsSnapshot.Get(i, &partition)
-			acc.RequireNoError(err, "error loading partition snapshot")
-			acc.Require(found, "failed to find partition for recorded proof in the snapshot")
+			acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error loading partition snapshot")
+			acc.RequireInvariant(builtin.InvariantMinerSnapshotProofPartition, found, nil,
+				"failed to find partition for recorded proof in the snapshot")
 			return nil
 		})
-		acc.RequireNoError(err, "error iterating proof partitions bitfield")
+		acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error iterating proof partitions bitfield")
 		return nil
 	})
-	acc.RequireNoError(err, "error iterating proofs snapshot")
+	acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error iterating proofs snapshot")
 
 	// Check memoized sector and power values.
 	live, err := bitfield.MultiMerge(allLiveSectors...)
 	if err != nil {
-		acc.Addf("error merging live sector numbers: %v", err)
+		acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error merging live sector numbers")
 		live = bitfield.New()
 	} else {
 		if liveCount, err := live.Count(); err != nil {
-			acc.Addf("error counting live sectors: %v", err)
+			acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error counting live sectors")
 		} else {
-			acc.Require(deadline.LiveSectors == liveCount, "deadline live sectors %d != partitions count %d", deadline.LiveSectors, liveCount)
+			acc.RequireInvariant(builtin.InvariantMinerDeadlineLiveSectors, deadline.LiveSectors == liveCount,
+				builtin.ViolationFields{"actual": deadline.LiveSectors, "expected": liveCount},
+				"deadline live sectors %d != partitions count %d", deadline.LiveSectors, liveCount)
 		}
 	}
 
 	if allCount, err := allSectors.Count(); err != nil {
-		acc.Addf("error counting all sectors: %v", err)
+		acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error counting all sectors")
 	} else {
-		acc.Require(deadline.TotalSectors == allCount, "deadline total sectors %d != partitions count %d", deadline.TotalSectors, allCount)
+		acc.RequireInvariant(builtin.InvariantMinerDeadlineTotalSectors, deadline.TotalSectors == allCount,
+			builtin.ViolationFields{"actual": deadline.TotalSectors, "expected": allCount},
+			"deadline total sectors %d != partitions count %d", deadline.TotalSectors, allCount)
 	}
 
 	faulty, err := bitfield.MultiMerge(allFaultySectors...)
 	if err != nil {
-		acc.Addf("error merging faulty sector numbers: %v", err)
+		acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error merging faulty sector numbers")
 		faulty = bitfield.New()
 	}
 	recovering, err := bitfield.MultiMerge(allRecoveringSectors...)
 	if err != nil {
-		acc.Addf("error merging recovering sector numbers: %v", err)
+		acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error merging recovering sector numbers")
 		recovering = bitfield.New()
 	}
 	unproven, err := bitfield.MultiMerge(allUnprovenSectors...)
 	if err != nil {
-		acc.Addf("error merging unproven sector numbers: %v", err)
+		acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error merging unproven sector numbers")
 		unproven = bitfield.New()
 	}
 	terminated, err := bitfield.MultiMerge(allTerminatedSectors...)
 	if err != nil {
-		acc.Addf("error merging terminated sector numbers: %v", err)
+		acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error merging terminated sector numbers")
 		terminated = bitfield.New()
 	}
 
-	acc.Require(deadline.FaultyPower.Equals(allFaultyPower), "deadline faulty power %v != partitions total %v", deadline.FaultyPower, allFaultyPower)
+	acc.RequireInvariant(builtin.InvariantMinerDeadlineFaultyPower, deadline.FaultyPower.Equals(allFaultyPower),
+		builtin.ViolationFields{"actual": deadline.FaultyPower, "expected": allFaultyPower},
+		"deadline faulty power %v != partitions total %v", deadline.FaultyPower, allFaultyPower)
 
 	{
 		// Validate partition expiration queue contains an entry for each partition and epoch with an expiration.
 		// The queue may be a superset of the partitions that have expirations because we never remove from it.
 		if expirationEpochs, err := adt.AsArray(store, deadline.ExpirationsEpochs, DeadlineExpirationAmtBitwidth); err != nil {
-			acc.Addf("error loading expiration queue: %v", err)
+			acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error loading expiration queue")
 		} else {
 			for epoch, expiringPIdxs := range partitionsWithExpirations { // nolint:nomaprange
 				var bf bitfield.BitField
 				if found, err := expirationEpochs.Get(uint64(epoch), &bf); err != nil {
-					acc.Addf("error fetching expiration bitfield: %v", err)
+					acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error fetching expiration bitfield")
 				} else {
-					acc.Require(found, "expected to find partition expiration entry at epoch %d", epoch)
+					acc.RequireInvariant(builtin.InvariantMinerDeadlineExpirationEntry, found,
+						builtin.ViolationFields{"epoch": epoch},
+						"expected to find partition expiration entry at epoch %d", epoch)
 				}
 
 				if queuedPIdxs, err := bf.AllMap(1 << 20); err != nil {
-					acc.Addf("error expanding expirating partitions: %v", err)
+					acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error expanding expirating partitions")
 				} else {
 					for _, p := range expiringPIdxs {
-						acc.Require(queuedPIdxs[p], "expected partition %d to be present in deadline expiration queue at epoch %d", p, epoch)
+						acc.RequireInvariant(builtin.InvariantMinerDeadlineExpirationPartition, queuedPIdxs[p],
+							builtin.ViolationFields{"partition": p, "epoch": epoch},
+							"expected partition %d to be present in deadline expiration queue at epoch %d", p, epoch)
 					}
 				}
 			}
@@ -346,7 +372,7 @@ func CheckDeadlineStateInvariants(deadline *Deadline, store adt.Store, quant bui
 	{
 		// Validate the early termination queue contains exactly the partitions with early terminations.
 		expected := bitfield.NewFromSet(partitionsWithEarlyTerminations)
-		requireEqual(expected, deadline.EarlyTerminations, acc, "deadline early terminations doesn't match expected partitions")
+		requireEqual(expected, deadline.EarlyTerminations, acc, builtin.InvariantMinerDeadlineEarlyTerminations, "deadline early terminations doesn't match expected partitions")
 	}
 
 	return &DeadlineStateSummary{
@@ -388,12 +414,12 @@ func CheckPartitionStateInvariants(
 	irrecoverable := false // State is so broken we can't make useful checks.
 	live, err := partition.LiveSectors()
 	if err != nil {
-		acc.Addf("error computing live sectors: %v", err)
+		acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error computing live sectors")
 		irrecoverable = true
 	}
 	active, err := partition.ActiveSectors()
 	if err != nil {
-		acc.Addf("error computing active sectors: %v", err)
+		acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error computing active sectors")
 		irrecoverable = true
 	}
 
@@ -415,31 +441,31 @@ func CheckPartitionStateInvariants(
 	}
 
 	// Live contains all active sectors.
-	requireContainsAll(live, active, acc, "live does not contain active")
+	requireContainsAll(live, active, acc, builtin.InvariantMinerPartitionLiveActive, "live does not contain active")
 
 	// Live contains all faults.
-	requireContainsAll(live, partition.Faults, acc, "live does not contain faults")
+	requireContainsAll(live, partition.Faults, acc, builtin.InvariantMinerPartitionLiveFaults, "live does not contain faults")
 
 	// Live contains all unproven.
-	requireContainsAll(live, partition.Unproven, acc, "live does not contain unproven")
+	requireContainsAll(live, partition.Unproven, acc, builtin.InvariantMinerPartitionLiveUnproven, "live does not contain unproven")
 
 	// Active contains no faults
-	requireContainsNone(active, partition.Faults, acc, "active includes faults")
+	requireContainsNone(active, partition.Faults, acc, builtin.InvariantMinerPartitionActiveFaults, "active includes faults")
 
 	// Active contains no unproven
-	requireContainsNone(active, partition.Unproven, acc, "active includes unproven")
+	requireContainsNone(active, partition.Unproven, acc, builtin.InvariantMinerPartitionActiveUnproven, "active includes unproven")
 
 	// Faults contains all recoveries.
-	requireContainsAll(partition.Faults, partition.Recoveries, acc, "faults do not contain recoveries")
+	requireContainsAll(partition.Faults, partition.Recoveries, acc, builtin.InvariantMinerPartitionFaultsRecoveries, "faults do not contain recoveries")
 
 	// Live contains no terminated sectors
-	requireContainsNone(live, partition.Terminated, acc, "live includes terminations")
+	requireContainsNone(live, partition.Terminated, acc, builtin.InvariantMinerPartitionLiveTerminated, "live includes terminations")
 
 	// Unproven contains no faults
-	requireContainsNone(partition.Faults, partition.Unproven, acc, "unproven includes faults")
+	requireContainsNone(partition.Faults, partition.Unproven, acc, builtin.InvariantMinerPartitionUnprovenFaults, "unproven includes faults")
 
 	// All terminated sectors are part of the partition.
-	requireContainsAll(partition.Sectors, partition.Terminated, acc, "sectors do not contain terminations")
+	requireContainsAll(partition.Sectors, partition.Terminated, acc, builtin.InvariantMinerPartitionSectorsTerminated, "sectors do not contain terminations")
 
 	// Validate power
 	var liveSectors map[abi.SectorNumber]*SectorOnChainInfo
@@ -449,65 +475,75 @@ func CheckPartitionStateInvariants(
 	unprovenPower := NewPowerPairZero()
 
 	if liveSectors, missing, err = selectSectorsMap(sectors, live); err != nil {
-		acc.Addf("error selecting live sectors: %v", err)
+		acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error selecting live sectors")
 	} else if len(missing) > 0 {
-		acc.Addf("live sectors missing from all sectors: %v", missing)
+		acc.AddInvariantf(builtin.InvariantMinerPartitionSectorsExist, builtin.ViolationFields{"missing": missing}, "live sectors missing from all sectors: %v", missing)
 	} else {
 		livePower = powerForSectors(liveSectors, sectorSize)
-		acc.Require(partition.LivePower.Equals(livePower), "live power was %v, expected %v", partition.LivePower, livePower)
+		acc.RequireInvariant(builtin.InvariantMinerPartitionLivePower, partition.LivePower.Equals(livePower),
+			builtin.ViolationFields{"actual": partition.LivePower, "expected": livePower},
+			"live power was %v, expected %v", partition.LivePower, livePower)
 	}
 
 	if unprovenSectors, missing, err := selectSectorsMap(sectors, partition.Unproven); err != nil {
-		acc.Addf("error selecting unproven sectors: %v", err)
+		acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error selecting unproven sectors")
 	} else if len(missing) > 0 {
-		acc.Addf("unproven sectors missing from all sectors: %v", missing)
+		acc.AddInvariantf(builtin.InvariantMinerPartitionSectorsExist, builtin.ViolationFields{"missing": missing}, "unproven sectors missing from all sectors: %v", missing)
 	} else {
 		unprovenPower = powerForSectors(unprovenSectors, sectorSize)
-		acc.Require(partition.UnprovenPower.Equals(unprovenPower), "unproven power was %v, expected %v", partition.UnprovenPower, unprovenPower)
+		acc.RequireInvariant(builtin.InvariantMinerPartitionUnprovenPower, partition.UnprovenPower.Equals(unprovenPower),
+			builtin.ViolationFields{"actual": partition.UnprovenPower, "expected": unprovenPower},
+			"unproven power was %v, expected %v", partition.UnprovenPower, unprovenPower)
 	}
 
 	if faultySectors, missing, err := selectSectorsMap(sectors, partition.Faults); err != nil {
-		acc.Addf("error selecting faulty sectors: %v", err)
+		acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error selecting faulty sectors")
 	} else if len(missing) > 0 {
-		acc.Addf("faulty sectors missing from all sectors: %v", missing)
+		acc.AddInvariantf(builtin.InvariantMinerPartitionSectorsExist, builtin.ViolationFields{"missing": missing}, "faulty sectors missing from all sectors: %v", missing)
 	} else {
 		faultyPower = powerForSectors(faultySectors, sectorSize)
-		acc.Require(partition.FaultyPower.Equals(faultyPower), "faulty power was %v, expected %v", partition.FaultyPower, faultyPower)
+		acc.RequireInvariant(builtin.InvariantMinerPartitionFaultyPower, partition.FaultyPower.Equals(faultyPower),
+			builtin.ViolationFields{"actual": partition.FaultyPower, "expected": faultyPower},
+			"faulty power was %v, expected %v", partition.FaultyPower, faultyPower)
 	}
 
 	if recoveringSectors, missing, err := selectSectorsMap(sectors, partition.Recoveries); err != nil {
-		acc.Addf("error selecting recovering sectors: %v", err)
+		acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error selecting recovering sectors")
 	} else if len(missing) > 0 {
-		acc.Addf("recovering sectors missing from all sectors: %v", missing)
+		acc.AddInvariantf(builtin.InvariantMinerPartitionSectorsExist, builtin.ViolationFields{"missing": missing}, "recovering sectors missing from all sectors: %v", missing)
 	} else {
 		recoveringPower := powerForSectors(recoveringSectors, sectorSize)
-		acc.Require(partition.RecoveringPower.Equals(recoveringPower), "recovering power was %v, expected %v", partition.RecoveringPower, recoveringPower)
+		acc.RequireInvariant(builtin.InvariantMinerPartitionRecoveringPower, partition.RecoveringPower.Equals(recoveringPower),
+			builtin.ViolationFields{"actual": partition.RecoveringPower, "expected": recoveringPower},
+			"recovering power was %v, expected %v", partition.RecoveringPower, recoveringPower)
 	}
 
 	activePower := livePower.Sub(faultyPower).Sub(unprovenPower)
 	partitionActivePower := partition.ActivePower()
-	acc.Require(partitionActivePower.Equals(activePower), "active power was %v, expected %v", partitionActivePower, activePower)
+	acc.RequireInvariant(builtin.InvariantMinerPartitionActivePower, partitionActivePower.Equals(activePower),
+		builtin.ViolationFields{"actual": partitionActivePower, "expected": activePower},
+		"active power was %v, expected %v", partitionActivePower, activePower)
 
 	// Validate the expiration queue.
 	var expirationEpochs []abi.ChainEpoch
 	if expQ, err := LoadExpirationQueue(store, partition.ExpirationsEpochs, quant, PartitionExpirationAmtBitwidth); err != nil {
-		acc.Addf("error loading expiration queue: %v", err)
+		acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error loading expiration queue")
 	} else if liveSectors != nil {
 		qsummary := CheckExpirationQueue(expQ, liveSectors, partition.Faults, quant, sectorSize, acc)
 		expirationEpochs = qsummary.ExpirationEpochs
 
 		// Check the queue is compatible with partition fields
 		if qSectors, err := bitfield.MergeBitFields(qsummary.OnTimeSectors, qsummary.EarlySectors); err != nil {
-			acc.Addf("error merging summary on-time and early sectors: %v", err)
+			acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error merging summary on-time and early sectors")
 		} else {
-			requireEqual(live, qSectors, acc, "live does not equal all expirations")
+			requireEqual(live, qSectors, acc, builtin.InvariantMinerPartitionExpirations, "live does not equal all expirations")
 		}
 	}
 
 	// Validate the early termination queue.
 	earlyTerminationCount := 0
 	if earlyQ, err := util.LoadBitfieldQueue(store, partition.EarlyTerminated, builtin.NoQuantization, PartitionEarlyTerminationArrayAmtBitwidth); err != nil {
-		acc.Addf("error loading early termination queue: %v", err)
+		acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error loading early termination queue")
 	} else {
 		earlyTerminationCount = CheckEarlyTerminationQueue(earlyQ, partition.Terminated, acc)
 	}
@@ -542,7 +578,7 @@ func CheckExpirationQueue(expQ ExpirationQueue, liveSectors map[abi.SectorNumber
 	partitionFaults bitfield.BitField, quant builtin.QuantSpec, sectorSize abi.SectorSize, acc *builtin.MessageAccumulator) *ExpirationQueueStateSummary {
 	partitionFaultsMap, err := partitionFaults.AllMap(1 << 30)
 	if err != nil {
-		acc.Addf("error loading partition faults map: %v", err)
+		acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error loading partition faults map")
 		partitionFaultsMap = nil
 	}
 
@@ -567,7 +603,9 @@ func CheckExpirationQueue(expQ ExpirationQueue, liveSectors map[abi.SectorNumber
 		err := exp.OnTimeSectors.ForEach(func(n uint64) error {
 			sno := abi.SectorNumber(n)
 			// Check sectors are present only once.
-			acc.Require(!seenSectors[sno], "sector %d in expiration queue twice", sno)
+			acc.RequireInvariant(builtin.InvariantMinerExpirationQueueDuplicate, !seenSectors[sno],
+				builtin.ViolationFields{"sector": sno},
+				"sector %d in expiration queue twice", sno)
 			seenSectors[sno] = true
 
 			// Check expiring sectors are still alive.
@@ -575,37 +613,45 @@ func CheckExpirationQueue(expQ ExpirationQueue, liveSectors map[abi.SectorNumber
 				// The sector can be "on time" either at its target expiration epoch, or in the first queue entry
 				// (a CC-replaced sector moved forward).
 				target := quant.QuantizeUp(sector.Expiration)
-				acc.Require(epoch == target || epoch == firstQueueEpoch, "invalid expiration %d for sector %d, expected %d or %d",
+				acc.RequireInvariant(builtin.InvariantMinerExpirationQueueEpoch, epoch == target || epoch == firstQueueEpoch,
+					builtin.ViolationFields{"actual": epoch, "sector": sector.SectorNumber, "expected_early": firstQueueEpoch, "expected": target},
+					"invalid expiration %d for sector %d, expected %d or %d",
 					epoch, sector.SectorNumber, firstQueueEpoch, target)
 
 				onTimeSectorsPledge = big.Add(onTimeSectorsPledge, sector.InitialPledge)
 			} else {
-				acc.Addf("on-time expiration sector %d isn't live", n)
+				acc.AddInvariantf(builtin.InvariantMinerExpirationQueueSectorLive, builtin.ViolationFields{"sector": n}, "on-time expiration sector %d isn't live", n)
 			}
 			return nil
 		})
-		acc.RequireNoError(err, "error iterating on-time sectors")
+		acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error iterating on-time sectors")
 
 		err = exp.EarlySectors.ForEach(func(n uint64) error {
 			sno := abi.SectorNumber(n)
 			// Check sectors are present only once.
-			acc.Require(!seenSectors[sno], "sector %d in expiration queue twice", sno)
+			acc.RequireInvariant(builtin.InvariantMinerExpirationQueueDuplicate, !seenSectors[sno],
+				builtin.ViolationFields{"sector": sno},
+				"sector %d in expiration queue twice", sno)
 			seenSectors[sno] = true
 
 			// Check early sectors are faulty
-			acc.Require(partitionFaultsMap == nil || partitionFaultsMap[n], "sector %d expiring early but not faulty", sno)
+			acc.RequireInvariant(builtin.InvariantMinerEarlyExpirationFaulty, partitionFaultsMap == nil || partitionFaultsMap[n],
+				builtin.ViolationFields{"sector": sno},
+				"sector %d expiring early but not faulty", sno)
 
 			// Check expiring sectors are still alive.
 			if sector, ok := liveSectors[sno]; ok {
 				target := quant.QuantizeUp(sector.Expiration)
-				acc.Require(epoch < target, "invalid early expiration %d for sector %d, expected < %d",
+				acc.RequireInvariant(builtin.InvariantMinerEarlyExpirationEpoch, epoch < target,
+					builtin.ViolationFields{"actual": epoch, "sector": sector.SectorNumber, "expected_before": target},
+					"invalid early expiration %d for sector %d, expected < %d",
 					epoch, sector.SectorNumber, target)
 			} else {
-				acc.Addf("on-time expiration sector %d isn't live", n)
+				acc.AddInvariantf(builtin.InvariantMinerExpirationQueueSectorLive, builtin.ViolationFields{"sector": n}, "on-time expiration sector %d isn't live", n)
 			}
 			return nil
 		})
-		acc.RequireNoError(err, "error iterating early sectors")
+		acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error iterating early sectors")
 
 		// Validate power and pledge.
 		var activeSectors, faultySectors map[abi.SectorNumber]*SectorOnChainInfo
@@ -613,42 +659,48 @@ func CheckExpirationQueue(expQ ExpirationQueue, liveSectors map[abi.SectorNumber
 
 		all, err := bitfield.MergeBitFields(exp.OnTimeSectors, exp.EarlySectors)
 		if err != nil {
-			acc.Addf("error merging all on-time and early bitfields: %v", err)
+			acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error merging all on-time and early bitfields")
 		} else {
 			if allActive, err := bitfield.SubtractBitField(all, partitionFaults); err != nil {
-				acc.Addf("error computing active sectors: %v", err)
+				acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error computing active sectors")
 			} else {
 				activeSectors, missing, err = selectSectorsMap(liveSectors, allActive)
 				if err != nil {
-					acc.Addf("error selecting active sectors: %v", err)
+					acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error selecting active sectors")
 					activeSectors = nil
 				} else if len(missing) > 0 {
-					acc.Addf("active sectors missing from live: %v", missing)
+					acc.AddInvariantf(builtin.InvariantMinerExpirationQueueSectorsLive, builtin.ViolationFields{"missing": missing}, "active sectors missing from live: %v", missing)
 				}
 			}
 
 			if allFaulty, err := bitfield.IntersectBitField(all, partitionFaults); err != nil {
-				acc.Addf("error computing faulty sectors: %v", err)
+				acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error computing faulty sectors")
 			} else {
 				faultySectors, missing, err = selectSectorsMap(liveSectors, allFaulty)
 				if err != nil {
-					acc.Addf("error selecting faulty sectors: %v", err)
+					acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error selecting faulty sectors")
 					faultySectors = nil
 				} else if len(missing) > 0 {
-					acc.Addf("faulty sectors missing from live: %v", missing)
+					acc.AddInvariantf(builtin.InvariantMinerExpirationQueueSectorsLive, builtin.ViolationFields{"missing": missing}, "faulty sectors missing from live: %v", missing)
 				}
 			}
 		}
 
 		if activeSectors != nil && faultySectors != nil {
 			activeSectorsPower := powerForSectors(activeSectors, sectorSize)
-			acc.Require(exp.ActivePower.Equals(activeSectorsPower), "active power recorded %v doesn't match computed %v", exp.ActivePower, activeSectorsPower)
+			acc.RequireInvariant(builtin.InvariantMinerExpirationQueueActivePower, exp.ActivePower.Equals(activeSectorsPower),
+				builtin.ViolationFields{"actual": exp.ActivePower, "expected": activeSectorsPower},
+				"active power recorded %v doesn't match computed %v", exp.ActivePower, activeSectorsPower)
 
 			faultySectorsPower := powerForSectors(faultySectors, sectorSize)
-			acc.Require(exp.FaultyPower.Equals(faultySectorsPower), "faulty power recorded %v doesn't match computed %v", exp.FaultyPower, faultySectorsPower)
+			acc.RequireInvariant(builtin.InvariantMinerExpirationQueueFaultyPower, exp.FaultyPower.Equals(faultySectorsPower),
+				builtin.ViolationFields{"actual": exp.FaultyPower, "expected": faultySectorsPower},
+				"faulty power recorded %v doesn't match computed %v", exp.FaultyPower, faultySectorsPower)
 		}
 
-		acc.Require(exp.OnTimePledge.Equals(onTimeSectorsPledge), "on time pledge recorded %v doesn't match computed %v", exp.OnTimePledge, onTimeSectorsPledge)
+		acc.RequireInvariant(builtin.InvariantMinerExpirationQueuePledge, exp.OnTimePledge.Equals(onTimeSectorsPledge),
+			builtin.ViolationFields{"actual": exp.OnTimePledge, "expected": onTimeSectorsPledge},
+			"on time pledge recorded %v doesn't match computed %v", exp.OnTimePledge, onTimeSectorsPledge)
 
 		allOnTime = append(allOnTime, exp.OnTimeSectors)
 		allEarly = append(allEarly, exp.EarlySectors)
@@ -657,16 +709,16 @@ func CheckExpirationQueue(expQ ExpirationQueue, liveSectors map[abi.SectorNumber
 		allOnTimePledge = big.Add(allOnTimePledge, exp.OnTimePledge)
 		return nil
 	})
-	acc.RequireNoError(err, "error iterating expiration queue")
+	acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error iterating expiration queue")
 
 	unionOnTime, err := bitfield.MultiMerge(allOnTime...)
 	if err != nil {
-		acc.Addf("error merging on-time sector numbers: %v", err)
+		acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error merging on-time sector numbers")
 		unionOnTime = bitfield.New()
 	}
 	unionEarly, err := bitfield.MultiMerge(allEarly...)
 	if err != nil {
-		acc.Addf("error merging early sector numbers: %v", err)
+		acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error merging early sector numbers")
 		unionEarly = bitfield.New()
 	}
 	return &ExpirationQueueStateSummary{
@@ -687,89 +739,124 @@ func CheckEarlyTerminationQueue(earlyQ util.BitfieldQueue, terminated bitfield.B
 	err := earlyQ.ForEach(func(epoch abi.ChainEpoch, bf bitfield.BitField) error {
 		acc := acc.WithPrefix("early termination epoch %d: ", epoch)
 		err := bf.ForEach(func(i uint64) error {
-			acc.Require(!seenMap[i], "sector %v in early termination queue twice", i)
+			acc.RequireInvariant(builtin.InvariantMinerEarlyTerminationDuplicate, !seenMap[i],
+				builtin.ViolationFields{"sector": i},
+				"sector %v in early termination queue twice", i)
 			seenMap[i] = true
 			seenBf.Set(i)
 			return nil
 		})
-		acc.RequireNoError(err, "error iterating early termination bitfield")
+		acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error iterating early termination bitfield")
 		return nil
 	})
-	acc.RequireNoError(err, "error iterating early termination queue")
+	acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error iterating early termination queue")
 
-	requireContainsAll(terminated, seenBf, acc, "terminated sectors missing early termination entry")
+	requireContainsAll(terminated, seenBf, acc, builtin.InvariantMinerPartitionEarlyTerminations, "terminated sectors missing early termination entry")
 	return len(seenMap)
 }
 
 func CheckMinerInfo(info *MinerInfo, acc *builtin.MessageAccumulator) {
-	acc.Require(info.Owner.Protocol() == addr.ID, "owner address %v is not an ID address", info.Owner)
-	acc.Require(info.Worker.Protocol() == addr.ID, "worker address %v is not an ID address", info.Worker)
+	acc.RequireInvariant(builtin.InvariantMinerOwnerAddress, info.Owner.Protocol() == addr.ID,
+		builtin.ViolationFields{"owner": info.Owner},
+		"owner address %v is not an ID address", info.Owner)
+	acc.RequireInvariant(builtin.InvariantMinerWorkerAddress, info.Worker.Protocol() == addr.ID,
+		builtin.ViolationFields{"worker": info.Worker},
+		"worker address %v is not an ID address", info.Worker)
 	for _, a := range info.ControlAddresses {
-		acc.Require(a.Protocol() == addr.ID, "control address %v is not an ID address", a)
+		acc.RequireInvariant(builtin.InvariantMinerControlAddress, a.Protocol() == addr.ID,
+			builtin.ViolationFields{"control": a},
+			"control address %v is not an ID address", a)
 	}
 
 	if info.PendingWorkerKey != nil {
-		acc.Require(info.PendingWorkerKey.NewWorker.Protocol() == addr.ID,
+		acc.RequireInvariant(builtin.InvariantMinerPendingWorkerAddress, info.PendingWorkerKey.NewWorker.Protocol() == addr.ID,
+			builtin.ViolationFields{"pending_worker": info.PendingWorkerKey.NewWorker},
 			"pending worker address %v is not an ID address", info.PendingWorkerKey.NewWorker)
-		acc.Require(info.PendingWorkerKey.NewWorker != info.Worker,
+		acc.RequireInvariant(builtin.InvariantMinerPendingWorkerChange, info.PendingWorkerKey.NewWorker != info.Worker,
+			builtin.ViolationFields{"pending_worker": info.PendingWorkerKey.NewWorker, "worker": info.Worker},
 			"pending worker key %v is same as existing worker %v", info.PendingWorkerKey.NewWorker, info.Worker)
 	}
 
 	if info.PendingOwnerAddress != nil {
-		acc.Require(info.PendingOwnerAddress.Protocol() == addr.ID,
+		acc.RequireInvariant(builtin.InvariantMinerPendingOwnerAddress, info.PendingOwnerAddress.Protocol() == addr.ID,
+			builtin.ViolationFields{"pending_owner": info.PendingOwnerAddress},
 			"pending owner address %v is not an ID address", info.PendingOwnerAddress)
-		acc.Require(*info.PendingOwnerAddress != info.Owner,
+		acc.RequireInvariant(builtin.InvariantMinerPendingOwnerChange, *info.PendingOwnerAddress != info.Owner,
+			builtin.ViolationFields{"pending_owner": info.PendingOwnerAddress, "owner": info.Owner},
 			"pending owner address %v is same as existing owner %v", info.PendingOwnerAddress, info.Owner)
 	}
 
 	windowPoStProofInfo, found := abi.PoStProofInfos[info.WindowPoStProofType]
-	acc.Require(found, "miner has unrecognized Window PoSt proof type %d", info.WindowPoStProofType)
+	acc.RequireInvariant(builtin.InvariantMinerWindowPoStProofType, found,
+		builtin.ViolationFields{"proof_type": info.WindowPoStProofType},
+		"miner has unrecognized Window PoSt proof type %d", info.WindowPoStProofType)
 	if found {
-		acc.Require(windowPoStProofInfo.SectorSize == info.SectorSize,
+		acc.RequireInvariant(builtin.InvariantMinerSectorSize, windowPoStProofInfo.SectorSize == info.SectorSize,
+			builtin.ViolationFields{"actual": info.SectorSize, "proof_type": info.WindowPoStProofType, "expected": windowPoStProofInfo.SectorSize},
 			"sector size %d is wrong for Window PoSt proof type %d: %d", info.SectorSize, info.WindowPoStProofType, windowPoStProofInfo.SectorSize)
 	}
 
 	poStProofPolicy, found := builtin.PoStProofPolicies[info.WindowPoStProofType]
-	acc.Require(found, "no PoSt proof policy exists for proof type %d", info.WindowPoStProofType)
+	acc.RequireInvariant(builtin.InvariantMinerPoStProofPolicy, found,
+		builtin.ViolationFields{"proof_type": info.WindowPoStProofType},
+		"no PoSt proof policy exists for proof type %d", info.WindowPoStProofType)
 	if found {
-		acc.Require(poStProofPolicy.WindowPoStPartitionSectors == info.WindowPoStPartitionSectors,
+		acc.RequireInvariant(builtin.InvariantMinerPartitionSectors, poStProofPolicy.WindowPoStPartitionSectors == info.WindowPoStPartitionSectors,
+			builtin.ViolationFields{"actual": info.WindowPoStPartitionSectors, "expected": poStProofPolicy.WindowPoStPartitionSectors, "proof_type": info.WindowPoStProofType},
 			"miner partition sectors %d does not match partition sectors %d for PoSt proof type %d",
 			info.WindowPoStPartitionSectors, poStProofPolicy.WindowPoStPartitionSectors, info.WindowPoStProofType)
 	}
 }
 
 func CheckMinerBalances(st *State, store adt.Store, balance abi.TokenAmount, acc *builtin.MessageAccumulator) {
-	acc.Require(balance.GreaterThanEqual(big.Zero()), "miner actor balance is less than zero: %v", balance)
-	acc.Require(st.LockedFunds.GreaterThanEqual(big.Zero()), "miner locked funds is less than zero: %v", st.LockedFunds)
-	acc.Require(st.PreCommitDeposits.GreaterThanEqual(big.Zero()), "miner precommit deposit is less than zero: %v", st.PreCommitDeposits)
-	acc.Require(st.InitialPledge.GreaterThanEqual(big.Zero()), "miner initial pledge is less than zero: %v", st.InitialPledge)
-	acc.Require(st.FeeDebt.GreaterThanEqual(big.Zero()), "miner fee debt is less than zero: %v", st.FeeDebt)
-
-	acc.Require(big.Subtract(balance, st.LockedFunds, st.PreCommitDeposits, st.InitialPledge).GreaterThanEqual(big.Zero()),
+	acc.RequireInvariant(builtin.InvariantMinerBalanceNonNegative, balance.GreaterThanEqual(big.Zero()),
+		builtin.ViolationFields{"actual": balance},
+		"miner actor balance is less than zero: %v", balance)
+	acc.RequireInvariant(builtin.InvariantMinerLockedFundsNonNegative, st.LockedFunds.GreaterThanEqual(big.Zero()),
+		builtin.ViolationFields{"actual": st.LockedFunds},
+		"miner locked funds is less than zero: %v", st.LockedFunds)
+	acc.RequireInvariant(builtin.InvariantMinerPreCommitDepositsNonNegative, st.PreCommitDeposits.GreaterThanEqual(big.Zero()),
+		builtin.ViolationFields{"actual": st.PreCommitDeposits},
+		"miner precommit deposit is less than zero: %v", st.PreCommitDeposits)
+	acc.RequireInvariant(builtin.InvariantMinerInitialPledgeNonNegative, st.InitialPledge.GreaterThanEqual(big.Zero()),
+		builtin.ViolationFields{"actual": st.InitialPledge},
+		"miner initial pledge is less than zero: %v", st.InitialPledge)
+	acc.RequireInvariant(builtin.InvariantMinerFeeDebtNonNegative, st.FeeDebt.GreaterThanEqual(big.Zero()),
+		builtin.ViolationFields{"actual": st.FeeDebt},
+		"miner fee debt is less than zero: %v", st.FeeDebt)
+
+	acc.RequireInvariant(builtin.InvariantMinerBalanceCoversRequirements, big.Subtract(balance, st.LockedFunds, st.PreCommitDeposits, st.InitialPledge).GreaterThanEqual(big.Zero()),
+		builtin.ViolationFields{"balance": balance, "locked_funds": st.LockedFunds, "precommit_deposits": st.PreCommitDeposits, "initial_pledge": st.InitialPledge},
 		"miner balance (%v) is less than sum of locked funds (%v), precommit deposit (%v), and initial pledge (%v)",
 		balance, st.LockedFunds, st.PreCommitDeposits, st.InitialPledge)
 
 	// locked funds must be sum of vesting table and vesting table payments must be quantized
 	vestingSum := big.Zero()
 	if funds, err := st.LoadVestingFunds(store); err != nil {
-		acc.Addf("error loading vesting funds: %v", err)
+		acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error loading vesting funds")
 	} else {
 		quant := st.QuantSpecEveryDeadline()
 		for _, entry := range funds {
-			acc.Require(entry.Amount.GreaterThan(big.Zero()), "non-positive amount in miner vesting table entry %v", entry)
+			acc.RequireInvariant(builtin.InvariantMinerVestingAmountPositive, entry.Amount.GreaterThan(big.Zero()),
+				builtin.ViolationFields{"actual": entry},
+				"non-positive amount in miner vesting table entry %v", entry)
 			vestingSum = big.Add(vestingSum, entry.Amount)
 
 			quantized := quant.QuantizeUp(entry.Epoch)
-			acc.Require(entry.Epoch == quantized, "vesting table entry has non-quantized epoch %d (should be %d)", entry.Epoch, quantized)
+			acc.RequireInvariant(builtin.InvariantMinerVestingEpochQuantized, entry.Epoch == quantized,
+				builtin.ViolationFields{"actual": entry.Epoch, "expected": quantized},
+				"vesting table entry has non-quantized epoch %d (should be %d)", entry.Epoch, quantized)
 		}
 	}
 
-	acc.Require(st.LockedFunds.Equals(vestingSum),
+	acc.RequireInvariant(builtin.InvariantMinerVestingLockedFunds, st.LockedFunds.Equals(vestingSum),
+		builtin.ViolationFields{"actual": st.LockedFunds, "expected": vestingSum},
 		"locked funds %d is not sum of vesting table entries %d", st.LockedFunds, vestingSum)
 
 	// Non zero funds implies that DeadlineCronActive is true.
 	if st.ContinueDeadlineCron() {
-		acc.Require(st.DeadlineCronActive, "DeadlineCronActive == false when IP+PCD+LF > 0")
+		acc.RequireInvariant(builtin.InvariantMinerDeadlineCronActive, st.DeadlineCronActive, nil,
+			"DeadlineCronActive == false when IP+PCD+LF > 0")
 	}
 }
 
@@ -779,31 +866,33 @@ func CheckPreCommits(st *State, store adt.Store, allocatedSectorsMap map[uint64]
 	// invert pre-commit clean up queue into a lookup by sector number
 	cleanUpEpochs := make(map[uint64]abi.ChainEpoch)
 	if cleanUpQ, err := util.LoadBitfieldQueue(store, st.PreCommittedSectorsCleanUp, st.QuantSpecEveryDeadline(), PrecommitCleanUpAmtBitwidth); err != nil {
-		acc.Addf("error loading pre-commit clean up queue: %v", err)
+		acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error loading pre-commit clean up queue")
 	} else {
 		err = cleanUpQ.ForEach(func(epoch abi.ChainEpoch, bf bitfield.BitField) error {
 			quantized := quant.QuantizeUp(epoch)
-			acc.Require(quantized == epoch, "precommit expiration %d is not quantized", epoch)
+			acc.RequireInvariant(builtin.InvariantMinerPreCommitExpirationQuantized, quantized == epoch,
+				builtin.ViolationFields{"epoch": epoch},
+				"precommit expiration %d is not quantized", epoch)
 			if err = bf.ForEach(func(secNum uint64) error {
 				cleanUpEpochs[secNum] = epoch
 				return nil
 			}); err != nil {
-				acc.Addf("error iteration pre-commit expiration bitfield: %v", err)
+				acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error iteration pre-commit expiration bitfield")
 			}
 			return nil
 		})
-		acc.RequireNoError(err, "error iterating pre-commit clean up queue")
+		acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error iterating pre-commit clean up queue")
 	}
 
 	precommitTotal := big.Zero()
 	if precommitted, err := adt.AsMap(store, st.PreCommittedSectors, builtin.DefaultHamtBitwidth); err != nil {
-		acc.Addf("error loading precommitted sectors: %v", err)
+		acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error loading precommitted sectors")
 	} else {
 		var precommit SectorPreCommitOnChainInfo
 		err = precommitted.ForEach(&precommit, func(key string) error {
 			secNum, err := abi.ParseUIntKey(key)
 			if err != nil {
-				acc.Addf("error parsing pre-commit key as uint: %v", err)
+				acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error parsing pre-commit key as uint")
 				return nil
 			}
 
@@ -813,22 +902,27 @@ func CheckPreCommits(st *State, store adt.Store, allocatedSectorsMap map[uint64]
 			} else {
 				allocated, err = allocatedSectorsBf.IsSet(secNum)
 				if err != nil {
-					acc.Addf("error checking allocated sectors: %v", err)
+					acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error checking allocated sectors")
 					return nil
 				}
 			}
-			acc.Require(allocated, "pre-committed sector number has not been allocated %d", secNum)
+			acc.RequireInvariant(builtin.InvariantMinerPreCommitAllocated, allocated,
+				builtin.ViolationFields{"sector": secNum},
+				"pre-committed sector number has not been allocated %d", secNum)
 
 			_, found := cleanUpEpochs[secNum]
-			acc.Require(found, "no clean up epoch for pre-commit at %d", precommit.PreCommitEpoch)
+			acc.RequireInvariant(builtin.InvariantMinerPreCommitCleanUp, found,
+				builtin.ViolationFields{"precommit_epoch": precommit.PreCommitEpoch},
+				"no clean up epoch for pre-commit at %d", precommit.PreCommitEpoch)
 
 			precommitTotal = big.Add(precommitTotal, precommit.PreCommitDeposit)
 			return nil
 		})
-		acc.RequireNoError(err, "error iterating pre-committed sectors")
+		acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error iterating pre-committed sectors")
 	}
 
-	acc.Require(st.PreCommitDeposits.Equals(precommitTotal),
+	acc.RequireInvariant(builtin.InvariantMinerPreCommitDeposits, st.PreCommitDeposits.Equals(precommitTotal),
+		builtin.ViolationFields{"expected": precommitTotal, "actual": st.PreCommitDeposits},
 		"sum of precommit deposits %v does not equal recorded precommit deposit %v", precommitTotal, st.PreCommitDeposits)
 }
 
@@ -862,11 +956,11 @@ func powerForSectors(sectors map[abi.SectorNumber]*SectorOnChainInfo, ssize abi.
 	}
 }
 
-func requireContainsAll(superset, subset bitfield.BitField, acc *builtin.MessageAccumulator, msg string) {
+func requireContainsAll(superset, subset bitfield.BitField, acc *builtin.MessageAccumulator, id, msg string) {
 	if contains, err := util.BitFieldContainsAll(superset, subset); err != nil {
-		acc.Addf("error in BitfieldContainsAll(): %v", err)
+		acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error in BitfieldContainsAll()")
 	} else if !contains {
-		acc.Addf(msg+": %v, %v", superset, subset)
+		acc.AddInvariantf(id, builtin.ViolationFields{"superset": superset, "subset": subset}, msg+": %v, %v", superset, subset)
 		// Verbose output for debugging
 		//sup, err := superset.All(1 << 20)
 		//if err != nil {
@@ -882,11 +976,11 @@ func requireContainsAll(superset, subset bitfield.BitField, acc *builtin.Message
 	}
 }
 
-func requireContainsNone(superset, subset bitfield.BitField, acc *builtin.MessageAccumulator, msg string) {
+func requireContainsNone(superset, subset bitfield.BitField, acc *builtin.MessageAccumulator, id, msg string) {
 	if contains, err := util.BitFieldContainsAny(superset, subset); err != nil {
-		acc.Addf("error in BitfieldContainsAny(): %v", err)
+		acc.RequireInvariantNoError(builtin.InvariantMinerStateLoadable, err, "error in BitfieldContainsAny()")
 	} else if contains {
-		acc.Addf(msg+": %v, %v", superset, subset)
+		acc.AddInvariantf(id, builtin.ViolationFields{"superset": superset, "subset": subset}, msg+": %v, %v", superset, subset)
 		// Verbose output for debugging
 		//sup, err := superset.All(1 << 20)
 		//if err != nil {
@@ -902,7 +996,7 @@ func requireContainsNone(superset, subset bitfield.BitField, acc *builtin.Messag
 	}
 }
 
-func requireEqual(a, b bitfield.BitField, acc *builtin.MessageAccumulator, msg string) {
-	requireContainsAll(a, b, acc, msg)
-	requireContainsAll(b, a, acc, msg)
+func requireEqual(a, b bitfield.BitField, acc *builtin.MessageAccumulator, id, msg string) {
+	requireContainsAll(a, b, acc, id, msg)
+	requireContainsAll(b, a, acc, id, msg)
 }
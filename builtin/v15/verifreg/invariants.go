@@ -20,77 +20,83 @@ type StateSummary struct {
 // Checks internal invariants of verified registry state.
 func CheckStateInvariants(st *State, store adt.Store, priorEpoch abi.ChainEpoch) (*StateSummary, *builtin.MessageAccumulator) {
 	acc := &builtin.MessageAccumulator{}
-	acc.Require(st.RootKey.Protocol() == addr.ID, "root key %v should have ID protocol", st.RootKey)
+	acc.RequireInvariant(builtin.InvariantVerifregRootKeyAddress, st.RootKey.Protocol() == addr.ID,
+		builtin.ViolationFields{"root_key": st.RootKey},
+		"root key %v should have ID protocol", st.RootKey)
 
 	// Check verifiers
 	allVerifiers := map[addr.Address]DataCap{}
 	if verifiers, err := adt.AsMap(store, st.Verifiers, builtin.DefaultHamtBitwidth); err != nil {
-		acc.Addf("error loading verifiers: %v", err)
+		acc.RequireInvariantNoError(builtin.InvariantVerifregStateLoadable, err, "error loading verifiers")
 	} else {
 		var vcap abi.StoragePower
 		err = verifiers.ForEach(&vcap, func(key string) error {
 			verifier, err := addr.NewFromBytes([]byte(key))
-			acc.RequireNoError(err, "error getting verifier from bytes")
-
-			acc.Require(verifier.Protocol() == addr.ID, "verifier %v should have ID protocol", verifier)
-			acc.Require(vcap.GreaterThanEqual(big.Zero()), "verifier %v cap %v is negative", verifier, vcap)
+			acc.RequireInvariantNoError(builtin.InvariantVerifregStateLoadable, err, "error getting verifier from bytes")
+
+			acc.RequireInvariant(builtin.InvariantVerifregVerifierAddress, verifier.Protocol() == addr.ID,
+				builtin.ViolationFields{"verifier": verifier},
+				"verifier %v should have ID protocol", verifier)
+			acc.RequireInvariant(builtin.InvariantVerifregVerifierCap, vcap.GreaterThanEqual(big.Zero()),
+				builtin.ViolationFields{"verifier": verifier, "actual": vcap},
+				"verifier %v cap %v is negative", verifier, vcap)
 			allVerifiers[verifier] = vcap.Copy()
 			return nil
 		})
-		acc.RequireNoError(err, "error iterating verifiers")
+		acc.RequireInvariantNoError(builtin.InvariantVerifregStateLoadable, err, "error iterating verifiers")
 	}
 
 	// Check Claims
 	allClaims := make(map[ClaimId]Claim)
 	outerClaimsMap, err := adt.AsMap(store, st.Claims, builtin.DefaultHamtBitwidth)
-	acc.RequireNoError(err, "error getting outer claims map")
+	acc.RequireInvariantNoError(builtin.InvariantVerifregStateLoadable, err, "error getting outer claims map")
 
 	var innerClaimHamtCid cbg.CborCid
 	err = outerClaimsMap.ForEach(&innerClaimHamtCid, func(idKey string) error {
 		providerId, err := abi.ParseUIntKey(idKey)
-		acc.RequireNoError(err, "error parsing provider id to uint")
+		acc.RequireInvariantNoError(builtin.InvariantVerifregStateLoadable, err, "error parsing provider id to uint")
 		innerMap, err := adt.AsMap(store, cid.Cid(innerClaimHamtCid), builtin.DefaultHamtBitwidth)
-		acc.RequireNoError(err, "error getting inner claims map")
+		acc.RequireInvariantNoError(builtin.InvariantVerifregStateLoadable, err, "error getting inner claims map")
 
 		var out Claim
 		err = innerMap.ForEach(&out, func(claimIdStr string) error {
 			claimId, err := abi.ParseUIntKey(claimIdStr)
-			acc.RequireNoError(err, "error parsing claim id to uint")
+			acc.RequireInvariantNoError(builtin.InvariantVerifregStateLoadable, err, "error parsing claim id to uint")
 
 			checkClaimState(ClaimId(claimId), &out, abi.ActorID(providerId), st.NextAllocationId, priorEpoch, acc)
 			allClaims[ClaimId(claimId)] = out
 			return nil
 		})
-		acc.RequireNoError(err, "error iterating over inner claims map")
+		acc.RequireInvariantNoError(builtin.InvariantVerifregStateLoadable, err, "error iterating over inner claims map")
 		return nil
 	})
-	acc.RequireNoError(err, "error iterating over claims")
+	acc.RequireInvariantNoError(builtin.InvariantVerifregStateLoadable, err, "error iterating over claims")
 
 	// Check Allocations
 	allAllocations := make(map[AllocationId]Allocation)
 	outerAllocMap, err := adt.AsMap(store, st.Allocations, builtin.DefaultHamtBitwidth)
-	acc.RequireNoError(err, "error getting outer allocations map")
+	acc.RequireInvariantNoError(builtin.InvariantVerifregStateLoadable, err, "error getting outer allocations map")
 
 	var innerAllocHamtCid cbg.CborCid
 	err = outerAllocMap.ForEach(&innerAllocHamtCid, func(idKey string) error {
 		clientId, err := abi.ParseUIntKey(idKey)
-		acc.RequireNoError(err, "error parsing client id to uint")
+		acc.RequireInvariantNoError(builtin.InvariantVerifregStateLoadable, err, "error parsing client id to uint")
 		innerMap, err := adt.AsMap(store, cid.Cid(innerAllocHamtCid), builtin.DefaultHamtBitwidth)
-		acc.RequireNoError(err, "error getting inner allocations map")
+		acc.RequireInvariantNoError(builtin.InvariantVerifregStateLoadable, err, "error getting inner allocations map")
 
 		var alloc Allocation
 		err = innerMap.ForEach(&alloc, func(claimIdStr string) error {
 			allocId, err := abi.ParseUIntKey(claimIdStr)
-			acc.RequireNoError(err, "error parsing allocation id to uint")
+			acc.RequireInvariantNoError(builtin.InvariantVerifregStateLoadable, err, "error parsing allocation id to uint")
 
 			checkAllocationState(AllocationId(allocId), &alloc, abi.ActorID(clientId), st.NextAllocationId, priorEpoch, acc)
 			allAllocations[AllocationId(allocId)] = alloc
 			return nil
 		})
-		acc.RequireNoError(err, "error iterating over inner allocations map")
+		acc.RequireInvariantNoError(builtin.InvariantVerifregStateLoadable, err, "error iterating over inner allocations map")
 		return nil
 	})
-	acc.RequireNoError(err, "error iterating over allocations")
+	acc.RequireInvariantNoError(builtin.InvariantVerifregStateLoadable, err, "error iterating over allocations")
 
 	return &StateSummary{
 		Verifiers:   allVerifiers,
@@ -100,31 +106,57 @@ func CheckStateInvariants(st *State, store adt.Store, priorEpoch abi.ChainEpoch)
 }
 
 func checkAllocationState(id AllocationId, alloc *Allocation, client abi.ActorID, nextAllocId AllocationId, priorEpoch abi.ChainEpoch, acc *builtin.MessageAccumulator) {
-	acc.Require(id < nextAllocId, "allocation id %d exceeds next %d", id, nextAllocId)
+	acc.RequireInvariant(builtin.InvariantVerifregAllocationID, id < nextAllocId,
+		builtin.ViolationFields{"allocation": id, "next_id": nextAllocId},
+		"allocation id %d exceeds next %d", id, nextAllocId)
 
-	acc.Require(alloc.Client == client, "allocation %d client %d doesn't match key %d", id, alloc.Client, client)
+	acc.RequireInvariant(builtin.InvariantVerifregAllocationClient, alloc.Client == client,
+		builtin.ViolationFields{"allocation": id, "actual": alloc.Client, "expected": client},
+		"allocation %d client %d doesn't match key %d", id, alloc.Client, client)
 
-	acc.Require(alloc.Size >= MinimumVerifiedAllocationSize, "allocation %d size %d too small", id, alloc.Size)
+	acc.ExpectInvariant(builtin.InvariantVerifregAllocationSize, alloc.Size >= NetworkMinimumVerifiedAllocationSize(),
+		builtin.ViolationFields{"allocation": id, "actual": alloc.Size, "expected_min": NetworkMinimumVerifiedAllocationSize()},
+		"allocation %d size %d too small", id, alloc.Size)
 
-	acc.Require(alloc.TermMin >= MinimumVerifiedAllocationTerm, "allocation %d term min %d too small", id, alloc.TermMin)
+	acc.ExpectInvariant(builtin.InvariantVerifregAllocationTermMin, alloc.TermMin >= NetworkMinimumVerifiedAllocationTerm(),
+		builtin.ViolationFields{"allocation": id, "actual": alloc.TermMin, "expected_min": NetworkMinimumVerifiedAllocationTerm()},
+		"allocation %d term min %d too small", id, alloc.TermMin)
 
-	acc.Require(alloc.TermMax <= MaximumVerifiedAllocationTerm, "allocation %d term max %d too large", id, alloc.TermMax)
+	acc.ExpectInvariant(builtin.InvariantVerifregAllocationTermMax, alloc.TermMax <= NetworkMaximumVerifiedAllocationTerm(),
+		builtin.ViolationFields{"allocation": id, "actual": alloc.TermMax, "expected_max": NetworkMaximumVerifiedAllocationTerm()},
+		"allocation %d term max %d too large", id, alloc.TermMax)
 
-	acc.Require(alloc.TermMin <= alloc.TermMax, "allocation %d term min %d exceeds max %d", id, alloc.TermMin, alloc.TermMin)
+	acc.RequireInvariant(builtin.InvariantVerifregAllocationTerm, alloc.TermMin <= alloc.TermMax,
+		builtin.ViolationFields{"allocation": id, "term_min": alloc.TermMin, "term_max": alloc.TermMax},
+		"allocation %d term min %d exceeds max %d", id, alloc.TermMin, alloc.TermMax)
 
-	acc.Require(alloc.Expiration <= priorEpoch+MaximumVerifiedAllocationExpiration, "allocation %d expiration %d too far from now %d", id, alloc.Expiration, priorEpoch)
+	acc.ExpectInvariant(builtin.InvariantVerifregAllocationExpiration, alloc.Expiration <= priorEpoch+NetworkMaximumVerifiedAllocationExpiration(),
+		builtin.ViolationFields{"allocation": id, "actual": alloc.Expiration, "current_epoch": priorEpoch, "expected_max": priorEpoch + NetworkMaximumVerifiedAllocationExpiration()},
+		"allocation %d expiration %d too far from now %d", id, alloc.Expiration, priorEpoch)
 }
 
 func checkClaimState(id ClaimId, claim *Claim, provider abi.ActorID, nextAllocId AllocationId, priorEpoch abi.ChainEpoch, acc *builtin.MessageAccumulator) {
-	acc.Require(AllocationId(id) < nextAllocId, "claim id %d exceeds next %d", id, nextAllocId)
+	acc.RequireInvariant(builtin.InvariantVerifregClaimID, AllocationId(id) < nextAllocId,
+		builtin.ViolationFields{"claim": id, "next_id": nextAllocId},
+		"claim id %d exceeds next %d", id, nextAllocId)
 
-	acc.Require(claim.Provider == provider, "claim %d provider %d doesn't match key %d", id, claim.Provider, provider)
+	acc.RequireInvariant(builtin.InvariantVerifregClaimProvider, claim.Provider == provider,
+		builtin.ViolationFields{"claim": id, "actual": claim.Provider, "expected": provider},
+		"claim %d provider %d doesn't match key %d", id, claim.Provider, provider)
 
-	acc.Require(claim.Size >= MinimumVerifiedAllocationSize, "claim %d size %d too small", id, claim.Size)
+	acc.ExpectInvariant(builtin.InvariantVerifregClaimSize, claim.Size >= NetworkMinimumVerifiedAllocationSize(),
+		builtin.ViolationFields{"claim": id, "actual": claim.Size, "expected_min": NetworkMinimumVerifiedAllocationSize()},
+		"claim %d size %d too small", id, claim.Size)
 
-	acc.Require(claim.TermMin >= MinimumVerifiedAllocationTerm, "claim %d term min %d too small", id, claim.TermMin)
+	acc.ExpectInvariant(builtin.InvariantVerifregClaimTermMin, claim.TermMin >= NetworkMinimumVerifiedAllocationTerm(),
+		builtin.ViolationFields{"claim": id, "actual": claim.TermMin, "expected_min": NetworkMinimumVerifiedAllocationTerm()},
+		"claim %d term min %d too small", id, claim.TermMin)
 
-	acc.Require(claim.TermMin <= claim.TermMax, "claim %d term min %d exceeds max %d", id, claim.TermMin, claim.TermMin)
+	acc.RequireInvariant(builtin.InvariantVerifregClaimTerm, claim.TermMin <= claim.TermMax,
+		builtin.ViolationFields{"claim": id, "term_min": claim.TermMin, "term_max": claim.TermMax},
+		"claim %d term min %d exceeds max %d", id, claim.TermMin, claim.TermMax)
 
-	acc.Require(claim.TermStart <= priorEpoch, "claim %d term start %d after now %d", id, claim.TermStart, priorEpoch)
+	acc.RequireInvariant(builtin.InvariantVerifregClaimTermStart, claim.TermStart <= priorEpoch,
+		builtin.ViolationFields{"claim": id, "actual": claim.TermStart, "current_epoch": priorEpoch},
+		"claim %d term start %d after now %d", id, claim.TermStart, priorEpoch)
 }
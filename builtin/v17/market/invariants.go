@@ -44,16 +44,16 @@ type StateSummary struct {
 func CheckStateInvariants(st *State, store adt.Store, balance abi.TokenAmount, currEpoch abi.ChainEpoch) (*StateSummary, *builtin.MessageAccumulator) {
 	acc := &builtin.MessageAccumulator{}
 
-	acc.Require(
-		st.TotalClientLockedCollateral.GreaterThanEqual(big.Zero()),
+	acc.RequireInvariant(builtin.InvariantMarketClientCollateralNonNegative, st.TotalClientLockedCollateral.GreaterThanEqual(big.Zero()),
+		builtin.ViolationFields{"actual": st.TotalClientLockedCollateral},
 		"negative total client locked collateral: %v", st.TotalClientLockedCollateral)
 
-	acc.Require(
-		st.TotalProviderLockedCollateral.GreaterThanEqual(big.Zero()),
+	acc.RequireInvariant(builtin.InvariantMarketProviderCollateralNonNegative, st.TotalProviderLockedCollateral.GreaterThanEqual(big.Zero()),
+		builtin.ViolationFields{"actual": st.TotalClientLockedCollateral},
 		"negative total provider locked collateral: %v", st.TotalClientLockedCollateral)
 
-	acc.Require(
-		st.TotalClientStorageFee.GreaterThanEqual(big.Zero()),
+	acc.RequireInvariant(builtin.InvariantMarketClientStorageFeeNonNegative, st.TotalClientStorageFee.GreaterThanEqual(big.Zero()),
+		builtin.ViolationFields{"actual": st.TotalClientLockedCollateral},
 		"negative total client storage fee: %v", st.TotalClientLockedCollateral)
 
 	//
@@ -67,12 +67,12 @@ func CheckStateInvariants(st *State, store adt.Store, balance abi.TokenAmount, c
 	totalProposalCollateral := abi.NewTokenAmount(0)
 
 	if proposals, err := adt.AsArray(store, st.Proposals, ProposalsAmtBitwidth); err != nil {
-		acc.Addf("error loading proposals: %v", err)
+		acc.RequireInvariantNoError(builtin.InvariantMarketStateLoadable, err, "error loading proposals")
 	} else {
 		var proposal DealProposal
 		err = proposals.ForEach(&proposal, func(dealID int64) error {
 			pcid, err := proposal.Cid()
-			acc.RequireNoError(err, "error getting cid from proposal")
+			acc.RequireInvariantNoError(builtin.InvariantMarketStateLoadable, err, "error getting cid from proposal")
 
 			if proposal.StartEpoch >= currEpoch {
 				expectedDealOps[abi.DealID(dealID)] = struct{}{}
@@ -95,27 +95,35 @@ func CheckStateInvariants(st *State, store adt.Store, balance abi.TokenAmount, c
 
 			totalProposalCollateral = big.Sum(totalProposalCollateral, proposal.ClientCollateral, proposal.ProviderCollateral)
 
-			acc.Require(proposal.Client.Protocol() == address.ID, "client address for deal %d is not an ID address", dealID)
-			acc.Require(proposal.Provider.Protocol() == address.ID, "provider address for deal %d is not an ID address", dealID)
+			acc.RequireInvariant(builtin.InvariantMarketDealClientAddress, proposal.Client.Protocol() == address.ID,
+				builtin.ViolationFields{"deal": dealID},
+				"client address for deal %d is not an ID address", dealID)
+			acc.RequireInvariant(builtin.InvariantMarketDealProviderAddress, proposal.Provider.Protocol() == address.ID,
+				builtin.ViolationFields{"deal": dealID},
+				"provider address for deal %d is not an ID address", dealID)
 			return nil
 		})
-		acc.RequireNoError(err, "error iterating proposals")
+		acc.RequireInvariantNoError(builtin.InvariantMarketStateLoadable, err, "error iterating proposals")
 	}
 
 	// next id should be higher than any existing deal
-	acc.Require(int64(st.NextID) > maxDealID, "next id, %d, is not greater than highest id in proposals, %d", st.NextID, maxDealID)
+	acc.RequireInvariant(builtin.InvariantMarketNextID, int64(st.NextID) > maxDealID,
+		builtin.ViolationFields{"next_id": st.NextID, "max_id": maxDealID},
+		"next id, %d, is not greater than highest id in proposals, %d", st.NextID, maxDealID)
 
 	//
 	// Deal States
 	//
 
 	pendingDealAllocationIds, err := st.GetPendingDealAllocationIds(store)
-	acc.RequireNoError(err, "error loading pending deal proposal Ids")
+	acc.RequireInvariantNoError(builtin.InvariantMarketStateLoadable, err, "error loading pending deal proposal Ids")
 
 	allocationIdToDealId := make(map[verifreg.AllocationId]abi.DealID)
 	for dealId, allocationId := range pendingDealAllocationIds {
 		_, found := proposalStats[dealId]
-		acc.Require(found, "pending deal allocation %d not found in proposals", dealId)
+		acc.RequireInvariant(builtin.InvariantMarketPendingAllocationProposal, found,
+			builtin.ViolationFields{"deal": dealId},
+			"pending deal allocation %d not found in proposals", dealId)
 
 		allocationIdToDealId[allocationId] = dealId
 	}
@@ -124,33 +132,33 @@ func CheckStateInvariants(st *State, store adt.Store, balance abi.TokenAmount, c
 	claimIdToDealId := make(map[verifreg.ClaimId]abi.DealID)
 	expectedProviderSectors := make(map[abi.DealID]struct{})
 	if dealStates, err := adt.AsArray(store, st.States, StatesAmtBitwidth); err != nil {
-		acc.Addf("error loading deal states: %v", err)
+		acc.RequireInvariantNoError(builtin.InvariantMarketStateLoadable, err, "error loading deal states")
 	} else {
 		var dealState DealState
 		err = dealStates.ForEach(&dealState, func(dealID int64) error {
-			acc.Require(
-				dealState.SectorStartEpoch >= 0,
+			acc.RequireInvariant(builtin.InvariantMarketDealStateSectorStart, dealState.SectorStartEpoch >= 0,
+				builtin.ViolationFields{"deal": dealID, "state": dealState},
 				"deal %d state start epoch undefined: %v", dealID, dealState)
 
-			acc.Require(
-				dealState.LastUpdatedEpoch == EpochUndefined || dealState.LastUpdatedEpoch >= dealState.SectorStartEpoch,
+			acc.RequireInvariant(builtin.InvariantMarketDealStateLastUpdated, dealState.LastUpdatedEpoch == EpochUndefined || dealState.LastUpdatedEpoch >= dealState.SectorStartEpoch,
+				builtin.ViolationFields{"deal": dealID, "state": dealState},
 				"deal %d state last updated before sector start: %v", dealID, dealState)
 
-			acc.Require(
-				dealState.LastUpdatedEpoch == EpochUndefined || dealState.LastUpdatedEpoch <= currEpoch,
+			acc.RequireInvariant(builtin.InvariantMarketDealStateLastUpdatedCurrent, dealState.LastUpdatedEpoch == EpochUndefined || dealState.LastUpdatedEpoch <= currEpoch,
+				builtin.ViolationFields{"deal": dealID, "last_updated": dealState.LastUpdatedEpoch, "current_epoch": currEpoch},
 				"deal %d last updated epoch %d after current %d", dealID, dealState.LastUpdatedEpoch, currEpoch)
 
-			acc.Require(
-				dealState.SlashEpoch == EpochUndefined || dealState.SlashEpoch >= dealState.SectorStartEpoch,
+			acc.RequireInvariant(builtin.InvariantMarketDealStateSlashEpoch, dealState.SlashEpoch == EpochUndefined || dealState.SlashEpoch >= dealState.SectorStartEpoch,
+				builtin.ViolationFields{"deal": dealID, "state": dealState},
 				"deal %d state slashed before sector start: %v", dealID, dealState)
 
-			acc.Require(
-				dealState.SlashEpoch == EpochUndefined || dealState.SlashEpoch <= currEpoch,
+			acc.RequireInvariant(builtin.InvariantMarketDealStateSlashEpochCurrent, dealState.SlashEpoch == EpochUndefined || dealState.SlashEpoch <= currEpoch,
+				builtin.ViolationFields{"deal": dealID, "current_epoch": currEpoch, "state": dealState},
 				"deal %d state slashed after current epoch %d: %v", dealID, currEpoch, dealState)
 
 			stats, found := proposalStats[abi.DealID(dealID)]
 			if !found {
-				acc.Addf("no deal proposal for deal state %d", dealID)
+				acc.AddInvariantf(builtin.InvariantMarketDealStateProposal, builtin.ViolationFields{"deal": dealID}, "no deal proposal for deal state %d", dealID)
 			} else {
 				stats.SectorStartEpoch = dealState.SectorStartEpoch
 				stats.LastUpdatedEpoch = dealState.LastUpdatedEpoch
@@ -158,7 +166,9 @@ func CheckStateInvariants(st *State, store adt.Store, balance abi.TokenAmount, c
 				stats.SectorNumber = dealState.SectorNumber
 			}
 			_, found = pendingDealAllocationIds[abi.DealID(dealID)]
-			acc.Require(!found, "deal %d has pending allocation", dealID)
+			acc.RequireInvariant(builtin.InvariantMarketDealStatePendingAllocation, !found,
+				builtin.ViolationFields{"deal": dealID},
+				"deal %d has pending allocation", dealID)
 
 			if dealState.SlashEpoch == EpochUndefined && dealState.SectorStartEpoch != EpochUndefined && stats.EndEpoch > currEpoch {
 				expectedProviderSectors[abi.DealID(dealID)] = struct{}{}
@@ -168,7 +178,7 @@ func CheckStateInvariants(st *State, store adt.Store, balance abi.TokenAmount, c
 
 			return nil
 		})
-		acc.RequireNoError(err, "error iterating deal states")
+		acc.RequireInvariantNoError(builtin.InvariantMarketStateLoadable, err, "error iterating deal states")
 	}
 
 	//
@@ -177,19 +187,21 @@ func CheckStateInvariants(st *State, store adt.Store, balance abi.TokenAmount, c
 
 	pendingProposalCount := uint64(0)
 	if pendingProposals, err := adt.AsMap(store, st.PendingProposals, builtin.DefaultHamtBitwidth); err != nil {
-		acc.Addf("error loading pending proposals: %v", err)
+		acc.RequireInvariantNoError(builtin.InvariantMarketStateLoadable, err, "error loading pending proposals")
 	} else {
 		err = pendingProposals.ForEach(nil, func(key string) error {
 			proposalCID, err := cid.Parse([]byte(key))
-			acc.RequireNoError(err, "error getting cid from proposal")
+			acc.RequireInvariantNoError(builtin.InvariantMarketStateLoadable, err, "error getting cid from proposal")
 
 			_, found := proposalCids[proposalCID]
-			acc.Require(found, "pending proposal with cid %v not found within proposals %v", proposalCID, pendingProposals)
+			acc.RequireInvariant(builtin.InvariantMarketPendingProposal, found,
+				builtin.ViolationFields{"proposal": proposalCID},
+				"pending proposal with cid %v not found within proposals %v", proposalCID, pendingProposals)
 
 			pendingProposalCount++
 			return nil
 		})
-		acc.RequireNoError(err, "error iterating pending proposals")
+		acc.RequireInvariantNoError(builtin.InvariantMarketStateLoadable, err, "error iterating pending proposals")
 	}
 
 	//
@@ -198,40 +210,46 @@ func CheckStateInvariants(st *State, store adt.Store, balance abi.TokenAmount, c
 
 	lockTableCount := uint64(0)
 	escrowTable, err := adt.AsBalanceTable(store, st.EscrowTable)
-	acc.RequireNoError(err, "error loading escrow table")
+	acc.RequireInvariantNoError(builtin.InvariantMarketStateLoadable, err, "error loading escrow table")
 	lockTable, err := adt.AsBalanceTable(store, st.LockedTable)
-	acc.RequireNoError(err, "error loading locked table")
+	acc.RequireInvariantNoError(builtin.InvariantMarketStateLoadable, err, "error loading locked table")
 	if escrowTable != nil && lockTable != nil {
 		var lockedAmount abi.TokenAmount
 		lockedTotal := abi.NewTokenAmount(0)
 		err = (*adt.Map)(lockTable).ForEach(&lockedAmount, func(key string) error {
 			addr, err := address.NewFromBytes([]byte(key))
-			acc.RequireNoError(err, "error getting address from bytes")
+			acc.RequireInvariantNoError(builtin.InvariantMarketStateLoadable, err, "error getting address from bytes")
 			lockedTotal = big.Add(lockedTotal, lockedAmount)
 
 			// every entry in locked table should have a corresponding entry in escrow table that is at least as high
 			escrowAmount, err := escrowTable.Get(addr)
-			acc.RequireNoError(err, "error escrow amount from table for %s", addr)
-			acc.Require(escrowAmount.GreaterThanEqual(lockedAmount),
+			acc.RequireInvariantNoError(builtin.InvariantMarketStateLoadable, err, "error escrow amount from table for %s", addr)
+			acc.RequireInvariant(builtin.InvariantMarketLockedWithinEscrow, escrowAmount.GreaterThanEqual(lockedAmount),
+				builtin.ViolationFields{"address": addr, "locked": lockedAmount, "escrow": escrowAmount},
 				"locked funds for %s, %s, greater than escrow amount, %s", addr, lockedAmount, escrowAmount)
 
 			lockTableCount++
 			return nil
 		})
-		acc.RequireNoError(err, "error iterating locked table")
+		acc.RequireInvariantNoError(builtin.InvariantMarketStateLoadable, err, "error iterating locked table")
 
 		// lockTable total should be sum of client and provider locked plus client storage fee
 		expectedLockTotal := big.Sum(st.TotalProviderLockedCollateral, st.TotalClientLockedCollateral, st.TotalClientStorageFee)
-		acc.Require(lockedTotal.Equals(expectedLockTotal),
+		acc.RequireInvariant(builtin.InvariantMarketLockedTotal, lockedTotal.Equals(expectedLockTotal),
+			builtin.ViolationFields{"actual": lockedTotal, "provider_locked": st.TotalProviderLockedCollateral, "client_locked": st.TotalClientLockedCollateral, "client_storage_fee": st.TotalClientStorageFee},
 			"locked total, %s, does not sum to provider locked, %s, client locked, %s, and client storage fee, %s",
 			lockedTotal, st.TotalProviderLockedCollateral, st.TotalClientLockedCollateral, st.TotalClientStorageFee)
 
 		// assert escrow <= actor balance
 		// lockTable item <= escrow item and escrowTotal <= balance implies lockTable total <= balance
 		escrowTotal, err := escrowTable.Total()
-		acc.RequireNoError(err, "error calculating escrow total")
-		acc.Require(escrowTotal.LessThanEqual(balance), "escrow total, %v, greater than actor balance, %v", escrowTotal, balance)
-		acc.Require(escrowTotal.GreaterThanEqual(totalProposalCollateral), "escrow total, %v, less than sum of proposal collateral, %v", escrowTotal, totalProposalCollateral)
+		acc.RequireInvariantNoError(builtin.InvariantMarketStateLoadable, err, "error calculating escrow total")
+		acc.RequireInvariant(builtin.InvariantMarketEscrowWithinBalance, escrowTotal.LessThanEqual(balance),
+			builtin.ViolationFields{"escrow_total": escrowTotal, "balance": balance},
+			"escrow total, %v, greater than actor balance, %v", escrowTotal, balance)
+		acc.RequireInvariant(builtin.InvariantMarketEscrowCoversCollateral, escrowTotal.GreaterThanEqual(totalProposalCollateral),
+			builtin.ViolationFields{"escrow_total": escrowTotal, "collateral": totalProposalCollateral},
+			"escrow total, %v, less than sum of proposal collateral, %v", escrowTotal, totalProposalCollateral)
 	}
 
 	//
@@ -241,27 +259,31 @@ func CheckStateInvariants(st *State, store adt.Store, balance abi.TokenAmount, c
 	dealOpEpochCount := uint64(0)
 	dealOpCount := uint64(0)
 	if dealOps, err := AsSetMultimap(store, st.DealOpsByEpoch, builtin.DefaultHamtBitwidth, builtin.DefaultHamtBitwidth); err != nil {
-		acc.Addf("error loading deal ops: %v", err)
+		acc.RequireInvariantNoError(builtin.InvariantMarketStateLoadable, err, "error loading deal ops")
 	} else {
 		// get into internals just to iterate through full data structure
 		var setRoot cbg.CborCid
 		err = dealOps.mp.ForEach(&setRoot, func(key string) error {
 			epoch, err := binary.ReadUvarint(bytes.NewReader([]byte(key)))
-			acc.RequireNoError(err, "error epoch from bytes")
+			acc.RequireInvariantNoError(builtin.InvariantMarketStateLoadable, err, "error epoch from bytes")
 
 			dealOpEpochCount++
 			return dealOps.ForEach(abi.ChainEpoch(epoch), func(id abi.DealID) error {
 				_, found := proposalStats[id]
-				acc.Require(found, "deal op found for deal id %d with missing proposal at epoch %d", id, epoch)
+				acc.RequireInvariant(builtin.InvariantMarketDealOpProposal, found,
+					builtin.ViolationFields{"deal": id, "epoch": epoch},
+					"deal op found for deal id %d with missing proposal at epoch %d", id, epoch)
 				delete(expectedDealOps, id)
 				dealOpCount++
 				return nil
 			})
 		})
-		acc.RequireNoError(err, "error iterating deal ops")
+		acc.RequireInvariantNoError(builtin.InvariantMarketStateLoadable, err, "error iterating deal ops")
 	}
 
-	acc.Require(len(expectedDealOps) == 0, "missing deal ops for proposals: %v", expectedDealOps)
+	acc.RequireInvariant(builtin.InvariantMarketProposalDealOps, len(expectedDealOps) == 0,
+		builtin.ViolationFields{"deals": expectedDealOps},
+		"missing deal ops for proposals: %v", expectedDealOps)
 
 	//
 	// Provider Sectors
@@ -270,28 +292,30 @@ func CheckStateInvariants(st *State, store adt.Store, balance abi.TokenAmount, c
 
 	providerSectors := make(map[abi.SectorID][]abi.DealID)
 	if sectorDeals, err := adt.AsMap(store, st.ProviderSectors, ProviderSectorsHamtBitwidth); err != nil {
-		acc.Addf("error loading st.ProviderSectors: %v", err)
+		acc.RequireInvariantNoError(builtin.InvariantMarketStateLoadable, err, "error loading st.ProviderSectors")
 	} else {
 		var sectorMapRoot cbg.CborCid
 		err = sectorDeals.ForEach(&sectorMapRoot, func(providerID string) error {
 			provider, err := abi.ParseUIntKey(providerID)
 			if err != nil {
-				acc.RequireNoError(err, "error getting address from bytes")
+				acc.RequireInvariantNoError(builtin.InvariantMarketStateLoadable, err, "error getting address from bytes")
 				return nil
 			}
 
 			sectorMap, err := adt.AsMap(store, cid.Cid(sectorMapRoot), ProviderSectorsHamtBitwidth)
 			if err != nil {
-				acc.RequireNoError(err, "error loading sector map for provider %s", provider)
+				acc.RequireInvariantNoError(builtin.InvariantMarketStateLoadable, err, "error loading sector map for provider %s", provider)
 				return nil
 			}
 
 			var dealIDs SectorDealIDs
 			err = sectorMap.ForEach(&dealIDs, func(sectorIDBytes string) error {
 				sectorNumber, err := abi.ParseUIntKey(sectorIDBytes)
-				acc.RequireNoError(err, "error sector number from bytes")
+				acc.RequireInvariantNoError(builtin.InvariantMarketStateLoadable, err, "error sector number from bytes")
 
-				acc.Require(len(dealIDs) > 0, "no deal ids in sector %d", sectorNumber)
+				acc.RequireInvariant(builtin.InvariantMarketProviderSectorDeals, len(dealIDs) > 0,
+					builtin.ViolationFields{"sector": sectorNumber},
+					"no deal ids in sector %d", sectorNumber)
 
 				dealIDsCopy := make([]abi.DealID, len(dealIDs))
 				copy(dealIDsCopy, dealIDs)
@@ -305,30 +329,37 @@ func CheckStateInvariants(st *State, store adt.Store, balance abi.TokenAmount, c
 						continue
 					}
 
-					acc.Require(st.SectorNumber == abi.SectorNumber(sectorNumber), "deal id %d sector number %d does not match sector id %d", dealID, st.SectorNumber, sectorNumber)
+					acc.RequireInvariant(builtin.InvariantMarketProviderSectorNumber, st.SectorNumber == abi.SectorNumber(sectorNumber),
+						builtin.ViolationFields{"deal": dealID, "actual": st.SectorNumber, "expected": sectorNumber},
+						"deal id %d sector number %d does not match sector id %d", dealID, st.SectorNumber, sectorNumber)
 
 					_, ok := expectedProviderSectors[dealID]
-					acc.Require(ok, "found unexpected deal in ProviderSectors")
+					acc.RequireInvariant(builtin.InvariantMarketProviderSectorUnexpectedDeal, ok, nil,
+						"found unexpected deal in ProviderSectors")
 					delete(expectedProviderSectors, dealID)
 
 					provID, err := address.IDFromAddress(st.Provider)
 					if err != nil {
-						acc.Addf("error creating ID address: %v", err)
+						acc.RequireInvariantNoError(builtin.InvariantMarketStateLoadable, err, "error creating ID address")
 						continue
 					}
 
-					acc.Require(provider == provID, "deal %d has provider %v, expected %v", dealID, provID, provider)
+					acc.RequireInvariant(builtin.InvariantMarketProviderSectorProvider, provider == provID,
+						builtin.ViolationFields{"deal": dealID, "actual": provID, "expected": provider},
+						"deal %d has provider %v, expected %v", dealID, provID, provider)
 				}
 
 				return nil
 			})
-			acc.RequireNoError(err, "error iterating sector deals")
+			acc.RequireInvariantNoError(builtin.InvariantMarketStateLoadable, err, "error iterating sector deals")
 			return nil
 		})
-		acc.RequireNoError(err, "error iterating sector deals")
+		acc.RequireInvariantNoError(builtin.InvariantMarketStateLoadable, err, "error iterating sector deals")
 	}
 
-	acc.Require(len(expectedProviderSectors) == 0, "missing %d providersectors entries for deals", len(expectedProviderSectors))
+	acc.RequireInvariant(builtin.InvariantMarketProviderSectorsMissing, len(expectedProviderSectors) == 0,
+		builtin.ViolationFields{"count": len(expectedProviderSectors)},
+		"missing %d providersectors entries for deals", len(expectedProviderSectors))
 
 	return &StateSummary{
 		Deals:                    proposalStats,
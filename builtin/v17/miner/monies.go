@@ -158,11 +158,25 @@ const ContinuedFaultFactorNum = 351
 const ContinuedFaultFactorDenom = 100
 const ContinuedFaultProjectionPeriod abi.ChainEpoch = (builtin.EpochsInDay * ContinuedFaultFactorNum) / ContinuedFaultFactorDenom
 
+// The network's continued fault projection period. It defaults to ContinuedFaultProjectionPeriod and is
+// set by a network profile.
+var networkContinuedFaultProjectionPeriod = ContinuedFaultProjectionPeriod
+
+// NetworkContinuedFaultProjectionPeriod returns the network's continued fault projection period.
+func NetworkContinuedFaultProjectionPeriod() abi.ChainEpoch {
+	return networkContinuedFaultProjectionPeriod
+}
+
+// SetNetworkContinuedFaultProjectionPeriod sets the network's continued fault projection period.
+func SetNetworkContinuedFaultProjectionPeriod(period abi.ChainEpoch) {
+	networkContinuedFaultProjectionPeriod = period
+}
+
 // PledgePenaltyForContinuedFault calculates the penalty for a sector continuing faulty for another
 // proving period.
 // It is a projection of the expected reward earned by the sector. Also known as "FF(t)"
 func PledgePenaltyForContinuedFault(rewardEstimate smoothing.FilterEstimate, networkQaPowerEstimate smoothing.FilterEstimate, qaSectorPower abi.StoragePower) abi.TokenAmount {
-	return ExpectedRewardForPower(rewardEstimate, networkQaPowerEstimate, qaSectorPower, ContinuedFaultProjectionPeriod)
+	return ExpectedRewardForPower(rewardEstimate, networkQaPowerEstimate, qaSectorPower, networkContinuedFaultProjectionPeriod)
 }
 
 // PledgePenaltyForTermination Calculates termination fee for a given sector. Normally, it's
@@ -178,7 +192,7 @@ func PledgePenaltyForTermination(
 		big.Div(big.Mul(initialPledge, TermFeePledgeMultiple.Numerator), TermFeePledgeMultiple.Denominator)
 
 	durationTerminationFee :=
-		big.Div(big.Mul(big.NewInt(int64(sectorAge)), simpleTerminationFee), big.NewInt(int64(TerminationLifetimeCap*builtin.EpochsInDay)))
+		big.Div(big.Mul(big.NewInt(int64(sectorAge)), simpleTerminationFee), big.NewInt(int64(TerminationLifetimeCap*builtin.NetworkEpochsInDay())))
 
 	// Apply the age adjustment for young sectors to arrive at the base termination fee.
 	baseTerminationFee := big.Min(simpleTerminationFee, durationTerminationFee)
@@ -30,18 +30,30 @@ func CheckStateInvariants(st *State, store adt.Store) (*StateSummary, *builtin.M
 	acc := &builtin.MessageAccumulator{}
 
 	// basic invariants around recorded power
-	acc.Require(st.TotalRawBytePower.GreaterThanEqual(big.Zero()), "total raw power is negative %v", st.TotalRawBytePower)
-	acc.Require(st.TotalQualityAdjPower.GreaterThanEqual(big.Zero()), "total qa power is negative %v", st.TotalQualityAdjPower)
-	acc.Require(st.TotalBytesCommitted.GreaterThanEqual(big.Zero()), "total raw power committed is negative %v", st.TotalBytesCommitted)
-	acc.Require(st.TotalQABytesCommitted.GreaterThanEqual(big.Zero()), "total qa power committed is negative %v", st.TotalQABytesCommitted)
-
-	acc.Require(st.TotalRawBytePower.LessThanEqual(st.TotalQualityAdjPower),
+	acc.RequireInvariant(builtin.InvariantPowerTotalRawNonNegative, st.TotalRawBytePower.GreaterThanEqual(big.Zero()),
+		builtin.ViolationFields{"actual": st.TotalRawBytePower},
+		"total raw power is negative %v", st.TotalRawBytePower)
+	acc.RequireInvariant(builtin.InvariantPowerTotalQANonNegative, st.TotalQualityAdjPower.GreaterThanEqual(big.Zero()),
+		builtin.ViolationFields{"actual": st.TotalQualityAdjPower},
+		"total qa power is negative %v", st.TotalQualityAdjPower)
+	acc.RequireInvariant(builtin.InvariantPowerCommittedRawNonNegative, st.TotalBytesCommitted.GreaterThanEqual(big.Zero()),
+		builtin.ViolationFields{"actual": st.TotalBytesCommitted},
+		"total raw power committed is negative %v", st.TotalBytesCommitted)
+	acc.RequireInvariant(builtin.InvariantPowerCommittedQANonNegative, st.TotalQABytesCommitted.GreaterThanEqual(big.Zero()),
+		builtin.ViolationFields{"actual": st.TotalQABytesCommitted},
+		"total qa power committed is negative %v", st.TotalQABytesCommitted)
+
+	acc.RequireInvariant(builtin.InvariantPowerTotalRawWithinQA, st.TotalRawBytePower.LessThanEqual(st.TotalQualityAdjPower),
+		builtin.ViolationFields{"raw": st.TotalRawBytePower, "qa": st.TotalQualityAdjPower},
 		"total raw power %v is greater than total quality adjusted power %v", st.TotalRawBytePower, st.TotalQualityAdjPower)
-	acc.Require(st.TotalBytesCommitted.LessThanEqual(st.TotalQABytesCommitted),
+	acc.RequireInvariant(builtin.InvariantPowerCommittedRawWithinQA, st.TotalBytesCommitted.LessThanEqual(st.TotalQABytesCommitted),
+		builtin.ViolationFields{"raw": st.TotalBytesCommitted, "qa": st.TotalQABytesCommitted},
 		"committed raw power %v is greater than committed quality adjusted power %v", st.TotalBytesCommitted, st.TotalQABytesCommitted)
-	acc.Require(st.TotalRawBytePower.LessThanEqual(st.TotalBytesCommitted),
+	acc.RequireInvariant(builtin.InvariantPowerTotalRawWithinCommitted, st.TotalRawBytePower.LessThanEqual(st.TotalBytesCommitted),
+		builtin.ViolationFields{"total": st.TotalRawBytePower, "committed": st.TotalBytesCommitted},
 		"total raw power %v is greater than raw power committed %v", st.TotalRawBytePower, st.TotalBytesCommitted)
-	acc.Require(st.TotalQualityAdjPower.LessThanEqual(st.TotalQABytesCommitted),
+	acc.RequireInvariant(builtin.InvariantPowerTotalQAWithinCommitted, st.TotalQualityAdjPower.LessThanEqual(st.TotalQABytesCommitted),
+		builtin.ViolationFields{"total": st.TotalQualityAdjPower, "committed": st.TotalQABytesCommitted},
 		"total qa power %v is greater than qa power committed %v", st.TotalQualityAdjPower, st.TotalQABytesCommitted)
 
 	crons := CheckCronInvariants(st, store, acc)
@@ -59,19 +71,22 @@ func CheckCronInvariants(st *State, store adt.Store, acc *builtin.MessageAccumul
 	byAddress := make(CronEventsByAddress)
 	queue, err := adt.AsMultimap(store, st.CronEventQueue, CronQueueHamtBitwidth, CronQueueAmtBitwidth)
 	if err != nil {
-		acc.Addf("error loading cron event queue: %v", err)
+		acc.RequireInvariantNoError(builtin.InvariantPowerStateLoadable, err, "error loading cron event queue")
 		// Bail here.
 		return byAddress
 	}
 
 	err = queue.ForAll(func(ekey string, arr *adt.Array) error {
 		epoch, err := abi.ParseIntKey(ekey)
-		acc.Require(err == nil, "non-int key in cron array")
+		acc.RequireInvariant(builtin.InvariantPowerCronKey, err == nil, nil,
+			"non-int key in cron array")
 		if err != nil {
 			return nil // error noted above
 		}
 
-		acc.Require(abi.ChainEpoch(epoch) >= st.FirstCronEpoch, "cron event at epoch %d before FirstCronEpoch %d",
+		acc.RequireInvariant(builtin.InvariantPowerCronEpoch, abi.ChainEpoch(epoch) >= st.FirstCronEpoch,
+			builtin.ViolationFields{"epoch": epoch, "first_cron_epoch": st.FirstCronEpoch},
+			"cron event at epoch %d before FirstCronEpoch %d",
 			epoch, st.FirstCronEpoch)
 
 		var event CronEvent
@@ -84,7 +99,7 @@ func CheckCronInvariants(st *State, store adt.Store, acc *builtin.MessageAccumul
 			return nil
 		})
 	})
-	acc.RequireNoError(err, "error iterating cron tasks")
+	acc.RequireInvariantNoError(builtin.InvariantPowerStateLoadable, err, "error iterating cron tasks")
 	return byAddress
 }
 
@@ -92,7 +107,7 @@ func CheckClaimInvariants(st *State, store adt.Store, acc *builtin.MessageAccumu
 	byAddress := make(ClaimsByAddress)
 	claims, err := adt.AsMap(store, st.Claims, builtin.DefaultHamtBitwidth)
 	if err != nil {
-		acc.Addf("error loading power claims: %v", err)
+		acc.RequireInvariantNoError(builtin.InvariantPowerStateLoadable, err, "error loading power claims")
 		// Bail here
 		return byAddress
 	}
@@ -113,7 +128,9 @@ func CheckClaimInvariants(st *State, store adt.Store, acc *builtin.MessageAccumu
 		committedQAPower = big.Add(committedQAPower, claim.QualityAdjPower)
 
 		minPower, err := builtin.ConsensusMinerMinPower(claim.WindowPoStProofType)
-		acc.Require(err == nil, "could not get consensus miner min power for miner %v: %v", addr, err)
+		acc.RequireInvariant(builtin.InvariantPowerConsensusMinerMinPower, err == nil,
+			builtin.ViolationFields{"miner": addr},
+			"could not get consensus miner min power for miner %v: %v", addr, err)
 		if err != nil {
 			return nil // noted above
 		}
@@ -125,22 +142,27 @@ func CheckClaimInvariants(st *State, store adt.Store, acc *builtin.MessageAccumu
 		}
 		return nil
 	})
-	acc.RequireNoError(err, "error iterating power claims")
+	acc.RequireInvariantNoError(builtin.InvariantPowerStateLoadable, err, "error iterating power claims")
 
-	acc.Require(committedRawPower.Equals(st.TotalBytesCommitted),
+	acc.RequireInvariant(builtin.InvariantPowerClaimsRawCommitted, committedRawPower.Equals(st.TotalBytesCommitted),
+		builtin.ViolationFields{"expected": committedRawPower, "actual": st.TotalBytesCommitted},
 		"sum of raw power in claims %v does not match recorded bytes committed %v",
 		committedRawPower, st.TotalBytesCommitted)
-	acc.Require(committedQAPower.Equals(st.TotalQABytesCommitted),
+	acc.RequireInvariant(builtin.InvariantPowerClaimsQACommitted, committedQAPower.Equals(st.TotalQABytesCommitted),
+		builtin.ViolationFields{"expected": committedQAPower, "actual": st.TotalQABytesCommitted},
 		"sum of qa power in claims %v does not match recorded qa power committed %v",
 		committedQAPower, st.TotalQABytesCommitted)
 
-	acc.Require(claimsWithSufficientPowerCount == st.MinerAboveMinPowerCount,
+	acc.RequireInvariant(builtin.InvariantPowerMinerAboveMinPowerCount, claimsWithSufficientPowerCount == st.MinerAboveMinPowerCount,
+		builtin.ViolationFields{"expected": claimsWithSufficientPowerCount, "actual": st.MinerAboveMinPowerCount},
 		"claims with sufficient power %d does not match MinerAboveMinPowerCount %d",
 		claimsWithSufficientPowerCount, st.MinerAboveMinPowerCount)
 
-	acc.Require(st.TotalRawBytePower.Equals(rawPower),
+	acc.RequireInvariant(builtin.InvariantPowerClaimsRaw, st.TotalRawBytePower.Equals(rawPower),
+		builtin.ViolationFields{"actual": st.TotalRawBytePower, "expected": rawPower},
 		"recorded raw power %v does not match raw power in claims %v", st.TotalRawBytePower, rawPower)
-	acc.Require(st.TotalQualityAdjPower.Equals(qaPower),
+	acc.RequireInvariant(builtin.InvariantPowerClaimsQA, st.TotalQualityAdjPower.Equals(qaPower),
+		builtin.ViolationFields{"actual": st.TotalQualityAdjPower, "expected": qaPower},
 		"recorded qa power %v does not match qa power in claims %v", st.TotalQualityAdjPower, qaPower)
 
 	return byAddress
@@ -153,7 +175,7 @@ func CheckProofValidationInvariants(st *State, store adt.Store, claims ClaimsByA
 
 	proofs := make(ProofsByAddress)
 	if queue, err := adt.AsMultimap(store, *st.ProofValidationBatch, builtin.DefaultHamtBitwidth, ProofValidationBatchAmtBitwidth); err != nil {
-		acc.Addf("error loading proof validation queue: %v", err)
+		acc.RequireInvariantNoError(builtin.InvariantPowerStateLoadable, err, "error loading proof validation queue")
 	} else {
 		err = queue.ForAll(func(key string, arr *adt.Array) error {
 			addr, err := address.NewFromBytes([]byte(key))
@@ -162,7 +184,9 @@ func CheckProofValidationInvariants(st *State, store adt.Store, claims ClaimsByA
 			}
 
 			claim, found := claims[addr]
-			acc.Require(found, "miner %v has proofs awaiting validation but no claim", addr)
+			acc.RequireInvariant(builtin.InvariantPowerProofValidationClaim, found,
+				builtin.ViolationFields{"miner": addr},
+				"miner %v has proofs awaiting validation but no claim", addr)
 			if !found {
 				return nil
 			}
@@ -170,8 +194,10 @@ func CheckProofValidationInvariants(st *State, store adt.Store, claims ClaimsByA
 			var info proof.SealVerifyInfo
 			err = arr.ForEach(&info, func(i int64) error {
 				sectorWindowPoStProofType, err := info.SealProof.RegisteredWindowPoStProofByNetworkVersion(network.Version19)
-				acc.RequireNoError(err, "failed to get PoSt proof type for seal proof %d", info.SealProof)
-				acc.Require(claim.WindowPoStProofType == sectorWindowPoStProofType, "miner submitted proof with proof type %d different from claim %d",
+				acc.RequireInvariantNoError(builtin.InvariantPowerStateLoadable, err, "failed to get PoSt proof type for seal proof %d", info.SealProof)
+				acc.RequireInvariant(builtin.InvariantPowerProofValidationProofType, claim.WindowPoStProofType == sectorWindowPoStProofType,
+					builtin.ViolationFields{"actual": sectorWindowPoStProofType, "expected": claim.WindowPoStProofType},
+					"miner submitted proof with proof type %d different from claim %d",
 					sectorWindowPoStProofType, claim.WindowPoStProofType)
 				proofs[addr] = append(proofs[addr], info)
 				return nil
@@ -179,11 +205,12 @@ func CheckProofValidationInvariants(st *State, store adt.Store, claims ClaimsByA
 			if err != nil {
 				return err
 			}
-			acc.Require(len(proofs[addr]) <= MaxMinerProveCommitsPerEpoch,
+			acc.RequireInvariant(builtin.InvariantPowerProofValidationBatchSize, len(proofs[addr]) <= MaxMinerProveCommitsPerEpoch,
+				builtin.ViolationFields{"miner": addr, "count": len(proofs[addr])},
 				"miner %v has submitted too many proofs (%d) for batch verification", addr, len(proofs[addr]))
 			return nil
 		})
-		acc.RequireNoError(err, "error iterating proof validation queue")
+		acc.RequireInvariantNoError(builtin.InvariantPowerStateLoadable, err, "error iterating proof validation queue")
 	}
 	return proofs
 }
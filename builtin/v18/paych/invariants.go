@@ -19,24 +19,32 @@ func CheckStateInvariants(st *State, store adt.Store, balance abi.TokenAmount) (
 		Redeemed: big.Zero(),
 	}
 
-	acc.Require(st.From.Protocol() == address.ID, "from address is not ID address %v", st.From)
-	acc.Require(st.To.Protocol() == address.ID, "to address is not ID address %v", st.To)
-	acc.Require(st.SettlingAt >= st.MinSettleHeight,
+	acc.RequireInvariant(builtin.InvariantPaychFromAddress, st.From.Protocol() == address.ID,
+		builtin.ViolationFields{"from": st.From},
+		"from address is not ID address %v", st.From)
+	acc.RequireInvariant(builtin.InvariantPaychToAddress, st.To.Protocol() == address.ID,
+		builtin.ViolationFields{"to": st.To},
+		"to address is not ID address %v", st.To)
+	acc.RequireInvariant(builtin.InvariantPaychSettlingAt, st.SettlingAt >= st.MinSettleHeight,
+		builtin.ViolationFields{"actual": st.SettlingAt, "expected_min": st.MinSettleHeight},
 		"channel is setting at epoch %d before min settle height %d", st.SettlingAt, st.MinSettleHeight)
 
 	if lanes, err := adt.AsArray(store, st.LaneStates, LaneStatesAmtBitwidth); err != nil {
-		acc.Addf("error loading lanes: %v", err)
+		acc.RequireInvariantNoError(builtin.InvariantPaychStateLoadable, err, "error loading lanes")
 	} else {
 		var lane LaneState
 		err = lanes.ForEach(&lane, func(i int64) error {
-			acc.Require(lane.Redeemed.GreaterThan(big.Zero()), "land %d redeemed is not greater than zero %v", i, lane.Redeemed)
+			acc.RequireInvariant(builtin.InvariantPaychLaneRedeemed, lane.Redeemed.GreaterThan(big.Zero()),
+				builtin.ViolationFields{"lane": i, "actual": lane.Redeemed},
+				"land %d redeemed is not greater than zero %v", i, lane.Redeemed)
 			paychSummary.Redeemed = big.Add(paychSummary.Redeemed, lane.Redeemed)
 			return nil
 		})
-		acc.RequireNoError(err, "error iterating lanes")
+		acc.RequireInvariantNoError(builtin.InvariantPaychStateLoadable, err, "error iterating lanes")
 	}
 
-	acc.Require(balance.GreaterThanEqual(st.ToSend),
+	acc.RequireInvariant(builtin.InvariantPaychBalanceCoversToSend, balance.GreaterThanEqual(st.ToSend),
+		builtin.ViolationFields{"actual": balance, "expected_min": st.ToSend},
 		"channel has insufficient funds to send (%v < %v)", balance, st.ToSend)
 
 	return paychSummary, acc
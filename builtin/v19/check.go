@@ -30,157 +30,234 @@ import (
 // can continue to find more errors rather than fail with no insight.
 // Only errors thar are particularly troublesome to recover from should propagate as Go errors.
 func CheckStateInvariants(tree *builtin.ActorTree, priorEpoch abi.ChainEpoch, actorCodes map[string]cid.Cid) (*builtin.MessageAccumulator, error) {
-	acc := &builtin.MessageAccumulator{}
-	totalFIl := big.Zero()
-	var initSummary *init_.StateSummary
-	var cronSummary *cron.StateSummary
-	var verifregSummary *verifreg.StateSummary
-	var datacapSummary *datacap.StateSummary
-	var marketSummary *market.StateSummary
-	var rewardSummary *reward.StateSummary
-	var accountSummaries []*account.StateSummary
-	var powerSummary *power.StateSummary
-	var paychSummaries []*paych.StateSummary
-	var multisigSummaries []*multisig.StateSummary
-	var delegatedAddrs []address.Address
-	minerSummaries := make(map[address.Address]*miner.StateSummary)
+	summaries, err := summarizeTree(tree, priorEpoch, actorCodes)
+	if err != nil {
+		return nil, err
+	}
+	return summaries.check(), nil
+}
+
+// The result of checking a single actor's own state invariants.
+type ActorSummary struct {
+	Actor builtin.ActorV5
+	// Summary of the actor's state, e.g. *miner.StateSummary, or nil for actors that don't produce one.
+	Summary interface{}
+	// Violations of the actor's own state invariants.
+	Messages *builtin.MessageAccumulator
+}
 
+// Per-actor results of checking a state tree, from which the cross-actor invariants are checked.
+// Retained between checks, they allow a subsequent state to be checked incrementally by
+// CheckStateInvariantsIncremental.
+type StateSummaries struct {
+	// Root of the checked state tree.
+	Root       cid.Cid
+	PriorEpoch abi.ChainEpoch
+	ActorCodes map[string]cid.Cid
+	Actors     map[address.Address]*ActorSummary
+	// Addresses of the actors in the iteration order of the state tree, in which their violations
+	// are reported.
+	Keys []address.Address
+}
+
+func summarizeTree(tree *builtin.ActorTree, priorEpoch abi.ChainEpoch, actorCodes map[string]cid.Cid) (*StateSummaries, error) {
 	emptyObjectCid, err := builtin.MakeEmptyState()
 	if err != nil {
 		return nil, err
 	}
 
+	summaries := &StateSummaries{
+		PriorEpoch: priorEpoch,
+		ActorCodes: actorCodes,
+		Actors:     make(map[address.Address]*ActorSummary),
+	}
 	if err := tree.ForEachV5(func(key address.Address, actor *builtin.ActorV5) error {
-		acc := acc.WithPrefix("%v ", key) // Intentional shadow
-		if key.Protocol() != address.ID {
-			acc.Addf("unexpected address protocol in state tree root: %v", key)
+		summary, err := checkActor(tree, key, actor, priorEpoch, actorCodes, emptyObjectCid)
+		if err != nil {
+			return err
 		}
-		totalFIl = big.Add(totalFIl, actor.Balance)
+		summaries.Actors[key] = summary
+		summaries.Keys = append(summaries.Keys, key)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return summaries, nil
+}
 
-		if actor.DelegatedAddress != nil {
-			acc.Require(actor.DelegatedAddress.Protocol() == address.Delegated, "actor.Address %v is not a delegated address", *actor.DelegatedAddress)
-			if actor.DelegatedAddress.Protocol() == address.Delegated {
-				delegatedAddrs = append(delegatedAddrs, *actor.DelegatedAddress)
-			}
+// Checks the invariants of a single actor's state.
+func checkActor(tree *builtin.ActorTree, key address.Address, actor *builtin.ActorV5, priorEpoch abi.ChainEpoch, actorCodes map[string]cid.Cid, emptyObjectCid cid.Cid) (*ActorSummary, error) {
+	acc := &builtin.MessageAccumulator{}
+	out := &ActorSummary{Actor: *actor, Messages: acc}
+
+	if key.Protocol() != address.ID {
+		acc.AddInvariantf(builtin.InvariantActorAddress, builtin.ViolationFields{"address": key}, "unexpected address protocol in state tree root: %v", key)
+	}
+	if actor.DelegatedAddress != nil {
+		acc.RequireInvariant(builtin.InvariantActorDelegatedAddress, actor.DelegatedAddress.Protocol() == address.Delegated,
+			builtin.ViolationFields{"delegated_address": *actor.DelegatedAddress},
+			"actor.Address %v is not a delegated address", *actor.DelegatedAddress)
+	}
+
+	switch actor.Code {
+	case actorCodes[manifest.SystemKey]:
+
+	case actorCodes[manifest.InitKey]:
+		var st init_.State
+		if err := tree.Store.Get(tree.Store.Context(), actor.Head, &st); err != nil {
+			fmt.Println("init invariant error = ", err)
+			return nil, err
+		}
+		summary, msgs := init_.CheckStateInvariants(&st, tree, actorCodes)
+		acc.WithPrefix("init: ").AddAll(msgs)
+		out.Summary = summary
+	case actorCodes[manifest.CronKey]:
+		var st cron.State
+		if err := tree.Store.Get(tree.Store.Context(), actor.Head, &st); err != nil {
+			return nil, err
+		}
+		summary, msgs := cron.CheckStateInvariants(&st, tree.Store)
+		acc.WithPrefix("cron: ").AddAll(msgs)
+		out.Summary = summary
+	case actorCodes[manifest.AccountKey]:
+		var st account.State
+		if err := tree.Store.Get(tree.Store.Context(), actor.Head, &st); err != nil {
+			return nil, err
+		}
+		summary, msgs := account.CheckStateInvariants(&st, key)
+		acc.WithPrefix("account: ").AddAll(msgs)
+		out.Summary = summary
+	case actorCodes[manifest.PowerKey]:
+		var st power.State
+		if err := tree.Store.Get(tree.Store.Context(), actor.Head, &st); err != nil {
+			return nil, err
+		}
+		summary, msgs := power.CheckStateInvariants(&st, tree.Store)
+		acc.WithPrefix("power: ").AddAll(msgs)
+		out.Summary = summary
+	case actorCodes[manifest.MinerKey]:
+		var st miner.State
+		if err := tree.Store.Get(tree.Store.Context(), actor.Head, &st); err != nil {
+			return nil, err
+		}
+		summary, msgs := miner.CheckStateInvariants(&st, tree.Store, actor.Balance)
+		acc.WithPrefix("miner: ").AddAll(msgs)
+		out.Summary = summary
+	case actorCodes[manifest.MarketKey]:
+		var st market.State
+		if err := tree.Store.Get(tree.Store.Context(), actor.Head, &st); err != nil {
+			return nil, err
+		}
+		summary, msgs := market.CheckStateInvariants(&st, tree.Store, actor.Balance, priorEpoch)
+		acc.WithPrefix("market: ").AddAll(msgs)
+		out.Summary = summary
+	case actorCodes[manifest.PaychKey]:
+		var st paych.State
+		if err := tree.Store.Get(tree.Store.Context(), actor.Head, &st); err != nil {
+			return nil, err
+		}
+		summary, msgs := paych.CheckStateInvariants(&st, tree.Store, actor.Balance)
+		acc.WithPrefix("paych: ").AddAll(msgs)
+		out.Summary = summary
+	case actorCodes[manifest.MultisigKey]:
+		var st multisig.State
+		if err := tree.Store.Get(tree.Store.Context(), actor.Head, &st); err != nil {
+			return nil, err
 		}
+		summary, msgs := multisig.CheckStateInvariants(&st, tree.Store)
+		acc.WithPrefix("multisig: ").AddAll(msgs)
+		out.Summary = summary
+	case actorCodes[manifest.RewardKey]:
+		var st reward.State
+		if err := tree.Store.Get(tree.Store.Context(), actor.Head, &st); err != nil {
+			return nil, err
+		}
+		summary, msgs := reward.CheckStateInvariants(&st, tree.Store, priorEpoch, actor.Balance)
+		acc.WithPrefix("reward: ").AddAll(msgs)
+		out.Summary = summary
+	case actorCodes[manifest.VerifregKey]:
+		var st verifreg.State
+		if err := tree.Store.Get(tree.Store.Context(), actor.Head, &st); err != nil {
+			return nil, err
+		}
+		summary, msgs := verifreg.CheckStateInvariants(&st, tree.Store, priorEpoch)
+		acc.WithPrefix("verifreg: ").AddAll(msgs)
+		out.Summary = summary
+	case actorCodes[manifest.DatacapKey]:
+		var st datacap.State
+		if err := tree.Store.Get(tree.Store.Context(), actor.Head, &st); err != nil {
+			return nil, err
+		}
+		summary, msgs := datacap.CheckStateInvariants(&st, tree.Store)
+		acc.WithPrefix("datacap: ").AddAll(msgs)
+		out.Summary = summary
+	case actorCodes[manifest.EvmKey]:
+		var st evm.State
+		if err := tree.Store.Get(tree.Store.Context(), actor.Head, &st); err != nil {
+			return nil, err
+		}
+		msgs := evm.CheckStateInvariants(&st, tree.Store)
+		acc.WithPrefix("evm: ").AddAll(msgs)
+	case actorCodes[manifest.PlaceholderKey]:
+		acc.RequireInvariant(builtin.InvariantEmptyActorHead, actor.Head == emptyObjectCid,
+			builtin.ViolationFields{"actual": actor.Head, "expected": emptyObjectCid},
+			"Placeholder actor head %v unequal to emptyObjectCid %v", actor.Head, emptyObjectCid)
+	case actorCodes[manifest.EthAccountKey]:
+		acc.RequireInvariant(builtin.InvariantEmptyActorHead, actor.Head == emptyObjectCid,
+			builtin.ViolationFields{"actual": actor.Head, "expected": emptyObjectCid},
+			"EthAccount actor head %v unequal to emptyObjectCid %v", actor.Head, emptyObjectCid)
+	case actorCodes[manifest.EamKey]:
+		acc.RequireInvariant(builtin.InvariantEmptyActorHead, actor.Head == emptyObjectCid,
+			builtin.ViolationFields{"actual": actor.Head, "expected": emptyObjectCid},
+			"Eam actor head %s unequal to emptyObjectCid %s", actor.Head, emptyObjectCid)
+	default:
+		return nil, xerrors.Errorf("unexpected actor code CID %v for address %v", actor.Code, key)
+	}
+	return out, nil
+}
+
+// Collects the per-actor violations, in state tree order, and performs the cross-actor checks.
+func (s *StateSummaries) check() *builtin.MessageAccumulator {
+	acc := &builtin.MessageAccumulator{}
+	totalFIl := big.Zero()
+	var initSummary *init_.StateSummary
+	var verifregSummary *verifreg.StateSummary
+	var datacapSummary *datacap.StateSummary
+	var marketSummary *market.StateSummary
+	var powerSummary *power.StateSummary
+	var delegatedAddrs []address.Address
+	minerSummaries := make(map[address.Address]*miner.StateSummary)
 
-		switch actor.Code {
-		case actorCodes[manifest.SystemKey]:
+	for _, key := range s.Keys {
+		actor := s.Actors[key]
+		acc.WithActor(key).AddAll(actor.Messages)
+		totalFIl = big.Add(totalFIl, actor.Actor.Balance)
+		if actor.Actor.DelegatedAddress != nil && actor.Actor.DelegatedAddress.Protocol() == address.Delegated {
+			delegatedAddrs = append(delegatedAddrs, *actor.Actor.DelegatedAddress)
+		}
 
-		case actorCodes[manifest.InitKey]:
-			var st init_.State
-			if err := tree.Store.Get(tree.Store.Context(), actor.Head, &st); err != nil {
-				fmt.Println("init invariant error = ", err)
-				return err
-			}
-			summary, msgs := init_.CheckStateInvariants(&st, tree, actorCodes)
-			acc.WithPrefix("init: ").AddAll(msgs)
+		switch summary := actor.Summary.(type) {
+		case *init_.StateSummary:
 			initSummary = summary
-		case actorCodes[manifest.CronKey]:
-			var st cron.State
-			if err := tree.Store.Get(tree.Store.Context(), actor.Head, &st); err != nil {
-				return err
-			}
-			summary, msgs := cron.CheckStateInvariants(&st, tree.Store)
-			acc.WithPrefix("cron: ").AddAll(msgs)
-			cronSummary = summary
-		case actorCodes[manifest.AccountKey]:
-			var st account.State
-			if err := tree.Store.Get(tree.Store.Context(), actor.Head, &st); err != nil {
-				return err
-			}
-			summary, msgs := account.CheckStateInvariants(&st, key)
-			acc.WithPrefix("account: ").AddAll(msgs)
-			accountSummaries = append(accountSummaries, summary)
-		case actorCodes[manifest.PowerKey]:
-			var st power.State
-			if err := tree.Store.Get(tree.Store.Context(), actor.Head, &st); err != nil {
-				return err
-			}
-			summary, msgs := power.CheckStateInvariants(&st, tree.Store)
-			acc.WithPrefix("power: ").AddAll(msgs)
+		case *power.StateSummary:
 			powerSummary = summary
-		case actorCodes[manifest.MinerKey]:
-			var st miner.State
-			if err := tree.Store.Get(tree.Store.Context(), actor.Head, &st); err != nil {
-				return err
-			}
-			summary, msgs := miner.CheckStateInvariants(&st, tree.Store, actor.Balance)
-			acc.WithPrefix("miner: ").AddAll(msgs)
+		case *miner.StateSummary:
 			minerSummaries[key] = summary
-		case actorCodes[manifest.MarketKey]:
-			var st market.State
-			if err := tree.Store.Get(tree.Store.Context(), actor.Head, &st); err != nil {
-				return err
-			}
-			summary, msgs := market.CheckStateInvariants(&st, tree.Store, actor.Balance, priorEpoch)
-			acc.WithPrefix("market: ").AddAll(msgs)
+		case *market.StateSummary:
 			marketSummary = summary
-		case actorCodes[manifest.PaychKey]:
-			var st paych.State
-			if err := tree.Store.Get(tree.Store.Context(), actor.Head, &st); err != nil {
-				return err
-			}
-			summary, msgs := paych.CheckStateInvariants(&st, tree.Store, actor.Balance)
-			acc.WithPrefix("paych: ").AddAll(msgs)
-			paychSummaries = append(paychSummaries, summary)
-		case actorCodes[manifest.MultisigKey]:
-			var st multisig.State
-			if err := tree.Store.Get(tree.Store.Context(), actor.Head, &st); err != nil {
-				return err
-			}
-			summary, msgs := multisig.CheckStateInvariants(&st, tree.Store)
-			acc.WithPrefix("multisig: ").AddAll(msgs)
-			multisigSummaries = append(multisigSummaries, summary)
-		case actorCodes[manifest.RewardKey]:
-			var st reward.State
-			if err := tree.Store.Get(tree.Store.Context(), actor.Head, &st); err != nil {
-				return err
-			}
-			summary, msgs := reward.CheckStateInvariants(&st, tree.Store, priorEpoch, actor.Balance)
-			acc.WithPrefix("reward: ").AddAll(msgs)
-			rewardSummary = summary
-		case actorCodes[manifest.VerifregKey]:
-			var st verifreg.State
-			if err := tree.Store.Get(tree.Store.Context(), actor.Head, &st); err != nil {
-				return err
-			}
-			summary, msgs := verifreg.CheckStateInvariants(&st, tree.Store, priorEpoch)
-			acc.WithPrefix("verifreg: ").AddAll(msgs)
+		case *verifreg.StateSummary:
 			verifregSummary = summary
-		case actorCodes[manifest.DatacapKey]:
-			var st datacap.State
-			if err := tree.Store.Get(tree.Store.Context(), actor.Head, &st); err != nil {
-				return err
-			}
-			summary, msgs := datacap.CheckStateInvariants(&st, tree.Store)
-			acc.WithPrefix("datacap: ").AddAll(msgs)
+		case *datacap.StateSummary:
 			datacapSummary = summary
-		case actorCodes[manifest.EvmKey]:
-			var st evm.State
-			if err := tree.Store.Get(tree.Store.Context(), actor.Head, &st); err != nil {
-				return err
-			}
-			msgs := evm.CheckStateInvariants(&st, tree.Store)
-			acc.WithPrefix("evm: ").AddAll(msgs)
-		case actorCodes[manifest.PlaceholderKey]:
-			acc.Require(actor.Head == emptyObjectCid, "Placeholder actor head %v unequal to emptyObjectCid %v", actor.Head, emptyObjectCid)
-		case actorCodes[manifest.EthAccountKey]:
-			acc.Require(actor.Head == emptyObjectCid, "EthAccount actor head %v unequal to emptyObjectCid %v", actor.Head, emptyObjectCid)
-		case actorCodes[manifest.EamKey]:
-			acc.Require(actor.Head == emptyObjectCid, "Eam actor head %s unequal to emptyObjectCid %s", actor.Head, emptyObjectCid)
-		default:
-			return xerrors.Errorf("unexpected actor code CID %v for address %v", actor.Code, key)
 		}
-		return nil
-	}); err != nil {
-		return nil, err
 	}
 
 	// Check if all delegated addresses are part of init actor
 	for _, addr := range delegatedAddrs {
 		_, found := initSummary.AddrIDs[addr]
-		acc.Require(found, "delegated address %v not found in init actor map", addr)
+		acc.RequireInvariant(builtin.InvariantDelegatedAddressMapped, found,
+			builtin.ViolationFields{"delegated_address": addr},
+			"delegated address %v not found in init actor map", addr)
 	}
 
 	//
@@ -188,35 +265,31 @@ func CheckStateInvariants(tree *builtin.ActorTree, priorEpoch abi.ChainEpoch, ac
 	//
 
 	CheckMinersAgainstPower(acc, minerSummaries, powerSummary)
-	CheckDealStatesAgainstSectors(acc, minerSummaries, marketSummary, priorEpoch)
+	CheckDealStatesAgainstSectors(acc, minerSummaries, marketSummary, s.PriorEpoch)
 	CheckVerifregAgainstMiners(acc, verifregSummary, minerSummaries)
 	CheckMarketAgainstVerifreg(acc, verifregSummary, marketSummary)
 	CheckVerifregAgainstDatacap(acc, verifregSummary, datacapSummary)
 
-	_ = initSummary
-	_ = verifregSummary
-	_ = cronSummary
-	_ = marketSummary
-	_ = rewardSummary
-	_ = datacapSummary
+	acc.RequireInvariant(builtin.InvariantTotalBalance, totalFIl.Equals(builtin.TotalFilecoin),
+		builtin.ViolationFields{"expected": builtin.TotalFilecoin, "actual": totalFIl},
+		"total token balance is %v, expected %v", totalFIl, builtin.TotalFilecoin)
 
-	if !totalFIl.Equals(builtin.TotalFilecoin) {
-		acc.Addf("total token balance is %v, expected %v", totalFIl, builtin.TotalFilecoin)
-	}
-
-	return acc, nil
+	return acc
 }
 
 func CheckMinersAgainstPower(acc *builtin.MessageAccumulator, minerSummaries map[address.Address]*miner.StateSummary, powerSummary *power.StateSummary) {
 	for addr, minerSummary := range minerSummaries { // nolint:nomaprange
 		// check claim
 		claim, ok := powerSummary.Claims[addr]
-		acc.Require(ok, "miner %v has no power claim", addr)
+		acc.RequireInvariant(builtin.InvariantMinerPowerClaim, ok, builtin.ViolationFields{"miner": addr},
+			"miner %v has no power claim", addr)
 		if ok {
 			claimPower := miner.NewPowerPair(claim.RawBytePower, claim.QualityAdjPower)
-			acc.Require(minerSummary.ActivePower.Equals(claimPower),
+			acc.RequireInvariant(builtin.InvariantMinerActivePower, minerSummary.ActivePower.Equals(claimPower),
+				builtin.ViolationFields{"miner": addr, "expected": claimPower, "actual": minerSummary.ActivePower},
 				"miner %v computed active power %v does not match claim %v", addr, minerSummary.ActivePower, claimPower)
-			acc.Require(minerSummary.WindowPoStProofType == claim.WindowPoStProofType,
+			acc.RequireInvariant(builtin.InvariantMinerClaimProofType, minerSummary.WindowPoStProofType == claim.WindowPoStProofType,
+				builtin.ViolationFields{"actual": minerSummary.WindowPoStProofType, "expected": claim.WindowPoStProofType, "miner": addr},
 				"miner seal proof type %d does not match claim proof type %d", minerSummary.WindowPoStProofType, claim.WindowPoStProofType)
 		}
 
@@ -230,24 +303,33 @@ func CheckMinersAgainstPower(acc *builtin.MessageAccumulator, minerSummaries map
 		var provingPeriodCron *power.MinerCronEvent
 		for _, event := range crons {
 			err := payload.UnmarshalCBOR(bytes.NewReader(event.Payload))
-			acc.Require(err == nil, "miner %v registered cron at epoch %d with wrong or corrupt payload",
+			acc.RequireInvariant(builtin.InvariantMinerCronPayload, err == nil,
+				builtin.ViolationFields{"miner": addr, "epoch": event.Epoch},
+				"miner %v registered cron at epoch %d with wrong or corrupt payload",
 				addr, event.Epoch)
-			acc.Require(payload.EventType == miner.CronEventProcessEarlyTerminations || payload.EventType == miner.CronEventProvingDeadline,
+			acc.RequireInvariant(builtin.InvariantMinerCronEventType, payload.EventType == miner.CronEventProcessEarlyTerminations || payload.EventType == miner.CronEventProvingDeadline,
+				builtin.ViolationFields{"miner": addr, "actual": payload.EventType},
 				"miner %v has unexpected cron event type %v", addr, payload.EventType)
 
 			if payload.EventType == miner.CronEventProvingDeadline {
 				if provingPeriodCron != nil {
-					acc.Require(false, "miner %v has duplicate proving period crons at epoch %d and %d",
+					acc.RequireInvariant(builtin.InvariantMinerCronDuplicate, false,
+						builtin.ViolationFields{"miner": addr, "epoch": provingPeriodCron.Epoch, "duplicate_epoch": event.Epoch},
+						"miner %v has duplicate proving period crons at epoch %d and %d",
 						addr, provingPeriodCron.Epoch, event.Epoch)
 				}
 				provingPeriodCron = &event
 			}
 		}
 		hasProvingPeriodCron := provingPeriodCron != nil
-		acc.Require(hasProvingPeriodCron == minerSummary.DeadlineCronActive, "miner %v has invalid DeadlineCronActive (%t) for hasProvingPeriodCron status (%t)",
+		acc.RequireInvariant(builtin.InvariantMinerDeadlineCron, hasProvingPeriodCron == minerSummary.DeadlineCronActive,
+			builtin.ViolationFields{"miner": addr, "actual": minerSummary.DeadlineCronActive, "expected": hasProvingPeriodCron},
+			"miner %v has invalid DeadlineCronActive (%t) for hasProvingPeriodCron status (%t)",
 			addr, minerSummary.DeadlineCronActive, hasProvingPeriodCron)
 
-		acc.Require(provingPeriodCron != nil, "miner %v has no proving period cron", addr)
+		acc.RequireInvariant(builtin.InvariantMinerProvingPeriodCron, provingPeriodCron != nil,
+			builtin.ViolationFields{"miner": addr},
+			"miner %v has no proving period cron", addr)
 	}
 }
 
@@ -263,7 +345,7 @@ func CheckDealStatesAgainstSectors(acc *builtin.MessageAccumulator, minerSummari
 
 		minerSummary, found := minerSummaries[deal.Provider]
 		if !found {
-			acc.Addf("provider %v for deal %d not found among miners", deal.Provider, dealID)
+			acc.AddInvariantf(builtin.InvariantDealProvider, builtin.ViolationFields{"provider": deal.Provider, "deal": dealID}, "provider %v for deal %d not found among miners", deal.Provider, dealID)
 			continue
 		}
 
@@ -272,23 +354,28 @@ func CheckDealStatesAgainstSectors(acc *builtin.MessageAccumulator, minerSummari
 			continue
 		}
 
-		acc.Require(deal.SectorStartEpoch >= sectorDeal.SectorStart,
+		acc.RequireInvariant(builtin.InvariantDealSectorStart, deal.SectorStartEpoch >= sectorDeal.SectorStart,
+			builtin.ViolationFields{"actual": deal.SectorStartEpoch, "expected": sectorDeal.SectorStart, "miner": deal.Provider},
 			"deal state start %d does not match sector start %d for miner %v",
 			deal.SectorStartEpoch, sectorDeal.SectorStart, deal.Provider)
 
-		acc.Require(deal.SectorStartEpoch <= sectorDeal.SectorExpiration,
+		acc.RequireInvariant(builtin.InvariantDealActivation, deal.SectorStartEpoch <= sectorDeal.SectorExpiration,
+			builtin.ViolationFields{"actual": deal.SectorStartEpoch, "sector_expiration": sectorDeal.SectorExpiration, "miner": deal.Provider},
 			"deal state start %d activated after sector expiration %d for miner %v",
 			deal.SectorStartEpoch, sectorDeal.SectorExpiration, deal.Provider)
 
-		acc.Require(deal.LastUpdatedEpoch <= sectorDeal.SectorExpiration,
+		acc.RequireInvariant(builtin.InvariantDealLastUpdated, deal.LastUpdatedEpoch <= sectorDeal.SectorExpiration,
+			builtin.ViolationFields{"actual": deal.LastUpdatedEpoch, "sector_expiration": sectorDeal.SectorExpiration, "miner": deal.Provider},
 			"deal state update at %d after sector expiration %d for miner %v",
 			deal.LastUpdatedEpoch, sectorDeal.SectorExpiration, deal.Provider)
 
-		acc.Require(deal.SlashEpoch <= sectorDeal.SectorExpiration,
+		acc.RequireInvariant(builtin.InvariantDealSlashEpoch, deal.SlashEpoch <= sectorDeal.SectorExpiration,
+			builtin.ViolationFields{"actual": deal.SlashEpoch, "sector_expiration": sectorDeal.SectorExpiration, "miner": deal.Provider},
 			"deal state slashed at %d after sector expiration %d for miner %v",
 			deal.SlashEpoch, sectorDeal.SectorExpiration, deal.Provider)
 
-		acc.Require((deal.SectorNumber == sectorDeal.SectorNumber) || (deal.SectorNumber == 0 && deal.SlashEpoch != -1) || (deal.SectorNumber == 0 && deal.EndEpoch < currEpoch),
+		acc.RequireInvariant(builtin.InvariantDealSectorNumber, (deal.SectorNumber == sectorDeal.SectorNumber) || (deal.SectorNumber == 0 && deal.SlashEpoch != -1) || (deal.SectorNumber == 0 && deal.EndEpoch < currEpoch),
+			builtin.ViolationFields{"actual": deal.SectorNumber, "expected": sectorDeal.SectorNumber, "miner": deal.Provider},
 			"deal sector number %d does not match sector %d for miner %v (ds: %#v; ss %#v)",
 			deal.SectorNumber, sectorDeal.SectorNumber, deal.Provider, deal, sectorDeal)
 	}
@@ -301,7 +388,9 @@ func CheckDealStatesAgainstSectors(acc *builtin.MessageAccumulator, minerSummari
 		for _, dealID := range dealIDs {
 
 			_, found := marketDealToSector[dealID]
-			acc.Require(!found, "deal %d found in multiple sectors", dealID)
+			acc.RequireInvariant(builtin.InvariantDealSectorUnique, !found,
+				builtin.ViolationFields{"deal": dealID},
+				"deal %d found in multiple sectors", dealID)
 
 			marketDealToSector[dealID] = sectorID
 		}
@@ -312,10 +401,12 @@ func CheckVerifregAgainstDatacap(acc *builtin.MessageAccumulator, verifregSummar
 	// Check verifiers and clients are disjoint.
 	for verifier := range verifregSummary.Verifiers {
 		actorId, err := address.IDFromAddress(verifier)
-		acc.RequireNoError(err, "error getting actor ID: %v", err)
+		acc.RequireInvariantNoError(builtin.InvariantIDAddress, err, "error getting actor ID: %v", err)
 
 		_, found := datacapSummary.Balances[abi.ActorID(actorId)]
-		acc.Require(!found, "verifier %v is also a client", verifier)
+		acc.RequireInvariant(builtin.InvariantVerifregVerifierClient, !found,
+			builtin.ViolationFields{"verifier": verifier},
+			"verifier %v is also a client", verifier)
 	}
 
 	// Check verifreg token balance matches unclaimed allocations
@@ -326,24 +417,30 @@ func CheckVerifregAgainstDatacap(acc *builtin.MessageAccumulator, verifregSummar
 
 	pendingAllocationsTotal = big.Mul(pendingAllocationsTotal, verifreg.DataCapGranularity)
 	verifregId, err := address.IDFromAddress(builtin.VerifiedRegistryActorAddr)
-	acc.RequireNoError(err, "could not get verifreg ID from address")
+	acc.RequireInvariantNoError(builtin.InvariantIDAddress, err, "could not get verifreg ID from address")
 	verifregBalance, found := datacapSummary.Balances[abi.ActorID(verifregId)]
 	if !found {
 		verifregBalance = big.Zero()
 	}
 
-	acc.Require(found, "verifreg not found in datacap actor balances map")
-	acc.Require(verifregBalance.Equals(pendingAllocationsTotal), "verifreg datacap balance %d does not match pending allocation size %d", verifregBalance, pendingAllocationsTotal)
+	// Token balances are positive, so verifreg only has a balance while allocations are pending.
+	acc.RequireInvariant(builtin.InvariantVerifregDatacapBalanceExists, found || pendingAllocationsTotal.IsZero(), nil,
+		"verifreg not found in datacap actor balances map")
+	acc.RequireInvariant(builtin.InvariantVerifregDatacapBalance, verifregBalance.Equals(pendingAllocationsTotal),
+		builtin.ViolationFields{"actual": verifregBalance, "expected": pendingAllocationsTotal},
+		"verifreg datacap balance %d does not match pending allocation size %d", verifregBalance, pendingAllocationsTotal)
 }
 
 func CheckVerifregAgainstMiners(acc *builtin.MessageAccumulator, verifregSummary *verifreg.StateSummary, minerSummaries map[address.Address]*miner.StateSummary) {
 	for _, claim := range verifregSummary.Claims {
 		// all claims are indexed by valid providers
 		maddr, err := address.NewIDAddress(uint64(claim.Provider))
-		acc.RequireNoError(err, "error creating ID address: %v", err)
+		acc.RequireInvariantNoError(builtin.InvariantIDAddress, err, "error creating ID address: %v", err)
 
 		_, ok := minerSummaries[maddr]
-		acc.Require(ok, "claim provider %s is not found in miner summaries", maddr)
+		acc.RequireInvariant(builtin.InvariantClaimProvider, ok,
+			builtin.ViolationFields{"provider": maddr},
+			"claim provider %s is not found in miner summaries", maddr)
 	}
 }
 
@@ -352,16 +449,24 @@ func CheckMarketAgainstVerifreg(acc *builtin.MessageAccumulator, verifregSummary
 	// note that it is possible for claims to exist with no matching deal if the deal expires
 	for claimId, dealId := range marketSummary.ClaimIdToDealId {
 		claim, found := verifregSummary.Claims[claimId]
-		acc.Require(found, "claim %d not found for activated deal %d", claimId, dealId)
+		acc.RequireInvariant(builtin.InvariantDealClaim, found,
+			builtin.ViolationFields{"claim": claimId, "deal": dealId},
+			"claim %d not found for activated deal %d", claimId, dealId)
 
 		info, found := marketSummary.Deals[dealId]
-		acc.Require(found, "internal invariant error invalid market state references missing deal %d", dealId)
+		acc.RequireInvariant(builtin.InvariantDealExists, found,
+			builtin.ViolationFields{"deal": dealId},
+			"internal invariant error invalid market state references missing deal %d", dealId)
 
 		providerId, err := address.IDFromAddress(info.Provider)
-		acc.RequireNoError(err, "error getting ID from provider address")
-		acc.Require(abi.ActorID(providerId) == claim.Provider, "mismatches providers %d %d on claim %d and deal %d", providerId, claim.Provider, claimId, dealId)
-
-		acc.Require(info.PieceCid == claim.Data, "mismatches piece cid %s %s on claim %d and deal %d", info.PieceCid, claim.Data, claimId, dealId)
+		acc.RequireInvariantNoError(builtin.InvariantIDAddress, err, "error getting ID from provider address")
+		acc.RequireInvariant(builtin.InvariantDealClaimProvider, abi.ActorID(providerId) == claim.Provider,
+			builtin.ViolationFields{"actual": providerId, "expected": claim.Provider, "claim": claimId, "deal": dealId},
+			"mismatches providers %d %d on claim %d and deal %d", providerId, claim.Provider, claimId, dealId)
+
+		acc.RequireInvariant(builtin.InvariantDealClaimPiece, info.PieceCid == claim.Data,
+			builtin.ViolationFields{"actual": info.PieceCid, "expected": claim.Data, "claim": claimId, "deal": dealId},
+			"mismatches piece cid %s %s on claim %d and deal %d", info.PieceCid, claim.Data, claimId, dealId)
 	}
 
 	// all pending deal allocation ids have an associated allocation
@@ -369,17 +474,25 @@ func CheckMarketAgainstVerifreg(acc *builtin.MessageAccumulator, verifregSummary
 	// if they are created from a direct DataCap transfer
 	for allocationId, dealId := range marketSummary.AllocIdToDealId {
 		alloc, found := verifregSummary.Allocations[allocationId]
-		acc.Require(found, "allocation %d not found for pending deal %d", allocationId, dealId)
+		acc.RequireInvariant(builtin.InvariantDealAllocation, found,
+			builtin.ViolationFields{"allocation": allocationId, "deal": dealId},
+			"allocation %d not found for pending deal %d", allocationId, dealId)
 		if !found {
 			continue
 		}
 		info, found := marketSummary.Deals[dealId]
-		acc.Require(found, "internal invariant error invalid market state references missing deal %d", dealId)
+		acc.RequireInvariant(builtin.InvariantDealExists, found,
+			builtin.ViolationFields{"deal": dealId},
+			"internal invariant error invalid market state references missing deal %d", dealId)
 
 		providerId, err := address.IDFromAddress(info.Provider)
-		acc.RequireNoError(err, "error getting ID from provider address")
-		acc.Require(abi.ActorID(providerId) == alloc.Provider, "mismatched providers %d %d on alloc %d and deal %d", providerId, alloc.Provider, allocationId, dealId)
-
-		acc.Require(info.PieceCid == alloc.Data, "mismatched piece cid %s %s on alloc %d and deal %d", info.PieceCid, alloc.Data, allocationId, dealId)
+		acc.RequireInvariantNoError(builtin.InvariantIDAddress, err, "error getting ID from provider address")
+		acc.RequireInvariant(builtin.InvariantDealAllocationProvider, abi.ActorID(providerId) == alloc.Provider,
+			builtin.ViolationFields{"actual": providerId, "expected": alloc.Provider, "allocation": allocationId, "deal": dealId},
+			"mismatched providers %d %d on alloc %d and deal %d", providerId, alloc.Provider, allocationId, dealId)
+
+		acc.RequireInvariant(builtin.InvariantDealAllocationPiece, info.PieceCid == alloc.Data,
+			builtin.ViolationFields{"actual": info.PieceCid, "expected": alloc.Data, "allocation": allocationId, "deal": dealId},
+			"mismatched piece cid %s %s on alloc %d and deal %d", info.PieceCid, alloc.Data, allocationId, dealId)
 	}
 }
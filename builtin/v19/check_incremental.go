@@ -0,0 +1,193 @@
+package v19
+
+import (
+	"bytes"
+
+	"github.com/ipfs/go-cid"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-address"
+	hamt "github.com/filecoin-project/go-hamt-ipld/v3"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/builtin"
+	"github.com/filecoin-project/go-state-types/builtin/v19/util/adt"
+)
+
+// Actors whose own invariants depend on the prior epoch, and so are rechecked at every new epoch
+// even if their state is unchanged.
+var epochDependentActors = []address.Address{
+	builtin.RewardActorAddr,
+	builtin.StorageMarketActorAddr,
+	builtin.VerifiedRegistryActorAddr,
+}
+
+// Checks the state tree invariants like CheckStateInvariants, re-using the per-actor results of
+// a prior check.
+// Only actors whose code, head, balance or delegated address differ from the state summarized by
+// prev are rechecked, along with the actors whose invariants depend on the epoch, and the init
+// actor if any actor was created, deleted or changed code. The cross-actor checks are then
+// recomputed from the summaries of all actors.
+// A nil prev, or one computed with different actor codes, results in a full check.
+// The returned summaries may be passed to the check of a subsequent state; prev is not modified.
+// Violations are reported in the same order as by CheckStateInvariants.
+//
+// Only state trees of actors version 19 are supported: prev must have been computed by this
+// package, and neither earlier actors versions nor a migration between versions are handled.
+func CheckStateInvariantsIncremental(tree *builtin.ActorTree, priorEpoch abi.ChainEpoch, actorCodes map[string]cid.Cid, prev *StateSummaries) (*builtin.MessageAccumulator, *StateSummaries, error) {
+	root, err := tree.Flush()
+	if err != nil {
+		return nil, nil, xerrors.Errorf("failed to flush state tree: %w", err)
+	}
+
+	var summaries *StateSummaries
+	if prev == nil || !sameActorCodes(prev.ActorCodes, actorCodes) {
+		if summaries, err = summarizeTree(tree, priorEpoch, actorCodes); err != nil {
+			return nil, nil, err
+		}
+	} else {
+		if summaries, err = updateSummaries(tree, root, priorEpoch, prev); err != nil {
+			return nil, nil, err
+		}
+	}
+	summaries.Root = root
+	return summaries.check(), summaries, nil
+}
+
+func updateSummaries(tree *builtin.ActorTree, root cid.Cid, priorEpoch abi.ChainEpoch, prev *StateSummaries) (*StateSummaries, error) {
+	emptyObjectCid, err := builtin.MakeEmptyState()
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := &StateSummaries{
+		PriorEpoch: priorEpoch,
+		ActorCodes: prev.ActorCodes,
+		Actors:     make(map[address.Address]*ActorSummary, len(prev.Actors)),
+		Keys:       prev.Keys,
+	}
+	for key, actor := range prev.Actors { // nolint:nomaprange
+		summaries.Actors[key] = actor
+	}
+
+	options := append(adt.DefaultHamtOptions, hamt.UseTreeBitWidth(builtin.DefaultHamtBitwidth))
+	changes, err := hamt.Diff(tree.Store.Context(), tree.Store, tree.Store, prev.Root, root, options...)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to diff state trees %v and %v: %w", prev.Root, root, err)
+	}
+
+	treeChanged, keysChanged := false, false
+	rechecked := make(map[address.Address]bool)
+	recheck := func(key address.Address, actor *builtin.ActorV5) error {
+		summary, err := checkActor(tree, key, actor, priorEpoch, prev.ActorCodes, emptyObjectCid)
+		if err != nil {
+			return err
+		}
+		summaries.Actors[key] = summary
+		rechecked[key] = true
+		return nil
+	}
+	recheckAt := func(key address.Address) error {
+		if rechecked[key] {
+			return nil
+		}
+		actor, found, err := tree.GetActorV5(key)
+		if err != nil {
+			return xerrors.Errorf("failed to load actor %v: %w", key, err)
+		}
+		if !found {
+			if _, cached := summaries.Actors[key]; cached {
+				delete(summaries.Actors, key)
+				keysChanged = true
+			}
+			return nil
+		}
+		return recheck(key, actor)
+	}
+
+	for _, change := range changes {
+		key, err := address.NewFromBytes([]byte(change.Key))
+		if err != nil {
+			return nil, xerrors.Errorf("invalid state tree key %x: %w", change.Key, err)
+		}
+
+		switch change.Type {
+		case hamt.Remove:
+			delete(summaries.Actors, key)
+			treeChanged, keysChanged = true, true
+		case hamt.Add, hamt.Modify:
+			var actor builtin.ActorV5
+			if err := actor.UnmarshalCBOR(bytes.NewReader(change.After.Raw)); err != nil {
+				return nil, xerrors.Errorf("failed to decode actor %v: %w", key, err)
+			}
+			cached, found := summaries.Actors[key]
+			if found && !actorStateChanged(&cached.Actor, &actor) {
+				// Only the call sequence number differs, which no invariant depends on.
+				updated := *cached
+				updated.Actor = actor
+				summaries.Actors[key] = &updated
+				continue
+			}
+			if !found {
+				keysChanged = true
+			}
+			if !found || cached.Actor.Code != actor.Code || !sameDelegatedAddress(&cached.Actor, &actor) {
+				treeChanged = true
+			}
+			if err := recheck(key, &actor); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if priorEpoch != prev.PriorEpoch {
+		for _, key := range epochDependentActors {
+			if err := recheckAt(key); err != nil {
+				return nil, err
+			}
+		}
+	}
+	// The init actor's invariants reference the code and delegated address of the actors it maps to.
+	if treeChanged {
+		if err := recheckAt(builtin.InitActorAddr); err != nil {
+			return nil, err
+		}
+	}
+	// The iteration order depends on the shape of the tree, so is recomputed when actors come or go.
+	if keysChanged {
+		summaries.Keys = nil
+		if err := tree.ForEachKey(func(key address.Address) error {
+			summaries.Keys = append(summaries.Keys, key)
+			return nil
+		}); err != nil {
+			return nil, xerrors.Errorf("failed to iterate state tree: %w", err)
+		}
+	}
+	return summaries, nil
+}
+
+// Whether any actor field that invariants depend on differs.
+func actorStateChanged(before, after *builtin.ActorV5) bool {
+	return before.Code != after.Code ||
+		before.Head != after.Head ||
+		!before.Balance.Equals(after.Balance) ||
+		!sameDelegatedAddress(before, after)
+}
+
+func sameDelegatedAddress(before, after *builtin.ActorV5) bool {
+	if before.DelegatedAddress == nil || after.DelegatedAddress == nil {
+		return before.DelegatedAddress == after.DelegatedAddress
+	}
+	return *before.DelegatedAddress == *after.DelegatedAddress
+}
+
+func sameActorCodes(a, b map[string]cid.Cid) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for name, code := range a { // nolint:nomaprange
+		if b[name] != code {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,99 @@
+package v19_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin"
+	v19 "github.com/filecoin-project/go-state-types/builtin/v19"
+	"github.com/filecoin-project/go-state-types/builtin/v19/miner"
+	"github.com/filecoin-project/go-state-types/builtin/v19/util/adt"
+	"github.com/filecoin-project/go-state-types/test_util"
+	"github.com/filecoin-project/go-state-types/test_util/synth"
+)
+
+func TestCheckStateInvariantsIncremental(t *testing.T) {
+	ctx := context.Background()
+	store := adt.WrapStore(ctx, cbor.NewCborStore(test_util.NewSyncBlockStoreInMemory()))
+	cfg := synth.DefaultConfig()
+	cfg.SectorsPerMiner = 50
+	res, err := synth.Generate(ctx, store, cfg)
+	require.NoError(t, err)
+
+	tree, err := builtin.LoadTree(store, res.StateRoot)
+	require.NoError(t, err)
+	acc, summaries, err := v19.CheckStateInvariantsIncremental(tree, cfg.PriorEpoch, res.ActorCodes, nil)
+	require.NoError(t, err)
+	require.True(t, acc.IsEmpty(), acc.Messages())
+	require.Equal(t, res.StateRoot, summaries.Root)
+
+	var minerAddr address.Address
+	for key, actor := range summaries.Actors {
+		if _, ok := actor.Summary.(*miner.StateSummary); ok {
+			minerAddr = key
+			break
+		}
+	}
+	require.NotEqual(t, address.Undef, minerAddr)
+
+	// Break the miner's balance invariant and check the incremental result matches a full check.
+	actor, found, err := tree.GetActorV5(minerAddr)
+	require.NoError(t, err)
+	require.True(t, found)
+	balance := actor.Balance
+	actor.Balance = big.NewInt(-1)
+	require.NoError(t, tree.SetActorV5(minerAddr, actor))
+
+	incAcc, incSummaries, err := v19.CheckStateInvariantsIncremental(tree, cfg.PriorEpoch+1, res.ActorCodes, summaries)
+	require.NoError(t, err)
+	fullAcc, err := v19.CheckStateInvariants(tree, cfg.PriorEpoch+1, res.ActorCodes)
+	require.NoError(t, err)
+	require.False(t, incAcc.IsEmpty())
+	require.Equal(t, fullAcc.Messages(), incAcc.Messages())
+
+	// The previous summaries are unchanged.
+	require.Equal(t, res.StateRoot, summaries.Root)
+	require.True(t, summaries.Actors[minerAddr].Messages.IsEmpty())
+	require.False(t, incSummaries.Actors[minerAddr].Messages.IsEmpty())
+
+	// Restoring the balance and the epoch clears the violations.
+	actor.Balance = balance
+	actor.CallSeqNum++
+	require.NoError(t, tree.SetActorV5(minerAddr, actor))
+	acc, summaries, err = v19.CheckStateInvariantsIncremental(tree, cfg.PriorEpoch, res.ActorCodes, incSummaries)
+	require.NoError(t, err)
+	require.True(t, acc.IsEmpty(), acc.Messages())
+
+	// Violations of added actors are reported in the same order as by a full check.
+	for id := uint64(10000); id < 10010; id++ {
+		addr, err := address.NewIDAddress(id)
+		require.NoError(t, err)
+		added := *actor
+		added.Balance = big.NewInt(-1)
+		require.NoError(t, tree.SetActorV5(addr, &added))
+	}
+	incAcc, _, err = v19.CheckStateInvariantsIncremental(tree, cfg.PriorEpoch, res.ActorCodes, summaries)
+	require.NoError(t, err)
+	fullAcc, err = v19.CheckStateInvariants(tree, cfg.PriorEpoch, res.ActorCodes)
+	require.NoError(t, err)
+	require.False(t, incAcc.IsEmpty())
+	// The cross-actor checks iterate over maps, so only the per-actor violations have a stable order.
+	require.Equal(t, actorMessages(fullAcc), actorMessages(incAcc))
+	require.ElementsMatch(t, fullAcc.Messages(), incAcc.Messages())
+}
+
+// Returns the messages of the violations of individual actors, in order.
+func actorMessages(acc *builtin.MessageAccumulator) []string {
+	var out []string
+	for _, v := range acc.Violations() {
+		if v.Actor != address.Undef {
+			out = append(out, v.String())
+		}
+	}
+	return out
+}
@@ -0,0 +1,38 @@
+package v19_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin"
+	v19 "github.com/filecoin-project/go-state-types/builtin/v19"
+	"github.com/filecoin-project/go-state-types/builtin/v19/datacap"
+	"github.com/filecoin-project/go-state-types/builtin/v19/verifreg"
+)
+
+func TestCheckVerifregAgainstDatacap(t *testing.T) {
+	id, err := address.IDFromAddress(builtin.VerifiedRegistryActorAddr)
+	require.NoError(t, err)
+	verifregID := abi.ActorID(id)
+	pending := &verifreg.StateSummary{Allocations: map[verifreg.AllocationId]verifreg.Allocation{
+		1: {Client: 100, Provider: 1000, Size: 1 << 20},
+	}}
+	held := big.Mul(big.NewInt(1<<20), verifreg.DataCapGranularity)
+
+	// The datacap token drops zero balances, so verifreg has none without pending allocations.
+	acc := &builtin.MessageAccumulator{}
+	v19.CheckVerifregAgainstDatacap(acc, &verifreg.StateSummary{}, &datacap.StateSummary{Balances: map[abi.ActorID]abi.TokenAmount{}})
+	require.True(t, acc.IsEmpty(), acc.Messages())
+
+	acc = &builtin.MessageAccumulator{}
+	v19.CheckVerifregAgainstDatacap(acc, pending, &datacap.StateSummary{Balances: map[abi.ActorID]abi.TokenAmount{verifregID: held}})
+	require.True(t, acc.IsEmpty(), acc.Messages())
+
+	acc = &builtin.MessageAccumulator{}
+	v19.CheckVerifregAgainstDatacap(acc, pending, &datacap.StateSummary{Balances: map[abi.ActorID]abi.TokenAmount{}})
+	require.False(t, acc.IsEmpty())
+}
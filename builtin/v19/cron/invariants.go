@@ -17,8 +17,12 @@ func CheckStateInvariants(st *State, _ adt.Store) (*StateSummary, *builtin.Messa
 		EntryCount: len(st.Entries),
 	}
 	for i, e := range st.Entries {
-		acc.Require(e.Receiver.Protocol() == address.ID, "entry %d receiver address %v must be ID protocol", i, e.Receiver)
-		acc.Require(e.MethodNum > 0, "entry %d has invalid method number %d", i, e.MethodNum)
+		acc.RequireInvariant(builtin.InvariantCronEntryReceiver, e.Receiver.Protocol() == address.ID,
+			builtin.ViolationFields{"entry": i, "receiver": e.Receiver},
+			"entry %d receiver address %v must be ID protocol", i, e.Receiver)
+		acc.RequireInvariant(builtin.InvariantCronEntryMethod, e.MethodNum > 0,
+			builtin.ViolationFields{"entry": i, "method": e.MethodNum},
+			"entry %d has invalid method number %d", i, e.MethodNum)
 	}
 	return cronSummary, acc
 }
@@ -16,16 +16,20 @@ import (
 func CheckStateInvariants(st *State, store adt.Store) *builtin.MessageAccumulator {
 	acc := &builtin.MessageAccumulator{}
 
-	acc.Require(st.Nonce > 0, "EVM actor state nonce needs to be greater than 0")
+	acc.RequireInvariant(builtin.InvariantEVMNonce, st.Nonce > 0,
+		builtin.ViolationFields{"actual": st.Nonce},
+		"EVM actor state nonce needs to be greater than 0")
 
 	byteCode, err := getBytecode(st.Bytecode, store)
-	acc.RequireNoError(err, "Unable to retrieve bytecode")
+	acc.RequireInvariantNoError(builtin.InvariantEVMStateLoadable, err, "Unable to retrieve bytecode")
 
 	hasher := keccak.NewLegacyKeccak256()
 	hasher.Write(byteCode)
 	byteCodeHash := hasher.Sum(nil)
 
-	acc.Require(bytes.Equal(byteCodeHash, st.BytecodeHash[:]), "Bytecode hash doesn't match bytecode cid, bytecode_hash: %x hash from bytecode cid: %x", st.BytecodeHash, byteCodeHash)
+	acc.RequireInvariant(builtin.InvariantEVMBytecodeHash, bytes.Equal(byteCodeHash, st.BytecodeHash[:]),
+		builtin.ViolationFields{"actual": st.BytecodeHash, "expected": byteCodeHash},
+		"Bytecode hash doesn't match bytecode cid, bytecode_hash: %x hash from bytecode cid: %x", st.BytecodeHash, byteCodeHash)
 
 	return acc
 }
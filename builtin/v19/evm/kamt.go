@@ -0,0 +1,319 @@
+package evm
+
+import (
+	"io"
+
+	"github.com/ipfs/go-cid"
+	cbg "github.com/whyrusleeping/cbor-gen"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/builtin/v19/util/adt"
+)
+
+// The configuration of the KAMT holding contract storage, as set by the EVM actor.
+const (
+	kamtBitWidth = 5
+	// The maximum number of nodes traversed by a lookup: the 256 bits of a key consumed 5 at a time.
+	kamtMaxDepth = (256 + kamtBitWidth - 1) / kamtBitWidth
+)
+
+// A read-only view of a KAMT<U256, U256> node.
+// Keys are not hashed: the bits indexing each level are read from the big-endian key, so iteration
+// visits keys in increasing order.
+type kamtNode struct {
+	bitfield []byte
+	pointers []kamtPointer
+}
+
+type kamtPointer struct {
+	// Either a link to a child node, preceded by an extension of the path, or key-value pairs.
+	link  cid.Cid
+	ext   kamtExtension
+	pairs []kamtPair
+}
+
+// An extension skips levels of the tree holding a single child, storing the bits of the path they
+// would have consumed.
+type kamtExtension struct {
+	bits uint64
+	path []byte
+}
+
+type kamtPair struct {
+	key   [32]byte
+	value [32]byte
+}
+
+func (n *kamtNode) UnmarshalCBOR(r io.Reader) error {
+	item, err := readCBORItem(cbg.NewCborReader(r), 0)
+	if err != nil {
+		return err
+	}
+	fields, ok := item.([]interface{})
+	if !ok || len(fields) != 2 {
+		return xerrors.Errorf("KAMT node is not a tuple of 2 fields")
+	}
+	if n.bitfield, ok = fields[0].([]byte); !ok {
+		return xerrors.Errorf("KAMT node bitfield is not a byte string")
+	}
+	pointers, ok := fields[1].([]interface{})
+	if !ok {
+		return xerrors.Errorf("KAMT node pointers are not a list")
+	}
+	n.pointers = make([]kamtPointer, len(pointers))
+	for i, p := range pointers {
+		if err := n.pointers[i].decode(p); err != nil {
+			return xerrors.Errorf("KAMT pointer %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// Pointers are encoded as an untagged union, as by fvm_ipld_kamt: a link, a pair of an extension and
+// a link, or a list of key-value pairs.
+func (p *kamtPointer) decode(item interface{}) error {
+	if c, ok := item.(cid.Cid); ok {
+		p.link = c
+		return nil
+	}
+	list, ok := item.([]interface{})
+	if !ok {
+		return xerrors.Errorf("unexpected pointer %T", item)
+	}
+	if len(list) == 2 {
+		if c, ok := list[1].(cid.Cid); ok {
+			p.link = c
+			return p.ext.decode(list[0])
+		}
+	}
+	for _, elem := range list {
+		kv, ok := elem.([]interface{})
+		if !ok || len(kv) != 2 {
+			return xerrors.Errorf("key-value pair is not a tuple of 2 fields")
+		}
+		key, err := decodeU256(kv[0])
+		if err != nil {
+			return xerrors.Errorf("key: %w", err)
+		}
+		value, err := decodeU256(kv[1])
+		if err != nil {
+			return xerrors.Errorf("value: %w", err)
+		}
+		p.pairs = append(p.pairs, kamtPair{key: key, value: value})
+	}
+	return nil
+}
+
+// Extensions are encoded as a tuple of the number of bits consumed and the path.
+func (e *kamtExtension) decode(item interface{}) error {
+	v, ok := item.([]interface{})
+	if !ok || len(v) != 2 {
+		return xerrors.Errorf("extension is not a tuple of 2 fields")
+	}
+	bits, ok := v[0].(uint64)
+	path, ok2 := v[1].([]byte)
+	if !ok || !ok2 {
+		return xerrors.Errorf("malformed extension")
+	}
+	e.bits, e.path = bits, path
+	if uint64(len(e.path))*8 < e.bits {
+		return xerrors.Errorf("extension of %d bits has a %d byte path", e.bits, len(e.path))
+	}
+	return nil
+}
+
+// U256 values are encoded as big-endian byte strings without leading zeros.
+func decodeU256(item interface{}) ([32]byte, error) {
+	var out [32]byte
+	b, ok := item.([]byte)
+	if !ok {
+		return out, xerrors.Errorf("U256 is not a byte string")
+	}
+	if len(b) > 32 {
+		return out, xerrors.Errorf("U256 of %d bytes", len(b))
+	}
+	copy(out[32-len(b):], b)
+	return out, nil
+}
+
+// Reads bits of a key, most significant first.
+type keyBits struct {
+	key      [32]byte
+	consumed uint64
+}
+
+func (k *keyBits) next(n uint64) (uint64, error) {
+	if k.consumed+n > 256 {
+		n = 256 - k.consumed
+		if n == 0 {
+			return 0, xerrors.Errorf("key bits exhausted")
+		}
+	}
+	var out uint64
+	for i := uint64(0); i < n; i++ {
+		out = out<<1 | bit(k.key[:], k.consumed+i)
+	}
+	k.consumed += n
+	return out, nil
+}
+
+// Consumes the extension's bits from the key, returning whether they match.
+func (k *keyBits) matchExtension(e kamtExtension) bool {
+	if k.consumed+e.bits > 256 {
+		return false
+	}
+	for i := uint64(0); i < e.bits; i++ {
+		if bit(k.key[:], k.consumed+i) != bit(e.path, i) {
+			return false
+		}
+	}
+	k.consumed += e.bits
+	return true
+}
+
+func bit(b []byte, i uint64) uint64 {
+	return uint64(b[i/8]>>(7-i%8)) & 1
+}
+
+// Returns whether bit i is set in a bitfield encoded as a big-endian integer, and the index of its
+// pointer: the number of lower bits set.
+func (n *kamtNode) index(i uint64) (int, bool) {
+	set := func(j uint64) bool {
+		byteIdx := len(n.bitfield) - 1 - int(j/8)
+		return byteIdx >= 0 && n.bitfield[byteIdx]&(1<<(j%8)) != 0
+	}
+	if !set(i) {
+		return 0, false
+	}
+	count := 0
+	for j := uint64(0); j < i; j++ {
+		if set(j) {
+			count++
+		}
+	}
+	return count, true
+}
+
+// Returns the value of a key in the KAMT rooted at a node.
+func kamtGet(store adt.Store, root cid.Cid, key [32]byte) ([32]byte, bool, error) {
+	bits := keyBits{key: key}
+	c := root
+	for depth := 0; depth <= kamtMaxDepth; depth++ {
+		var node kamtNode
+		if err := store.Get(store.Context(), c, &node); err != nil {
+			return [32]byte{}, false, xerrors.Errorf("failed to load KAMT node %s: %w", c, err)
+		}
+		idx, err := bits.next(kamtBitWidth)
+		if err != nil {
+			return [32]byte{}, false, err
+		}
+		i, ok := node.index(idx)
+		if !ok {
+			return [32]byte{}, false, nil
+		}
+		if i >= len(node.pointers) {
+			return [32]byte{}, false, xerrors.Errorf("KAMT node %s bitfield exceeds its %d pointers", c, len(node.pointers))
+		}
+		p := node.pointers[i]
+		if !p.link.Defined() {
+			for _, kv := range p.pairs {
+				if kv.key == key {
+					return kv.value, true, nil
+				}
+			}
+			return [32]byte{}, false, nil
+		}
+		if !bits.matchExtension(p.ext) {
+			return [32]byte{}, false, nil
+		}
+		c = p.link
+	}
+	return [32]byte{}, false, xerrors.Errorf("KAMT deeper than %d levels", kamtMaxDepth)
+}
+
+// Visits the key-value pairs of the KAMT rooted at a node, in increasing key order.
+func kamtForEach(store adt.Store, root cid.Cid, depth int, cb func(key, value [32]byte) error) error {
+	if depth > kamtMaxDepth {
+		return xerrors.Errorf("KAMT deeper than %d levels", kamtMaxDepth)
+	}
+	var node kamtNode
+	if err := store.Get(store.Context(), root, &node); err != nil {
+		return xerrors.Errorf("failed to load KAMT node %s: %w", root, err)
+	}
+	for _, p := range node.pointers {
+		if p.link.Defined() {
+			if err := kamtForEach(store, p.link, depth+1, cb); err != nil {
+				return err
+			}
+			continue
+		}
+		for _, kv := range p.pairs {
+			if err := cb(kv.key, kv.value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// The maximum nesting of CBOR arrays in a KAMT node.
+const maxCBORDepth = 4
+
+// Reads a CBOR item as unsigned integers, byte strings, CIDs and lists, the only types appearing in
+// a KAMT node.
+func readCBORItem(br *cbg.CborReader, depth int) (interface{}, error) {
+	if depth > maxCBORDepth {
+		return nil, xerrors.Errorf("CBOR nested deeper than %d", maxCBORDepth)
+	}
+	maj, extra, err := br.ReadHeader()
+	if err != nil {
+		return nil, err
+	}
+	switch maj {
+	case cbg.MajUnsignedInt:
+		return extra, nil
+	case cbg.MajByteString:
+		if extra > cbg.ByteArrayMaxLen {
+			return nil, xerrors.Errorf("byte string too large (%d)", extra)
+		}
+		buf := make([]byte, extra)
+		if _, err := io.ReadFull(br, buf); err != nil {
+			return nil, err
+		}
+		return buf, nil
+	case cbg.MajArray:
+		if extra > cbg.MaxLength {
+			return nil, xerrors.Errorf("array too large (%d)", extra)
+		}
+		out := make([]interface{}, 0, extra)
+		for i := uint64(0); i < extra; i++ {
+			item, err := readCBORItem(br, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, item)
+		}
+		return out, nil
+	case cbg.MajTag:
+		if extra != 42 {
+			return nil, xerrors.Errorf("unexpected CBOR tag %d", extra)
+		}
+		maj, extra, err := br.ReadHeader()
+		if err != nil {
+			return nil, err
+		}
+		if maj != cbg.MajByteString || extra == 0 || extra > cbg.ByteArrayMaxLen {
+			return nil, xerrors.Errorf("malformed CID")
+		}
+		buf := make([]byte, extra)
+		if _, err := io.ReadFull(br, buf); err != nil {
+			return nil, err
+		}
+		if buf[0] != 0 {
+			return nil, xerrors.Errorf("CID has invalid multibase prefix %d", buf[0])
+		}
+		return cid.Cast(buf[1:])
+	default:
+		return nil, xerrors.Errorf("unexpected CBOR major type %d", maj)
+	}
+}
@@ -0,0 +1,51 @@
+package evm
+
+import (
+	"bytes"
+
+	"github.com/filecoin-project/go-keccak"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/builtin/v19/util/adt"
+)
+
+// GetStorage returns the value of a contract storage slot, keyed by a big-endian U256.
+// Slots that were never written, or were cleared, are reported as not found; the EVM reads them as zero.
+func (st *State) GetStorage(store adt.Store, key [32]byte) ([32]byte, bool, error) {
+	value, found, err := kamtGet(store, st.ContractState, key)
+	if err != nil {
+		return [32]byte{}, false, xerrors.Errorf("failed to get storage slot %x: %w", key, err)
+	}
+	return value, found, nil
+}
+
+// ForEachStorage calls a function for each non-zero contract storage slot, in increasing key order.
+func (st *State) ForEachStorage(store adt.Store, cb func(key, value [32]byte) error) error {
+	return kamtForEach(store, st.ContractState, 0, cb)
+}
+
+// GetBytecode returns the contract's bytecode.
+func (st *State) GetBytecode(store adt.Store) ([]byte, error) {
+	return getBytecode(st.Bytecode, store)
+}
+
+// VerifyBytecodeHash checks that BytecodeHash is the Keccak-256 digest of the contract's bytecode.
+func (st *State) VerifyBytecodeHash(store adt.Store) error {
+	bytecode, err := st.GetBytecode(store)
+	if err != nil {
+		return err
+	}
+	hasher := keccak.NewLegacyKeccak256()
+	hasher.Write(bytecode)
+	if digest := hasher.Sum(nil); !bytes.Equal(digest, st.BytecodeHash[:]) {
+		return xerrors.Errorf("bytecode hash %x doesn't match hash of bytecode %x", st.BytecodeHash, digest)
+	}
+	return nil
+}
+
+// IsDead returns whether the contract has self-destructed, as seen by a message with the given
+// origin and nonce. A contract remains alive for the rest of the transaction that destroyed it.
+func (st *State) IsDead(origin abi.ActorID, nonce uint64) bool {
+	return st.Tombstone != nil && *st.Tombstone != (Tombstone{Origin: origin, Nonce: nonce})
+}
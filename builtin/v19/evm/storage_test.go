@@ -0,0 +1,241 @@
+package evm
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+	"testing"
+
+	"github.com/filecoin-project/go-keccak"
+	block "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	"github.com/stretchr/testify/require"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/builtin/v19/util/adt"
+	"github.com/filecoin-project/go-state-types/test_util"
+)
+
+func TestStorage(t *testing.T) {
+	store := adt.WrapStore(context.Background(), cbor.NewCborStore(test_util.NewBlockStoreInMemory()))
+
+	key := func(b ...byte) [32]byte {
+		var k [32]byte
+		copy(k[:], b)
+		return k
+	}
+	value := func(v byte) [32]byte {
+		var out [32]byte
+		out[31] = v
+		return out
+	}
+	k1, k2 := key(0x00, 0x01), key(0x00, 0x02)
+	k3 := key(0xf8)
+	// Bits 00001 index the root, 101 the extension, then 00011 the child.
+	k4 := key(0x0d, 0x18)
+
+	child, err := store.Put(store.Context(), &testKamtNode{
+		bitfield: []byte{0x08},
+		pointers: []kamtPointer{{pairs: []kamtPair{{k4, value(4)}}}},
+	})
+	require.NoError(t, err)
+	root, err := store.Put(store.Context(), &testKamtNode{
+		bitfield: []byte{0x80, 0x00, 0x00, 0x03},
+		pointers: []kamtPointer{
+			{pairs: []kamtPair{{k1, value(1)}, {k2, value(2)}}},
+			{link: child, ext: kamtExtension{bits: 3, path: []byte{0xa0}}},
+			{pairs: []kamtPair{{k3, value(3)}}},
+		},
+	})
+	require.NoError(t, err)
+
+	st := &State{ContractState: root}
+	for k, v := range map[[32]byte][32]byte{k1: value(1), k2: value(2), k3: value(3), k4: value(4)} {
+		got, found, err := st.GetStorage(store, k)
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Equal(t, v, got)
+	}
+	// Absent from a leaf, a missing index, and diverging from an extension.
+	for _, k := range [][32]byte{key(0x00, 0x03), key(0x10), key(0x0c, 0x18)} {
+		_, found, err := st.GetStorage(store, k)
+		require.NoError(t, err)
+		require.False(t, found)
+	}
+
+	var keys [][32]byte
+	require.NoError(t, st.ForEachStorage(store, func(k, v [32]byte) error {
+		keys = append(keys, k)
+		return nil
+	}))
+	require.Equal(t, [][32]byte{k1, k2, k4, k3}, keys)
+
+	// A newly constructed contract has empty storage.
+	bytecode := abi.CborBytesTransparent{0x60, 0x80, 0x60, 0x40}
+	bytecodeCid, err := store.Put(store.Context(), &bytecode)
+	require.NoError(t, err)
+	st, err = ConstructState(store, bytecodeCid)
+	require.NoError(t, err)
+	_, found, err := st.GetStorage(store, k1)
+	require.NoError(t, err)
+	require.False(t, found)
+	require.NoError(t, st.ForEachStorage(store, func(k, v [32]byte) error {
+		t.Fatalf("unexpected slot %x", k)
+		return nil
+	}))
+
+	got, err := st.GetBytecode(store)
+	require.NoError(t, err)
+	require.Equal(t, []byte(bytecode), got)
+	require.Error(t, st.VerifyBytecodeHash(store))
+	hasher := keccak.NewLegacyKeccak256()
+	hasher.Write(bytecode)
+	copy(st.BytecodeHash[:], hasher.Sum(nil))
+	require.NoError(t, st.VerifyBytecodeHash(store))
+
+	require.False(t, st.IsDead(100, 1))
+	st.Tombstone = &Tombstone{Origin: 100, Nonce: 1}
+	require.False(t, st.IsDead(100, 1))
+	require.True(t, st.IsDead(100, 2))
+	require.True(t, st.IsDead(101, 1))
+}
+
+// The blocks of a contract storage KAMT, encoded by hand following the fvm_ipld_kamt serialization:
+// slots 0 and 1 share a bucket of the root, two slots under 0x84 sit behind a link with a 3 bit
+// extension, and the last slot has its own bucket.
+const (
+	kamtFixtureChild = "82410181828258208400000000000000000000000000000000000000000000000000000000000005410a8258208400000000000000000000000000000000000000000000000000000000000006410b"
+	kamtFixtureRoot  = "82448001000183828240412a8241014201008282034180d82a5827000171a0e40220c665e2fa1869ffb7799e95addcdfabe5fc02855e4bc8877478bfbbea1ada393881825820ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff44deadbeef"
+)
+
+func TestStorageFixture(t *testing.T) {
+	bs := test_util.NewBlockStoreInMemory()
+	store := adt.WrapStore(context.Background(), cbor.NewCborStore(bs))
+	put := func(h string) cid.Cid {
+		data, err := hex.DecodeString(h)
+		require.NoError(t, err)
+		c, err := abi.CidBuilder.Sum(data)
+		require.NoError(t, err)
+		blk, err := block.NewBlockWithCid(data, c)
+		require.NoError(t, err)
+		require.NoError(t, bs.Put(context.Background(), blk))
+		return c
+	}
+	put(kamtFixtureChild)
+	st := &State{ContractState: put(kamtFixtureRoot)}
+
+	slot := func(hi byte, lo uint64) [32]byte {
+		var k [32]byte
+		k[0] = hi
+		binary.BigEndian.PutUint64(k[24:], lo)
+		return k
+	}
+	all := slot(0xff, ^uint64(0))
+	for i := 1; i < 24; i++ {
+		all[i] = 0xff
+	}
+	expected := [][2][32]byte{
+		{slot(0, 0), slot(0, 0x2a)},
+		{slot(0, 1), slot(0, 0x100)},
+		{slot(0x84, 5), slot(0, 0x0a)},
+		{slot(0x84, 6), slot(0, 0x0b)},
+		{all, slot(0, 0xdeadbeef)},
+	}
+	var got [][2][32]byte
+	require.NoError(t, st.ForEachStorage(store, func(k, v [32]byte) error {
+		got = append(got, [2][32]byte{k, v})
+		return nil
+	}))
+	require.Equal(t, expected, got)
+	for _, kv := range expected {
+		v, found, err := st.GetStorage(store, kv[0])
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Equal(t, kv[1], v)
+	}
+
+	// A link with an extension is the tuple of the extension and the link, and other encodings are rejected.
+	data, err := hex.DecodeString("82440001000081" + "8282034180d82a5827000171a0e40220c665e2fa1869ffb7799e95addcdfabe5fc02855e4bc8877478bfbbea1ada3938")
+	require.NoError(t, err)
+	var node kamtNode
+	require.NoError(t, node.UnmarshalCBOR(bytes.NewReader(data)))
+	require.Equal(t, kamtExtension{bits: 3, path: []byte{0x80}}, node.pointers[0].ext)
+	for _, ptr := range []string{
+		// The extension as a byte string of the number of bits followed by the path.
+		"82420380d82a5827000171a0e40220c665e2fa1869ffb7799e95addcdfabe5fc02855e4bc8877478bfbbea1ada3938",
+		// The link before the extension.
+		"82d82a5827000171a0e40220c665e2fa1869ffb7799e95addcdfabe5fc02855e4bc8877478bfbbea1ada393882034180",
+	} {
+		// A node with a single pointer at index 16.
+		data, err := hex.DecodeString("82440001000081" + ptr)
+		require.NoError(t, err)
+		var node kamtNode
+		require.Error(t, node.UnmarshalCBOR(bytes.NewReader(data)))
+	}
+}
+
+// Encodes KAMT nodes as fvm_ipld_kamt does.
+type testKamtNode kamtNode
+
+func (n *testKamtNode) MarshalCBOR(w io.Writer) error {
+	var buf bytes.Buffer
+	cw := cbg.NewCborWriter(&buf)
+	if err := cw.WriteMajorTypeHeader(cbg.MajArray, 2); err != nil {
+		return err
+	}
+	if err := cbg.WriteByteArray(cw, n.bitfield); err != nil {
+		return err
+	}
+	if err := cw.WriteMajorTypeHeader(cbg.MajArray, uint64(len(n.pointers))); err != nil {
+		return err
+	}
+	for _, p := range n.pointers {
+		switch {
+		case p.link.Defined() && p.ext.bits == 0:
+			if err := cbg.WriteCid(cw, p.link); err != nil {
+				return err
+			}
+		case p.link.Defined():
+			if err := cw.WriteMajorTypeHeader(cbg.MajArray, 2); err != nil {
+				return err
+			}
+			if err := cw.WriteMajorTypeHeader(cbg.MajArray, 2); err != nil {
+				return err
+			}
+			if err := cw.WriteMajorTypeHeader(cbg.MajUnsignedInt, p.ext.bits); err != nil {
+				return err
+			}
+			if err := cbg.WriteByteArray(cw, p.ext.path); err != nil {
+				return err
+			}
+			if err := cbg.WriteCid(cw, p.link); err != nil {
+				return err
+			}
+		default:
+			if err := cw.WriteMajorTypeHeader(cbg.MajArray, uint64(len(p.pairs))); err != nil {
+				return err
+			}
+			for _, kv := range p.pairs {
+				if err := cw.WriteMajorTypeHeader(cbg.MajArray, 2); err != nil {
+					return err
+				}
+				if err := cbg.WriteByteArray(cw, bytes.TrimLeft(kv.key[:], "\x00")); err != nil {
+					return err
+				}
+				if err := cbg.WriteByteArray(cw, bytes.TrimLeft(kv.value[:], "\x00")); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+func (n *testKamtNode) UnmarshalCBOR(r io.Reader) error {
+	return (*kamtNode)(n).UnmarshalCBOR(r)
+}
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"path/filepath"
+
 	gen "github.com/whyrusleeping/cbor-gen"
 
 	"github.com/filecoin-project/go-state-types/builtin/v19/account"
@@ -18,17 +20,18 @@ import (
 	"github.com/filecoin-project/go-state-types/builtin/v19/system"
 	"github.com/filecoin-project/go-state-types/builtin/v19/util/smoothing"
 	"github.com/filecoin-project/go-state-types/builtin/v19/verifreg"
+	"github.com/filecoin-project/go-state-types/ipld/schemagen"
 )
 
 func main() {
-	if err := gen.WriteTupleEncodersToFile("./builtin/v19/system/cbor_gen.go", "system",
+	if err := writeTupleEncoders("./builtin/v19/system", "system",
 		// actor state
 		system.State{},
 	); err != nil {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v19/account/cbor_gen.go", "account",
+	if err := writeTupleEncoders("./builtin/v19/account", "account",
 		// actor state
 		account.State{},
 		// method params and returns
@@ -37,7 +40,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v19/cron/cbor_gen.go", "cron",
+	if err := writeTupleEncoders("./builtin/v19/cron", "cron",
 		// actor state
 		cron.State{},
 		cron.Entry{},
@@ -46,7 +49,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v19/reward/cbor_gen.go", "reward",
+	if err := writeTupleEncoders("./builtin/v19/reward", "reward",
 		// actor state
 		reward.State{},
 		// method params and returns
@@ -56,7 +59,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v19/multisig/cbor_gen.go", "multisig",
+	if err := writeTupleEncoders("./builtin/v19/multisig", "multisig",
 		// actor state
 		multisig.State{},
 		multisig.Transaction{},
@@ -76,7 +79,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v19/paych/cbor_gen.go", "paych",
+	if err := writeTupleEncoders("./builtin/v19/paych", "paych",
 		// actor state
 		paych.State{},
 		paych.LaneState{},
@@ -91,7 +94,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v19/power/cbor_gen.go", "power",
+	if err := writeTupleEncoders("./builtin/v19/power", "power",
 		// actors state
 		power.State{},
 		power.Claim{},
@@ -110,7 +113,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v19/market/cbor_gen.go", "market",
+	if err := writeTupleEncoders("./builtin/v19/market", "market",
 		// actor state
 		market.State{},
 		market.DealState{},
@@ -142,7 +145,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v19/miner/cbor_gen.go", "miner",
+	if err := writeTupleEncoders("./builtin/v19/miner", "miner",
 		// actor state
 		miner.State{},
 		miner.MinerInfo{},
@@ -227,7 +230,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v19/verifreg/cbor_gen.go", "verifreg",
+	if err := writeTupleEncoders("./builtin/v19/verifreg", "verifreg",
 		// actor state
 		verifreg.State{},
 
@@ -266,7 +269,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v19/datacap/cbor_gen.go", "datacap",
+	if err := writeTupleEncoders("./builtin/v19/datacap", "datacap",
 		// actor state
 		datacap.State{},
 		datacap.TokenState{},
@@ -291,13 +294,13 @@ func main() {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v19/util/smoothing/cbor_gen.go", "smoothing",
+	if err := writeTupleEncoders("./builtin/v19/util/smoothing", "smoothing",
 		smoothing.FilterEstimate{},
 	); err != nil {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v19/init/cbor_gen.go", "init",
+	if err := writeTupleEncoders("./builtin/v19/init", "init",
 		// actor state
 		init_.State{},
 		// method params and returns
@@ -309,7 +312,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v19/evm/cbor_gen.go", "evm",
+	if err := writeTupleEncoders("./builtin/v19/evm", "evm",
 		// actor state
 		evm.Tombstone{},
 		evm.TransientDataLifespan{},
@@ -323,7 +326,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v19/eam/cbor_gen.go", "eam",
+	if err := writeTupleEncoders("./builtin/v19/eam", "eam",
 		// method params and returns
 		eam.CreateParams{},
 		eam.CreateReturn{},
@@ -334,3 +337,12 @@ func main() {
 		panic(err)
 	}
 }
+
+// writeTupleEncoders generates the CBOR tuple encoders of types from the package in dir, along with
+// the IPLD schema describing them.
+func writeTupleEncoders(dir, pkg string, types ...interface{}) error {
+	if err := gen.WriteTupleEncodersToFile(filepath.Join(dir, "cbor_gen.go"), pkg, types...); err != nil {
+		return err
+	}
+	return schemagen.WriteSchemaToDir(dir, pkg, types...)
+}
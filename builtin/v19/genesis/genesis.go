@@ -0,0 +1,612 @@
+package genesis
+
+import (
+	"context"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/ipfs/go-cid"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	cbg "github.com/whyrusleeping/cbor-gen"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/actors"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin"
+	"github.com/filecoin-project/go-state-types/builtin/v19/account"
+	"github.com/filecoin-project/go-state-types/builtin/v19/cron"
+	"github.com/filecoin-project/go-state-types/builtin/v19/datacap"
+	init_ "github.com/filecoin-project/go-state-types/builtin/v19/init"
+	"github.com/filecoin-project/go-state-types/builtin/v19/market"
+	"github.com/filecoin-project/go-state-types/builtin/v19/multisig"
+	"github.com/filecoin-project/go-state-types/builtin/v19/power"
+	"github.com/filecoin-project/go-state-types/builtin/v19/reward"
+	"github.com/filecoin-project/go-state-types/builtin/v19/system"
+	"github.com/filecoin-project/go-state-types/builtin/v19/util/adt"
+	"github.com/filecoin-project/go-state-types/builtin/v19/verifreg"
+	"github.com/filecoin-project/go-state-types/manifest"
+)
+
+// The epoch at which the genesis state is built. The state is that at the end of this epoch, so
+// invariants are checked with it as the prior epoch.
+const GenesisEpoch = abi.ChainEpoch(0)
+
+// Template describes the actors and balances of a genesis state.
+type Template struct {
+	NetworkName string
+	// The manifest of the builtin actors; it and its data must be in the store.
+	Manifest cid.Cid
+
+	Accounts  []Account
+	Multisigs []Multisig
+	Miners    []Miner
+
+	// The verified registry root key, an address of one of the accounts or multisigs.
+	VerifregRootKey address.Address
+	Verifiers       []Verifier
+}
+
+// Account is a key-addressed account actor.
+type Account struct {
+	Address address.Address // A secp256k1 or BLS address.
+	Balance abi.TokenAmount
+}
+
+// Multisig is a multisig actor, addressed by ID only.
+type Multisig struct {
+	Signers   []address.Address // Addresses of accounts.
+	Threshold uint64
+	// The balance vests linearly over the duration from the start epoch. Zero duration means no vesting.
+	VestingStart    abi.ChainEpoch
+	VestingDuration abi.ChainEpoch
+	Balance         abi.TokenAmount
+}
+
+// Verifier is a verifier registered with the verified registry.
+type Verifier struct {
+	Address   address.Address
+	Allowance verifreg.DataCap
+}
+
+// Result is the outcome of building a genesis state.
+type Result struct {
+	StateRoot cid.Cid
+	// The code CID of each builtin actor, by manifest key.
+	ActorCodes map[string]cid.Cid
+	// The ID addresses assigned to the template's multisigs and miners, in order.
+	Multisigs []address.Address
+	Miners    []address.Address
+}
+
+// builder accumulates the state of every actor while a genesis state tree is built.
+type builder struct {
+	store adt.Store
+	codes map[string]cid.Cid
+
+	tree     *builtin.ActorTree
+	addrMap  *adt.Map // init actor address map
+	nextID   abi.ActorID
+	accounts map[address.Address]struct{} // ID addresses of account actors
+	// Sum of all balances assigned so far; the reward actor receives the remainder.
+	allocated abi.TokenAmount
+	// Sum of the balances of accounts and multisigs, taken as the circulating supply.
+	circulating abi.TokenAmount
+
+	// Reward and power estimates at genesis, used to compute sector pledges.
+	rewardSt *reward.State
+	powerSt  *power.State
+
+	// Market, verified registry and power state gathered from the miners.
+	market          *market.State
+	balances        *market.Balances
+	proposals       *market.DealArray
+	dealStates      *adt.Array
+	dealOps         *market.SetMultimap
+	providerSectors map[abi.ActorID]map[abi.SectorNumber][]abi.DealID
+	claims          map[abi.ActorID]map[verifreg.ClaimId]verifreg.Claim
+	nextClaimID     verifreg.ClaimId
+	powerClaims     map[address.Address]power.Claim
+	cronEvents      map[abi.ChainEpoch][]power.CronEvent
+}
+
+// Build writes the genesis state described by a template into store.
+func Build(ctx context.Context, store cbor.IpldStore, tmpl *Template) (*Result, error) {
+	adtStore := adt.WrapStore(ctx, store)
+	codes, err := loadActorCodes(adtStore, tmpl.Manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err := builtin.NewTree(adtStore)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to create actors tree: %w", err)
+	}
+	initState, err := init_.ConstructState(adtStore, tmpl.NetworkName)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to construct init state: %w", err)
+	}
+	addrMap, err := adt.AsMap(adtStore, initState.AddressMap, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to load init address map: %w", err)
+	}
+	powerSt, err := power.ConstructState(adtStore)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to construct power state: %w", err)
+	}
+	marketSt, err := market.ConstructState(adtStore)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to construct market state: %w", err)
+	}
+
+	b := &builder{
+		store:           adtStore,
+		codes:           codes,
+		tree:            tree,
+		addrMap:         addrMap,
+		nextID:          initState.NextID,
+		accounts:        make(map[address.Address]struct{}),
+		allocated:       big.Zero(),
+		circulating:     big.Zero(),
+		rewardSt:        reward.ConstructState(big.Zero()),
+		powerSt:         powerSt,
+		market:          marketSt,
+		providerSectors: make(map[abi.ActorID]map[abi.SectorNumber][]abi.DealID),
+		claims:          make(map[abi.ActorID]map[verifreg.ClaimId]verifreg.Claim),
+		nextClaimID:     1,
+		powerClaims:     make(map[address.Address]power.Claim),
+		cronEvents:      make(map[abi.ChainEpoch][]power.CronEvent),
+	}
+	if b.balances, err = marketSt.LoadBalances(adtStore); err != nil {
+		return nil, err
+	}
+	if b.proposals, err = market.AsDealProposalArray(adtStore, marketSt.Proposals); err != nil {
+		return nil, xerrors.Errorf("failed to load deal proposals: %w", err)
+	}
+	if b.dealStates, err = adt.AsArray(adtStore, marketSt.States, market.StatesAmtBitwidth); err != nil {
+		return nil, xerrors.Errorf("failed to load deal states: %w", err)
+	}
+	if b.dealOps, err = market.AsSetMultimap(adtStore, marketSt.DealOpsByEpoch, builtin.DefaultHamtBitwidth, builtin.DefaultHamtBitwidth); err != nil {
+		return nil, xerrors.Errorf("failed to load deal ops: %w", err)
+	}
+
+	res := &Result{ActorCodes: codes}
+	if err := b.build(tmpl, res); err != nil {
+		return nil, err
+	}
+
+	initState.AddressMap, err = b.addrMap.Root()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to flush init address map: %w", err)
+	}
+	initState.NextID = b.nextID
+	if err := b.setActor(builtin.InitActorAddr, manifest.InitKey, initState, big.Zero()); err != nil {
+		return nil, err
+	}
+
+	if res.StateRoot, err = tree.Flush(); err != nil {
+		return nil, xerrors.Errorf("failed to flush actors tree: %w", err)
+	}
+	return res, nil
+}
+
+func loadActorCodes(store adt.Store, manifestCid cid.Cid) (map[string]cid.Cid, error) {
+	var m manifest.Manifest
+	if err := store.Get(store.Context(), manifestCid, &m); err != nil {
+		return nil, xerrors.Errorf("error reading actor manifest: %w", err)
+	}
+	if err := m.Load(store.Context(), store); err != nil {
+		return nil, xerrors.Errorf("error loading actor manifest: %w", err)
+	}
+	codes := make(map[string]cid.Cid)
+	for _, name := range manifest.GetBuiltinActorsKeys(actors.Version19) {
+		c, ok := m.Get(name)
+		if !ok {
+			return nil, xerrors.Errorf("code cid for %s actor not found in manifest", name)
+		}
+		codes[name] = c
+	}
+	return codes, nil
+}
+
+func (b *builder) build(tmpl *Template, res *Result) error {
+	systemState, err := system.ConstructState(b.store)
+	if err != nil {
+		return xerrors.Errorf("failed to construct system state: %w", err)
+	}
+	var m manifest.Manifest
+	if err := b.store.Get(b.store.Context(), tmpl.Manifest, &m); err != nil {
+		return xerrors.Errorf("error reading actor manifest: %w", err)
+	}
+	systemState.BuiltinActors = m.Data
+	if err := b.setActor(builtin.SystemActorAddr, manifest.SystemKey, systemState, big.Zero()); err != nil {
+		return err
+	}
+	if err := b.setActor(builtin.CronActorAddr, manifest.CronKey, cron.ConstructState(cron.BuiltInEntries()), big.Zero()); err != nil {
+		return err
+	}
+	if err := b.setEmptyActor(builtin.EthereumAddressManagerActorAddr, manifest.EamKey); err != nil {
+		return err
+	}
+	if err := b.setActor(builtin.BurntFundsActorAddr, manifest.AccountKey, &account.State{Address: builtin.BurntFundsActorAddr}, big.Zero()); err != nil {
+		return err
+	}
+
+	for i := range tmpl.Accounts {
+		if err := b.newAccount(&tmpl.Accounts[i]); err != nil {
+			return xerrors.Errorf("failed to create account %d: %w", i, err)
+		}
+	}
+	for i := range tmpl.Multisigs {
+		idAddr, err := b.newMultisig(&tmpl.Multisigs[i])
+		if err != nil {
+			return xerrors.Errorf("failed to create multisig %d: %w", i, err)
+		}
+		res.Multisigs = append(res.Multisigs, idAddr)
+	}
+	for i := range tmpl.Miners {
+		idAddr, err := b.newMiner(&tmpl.Miners[i])
+		if err != nil {
+			return xerrors.Errorf("failed to create miner %d: %w", i, err)
+		}
+		res.Miners = append(res.Miners, idAddr)
+	}
+
+	if err := b.setMarket(); err != nil {
+		return err
+	}
+	if err := b.setPower(); err != nil {
+		return err
+	}
+	if err := b.setVerifregAndDatacap(tmpl.VerifregRootKey, tmpl.Verifiers); err != nil {
+		return err
+	}
+
+	// The reward actor holds everything not handed out, having accounted for the genesis epoch.
+	remaining := big.Sub(builtin.TotalFilecoin, b.allocated)
+	if remaining.LessThan(reward.StorageMiningAllocationCheck) {
+		return xerrors.Errorf("genesis balances %v leave less than the storage mining allocation to the reward actor", b.allocated)
+	}
+	rewardState := reward.ConstructState(b.powerSt.TotalRawBytePower)
+	rewardState.AdvanceEpoch(b.powerSt.TotalRawBytePower)
+	return b.setActor(builtin.RewardActorAddr, manifest.RewardKey, rewardState, remaining)
+}
+
+// setActor stores the actor state head and records the actor in the tree.
+func (b *builder) setActor(addr address.Address, codeKey string, state cbg.CBORMarshaler, balance abi.TokenAmount) error {
+	head, err := b.store.Put(b.store.Context(), state)
+	if err != nil {
+		return xerrors.Errorf("failed to store %s state for %v: %w", codeKey, addr, err)
+	}
+	return b.setActorHead(addr, codeKey, head, balance)
+}
+
+func (b *builder) setEmptyActor(addr address.Address, codeKey string) error {
+	// Same object as builtin.MakeEmptyState, but written to the target store.
+	head, err := b.store.Put(b.store.Context(), []struct{}{})
+	if err != nil {
+		return xerrors.Errorf("failed to store empty state: %w", err)
+	}
+	return b.setActorHead(addr, codeKey, head, big.Zero())
+}
+
+func (b *builder) setActorHead(addr address.Address, codeKey string, head cid.Cid, balance abi.TokenAmount) error {
+	if balance.Sign() < 0 {
+		return xerrors.Errorf("negative balance %v for %s actor %v", balance, codeKey, addr)
+	}
+	if err := b.tree.SetActorV5(addr, &builtin.ActorV5{
+		Code:       b.codes[codeKey],
+		Head:       head,
+		CallSeqNum: 0,
+		Balance:    balance,
+	}); err != nil {
+		return xerrors.Errorf("failed to set %s actor %v: %w", codeKey, addr, err)
+	}
+	b.allocated = big.Add(b.allocated, balance)
+	return nil
+}
+
+// newID assigns the next actor ID, registering the robust address (if any) in the init actor.
+func (b *builder) newID(robust ...address.Address) (address.Address, error) {
+	for _, r := range robust {
+		found, err := b.addrMap.Has(abi.AddrKey(r))
+		if err != nil {
+			return address.Undef, xerrors.Errorf("failed to look up address %v: %w", r, err)
+		}
+		if found {
+			return address.Undef, xerrors.Errorf("duplicate address %v", r)
+		}
+		v := cbg.CborInt(b.nextID)
+		if err := b.addrMap.Put(abi.AddrKey(r), &v); err != nil {
+			return address.Undef, xerrors.Errorf("failed to register address %v: %w", r, err)
+		}
+	}
+	id := b.nextID
+	b.nextID++
+	return address.NewIDAddress(uint64(id))
+}
+
+// resolve returns the ID address of an actor created by the template.
+func (b *builder) resolve(a address.Address) (address.Address, error) {
+	if a.Protocol() == address.ID {
+		id, err := address.IDFromAddress(a)
+		if err != nil {
+			return address.Undef, err
+		}
+		if id < builtin.FirstNonSingletonActorId || abi.ActorID(id) >= b.nextID {
+			return address.Undef, xerrors.Errorf("no actor with address %v", a)
+		}
+		return a, nil
+	}
+	var id cbg.CborInt
+	found, err := b.addrMap.Get(abi.AddrKey(a), &id)
+	if err != nil {
+		return address.Undef, xerrors.Errorf("failed to look up address %v: %w", a, err)
+	}
+	if !found {
+		return address.Undef, xerrors.Errorf("no actor with address %v", a)
+	}
+	return address.NewIDAddress(uint64(id))
+}
+
+// resolveAccount returns the ID address of an account actor created by the template.
+func (b *builder) resolveAccount(a address.Address) (address.Address, error) {
+	idAddr, err := b.resolve(a)
+	if err != nil {
+		return address.Undef, err
+	}
+	if _, ok := b.accounts[idAddr]; !ok {
+		return address.Undef, xerrors.Errorf("%v is not an account", a)
+	}
+	return idAddr, nil
+}
+
+func (b *builder) newAccount(a *Account) error {
+	if a.Address.Protocol() != address.SECP256K1 && a.Address.Protocol() != address.BLS {
+		return xerrors.Errorf("account address %v is not a key address", a.Address)
+	}
+	idAddr, err := b.newID(a.Address)
+	if err != nil {
+		return err
+	}
+	if err := b.setActor(idAddr, manifest.AccountKey, &account.State{Address: a.Address}, a.Balance); err != nil {
+		return err
+	}
+	b.accounts[idAddr] = struct{}{}
+	b.circulating = big.Add(b.circulating, a.Balance)
+	return nil
+}
+
+func (b *builder) newMultisig(ms *Multisig) (address.Address, error) {
+	if ms.Threshold == 0 || ms.Threshold > uint64(len(ms.Signers)) {
+		return address.Undef, xerrors.Errorf("threshold %d out of range for %d signers", ms.Threshold, len(ms.Signers))
+	}
+	if ms.VestingDuration < 0 {
+		return address.Undef, xerrors.Errorf("negative vesting duration %d", ms.VestingDuration)
+	}
+	signers := make([]address.Address, len(ms.Signers))
+	seen := make(map[address.Address]struct{}, len(ms.Signers))
+	for i, s := range ms.Signers {
+		idAddr, err := b.resolveAccount(s)
+		if err != nil {
+			return address.Undef, xerrors.Errorf("invalid signer: %w", err)
+		}
+		if _, ok := seen[idAddr]; ok {
+			return address.Undef, xerrors.Errorf("duplicate signer %v", s)
+		}
+		seen[idAddr] = struct{}{}
+		signers[i] = idAddr
+	}
+
+	idAddr, err := b.newID()
+	if err != nil {
+		return address.Undef, err
+	}
+	pendingTxns, err := adt.StoreEmptyMap(b.store, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		return address.Undef, err
+	}
+	st := &multisig.State{
+		Signers:               signers,
+		NumApprovalsThreshold: ms.Threshold,
+		NextTxnID:             0,
+		InitialBalance:        big.Zero(),
+		PendingTxns:           pendingTxns,
+	}
+	if ms.VestingDuration > 0 {
+		st.InitialBalance = ms.Balance
+		st.StartEpoch = ms.VestingStart
+		st.UnlockDuration = ms.VestingDuration
+	}
+	if err := b.setActor(idAddr, manifest.MultisigKey, st, ms.Balance); err != nil {
+		return address.Undef, err
+	}
+	b.circulating = big.Add(b.circulating, ms.Balance)
+	return idAddr, nil
+}
+
+func (b *builder) setMarket() error {
+	st := b.market
+	if err := b.balances.Flush(); err != nil {
+		return err
+	}
+	escrowTotal, err := b.balances.Escrow.Total()
+	if err != nil {
+		return xerrors.Errorf("failed to total escrow: %w", err)
+	}
+	if st.Proposals, err = b.proposals.Root(); err != nil {
+		return xerrors.Errorf("failed to flush deal proposals: %w", err)
+	}
+	if st.States, err = b.dealStates.Root(); err != nil {
+		return xerrors.Errorf("failed to flush deal states: %w", err)
+	}
+	if st.DealOpsByEpoch, err = b.dealOps.Root(); err != nil {
+		return xerrors.Errorf("failed to flush deal ops: %w", err)
+	}
+
+	providerSectors, err := adt.AsMap(b.store, st.ProviderSectors, market.ProviderSectorsHamtBitwidth)
+	if err != nil {
+		return err
+	}
+	for provider, sectors := range b.providerSectors { // nolint:nomaprange
+		inner, err := adt.MakeEmptyMap(b.store, market.ProviderSectorsHamtBitwidth)
+		if err != nil {
+			return err
+		}
+		for sno, dealIDs := range sectors { // nolint:nomaprange
+			ids := market.SectorDealIDs(dealIDs)
+			if err := inner.Put(abi.UIntKey(uint64(sno)), &ids); err != nil {
+				return err
+			}
+		}
+		innerRoot, err := inner.Root()
+		if err != nil {
+			return err
+		}
+		if err := providerSectors.Put(abi.UIntKey(uint64(provider)), cbg.CborCid(innerRoot)); err != nil {
+			return err
+		}
+	}
+	if st.ProviderSectors, err = providerSectors.Root(); err != nil {
+		return err
+	}
+	st.LastCron = GenesisEpoch
+	return b.setActor(builtin.StorageMarketActorAddr, manifest.MarketKey, st, escrowTotal)
+}
+
+func (b *builder) setPower() error {
+	st := b.powerSt
+	claims, err := adt.AsMap(b.store, st.Claims, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		return err
+	}
+	for maddr, claim := range b.powerClaims { // nolint:nomaprange
+		claim := claim
+		if err := claims.Put(abi.AddrKey(maddr), &claim); err != nil {
+			return xerrors.Errorf("failed to put claim of %v: %w", maddr, err)
+		}
+
+		st.MinerCount++
+		st.TotalBytesCommitted = big.Add(st.TotalBytesCommitted, claim.RawBytePower)
+		st.TotalQABytesCommitted = big.Add(st.TotalQABytesCommitted, claim.QualityAdjPower)
+		minPower, err := builtin.ConsensusMinerMinPower(claim.WindowPoStProofType)
+		if err != nil {
+			return err
+		}
+		if claim.RawBytePower.GreaterThanEqual(minPower) {
+			st.MinerAboveMinPowerCount++
+			st.TotalRawBytePower = big.Add(st.TotalRawBytePower, claim.RawBytePower)
+			st.TotalQualityAdjPower = big.Add(st.TotalQualityAdjPower, claim.QualityAdjPower)
+		}
+	}
+	if st.Claims, err = claims.Root(); err != nil {
+		return err
+	}
+
+	// The cron event queue is a HAMT of epochs to AMTs of events.
+	queue, err := adt.AsMap(b.store, st.CronEventQueue, power.CronQueueHamtBitwidth)
+	if err != nil {
+		return err
+	}
+	for epoch, events := range b.cronEvents { // nolint:nomaprange
+		arr, err := adt.MakeEmptyArray(b.store, power.CronQueueAmtBitwidth)
+		if err != nil {
+			return err
+		}
+		for i := range events {
+			if err := arr.AppendContinuous(&events[i]); err != nil {
+				return err
+			}
+		}
+		arrRoot, err := arr.Root()
+		if err != nil {
+			return err
+		}
+		if err := queue.Put(abi.IntKey(int64(epoch)), cbg.CborCid(arrRoot)); err != nil {
+			return err
+		}
+	}
+	if st.CronEventQueue, err = queue.Root(); err != nil {
+		return err
+	}
+
+	st.ThisEpochRawBytePower = st.TotalRawBytePower
+	st.ThisEpochQualityAdjPower = st.TotalQualityAdjPower
+	st.ThisEpochPledgeCollateral = st.TotalPledgeCollateral
+	return b.setActor(builtin.StoragePowerActorAddr, manifest.PowerKey, st, big.Zero())
+}
+
+func (b *builder) setVerifregAndDatacap(rootKey address.Address, verifiers []Verifier) error {
+	rootID, err := b.resolve(rootKey)
+	if err != nil {
+		return xerrors.Errorf("invalid verified registry root key: %w", err)
+	}
+	st, err := verifreg.ConstructState(b.store, rootID)
+	if err != nil {
+		return xerrors.Errorf("failed to construct verifreg state: %w", err)
+	}
+
+	verifierMap, err := adt.AsMap(b.store, st.Verifiers, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		return err
+	}
+	for _, v := range verifiers {
+		idAddr, err := b.resolve(v.Address)
+		if err != nil {
+			return xerrors.Errorf("invalid verifier: %w", err)
+		}
+		if v.Allowance.Sign() < 0 {
+			return xerrors.Errorf("negative allowance %v for verifier %v", v.Allowance, v.Address)
+		}
+		allowance := v.Allowance
+		if err := verifierMap.Put(abi.AddrKey(idAddr), &allowance); err != nil {
+			return err
+		}
+	}
+	if st.Verifiers, err = verifierMap.Root(); err != nil {
+		return err
+	}
+
+	// Claims for the verified deals in pre-sealed sectors.
+	claimsMap, err := adt.AsMap(b.store, st.Claims, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		return err
+	}
+	for provider, claims := range b.claims { // nolint:nomaprange
+		inner, err := adt.MakeEmptyMap(b.store, builtin.DefaultHamtBitwidth)
+		if err != nil {
+			return err
+		}
+		for id, claim := range claims { // nolint:nomaprange
+			claim := claim
+			if err := inner.Put(id, &claim); err != nil {
+				return err
+			}
+		}
+		innerRoot, err := inner.Root()
+		if err != nil {
+			return err
+		}
+		providerAddr, err := address.NewIDAddress(uint64(provider))
+		if err != nil {
+			return err
+		}
+		if err := claimsMap.Put(abi.IdAddrKey(providerAddr), cbg.CborCid(innerRoot)); err != nil {
+			return err
+		}
+	}
+	if st.Claims, err = claimsMap.Root(); err != nil {
+		return err
+	}
+	st.NextAllocationId = verifreg.AllocationId(b.nextClaimID)
+
+	if err := b.setActor(builtin.VerifiedRegistryActorAddr, manifest.VerifregKey, st, big.Zero()); err != nil {
+		return err
+	}
+
+	// No DataCap exists at genesis: verified deals in pre-sealed sectors are already claimed.
+	dc, err := datacap.ConstructState(b.store, builtin.VerifiedRegistryActorAddr, builtin.DefaultTokenActorBitwidth)
+	if err != nil {
+		return xerrors.Errorf("failed to construct datacap state: %w", err)
+	}
+	return b.setActor(builtin.DatacapActorAddr, manifest.DatacapKey, dc, big.Zero())
+}
@@ -0,0 +1,130 @@
+package genesis_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/ipfs/go-cid"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	mh "github.com/multiformats/go-multihash"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/actors"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin"
+	v19 "github.com/filecoin-project/go-state-types/builtin/v19"
+	"github.com/filecoin-project/go-state-types/builtin/v19/genesis"
+	"github.com/filecoin-project/go-state-types/builtin/v19/market"
+	"github.com/filecoin-project/go-state-types/builtin/v19/power"
+	"github.com/filecoin-project/go-state-types/builtin/v19/util/adt"
+	"github.com/filecoin-project/go-state-types/test_util"
+	"github.com/filecoin-project/go-state-types/test_util/synth"
+)
+
+func TestBuildPassesInvariants(t *testing.T) {
+	ctx := context.Background()
+	store := cbor.NewCborStore(test_util.NewSyncBlockStoreInMemory())
+	manifestCid, err := synth.MakeManifest(ctx, store, actors.Version19)
+	require.NoError(t, err)
+
+	worker := blsAddress(t, 1)
+	client := blsAddress(t, 2)
+	rootKey := blsAddress(t, 3)
+	fil := func(n int64) abi.TokenAmount { return big.Mul(big.NewInt(n), builtin.TokenPrecision) }
+
+	proof := abi.RegisteredSealProof_StackedDrg8MiBV1_1
+	sectorSize, err := proof.SectorSize()
+	require.NoError(t, err)
+	preseal := func(sno abi.SectorNumber, verified bool) genesis.PreSeal {
+		commD := commitment(t, cid.FilCommitmentUnsealed, mh.SHA2_256_TRUNC254_PADDED, uint64(sno))
+		return genesis.PreSeal{
+			CommR:     commitment(t, cid.FilCommitmentSealed, mh.POSEIDON_BLS12_381_A1_FC1, uint64(sno)),
+			CommD:     commD,
+			SectorID:  sno,
+			ProofType: proof,
+			Deal: market.DealProposal{
+				PieceCID:             commD,
+				PieceSize:            abi.PaddedPieceSize(sectorSize),
+				VerifiedDeal:         verified,
+				Client:               client,
+				StartEpoch:           0,
+				EndEpoch:             540 * builtin.EpochsInDay,
+				StoragePricePerEpoch: big.NewInt(1000),
+				ProviderCollateral:   fil(1),
+			},
+		}
+	}
+
+	tmpl := &genesis.Template{
+		NetworkName: "testnet",
+		Manifest:    manifestCid,
+		Accounts: []genesis.Account{
+			{Address: worker, Balance: fil(1_000)},
+			{Address: client, Balance: fil(1_000)},
+			{Address: rootKey, Balance: big.Zero()},
+		},
+		Multisigs: []genesis.Multisig{
+			{Signers: []address.Address{worker, client}, Threshold: 2, VestingDuration: builtin.EpochsInYear, Balance: fil(1_000_000)},
+		},
+		Miners: []genesis.Miner{{
+			ID:        104,
+			Owner:     worker,
+			Worker:    worker,
+			PeerID:    abi.PeerID("peer"),
+			SealProof: proof,
+			Sectors:   []genesis.PreSeal{preseal(2, true), preseal(1, false), preseal(3, false)},
+		}},
+		VerifregRootKey: rootKey,
+	}
+
+	res, err := genesis.Build(ctx, store, tmpl)
+	require.NoError(t, err)
+	require.Len(t, res.Multisigs, 1)
+	require.Equal(t, []address.Address{idAddress(t, 104)}, res.Miners)
+
+	adtStore := adt.WrapStore(ctx, store)
+	tree, err := builtin.LoadTree(adtStore, res.StateRoot)
+	require.NoError(t, err)
+	acc, err := v19.CheckStateInvariants(tree, genesis.GenesisEpoch, res.ActorCodes)
+	require.NoError(t, err)
+	require.True(t, acc.IsEmpty(), acc.Messages())
+
+	powerActor, found, err := tree.GetActorV5(builtin.StoragePowerActorAddr)
+	require.NoError(t, err)
+	require.True(t, found)
+	var powerSt power.State
+	require.NoError(t, adtStore.Get(ctx, powerActor.Head, &powerSt))
+	require.Equal(t, int64(1), powerSt.MinerCount)
+	require.Equal(t, big.NewIntUnsigned(3*uint64(sectorSize)), powerSt.TotalBytesCommitted)
+	require.True(t, powerSt.TotalQABytesCommitted.GreaterThan(powerSt.TotalBytesCommitted))
+
+	// Pre-sealed sectors are bound to their miner's ID.
+	tmpl.Miners[0].ID = 105
+	_, err = genesis.Build(ctx, store, tmpl)
+	require.Error(t, err)
+}
+
+func blsAddress(t *testing.T, seed byte) address.Address {
+	pubkey := make([]byte, address.BlsPublicKeyBytes)
+	pubkey[0] = seed
+	a, err := address.NewBLSAddress(pubkey)
+	require.NoError(t, err)
+	return a
+}
+
+func idAddress(t *testing.T, id uint64) address.Address {
+	a, err := address.NewIDAddress(id)
+	require.NoError(t, err)
+	return a
+}
+
+func commitment(t *testing.T, codec uint64, hashType uint64, seed uint64) cid.Cid {
+	digest := sha256.Sum256([]byte{byte(codec), byte(seed)})
+	digest[31] &= 0x3f // Fr32 padding
+	h, err := mh.Encode(digest[:], hashType)
+	require.NoError(t, err)
+	return cid.NewCidV1(codec, h)
+}
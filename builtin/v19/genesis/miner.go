@@ -0,0 +1,530 @@
+package genesis
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sort"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-bitfield"
+	"github.com/ipfs/go-cid"
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin"
+	"github.com/filecoin-project/go-state-types/builtin/v19/market"
+	"github.com/filecoin-project/go-state-types/builtin/v19/miner"
+	"github.com/filecoin-project/go-state-types/builtin/v19/power"
+	"github.com/filecoin-project/go-state-types/builtin/v19/util/adt"
+	"github.com/filecoin-project/go-state-types/builtin/v19/verifreg"
+	"github.com/filecoin-project/go-state-types/manifest"
+)
+
+// Miner is a storage miner whose pre-sealed sectors are proven at genesis.
+// The miner is addressed by ID only, and its balance is its initial pledge.
+type Miner struct {
+	// The ID the sectors were sealed for, or zero to take the next free ID.
+	ID         abi.ActorID
+	Owner      address.Address // An account or multisig.
+	Worker     address.Address // An account.
+	PeerID     abi.PeerID
+	Multiaddrs []abi.Multiaddrs
+	SealProof  abi.RegisteredSealProof
+	Sectors    []PreSeal
+}
+
+// PreSeal is a sector sealed before genesis, filled by a single deal.
+type PreSeal struct {
+	CommR     cid.Cid
+	CommD     cid.Cid // The piece CID of the deal.
+	SectorID  abi.SectorNumber
+	Deal      market.DealProposal
+	ProofType abi.RegisteredSealProof
+}
+
+// newMiner creates a miner with its pre-sealed sectors active, recording its deals, claims, power
+// and cron event with the builder.
+func (b *builder) newMiner(m *Miner) (address.Address, error) {
+	owner, err := b.resolve(m.Owner)
+	if err != nil {
+		return address.Undef, xerrors.Errorf("invalid owner: %w", err)
+	}
+	worker, err := b.resolveAccount(m.Worker)
+	if err != nil {
+		return address.Undef, xerrors.Errorf("invalid worker: %w", err)
+	}
+	sectorSize, err := m.SealProof.SectorSize()
+	if err != nil {
+		return address.Undef, xerrors.Errorf("invalid seal proof: %w", err)
+	}
+	postProof, err := m.SealProof.RegisteredWindowPoStProof()
+	if err != nil {
+		return address.Undef, xerrors.Errorf("invalid seal proof: %w", err)
+	}
+	postPolicy, ok := builtin.PoStProofPolicies[postProof]
+	if !ok {
+		return address.Undef, xerrors.Errorf("no PoSt proof policy for proof type %d", postProof)
+	}
+
+	if m.ID != 0 && m.ID != b.nextID {
+		return address.Undef, xerrors.Errorf("miner ID %d does not match the next ID %d", m.ID, b.nextID)
+	}
+	maddr, err := b.newID()
+	if err != nil {
+		return address.Undef, err
+	}
+	minerID, err := address.IDFromAddress(maddr)
+	if err != nil {
+		return address.Undef, err
+	}
+
+	info := &miner.MinerInfo{
+		Owner:                      owner,
+		Worker:                     worker,
+		ControlAddresses:           nil,
+		PendingWorkerKey:           nil,
+		PeerId:                     m.PeerID,
+		Multiaddrs:                 m.Multiaddrs,
+		WindowPoStProofType:        postProof,
+		SectorSize:                 sectorSize,
+		WindowPoStPartitionSectors: postPolicy.WindowPoStPartitionSectors,
+		ConsensusFaultElapsed:      abi.ChainEpoch(-1),
+		PendingOwnerAddress:        nil,
+		Beneficiary:                owner,
+		BeneficiaryTerm: miner.BeneficiaryTerm{
+			Quota:      big.Zero(),
+			UsedQuota:  big.Zero(),
+			Expiration: 0,
+		},
+	}
+	infoCid, err := b.store.Put(b.store.Context(), info)
+	if err != nil {
+		return address.Undef, xerrors.Errorf("failed to store miner info: %w", err)
+	}
+
+	offset, err := assignProvingPeriodOffset(maddr, GenesisEpoch)
+	if err != nil {
+		return address.Undef, err
+	}
+	provingPeriodStart := currentProvingPeriodStart(GenesisEpoch, offset)
+	currentDeadline := uint64((GenesisEpoch - provingPeriodStart) / miner.WPoStChallengeWindow)
+
+	st := &miner.State{
+		Info:               infoCid,
+		PreCommitDeposits:  big.Zero(),
+		LockedFunds:        big.Zero(),
+		FeeDebt:            big.Zero(),
+		InitialPledge:      big.Zero(),
+		ProvingPeriodStart: provingPeriodStart,
+		CurrentDeadline:    currentDeadline,
+		EarlyTerminations:  bitfield.New(),
+		DeadlineCronActive: true,
+	}
+	if st.PreCommittedSectors, err = adt.StoreEmptyMap(b.store, builtin.DefaultHamtBitwidth); err != nil {
+		return address.Undef, err
+	}
+	if st.PreCommittedSectorsCleanUp, err = adt.StoreEmptyArray(b.store, miner.PrecommitCleanUpAmtBitwidth); err != nil {
+		return address.Undef, err
+	}
+
+	preseals := append([]PreSeal{}, m.Sectors...)
+	sort.Slice(preseals, func(i, j int) bool { return preseals[i].SectorID < preseals[j].SectorID })
+	sectors := make([]*miner.SectorOnChainInfo, len(preseals))
+	sectorsArr, err := adt.MakeEmptyArray(b.store, miner.SectorsAmtBitwidth)
+	if err != nil {
+		return address.Undef, err
+	}
+	livePower := miner.NewPowerPairZero()
+	for i := range preseals {
+		ps := &preseals[i]
+		if i > 0 && ps.SectorID == preseals[i-1].SectorID {
+			return address.Undef, xerrors.Errorf("duplicate sector %d", ps.SectorID)
+		}
+		if ps.ProofType != m.SealProof {
+			return address.Undef, xerrors.Errorf("sector %d proof type %d does not match miner seal proof %d", ps.SectorID, ps.ProofType, m.SealProof)
+		}
+		s, err := b.newSector(abi.ActorID(minerID), ps, sectorSize)
+		if err != nil {
+			return address.Undef, xerrors.Errorf("failed to set up sector %d: %w", ps.SectorID, err)
+		}
+		if err := sectorsArr.Set(uint64(s.SectorNumber), s); err != nil {
+			return address.Undef, xerrors.Errorf("failed to set sector %d: %w", s.SectorNumber, err)
+		}
+		sectors[i] = s
+		st.InitialPledge = big.Add(st.InitialPledge, s.InitialPledge)
+		livePower = livePower.Add(miner.NewPowerPair(big.NewIntUnsigned(uint64(sectorSize)), miner.QAPowerForSector(sectorSize, s)))
+	}
+	if st.Sectors, err = sectorsArr.Root(); err != nil {
+		return address.Undef, err
+	}
+	sectorNos := make([]uint64, len(sectors))
+	for i, s := range sectors {
+		sectorNos[i] = uint64(s.SectorNumber)
+	}
+	allocated := bitfield.NewFromSet(sectorNos)
+	if st.AllocatedSectors, err = b.store.Put(b.store.Context(), &allocated); err != nil {
+		return address.Undef, err
+	}
+
+	if err := b.setDeadlines(st, sectors, sectorSize, postPolicy.WindowPoStPartitionSectors); err != nil {
+		return address.Undef, err
+	}
+
+	// Register the proving deadline cron event at the end of the current deadline.
+	var payload bytes.Buffer
+	if err := (&miner.CronEventPayload{EventType: miner.CronEventProvingDeadline}).MarshalCBOR(&payload); err != nil {
+		return address.Undef, err
+	}
+	cronEpoch := miner.NewDeadlineInfo(provingPeriodStart, currentDeadline, GenesisEpoch).Last()
+	b.cronEvents[cronEpoch] = append(b.cronEvents[cronEpoch], power.CronEvent{MinerAddr: maddr, CallbackPayload: payload.Bytes()})
+
+	b.powerClaims[maddr] = power.Claim{
+		WindowPoStProofType: postProof,
+		RawBytePower:        livePower.Raw,
+		QualityAdjPower:     livePower.QA,
+	}
+	b.powerSt.TotalPledgeCollateral = big.Add(b.powerSt.TotalPledgeCollateral, st.InitialPledge)
+
+	if err := b.setActor(maddr, manifest.MinerKey, st, st.InitialPledge); err != nil {
+		return address.Undef, err
+	}
+	return maddr, nil
+}
+
+// newSector activates a pre-sealed sector and its deal at genesis.
+func (b *builder) newSector(minerID abi.ActorID, ps *PreSeal, sectorSize abi.SectorSize) (*miner.SectorOnChainInfo, error) {
+	s := &miner.SectorOnChainInfo{
+		SectorNumber:   ps.SectorID,
+		SealProof:      ps.ProofType,
+		SealedCID:      ps.CommR,
+		Activation:     GenesisEpoch,
+		Expiration:     ps.Deal.EndEpoch,
+		PowerBaseEpoch: GenesisEpoch,
+		Flags:          miner.SIMPLE_QA_POWER,
+	}
+	var err error
+	if s.DealWeight, s.VerifiedDealWeight, err = b.addDeal(minerID, ps, sectorSize); err != nil {
+		return nil, err
+	}
+
+	qa := miner.QAPowerForSector(sectorSize, s)
+	rewardEstimate, powerEstimate := b.rewardSt.ThisEpochRewardSmoothed, b.powerSt.ThisEpochQAPowerSmoothed
+	dayReward := miner.ExpectedRewardForPower(rewardEstimate, powerEstimate, qa, builtin.NetworkEpochsInDay())
+	storagePledge := miner.ExpectedRewardForPower(rewardEstimate, powerEstimate, qa, miner.InitialPledgeProjectionPeriod)
+	replaced := big.Zero()
+	s.InitialPledge = miner.InitialPledgeForPower(qa, b.rewardSt.ThisEpochBaselinePower, rewardEstimate, powerEstimate,
+		b.circulating, int64(GenesisEpoch)-b.powerSt.RampStartEpoch, b.powerSt.RampDurationEpochs)
+	s.ExpectedDayReward = &dayReward
+	s.ExpectedStoragePledge = &storagePledge
+	s.ReplacedDayReward = &replaced
+	s.DailyFee = miner.DailyProofFee(b.circulating, qa)
+	return s, nil
+}
+
+// addDeal publishes and activates the deal of a pre-sealed sector, locking its funds in escrow, and
+// returns the sector's deal weight and verified deal weight.
+func (b *builder) addDeal(minerID abi.ActorID, ps *PreSeal, sectorSize abi.SectorSize) (abi.DealWeight, abi.DealWeight, error) {
+	p := ps.Deal
+	if !p.PieceCID.Equals(ps.CommD) {
+		return big.Zero(), big.Zero(), xerrors.Errorf("deal piece %v does not match CommD %v", p.PieceCID, ps.CommD)
+	}
+	if p.PieceSize != abi.PaddedPieceSize(sectorSize) {
+		return big.Zero(), big.Zero(), xerrors.Errorf("deal piece size %d does not fill sector of size %d", p.PieceSize, sectorSize)
+	}
+	if p.StartEpoch < GenesisEpoch || p.EndEpoch <= p.StartEpoch {
+		return big.Zero(), big.Zero(), xerrors.Errorf("invalid deal epochs [%d, %d)", p.StartEpoch, p.EndEpoch)
+	}
+	for _, amt := range []*abi.TokenAmount{&p.StoragePricePerEpoch, &p.ProviderCollateral, &p.ClientCollateral} {
+		if amt.Nil() {
+			*amt = big.Zero()
+		}
+	}
+
+	provider, err := address.NewIDAddress(uint64(minerID))
+	if err != nil {
+		return big.Zero(), big.Zero(), err
+	}
+	if p.Provider != address.Undef {
+		if resolved, err := b.resolve(p.Provider); err != nil || resolved != provider {
+			return big.Zero(), big.Zero(), xerrors.Errorf("deal provider %v is not the miner %v", p.Provider, provider)
+		}
+	}
+	p.Provider = provider
+	if p.Client, err = b.resolveAccount(p.Client); err != nil {
+		return big.Zero(), big.Zero(), xerrors.Errorf("invalid deal client: %w", err)
+	}
+
+	// No payments have been made yet, so the whole storage fee remains locked.
+	fee := p.TotalStorageFee()
+	if err := b.balances.Deposit(p.Client, p.ClientBalanceRequirement()); err != nil {
+		return big.Zero(), big.Zero(), err
+	}
+	if err := b.balances.Lock(p.Client, fee, market.ClientStorageFee); err != nil {
+		return big.Zero(), big.Zero(), err
+	}
+	if err := b.balances.Lock(p.Client, p.ClientCollateral, market.ClientCollateral); err != nil {
+		return big.Zero(), big.Zero(), err
+	}
+	if err := b.balances.Deposit(p.Provider, p.ProviderCollateral); err != nil {
+		return big.Zero(), big.Zero(), err
+	}
+	if err := b.balances.Lock(p.Provider, p.ProviderCollateral, market.ProviderCollateral); err != nil {
+		return big.Zero(), big.Zero(), err
+	}
+
+	dealID := b.market.NextID
+	b.market.NextID++
+	if err := b.proposals.Set(dealID, &p); err != nil {
+		return big.Zero(), big.Zero(), xerrors.Errorf("failed to set deal proposal %d: %w", dealID, err)
+	}
+	if err := b.dealStates.Set(uint64(dealID), &market.DealState{
+		SectorNumber:     ps.SectorID,
+		SectorStartEpoch: GenesisEpoch,
+		LastUpdatedEpoch: market.EpochUndefined,
+		SlashEpoch:       market.EpochUndefined,
+	}); err != nil {
+		return big.Zero(), big.Zero(), xerrors.Errorf("failed to set deal state %d: %w", dealID, err)
+	}
+	// The market cron of the genesis epoch has run, so deals are first processed in the next one.
+	if err := b.dealOps.Put(max(p.StartEpoch, GenesisEpoch+1), dealID); err != nil {
+		return big.Zero(), big.Zero(), xerrors.Errorf("failed to schedule deal %d: %w", dealID, err)
+	}
+	if b.providerSectors[minerID] == nil {
+		b.providerSectors[minerID] = make(map[abi.SectorNumber][]abi.DealID)
+	}
+	b.providerSectors[minerID][ps.SectorID] = append(b.providerSectors[minerID][ps.SectorID], dealID)
+
+	weight := market.DealWeight(&p, p.EndEpoch, GenesisEpoch)
+	if !p.VerifiedDeal {
+		return weight, big.Zero(), nil
+	}
+	if err := b.addClaim(minerID, ps.SectorID, &p); err != nil {
+		return big.Zero(), big.Zero(), err
+	}
+	return big.Zero(), weight, nil
+}
+
+// addClaim records the verified registry claim of a verified deal.
+func (b *builder) addClaim(minerID abi.ActorID, sno abi.SectorNumber, p *market.DealProposal) error {
+	if minSize := verifreg.NetworkMinimumVerifiedAllocationSize(); p.PieceSize < minSize {
+		return xerrors.Errorf("verified deal size %d below minimum %d", p.PieceSize, minSize)
+	}
+	termMin := p.Duration()
+	if minTerm := verifreg.NetworkMinimumVerifiedAllocationTerm(); termMin < minTerm {
+		return xerrors.Errorf("verified deal duration %d below minimum term %d", termMin, minTerm)
+	}
+	client, err := address.IDFromAddress(p.Client)
+	if err != nil {
+		return err
+	}
+
+	id := b.nextClaimID
+	b.nextClaimID++
+	if b.claims[minerID] == nil {
+		b.claims[minerID] = make(map[verifreg.ClaimId]verifreg.Claim)
+	}
+	b.claims[minerID][id] = verifreg.Claim{
+		Provider:  minerID,
+		Client:    abi.ActorID(client),
+		Data:      p.PieceCID,
+		Size:      p.PieceSize,
+		TermMin:   termMin,
+		TermMax:   max(termMin, verifreg.NetworkMaximumVerifiedAllocationTerm()),
+		TermStart: GenesisEpoch,
+		Sector:    sno,
+	}
+	return nil
+}
+
+// assignProvingPeriodOffset derives a miner's proving period offset from its address, as the miner
+// actor constructor does.
+func assignProvingPeriodOffset(maddr address.Address, currEpoch abi.ChainEpoch) (abi.ChainEpoch, error) {
+	var seed bytes.Buffer
+	if err := maddr.MarshalCBOR(&seed); err != nil {
+		return 0, xerrors.Errorf("failed to serialize address: %w", err)
+	}
+	if err := binary.Write(&seed, binary.BigEndian, currEpoch); err != nil {
+		return 0, xerrors.Errorf("failed to serialize epoch: %w", err)
+	}
+	digest := blake2b.Sum256(seed.Bytes())
+	return abi.ChainEpoch(binary.BigEndian.Uint64(digest[:8]) % uint64(miner.WPoStProvingPeriod)), nil
+}
+
+// currentProvingPeriodStart returns the start of the proving period with an offset that contains
+// the current epoch.
+func currentProvingPeriodStart(currEpoch abi.ChainEpoch, offset abi.ChainEpoch) abi.ChainEpoch {
+	currModulus := currEpoch % miner.WPoStProvingPeriod
+	var periodProgress abi.ChainEpoch
+	if currModulus >= offset {
+		periodProgress = currModulus - offset
+	} else {
+		periodProgress = miner.WPoStProvingPeriod - (offset - currModulus)
+	}
+	return currEpoch - periodProgress
+}
+
+// setDeadlines assigns sectors round-robin to the deadlines that may accept new sectors, filling
+// partitions in order, and stores the deadlines with their partitions and expiration queues.
+func (b *builder) setDeadlines(st *miner.State, sectors []*miner.SectorOnChainInfo, sectorSize abi.SectorSize, partitionSectors uint64) error {
+	emptyDeadline, err := miner.ConstructDeadline(b.store)
+	if err != nil {
+		return xerrors.Errorf("failed to construct empty deadline: %w", err)
+	}
+	emptyDeadline.LivePower = miner.NewPowerPairZero()
+	emptyDeadline.DailyFee = big.Zero()
+	emptyDeadlineCid, err := b.store.Put(b.store.Context(), emptyDeadline)
+	if err != nil {
+		return err
+	}
+	deadlines := miner.ConstructDeadlines(emptyDeadlineCid)
+
+	// The current and next deadlines are immutable.
+	var mutable []uint64
+	for dlIdx := uint64(0); dlIdx < miner.WPoStPeriodDeadlines; dlIdx++ {
+		dlInfo := miner.NewDeadlineInfo(st.ProvingPeriodStart, dlIdx, GenesisEpoch).NextNotElapsed()
+		if GenesisEpoch < dlInfo.Open-miner.WPoStChallengeWindow {
+			mutable = append(mutable, dlIdx)
+		}
+	}
+	byDeadline := make([][]*miner.SectorOnChainInfo, miner.WPoStPeriodDeadlines)
+	for i, s := range sectors {
+		dlIdx := mutable[i%len(mutable)]
+		byDeadline[dlIdx] = append(byDeadline[dlIdx], s)
+	}
+
+	for dlIdx, dlSectors := range byDeadline {
+		if len(dlSectors) == 0 {
+			continue
+		}
+		quant := st.QuantSpecForDeadline(uint64(dlIdx))
+
+		dl, err := miner.ConstructDeadline(b.store)
+		if err != nil {
+			return err
+		}
+		dl.LivePower = miner.NewPowerPairZero()
+		dl.DailyFee = big.Zero()
+
+		partitions, err := adt.MakeEmptyArray(b.store, miner.DeadlinePartitionsAmtBitwidth)
+		if err != nil {
+			return err
+		}
+		partitionsByExpiration := make(map[abi.ChainEpoch][]uint64)
+		for pIdx := uint64(0); pIdx*partitionSectors < uint64(len(dlSectors)); pIdx++ {
+			partSectors := dlSectors[pIdx*partitionSectors : min((pIdx+1)*partitionSectors, uint64(len(dlSectors)))]
+			partition, expirations, err := b.newPartition(partSectors, sectorSize, quant)
+			if err != nil {
+				return xerrors.Errorf("failed to set up deadline %d partition %d: %w", dlIdx, pIdx, err)
+			}
+			if err := partitions.AppendContinuous(partition); err != nil {
+				return err
+			}
+			for _, e := range expirations {
+				partitionsByExpiration[e] = append(partitionsByExpiration[e], pIdx)
+			}
+
+			dl.LiveSectors += uint64(len(partSectors))
+			dl.TotalSectors += uint64(len(partSectors))
+			dl.LivePower = dl.LivePower.Add(partition.LivePower)
+			for _, s := range partSectors {
+				dl.DailyFee = big.Add(dl.DailyFee, s.DailyFee)
+			}
+		}
+		if dl.Partitions, err = partitions.Root(); err != nil {
+			return err
+		}
+
+		expirationEpochs, err := adt.MakeEmptyArray(b.store, miner.DeadlineExpirationAmtBitwidth)
+		if err != nil {
+			return err
+		}
+		for epoch, pIdxs := range partitionsByExpiration { // nolint:nomaprange
+			bf := bitfield.NewFromSet(pIdxs)
+			if err := expirationEpochs.Set(uint64(epoch), &bf); err != nil {
+				return err
+			}
+		}
+		if dl.ExpirationsEpochs, err = expirationEpochs.Root(); err != nil {
+			return err
+		}
+
+		if err := deadlines.UpdateDeadline(b.store, uint64(dlIdx), dl); err != nil {
+			return err
+		}
+	}
+
+	return st.SaveDeadlines(b.store, deadlines)
+}
+
+// newPartition builds a proven partition of the given sectors, returning it with the epochs of its
+// expiration queue.
+func (b *builder) newPartition(sectors []*miner.SectorOnChainInfo, sectorSize abi.SectorSize, quant builtin.QuantSpec) (*miner.Partition, []abi.ChainEpoch, error) {
+	sectorNos := make([]uint64, len(sectors))
+	livePower := miner.NewPowerPairZero()
+
+	type expiration struct {
+		sectors []uint64
+		set     miner.ExpirationSet
+	}
+	byEpoch := make(map[abi.ChainEpoch]*expiration)
+	var epochs []abi.ChainEpoch
+	for i, s := range sectors {
+		sectorNos[i] = uint64(s.SectorNumber)
+		pwr := miner.NewPowerPair(big.NewIntUnsigned(uint64(sectorSize)), miner.QAPowerForSector(sectorSize, s))
+		livePower = livePower.Add(pwr)
+
+		epoch := quant.QuantizeUp(s.Expiration)
+		e, ok := byEpoch[epoch]
+		if !ok {
+			e = &expiration{set: miner.ExpirationSet{
+				OnTimePledge: big.Zero(),
+				ActivePower:  miner.NewPowerPairZero(),
+				FaultyPower:  miner.NewPowerPairZero(),
+				FeeDeduction: big.Zero(),
+			}}
+			byEpoch[epoch] = e
+			epochs = append(epochs, epoch)
+		}
+		e.sectors = append(e.sectors, uint64(s.SectorNumber))
+		e.set.OnTimePledge = big.Add(e.set.OnTimePledge, s.InitialPledge)
+		e.set.ActivePower = e.set.ActivePower.Add(pwr)
+		e.set.FeeDeduction = big.Add(e.set.FeeDeduction, s.DailyFee)
+	}
+
+	queue, err := adt.MakeEmptyArray(b.store, miner.PartitionExpirationAmtBitwidth)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, epoch := range epochs {
+		e := byEpoch[epoch]
+		e.set.OnTimeSectors = bitfield.NewFromSet(e.sectors)
+		e.set.EarlySectors = bitfield.New()
+		if err := queue.Set(uint64(epoch), &e.set); err != nil {
+			return nil, nil, err
+		}
+	}
+	queueRoot, err := queue.Root()
+	if err != nil {
+		return nil, nil, err
+	}
+	earlyTerminated, err := adt.StoreEmptyArray(b.store, miner.PartitionEarlyTerminationArrayAmtBitwidth)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &miner.Partition{
+		Sectors:           bitfield.NewFromSet(sectorNos),
+		Unproven:          bitfield.New(),
+		Faults:            bitfield.New(),
+		Recoveries:        bitfield.New(),
+		Terminated:        bitfield.New(),
+		ExpirationsEpochs: queueRoot,
+		EarlyTerminated:   earlyTerminated,
+		LivePower:         livePower,
+		UnprovenPower:     miner.NewPowerPairZero(),
+		FaultyPower:       miner.NewPowerPairZero(),
+		RecoveringPower:   miner.NewPowerPairZero(),
+	}, epochs, nil
+}
@@ -0,0 +1,181 @@
+package init
+
+import (
+	"bytes"
+
+	"github.com/ipfs/go-cid"
+	cbg "github.com/whyrusleeping/cbor-gen"
+	"golang.org/x/xerrors"
+
+	addr "github.com/filecoin-project/go-address"
+	hamt "github.com/filecoin-project/go-hamt-ipld/v3"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/builtin"
+	"github.com/filecoin-project/go-state-types/builtin/v19/util/adt"
+)
+
+// ResolveAddresses resolves many addresses to ID-addresses, loading the address map once so that
+// lookups share the nodes they traverse.
+// ID addresses resolve to themselves. Addresses not found in the mapping are absent from the result.
+func (s *State) ResolveAddresses(store adt.Store, addresses []addr.Address) (map[addr.Address]addr.Address, error) {
+	m, err := adt.AsMap(store, s.AddressMap, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to load address map: %w", err)
+	}
+
+	out := make(map[addr.Address]addr.Address, len(addresses))
+	for _, address := range addresses {
+		if address.Protocol() == addr.ID {
+			out[address] = address
+			continue
+		}
+		if _, ok := out[address]; ok {
+			continue
+		}
+		var actorID cbg.CborInt
+		found, err := m.Get(abi.AddrKey(address), &actorID)
+		if err != nil {
+			return nil, xerrors.Errorf("failed to get %v from address map: %w", address, err)
+		}
+		if !found {
+			continue
+		}
+		idAddr, err := addr.NewIDAddress(uint64(actorID))
+		if err != nil {
+			return nil, err
+		}
+		out[address] = idAddr
+	}
+	return out, nil
+}
+
+// AddressIndex is an in-memory index of the init actor's address map, resolving addresses in both
+// directions.
+// An actor ID maps to at most one robust (f1, f2 or f3) address and one delegated (f4) address:
+// actors created through the Ethereum Address Manager have both an f2 and an f4 address.
+type AddressIndex struct {
+	// The root of the address map indexed.
+	Root cid.Cid
+
+	ids       map[addr.Address]abi.ActorID
+	robust    map[abi.ActorID]addr.Address
+	delegated map[abi.ActorID]addr.Address
+}
+
+// NewAddressIndex indexes the address map of an init actor state.
+func NewAddressIndex(store adt.Store, st *State) (*AddressIndex, error) {
+	m, err := adt.AsMap(store, st.AddressMap, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to load address map: %w", err)
+	}
+
+	idx := &AddressIndex{
+		Root:      st.AddressMap,
+		ids:       make(map[addr.Address]abi.ActorID),
+		robust:    make(map[abi.ActorID]addr.Address),
+		delegated: make(map[abi.ActorID]addr.Address),
+	}
+	var actorID cbg.CborInt
+	err = m.ForEach(&actorID, func(key string) error {
+		address, err := addr.NewFromBytes([]byte(key))
+		if err != nil {
+			return xerrors.Errorf("invalid address map key %x: %w", key, err)
+		}
+		idx.put(address, abi.ActorID(actorID))
+		return nil
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("failed to index address map: %w", err)
+	}
+	return idx, nil
+}
+
+// Update brings the index up to date with the address map of a later init actor state, applying
+// only the entries that differ from those indexed.
+func (idx *AddressIndex) Update(store adt.Store, st *State) error {
+	options := append(adt.DefaultHamtOptions, hamt.UseTreeBitWidth(builtin.DefaultHamtBitwidth))
+	changes, err := hamt.Diff(store.Context(), store, store, idx.Root, st.AddressMap, options...)
+	if err != nil {
+		return xerrors.Errorf("failed to diff address maps %v and %v: %w", idx.Root, st.AddressMap, err)
+	}
+
+	for _, change := range changes {
+		address, err := addr.NewFromBytes([]byte(change.Key))
+		if err != nil {
+			return xerrors.Errorf("invalid address map key %x: %w", change.Key, err)
+		}
+		if change.Type == hamt.Remove || change.Type == hamt.Modify {
+			idx.remove(address)
+		}
+		if change.Type == hamt.Add || change.Type == hamt.Modify {
+			var actorID cbg.CborInt
+			if err := actorID.UnmarshalCBOR(bytes.NewReader(change.After.Raw)); err != nil {
+				return xerrors.Errorf("failed to decode ID of %v: %w", address, err)
+			}
+			idx.put(address, abi.ActorID(actorID))
+		}
+	}
+	idx.Root = st.AddressMap
+	return nil
+}
+
+// AddActor records the delegated address carried by an actor's state, for actors whose f4 address
+// is not in the indexed address map.
+func (idx *AddressIndex) AddActor(id abi.ActorID, actor *builtin.ActorV5) {
+	if actor.DelegatedAddress == nil || actor.DelegatedAddress.Protocol() != addr.Delegated {
+		return
+	}
+	if _, ok := idx.delegated[id]; !ok {
+		idx.put(*actor.DelegatedAddress, id)
+	}
+}
+
+// ResolveAddress returns the ID of the actor with an address. ID addresses resolve to themselves.
+func (idx *AddressIndex) ResolveAddress(address addr.Address) (abi.ActorID, bool) {
+	if address.Protocol() == addr.ID {
+		id, err := addr.IDFromAddress(address)
+		return abi.ActorID(id), err == nil
+	}
+	id, ok := idx.ids[address]
+	return id, ok
+}
+
+// RobustAddress returns the robust (f1, f2 or f3) address of an actor.
+func (idx *AddressIndex) RobustAddress(id abi.ActorID) (addr.Address, bool) {
+	a, ok := idx.robust[id]
+	return a, ok
+}
+
+// DelegatedAddress returns the delegated (f4) address of an actor.
+func (idx *AddressIndex) DelegatedAddress(id abi.ActorID) (addr.Address, bool) {
+	a, ok := idx.delegated[id]
+	return a, ok
+}
+
+// Len returns the number of addresses indexed.
+func (idx *AddressIndex) Len() int {
+	return len(idx.ids)
+}
+
+func (idx *AddressIndex) put(address addr.Address, id abi.ActorID) {
+	idx.ids[address] = id
+	if address.Protocol() == addr.Delegated {
+		idx.delegated[id] = address
+	} else {
+		idx.robust[id] = address
+	}
+}
+
+func (idx *AddressIndex) remove(address addr.Address) {
+	id, ok := idx.ids[address]
+	if !ok {
+		return
+	}
+	delete(idx.ids, address)
+	if idx.robust[id] == address {
+		delete(idx.robust, id)
+	}
+	if idx.delegated[id] == address {
+		delete(idx.delegated, id)
+	}
+}
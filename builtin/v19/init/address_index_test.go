@@ -0,0 +1,72 @@
+package init
+
+import (
+	"context"
+	"testing"
+
+	cbor "github.com/ipfs/go-ipld-cbor"
+	"github.com/stretchr/testify/require"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/builtin"
+	"github.com/filecoin-project/go-state-types/builtin/v19/util/adt"
+	"github.com/filecoin-project/go-state-types/test_util"
+)
+
+func TestAddressIndex(t *testing.T) {
+	store := adt.WrapStore(context.Background(), cbor.NewCborStore(test_util.NewBlockStoreInMemory()))
+	st, err := ConstructState(store, "test")
+	require.NoError(t, err)
+
+	actorAddr, err := addr.NewActorAddress([]byte("actor"))
+	require.NoError(t, err)
+	keyAddr, err := addr.NewSecp256k1Address(make([]byte, 65))
+	require.NoError(t, err)
+	f4, err := addr.NewDelegatedAddress(builtin.EthereumAddressManagerActorID, make([]byte, 20))
+	require.NoError(t, err)
+
+	actorID, err := st.MapAddressToNewID(store, actorAddr)
+	require.NoError(t, err)
+	idx, err := NewAddressIndex(store, st)
+	require.NoError(t, err)
+	require.Equal(t, 1, idx.Len())
+
+	// Map an f4 address to the same actor and a new account, then catch the index up.
+	m, err := adt.AsMap(store, st.AddressMap, builtin.DefaultHamtBitwidth)
+	require.NoError(t, err)
+	id, err := addr.IDFromAddress(actorID)
+	require.NoError(t, err)
+	value := cbg.CborInt(id)
+	require.NoError(t, m.Put(abi.AddrKey(f4), &value))
+	st.AddressMap, err = m.Root()
+	require.NoError(t, err)
+	accountID, err := st.MapAddressToNewID(store, keyAddr)
+	require.NoError(t, err)
+	require.NoError(t, idx.Update(store, st))
+	require.Equal(t, 3, idx.Len())
+
+	robust, found := idx.RobustAddress(abi.ActorID(id))
+	require.True(t, found)
+	require.Equal(t, actorAddr, robust)
+	delegated, found := idx.DelegatedAddress(abi.ActorID(id))
+	require.True(t, found)
+	require.Equal(t, f4, delegated)
+	resolved, found := idx.ResolveAddress(f4)
+	require.True(t, found)
+	require.Equal(t, abi.ActorID(id), resolved)
+	_, found = idx.DelegatedAddress(abi.ActorID(id + 1))
+	require.False(t, found)
+
+	unknown, err := addr.NewActorAddress([]byte("unknown"))
+	require.NoError(t, err)
+	ids, err := st.ResolveAddresses(store, []addr.Address{actorAddr, keyAddr, f4, unknown, builtin.InitActorAddr})
+	require.NoError(t, err)
+	require.Equal(t, map[addr.Address]addr.Address{
+		actorAddr:             actorID,
+		keyAddr:               accountID,
+		f4:                    actorID,
+		builtin.InitActorAddr: builtin.InitActorAddr,
+	}, ids)
+}
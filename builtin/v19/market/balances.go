@@ -0,0 +1,179 @@
+package market
+
+import (
+	"golang.org/x/xerrors"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin/v19/util/adt"
+)
+
+// The purpose of a locked balance, determining which of the state's totals accounts for it.
+type LockReason int
+
+const (
+	ClientCollateral LockReason = iota
+	ClientStorageFee
+	ProviderCollateral
+)
+
+// Balances is a mutable view of the market's escrow and locked balance tables.
+// The locked balance of an address is the portion of its escrow reserved for deals; the rest is
+// available to withdraw or lock. Changes are written to the state by Flush.
+type Balances struct {
+	st     *State
+	Escrow *adt.BalanceTable
+	Locked *adt.BalanceTable
+}
+
+// LoadBalances loads the escrow and locked balance tables of the state.
+func (st *State) LoadBalances(store adt.Store) (*Balances, error) {
+	escrow, err := adt.AsBalanceTable(store, st.EscrowTable)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to load escrow table: %w", err)
+	}
+	locked, err := adt.AsBalanceTable(store, st.LockedTable)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to load locked table: %w", err)
+	}
+	return &Balances{st: st, Escrow: escrow, Locked: locked}, nil
+}
+
+// Available returns the escrow balance of an address that is not locked.
+func (b *Balances) Available(a addr.Address) (abi.TokenAmount, error) {
+	escrow, err := b.Escrow.Get(a)
+	if err != nil {
+		return big.Zero(), xerrors.Errorf("failed to get escrow balance of %v: %w", a, err)
+	}
+	locked, err := b.Locked.Get(a)
+	if err != nil {
+		return big.Zero(), xerrors.Errorf("failed to get locked balance of %v: %w", a, err)
+	}
+	return big.Sub(escrow, locked), nil
+}
+
+// ForEach iterates over the addresses with escrow, with their escrow and locked balances.
+func (b *Balances) ForEach(cb func(a addr.Address, escrow, locked abi.TokenAmount) error) error {
+	return b.Escrow.ForEach(func(a addr.Address, escrow abi.TokenAmount) error {
+		locked, err := b.Locked.Get(a)
+		if err != nil {
+			return xerrors.Errorf("failed to get locked balance of %v: %w", a, err)
+		}
+		return cb(a, escrow, locked)
+	})
+}
+
+// Deposit adds an amount to the escrow of an address.
+func (b *Balances) Deposit(a addr.Address, amount abi.TokenAmount) error {
+	if amount.Sign() < 0 {
+		return xerrors.Errorf("negative deposit %v", amount)
+	}
+	if err := b.Escrow.Add(a, amount); err != nil {
+		return xerrors.Errorf("failed to add escrow of %v: %w", a, err)
+	}
+	return nil
+}
+
+// Withdraw removes up to an amount from the escrow of an address, without touching its locked
+// balance. Returns the amount withdrawn.
+func (b *Balances) Withdraw(a addr.Address, amount abi.TokenAmount) (abi.TokenAmount, error) {
+	if amount.Sign() < 0 {
+		return big.Zero(), xerrors.Errorf("negative withdrawal %v", amount)
+	}
+	locked, err := b.Locked.Get(a)
+	if err != nil {
+		return big.Zero(), xerrors.Errorf("failed to get locked balance of %v: %w", a, err)
+	}
+	withdrawn, err := b.Escrow.SubtractWithMinimum(a, amount, locked)
+	if err != nil {
+		return big.Zero(), xerrors.Errorf("failed to subtract escrow of %v: %w", a, err)
+	}
+	return withdrawn, nil
+}
+
+// Lock reserves an amount of the available escrow of an address.
+func (b *Balances) Lock(a addr.Address, amount abi.TokenAmount, reason LockReason) error {
+	if amount.Sign() < 0 {
+		return xerrors.Errorf("negative amount %v to lock", amount)
+	}
+	available, err := b.Available(a)
+	if err != nil {
+		return err
+	}
+	if amount.GreaterThan(available) {
+		return xerrors.Errorf("insufficient balance for %v to lock %v, available %v", a, amount, available)
+	}
+	if err := b.Locked.Add(a, amount); err != nil {
+		return xerrors.Errorf("failed to add locked balance of %v: %w", a, err)
+	}
+	return b.addTotal(reason, amount)
+}
+
+// Unlock releases an amount of the locked balance of an address.
+func (b *Balances) Unlock(a addr.Address, amount abi.TokenAmount, reason LockReason) error {
+	if amount.Sign() < 0 {
+		return xerrors.Errorf("negative amount %v to unlock", amount)
+	}
+	if err := b.Locked.MustSubtract(a, amount); err != nil {
+		return xerrors.Errorf("failed to subtract locked balance of %v: %w", a, err)
+	}
+	return b.addTotal(reason, amount.Neg())
+}
+
+// Transfer pays a locked amount of storage fee from a client's escrow to the escrow of a provider.
+func (b *Balances) Transfer(from, to addr.Address, amount abi.TokenAmount) error {
+	if amount.Sign() < 0 {
+		return xerrors.Errorf("negative amount %v to transfer", amount)
+	}
+	if err := b.Escrow.MustSubtract(from, amount); err != nil {
+		return xerrors.Errorf("failed to subtract escrow of %v: %w", from, err)
+	}
+	if err := b.Unlock(from, amount, ClientStorageFee); err != nil {
+		return err
+	}
+	if err := b.Escrow.Add(to, amount); err != nil {
+		return xerrors.Errorf("failed to add escrow of %v: %w", to, err)
+	}
+	return nil
+}
+
+// Slash removes a locked amount from the escrow of an address.
+func (b *Balances) Slash(a addr.Address, amount abi.TokenAmount, reason LockReason) error {
+	if amount.Sign() < 0 {
+		return xerrors.Errorf("negative amount %v to slash", amount)
+	}
+	if err := b.Escrow.MustSubtract(a, amount); err != nil {
+		return xerrors.Errorf("failed to subtract escrow of %v: %w", a, err)
+	}
+	return b.Unlock(a, amount, reason)
+}
+
+// Flush writes the balance tables to the store and their roots to the state.
+func (b *Balances) Flush() error {
+	escrow, err := b.Escrow.Root()
+	if err != nil {
+		return xerrors.Errorf("failed to flush escrow table: %w", err)
+	}
+	locked, err := b.Locked.Root()
+	if err != nil {
+		return xerrors.Errorf("failed to flush locked table: %w", err)
+	}
+	b.st.EscrowTable = escrow
+	b.st.LockedTable = locked
+	return nil
+}
+
+func (b *Balances) addTotal(reason LockReason, amount abi.TokenAmount) error {
+	switch reason {
+	case ClientCollateral:
+		b.st.TotalClientLockedCollateral = big.Add(b.st.TotalClientLockedCollateral, amount)
+	case ClientStorageFee:
+		b.st.TotalClientStorageFee = big.Add(b.st.TotalClientStorageFee, amount)
+	case ProviderCollateral:
+		b.st.TotalProviderLockedCollateral = big.Add(b.st.TotalProviderLockedCollateral, amount)
+	default:
+		return xerrors.Errorf("unknown lock reason %d", reason)
+	}
+	return nil
+}
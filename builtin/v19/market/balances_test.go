@@ -0,0 +1,66 @@
+package market
+
+import (
+	"context"
+	"testing"
+
+	cbor "github.com/ipfs/go-ipld-cbor"
+	"github.com/stretchr/testify/require"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin/v19/util/adt"
+	"github.com/filecoin-project/go-state-types/test_util"
+)
+
+func TestBalances(t *testing.T) {
+	store := adt.WrapStore(context.Background(), cbor.NewCborStore(test_util.NewBlockStoreInMemory()))
+	st, err := ConstructState(store)
+	require.NoError(t, err)
+	client, err := addr.NewIDAddress(1000)
+	require.NoError(t, err)
+	provider, err := addr.NewIDAddress(1001)
+	require.NoError(t, err)
+
+	b, err := st.LoadBalances(store)
+	require.NoError(t, err)
+	require.NoError(t, b.Deposit(client, big.NewInt(100)))
+	require.NoError(t, b.Deposit(provider, big.NewInt(50)))
+	require.NoError(t, b.Lock(client, big.NewInt(60), ClientStorageFee))
+	require.NoError(t, b.Lock(client, big.NewInt(10), ClientCollateral))
+	require.Error(t, b.Lock(client, big.NewInt(31), ClientCollateral))
+	require.NoError(t, b.Lock(provider, big.NewInt(50), ProviderCollateral))
+
+	available, err := b.Available(client)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(30), available)
+	withdrawn, err := b.Withdraw(client, big.NewInt(50))
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(30), withdrawn)
+
+	// Pay the storage fee to the provider, who is slashed all of its collateral.
+	require.NoError(t, b.Transfer(client, provider, big.NewInt(60)))
+	require.Error(t, b.Transfer(client, provider, big.NewInt(11)))
+	require.NoError(t, b.Slash(provider, big.NewInt(50), ProviderCollateral))
+	require.NoError(t, b.Flush())
+
+	b, err = st.LoadBalances(store)
+	require.NoError(t, err)
+	balances := map[addr.Address][2]abi.TokenAmount{}
+	require.NoError(t, b.ForEach(func(a addr.Address, escrow, locked abi.TokenAmount) error {
+		balances[a] = [2]abi.TokenAmount{escrow, locked}
+		return nil
+	}))
+	require.Equal(t, map[addr.Address][2]abi.TokenAmount{
+		client:   {big.NewInt(10), big.NewInt(10)},
+		provider: {big.NewInt(60), big.Zero()},
+	}, balances)
+	total, err := b.Escrow.Total()
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(70), total)
+
+	require.Equal(t, big.NewInt(10), st.TotalClientLockedCollateral)
+	require.True(t, st.TotalClientStorageFee.IsZero())
+	require.True(t, st.TotalProviderLockedCollateral.IsZero())
+}
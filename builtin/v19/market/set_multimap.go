@@ -87,3 +87,26 @@ func (mm *SetMultimap) ForEach(epoch abi.ChainEpoch, fn func(id abi.DealID) erro
 	}
 	return nil
 }
+
+// Puts the deal ID in the set for an epoch, creating the set if needed.
+func (mm *SetMultimap) Put(epoch abi.ChainEpoch, id abi.DealID) error {
+	k := abi.UIntKey(uint64(epoch))
+	set, found, err := mm.get(k)
+	if err != nil {
+		return err
+	}
+	if !found {
+		set, err = adt.MakeEmptySet(mm.store, mm.innerBitwidth)
+		if err != nil {
+			return err
+		}
+	}
+	if err := set.Put(abi.UIntKey(uint64(id))); err != nil {
+		return xerrors.Errorf("failed to add deal %d to set for epoch %d: %w", id, epoch, err)
+	}
+	setRoot, err := set.Root()
+	if err != nil {
+		return xerrors.Errorf("failed to flush set for epoch %d: %w", epoch, err)
+	}
+	return mm.mp.Put(k, cbg.CborCid(setRoot))
+}
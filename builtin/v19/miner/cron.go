@@ -0,0 +1,50 @@
+package miner
+
+import (
+	"bytes"
+	"fmt"
+
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/builtin/v19/power"
+	"github.com/filecoin-project/go-state-types/builtin/v19/util/smoothing"
+)
+
+func (t CronEventType) String() string {
+	switch t {
+	case CronEventWorkerKeyChange:
+		return "WorkerKeyChange"
+	case CronEventProvingDeadline:
+		return "ProvingDeadline"
+	case CronEventProcessEarlyTerminations:
+		return "ProcessEarlyTerminations"
+	default:
+		return fmt.Sprintf("CronEventType(%d)", int64(t))
+	}
+}
+
+// NewDeferredCronEventParams returns the parameters with which the power actor calls a miner for a
+// cron event, given the smoothed reward and power estimates of the epoch making the call.
+func NewDeferredCronEventParams(event *power.CronEvent, rewardSmoothed, qualityAdjPowerSmoothed smoothing.FilterEstimate) *DeferredCronEventParams {
+	return &DeferredCronEventParams{
+		EventPayload:            event.CallbackPayload,
+		RewardSmoothed:          rewardSmoothed,
+		QualityAdjPowerSmoothed: qualityAdjPowerSmoothed,
+	}
+}
+
+// DecodePayload decodes the miner cron event payload carried by the parameters.
+func (p *DeferredCronEventParams) DecodePayload() (*CronEventPayload, error) {
+	var payload CronEventPayload
+	if err := payload.UnmarshalCBOR(bytes.NewReader(p.EventPayload)); err != nil {
+		return nil, xerrors.Errorf("failed to decode cron event payload %x: %w", p.EventPayload, err)
+	}
+	return &payload, nil
+}
+
+// DeadlineCronEpoch returns the epoch for which the miner's proving deadline cron event is scheduled
+// while DeadlineCronActive is set: the last epoch of the current deadline.
+func (st *State) DeadlineCronEpoch() abi.ChainEpoch {
+	return NewDeadlineInfo(st.ProvingPeriodStart, st.CurrentDeadline, 0).Last()
+}
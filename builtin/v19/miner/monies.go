@@ -94,25 +94,7 @@ func InitialPledgeForPower(
 	pledgeShareNum := qaPower
 	networkQAPower := smoothing.Estimate(&networkQAPowerEstimate)
 
-	// Once FIP-0081 has fully activated, additional pledge will be 70% baseline
-	// pledge + 30% simple pledge.
-	const fip0081ActivationPermille = 300
-	// Gamma/GAMMA_FIXED_POINT_FACTOR is the share of pledge coming from the
-	// baseline formulation, with 1-(gamma/GAMMA_FIXED_POINT_FACTOR) coming from
-	// simple pledge.
-	// gamma = 1000 - 300 * (epochs_since_ramp_start / ramp_duration_epochs).max(0).min(1)
-	var skew uint64
-	switch {
-	case epochsSinceRampStart < 0:
-		// No skew before ramp start
-		skew = 0
-	case rampDurationEpochs == 0 || epochsSinceRampStart >= int64(rampDurationEpochs):
-		// 100% skew after ramp end
-		skew = fip0081ActivationPermille
-	case epochsSinceRampStart > 0:
-		skew = (uint64(epochsSinceRampStart*fip0081ActivationPermille) / rampDurationEpochs)
-	}
-	gamma := big.NewInt(int64(GammaFixedPointFactor - skew))
+	gamma := big.NewInt(PledgeRampGamma(epochsSinceRampStart, rampDurationEpochs))
 
 	additionalIPNum := big.Mul(lockTargetNum, pledgeShareNum)
 
@@ -133,6 +115,29 @@ func InitialPledgeForPower(
 	return big.Min(nominalPledge, pledgeCap)
 }
 
+// Once FIP-0081 has fully activated, additional pledge will be 70% baseline
+// pledge + 30% simple pledge.
+const fip0081ActivationPermille = 300
+
+// Gamma/GAMMA_FIXED_POINT_FACTOR is the share of pledge coming from the
+// baseline formulation, with 1-(gamma/GAMMA_FIXED_POINT_FACTOR) coming from
+// simple pledge.
+// gamma = 1000 - 300 * (epochs_since_ramp_start / ramp_duration_epochs).max(0).min(1)
+func PledgeRampGamma(epochsSinceRampStart int64, rampDurationEpochs uint64) int64 {
+	var skew uint64
+	switch {
+	case epochsSinceRampStart < 0:
+		// No skew before ramp start
+		skew = 0
+	case rampDurationEpochs == 0 || epochsSinceRampStart >= int64(rampDurationEpochs):
+		// 100% skew after ramp end
+		skew = fip0081ActivationPermille
+	case epochsSinceRampStart > 0:
+		skew = (uint64(epochsSinceRampStart*fip0081ActivationPermille) / rampDurationEpochs)
+	}
+	return int64(GammaFixedPointFactor - skew)
+}
+
 // Maximum number of lifetime days penalized when a sector is terminated.
 const TerminationLifetimeCap abi.ChainEpoch = 140
 
@@ -158,11 +163,25 @@ const ContinuedFaultFactorNum = 351
 const ContinuedFaultFactorDenom = 100
 const ContinuedFaultProjectionPeriod abi.ChainEpoch = (builtin.EpochsInDay * ContinuedFaultFactorNum) / ContinuedFaultFactorDenom
 
+// The network's continued fault projection period. It defaults to ContinuedFaultProjectionPeriod and is
+// set by a network profile.
+var networkContinuedFaultProjectionPeriod = ContinuedFaultProjectionPeriod
+
+// NetworkContinuedFaultProjectionPeriod returns the network's continued fault projection period.
+func NetworkContinuedFaultProjectionPeriod() abi.ChainEpoch {
+	return networkContinuedFaultProjectionPeriod
+}
+
+// SetNetworkContinuedFaultProjectionPeriod sets the network's continued fault projection period.
+func SetNetworkContinuedFaultProjectionPeriod(period abi.ChainEpoch) {
+	networkContinuedFaultProjectionPeriod = period
+}
+
 // PledgePenaltyForContinuedFault calculates the penalty for a sector continuing faulty for another
 // proving period.
 // It is a projection of the expected reward earned by the sector. Also known as "FF(t)"
 func PledgePenaltyForContinuedFault(rewardEstimate smoothing.FilterEstimate, networkQaPowerEstimate smoothing.FilterEstimate, qaSectorPower abi.StoragePower) abi.TokenAmount {
-	return ExpectedRewardForPower(rewardEstimate, networkQaPowerEstimate, qaSectorPower, ContinuedFaultProjectionPeriod)
+	return ExpectedRewardForPower(rewardEstimate, networkQaPowerEstimate, qaSectorPower, networkContinuedFaultProjectionPeriod)
 }
 
 // PledgePenaltyForTermination Calculates termination fee for a given sector. Normally, it's
@@ -178,7 +197,7 @@ func PledgePenaltyForTermination(
 		big.Div(big.Mul(initialPledge, TermFeePledgeMultiple.Numerator), TermFeePledgeMultiple.Denominator)
 
 	durationTerminationFee :=
-		big.Div(big.Mul(big.NewInt(int64(sectorAge)), simpleTerminationFee), big.NewInt(int64(TerminationLifetimeCap*builtin.EpochsInDay)))
+		big.Div(big.Mul(big.NewInt(int64(sectorAge)), simpleTerminationFee), big.NewInt(int64(TerminationLifetimeCap*builtin.NetworkEpochsInDay())))
 
 	// Apply the age adjustment for young sectors to arrive at the base termination fee.
 	baseTerminationFee := big.Min(simpleTerminationFee, durationTerminationFee)
@@ -0,0 +1,63 @@
+package miner
+
+import (
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin"
+	"github.com/filecoin-project/go-state-types/builtin/v19/power"
+	"github.com/filecoin-project/go-state-types/builtin/v19/reward"
+)
+
+// PledgeRampPoint is the split of additional initial pledge between the baseline and simple
+// formulations at an epoch of the FIP-0081 ramp.
+type PledgeRampPoint struct {
+	Epoch abi.ChainEpoch
+	// Share of additional pledge from the baseline formulation, out of GammaFixedPointFactor.
+	Gamma int64
+}
+
+// PledgeRampProgress returns the fraction of the FIP-0081 ramp of a power state elapsed at an epoch,
+// from zero before the ramp starts to one once it has ended.
+func PledgeRampProgress(st *power.State, epoch abi.ChainEpoch) builtin.BigFrac {
+	elapsed := int64(epoch) - st.RampStartEpoch
+	switch {
+	case elapsed < 0:
+		return builtin.BigFrac{Numerator: big.Zero(), Denominator: big.NewInt(1)}
+	case st.RampDurationEpochs == 0 || elapsed >= int64(st.RampDurationEpochs):
+		return builtin.BigFrac{Numerator: big.NewInt(1), Denominator: big.NewInt(1)}
+	default:
+		return builtin.BigFrac{Numerator: big.NewInt(elapsed), Denominator: big.NewIntUnsigned(st.RampDurationEpochs)}
+	}
+}
+
+// PledgeRampGammaAt returns gamma for a sector activated at an epoch under the ramp of a power state.
+func PledgeRampGammaAt(st *power.State, epoch abi.ChainEpoch) int64 {
+	return PledgeRampGamma(int64(epoch)-st.RampStartEpoch, st.RampDurationEpochs)
+}
+
+// PledgeRampSchedule projects gamma every step epochs from an epoch until the ramp of a power state
+// ends. The last point is the end of the ramp, or the first epoch if the ramp has already ended.
+func PledgeRampSchedule(st *power.State, from, step abi.ChainEpoch) ([]PledgeRampPoint, error) {
+	if step <= 0 {
+		return nil, xerrors.Errorf("step %d must be positive", step)
+	}
+	end := abi.ChainEpoch(st.RampStartEpoch + int64(st.RampDurationEpochs))
+	var points []PledgeRampPoint
+	for epoch := from; epoch < end; epoch += step {
+		points = append(points, PledgeRampPoint{Epoch: epoch, Gamma: PledgeRampGammaAt(st, epoch)})
+	}
+	end = max(end, from)
+	return append(points, PledgeRampPoint{Epoch: end, Gamma: PledgeRampGammaAt(st, end)}), nil
+}
+
+// ProjectInitialPledge estimates the initial pledge of a sector of some quality-adjusted power
+// activated at an epoch no earlier than that of the reward state.
+// The baseline power is projected to the epoch and the ramp follows the power state, while the
+// smoothed reward and network power estimates and the circulating supply are taken as constant.
+func ProjectInitialPledge(powerSt *power.State, rewardSt *reward.State, circulatingSupply abi.TokenAmount, qaPower abi.StoragePower, epoch abi.ChainEpoch) abi.TokenAmount {
+	baselinePower := reward.ProjectBaselinePower(rewardSt.ThisEpochBaselinePower, epoch-rewardSt.Epoch)
+	return InitialPledgeForPower(qaPower, baselinePower, rewardSt.ThisEpochRewardSmoothed, powerSt.ThisEpochQAPowerSmoothed,
+		circulatingSupply, int64(epoch)-powerSt.RampStartEpoch, powerSt.RampDurationEpochs)
+}
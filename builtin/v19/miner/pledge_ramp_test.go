@@ -0,0 +1,66 @@
+package miner_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin"
+	"github.com/filecoin-project/go-state-types/builtin/v19/miner"
+	"github.com/filecoin-project/go-state-types/builtin/v19/power"
+	"github.com/filecoin-project/go-state-types/builtin/v19/reward"
+	"github.com/filecoin-project/go-state-types/builtin/v19/util/smoothing"
+)
+
+func TestPledgeRamp(t *testing.T) {
+	powerSt := &power.State{
+		RampStartEpoch:           100,
+		RampDurationEpochs:       1000,
+		ThisEpochQAPowerSmoothed: smoothing.NewEstimate(big.NewInt(1<<60), big.Zero()),
+	}
+
+	progress := miner.PledgeRampProgress(powerSt, 600)
+	require.EqualValues(t, 500, progress.Numerator.Int64())
+	require.EqualValues(t, 1000, progress.Denominator.Int64())
+	require.EqualValues(t, 0, miner.PledgeRampProgress(powerSt, 50).Numerator.Int64())
+	require.EqualValues(t, 1, miner.PledgeRampProgress(powerSt, 2000).Numerator.Int64())
+
+	schedule, err := miner.PledgeRampSchedule(powerSt, 0, 400)
+	require.NoError(t, err)
+	require.Equal(t, []miner.PledgeRampPoint{
+		{Epoch: 0, Gamma: 1000},
+		{Epoch: 400, Gamma: 910},
+		{Epoch: 800, Gamma: 790},
+		{Epoch: 1100, Gamma: 700},
+	}, schedule)
+
+	schedule, err = miner.PledgeRampSchedule(powerSt, 5000, 400)
+	require.NoError(t, err)
+	require.Equal(t, []miner.PledgeRampPoint{{Epoch: 5000, Gamma: 700}}, schedule)
+
+	_, err = miner.PledgeRampSchedule(powerSt, 0, 0)
+	require.Error(t, err)
+
+	rewardSt := reward.ConstructState(big.Zero())
+	circSupply := big.Mul(big.NewInt(1e6), builtin.TokenPrecision)
+	qaPower := abi.NewStoragePower(32 << 30)
+	at := func(epoch abi.ChainEpoch) abi.TokenAmount {
+		return miner.ProjectInitialPledge(powerSt, rewardSt, circSupply, qaPower, epoch)
+	}
+
+	// At the reward state's epoch, the projection is the pledge the miner actor would require.
+	require.True(t, at(rewardSt.Epoch).Equals(miner.InitialPledgeForPower(qaPower, rewardSt.ThisEpochBaselinePower,
+		rewardSt.ThisEpochRewardSmoothed, powerSt.ThisEpochQAPowerSmoothed, circSupply,
+		int64(rewardSt.Epoch)-powerSt.RampStartEpoch, powerSt.RampDurationEpochs)))
+	// With the network below the baseline, the pledge grows as the ramp shifts weight to simple pledge.
+	require.True(t, at(1100).GreaterThan(at(100)))
+
+	projected := reward.ProjectBaselinePower(rewardSt.ThisEpochBaselinePower, 1000)
+	iterated := rewardSt.ThisEpochBaselinePower
+	for i := 0; i < 1000; i++ {
+		iterated = reward.BaselinePowerFromPrev(iterated)
+	}
+	require.True(t, big.Sub(projected, iterated).Abs().LessThan(big.NewInt(1000)))
+}
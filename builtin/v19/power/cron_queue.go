@@ -0,0 +1,134 @@
+package power
+
+import (
+	"sort"
+
+	"github.com/ipfs/go-cid"
+	cbg "github.com/whyrusleeping/cbor-gen"
+	"golang.org/x/xerrors"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/builtin"
+	"github.com/filecoin-project/go-state-types/builtin/v19/util/adt"
+)
+
+// ScheduledCronEvent is a cron event in the queue with the epoch it is scheduled for.
+type ScheduledCronEvent struct {
+	Epoch abi.ChainEpoch
+	CronEvent
+}
+
+// CronCall is a call the power actor's cron makes to a miner for a scheduled event.
+type CronCall struct {
+	// The epoch whose cron makes the call. This is after the scheduled epoch if cron has fallen behind.
+	Epoch abi.ChainEpoch
+	Event ScheduledCronEvent
+}
+
+// CronSimulation is the outcome of the power actor's cron over a range of epochs.
+type CronSimulation struct {
+	// Calls to miners, in the order they are made.
+	Calls []CronCall
+	// Events dropped because their miner has no power claim.
+	Skipped []ScheduledCronEvent
+	// Events scheduled before FirstCronEpoch, which are never processed.
+	Stranded []ScheduledCronEvent
+}
+
+// LoadCronEvents returns the events scheduled in an epoch range (inclusive), ordered by epoch and
+// then by their order in the queue.
+func (st *State) LoadCronEvents(store adt.Store, from, to abi.ChainEpoch) ([]ScheduledCronEvent, error) {
+	return st.filterCronEvents(store, func(epoch abi.ChainEpoch) bool {
+		return epoch >= from && epoch <= to
+	}, nil)
+}
+
+// MinerCronEvents returns the events scheduled for a miner, ordered by epoch.
+func (st *State) MinerCronEvents(store adt.Store, miner addr.Address) ([]ScheduledCronEvent, error) {
+	return st.filterCronEvents(store, nil, func(e *CronEvent) bool {
+		return e.MinerAddr == miner
+	})
+}
+
+// SimulateCron determines the calls the power actor's cron makes to miners if it runs at every epoch
+// in a range (inclusive), starting from this state.
+// The first run processes every event from FirstCronEpoch on, so events missed by null rounds are
+// called then. Events that the calls schedule themselves, such as the next proving deadline of a
+// miner, are not included.
+func (st *State) SimulateCron(store adt.Store, from, to abi.ChainEpoch) (*CronSimulation, error) {
+	events, err := st.filterCronEvents(store, func(epoch abi.ChainEpoch) bool {
+		return epoch <= to
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+	claims, err := adt.AsMap(store, st.Claims, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to load claims: %w", err)
+	}
+
+	sim := &CronSimulation{}
+	for _, event := range events {
+		if event.Epoch < st.FirstCronEpoch {
+			sim.Stranded = append(sim.Stranded, event)
+			continue
+		}
+		found, err := claims.Has(abi.AddrKey(event.MinerAddr))
+		if err != nil {
+			return nil, xerrors.Errorf("failed to look up claim for %v: %w", event.MinerAddr, err)
+		}
+		if !found {
+			sim.Skipped = append(sim.Skipped, event)
+			continue
+		}
+		// Events are ordered by epoch, so calls are too.
+		epoch := event.Epoch
+		if epoch < from {
+			epoch = from
+		}
+		sim.Calls = append(sim.Calls, CronCall{Epoch: epoch, Event: event})
+	}
+	return sim, nil
+}
+
+// filterCronEvents returns the events scheduled at epochs accepted by epochFilter that are accepted
+// by eventFilter, ordered by epoch and then by their order in the queue. Nil filters accept all.
+func (st *State) filterCronEvents(store adt.Store, epochFilter func(abi.ChainEpoch) bool, eventFilter func(*CronEvent) bool) ([]ScheduledCronEvent, error) {
+	queue, err := adt.AsMap(store, st.CronEventQueue, CronQueueHamtBitwidth)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to load cron event queue: %w", err)
+	}
+
+	var out []ScheduledCronEvent
+	var arrRoot cbg.CborCid
+	err = queue.ForEach(&arrRoot, func(k string) error {
+		key, err := abi.ParseIntKey(k)
+		if err != nil {
+			return xerrors.Errorf("invalid cron event queue key: %w", err)
+		}
+		epoch := abi.ChainEpoch(key)
+		if epochFilter != nil && !epochFilter(epoch) {
+			return nil
+		}
+		arr, err := adt.AsArray(store, cid.Cid(arrRoot), CronQueueAmtBitwidth)
+		if err != nil {
+			return xerrors.Errorf("failed to load cron events for epoch %d: %w", epoch, err)
+		}
+		var event CronEvent
+		return arr.ForEach(&event, func(i int64) error {
+			if eventFilter == nil || eventFilter(&event) {
+				out = append(out, ScheduledCronEvent{Epoch: epoch, CronEvent: event})
+			}
+			// Decoding an empty payload leaves the previous one in place.
+			event = CronEvent{}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("failed to iterate cron event queue: %w", err)
+	}
+
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Epoch < out[j].Epoch })
+	return out, nil
+}
@@ -0,0 +1,76 @@
+package power
+
+import (
+	"context"
+	"testing"
+
+	cbor "github.com/ipfs/go-ipld-cbor"
+	"github.com/stretchr/testify/require"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin"
+	"github.com/filecoin-project/go-state-types/builtin/v19/util/adt"
+	"github.com/filecoin-project/go-state-types/test_util"
+)
+
+func TestCronQueue(t *testing.T) {
+	store := adt.WrapStore(context.Background(), cbor.NewCborStore(test_util.NewBlockStoreInMemory()))
+	st, err := ConstructState(store)
+	require.NoError(t, err)
+	minerA, err := addr.NewIDAddress(1000)
+	require.NoError(t, err)
+	minerB, err := addr.NewIDAddress(1001)
+	require.NoError(t, err)
+
+	claims, err := adt.AsMap(store, st.Claims, builtin.DefaultHamtBitwidth)
+	require.NoError(t, err)
+	require.NoError(t, claims.Put(abi.AddrKey(minerA), &Claim{RawBytePower: big.Zero(), QualityAdjPower: big.Zero()}))
+	st.Claims, err = claims.Root()
+	require.NoError(t, err)
+
+	event := func(miner addr.Address, payload ...byte) CronEvent {
+		return CronEvent{MinerAddr: miner, CallbackPayload: payload}
+	}
+	queue, err := adt.AsMap(store, st.CronEventQueue, CronQueueHamtBitwidth)
+	require.NoError(t, err)
+	for epoch, events := range map[abi.ChainEpoch][]CronEvent{
+		5:  {event(minerA, 1)},
+		10: {event(minerA, 2)},
+		20: {event(minerB, 3)},
+		30: {event(minerA), event(minerA, 4)},
+	} {
+		arr, err := adt.MakeEmptyArray(store, CronQueueAmtBitwidth)
+		require.NoError(t, err)
+		for i := range events {
+			require.NoError(t, arr.AppendContinuous(&events[i]))
+		}
+		arrRoot, err := arr.Root()
+		require.NoError(t, err)
+		require.NoError(t, queue.Put(abi.IntKey(int64(epoch)), cbg.CborCid(arrRoot)))
+	}
+	st.CronEventQueue, err = queue.Root()
+	require.NoError(t, err)
+	st.FirstCronEpoch = 8
+
+	events, err := st.LoadCronEvents(store, 10, 30)
+	require.NoError(t, err)
+	require.Equal(t, []ScheduledCronEvent{
+		{Epoch: 10, CronEvent: event(minerA, 2)},
+		{Epoch: 20, CronEvent: event(minerB, 3)},
+		{Epoch: 30, CronEvent: CronEvent{MinerAddr: minerA}},
+		{Epoch: 30, CronEvent: event(minerA, 4)},
+	}, events)
+
+	events, err = st.MinerCronEvents(store, minerB)
+	require.NoError(t, err)
+	require.Equal(t, []ScheduledCronEvent{{Epoch: 20, CronEvent: event(minerB, 3)}}, events)
+
+	sim, err := st.SimulateCron(store, 12, 29)
+	require.NoError(t, err)
+	require.Equal(t, []CronCall{{Epoch: 12, Event: ScheduledCronEvent{Epoch: 10, CronEvent: event(minerA, 2)}}}, sim.Calls)
+	require.Equal(t, []ScheduledCronEvent{{Epoch: 20, CronEvent: event(minerB, 3)}}, sim.Skipped)
+	require.Equal(t, []ScheduledCronEvent{{Epoch: 5, CronEvent: event(minerA, 1)}}, sim.Stranded)
+}
@@ -0,0 +1,34 @@
+package power
+
+import (
+	"context"
+	"testing"
+
+	cbor "github.com/ipfs/go-ipld-cbor"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin"
+	"github.com/filecoin-project/go-state-types/builtin/v19/util/adt"
+	"github.com/filecoin-project/go-state-types/test_util"
+)
+
+func TestCheckStateInvariantsViolations(t *testing.T) {
+	store := adt.WrapStore(context.Background(), cbor.NewCborStore(test_util.NewBlockStoreInMemory()))
+	st, err := ConstructState(store)
+	require.NoError(t, err)
+
+	_, acc := CheckStateInvariants(st, store)
+	require.True(t, acc.IsEmpty(), acc.Messages())
+
+	st.TotalRawBytePower = big.NewInt(-1)
+	_, acc = CheckStateInvariants(st, store)
+	vs := acc.Violations()
+	require.Len(t, vs, 2)
+	require.Equal(t, builtin.InvariantPowerTotalRawNonNegative, vs[0].ID)
+	require.Equal(t, builtin.SeverityError, vs[0].Severity)
+	require.Equal(t, builtin.ViolationFields{"actual": big.NewInt(-1)}, vs[0].Fields)
+	require.Equal(t, "total raw power is negative -1", vs[0].Message)
+	require.Equal(t, builtin.InvariantPowerClaimsRaw, vs[1].ID)
+	require.Equal(t, builtin.ViolationFields{"actual": big.NewInt(-1), "expected": big.Zero()}, vs[1].Fields)
+}
@@ -34,6 +34,21 @@ func BaselinePowerFromPrev(prevEpochBaselinePower abi.StoragePower) abi.StorageP
 	return big.Rsh(thisEpochBaselinePower, math.Precision128)                   // Q.128 => Q.0
 }
 
+// ProjectBaselinePower computes the baseline power a number of epochs after an epoch with the given
+// baseline power. The exponent is raised by squaring, so the result may differ from that of
+// iterating BaselinePowerFromPrev by rounding.
+func ProjectBaselinePower(baselinePower abi.StoragePower, epochs abi.ChainEpoch) abi.StoragePower {
+	factor := big.Lsh(big.NewInt(1), math.Precision128) // Q.128
+	base := BaselineExponent
+	for n := epochs; n > 0; n >>= 1 {
+		if n&1 == 1 {
+			factor = big.Rsh(big.Mul(factor, base), math.Precision128) // Q.128 * Q.128 => Q.128
+		}
+		base = big.Rsh(big.Mul(base, base), math.Precision128)
+	}
+	return big.Rsh(big.Mul(baselinePower, factor), math.Precision128) // Q.0 * Q.128 => Q.0
+}
+
 // These numbers are estimates of the onchain constants.  They are good for initializing state in
 // devnets and testing but will not match the on chain values exactly which depend on storage onboarding
 // and upgrade epoch history. They are in units of attoFIL, 10^-18 FIL
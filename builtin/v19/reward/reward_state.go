@@ -86,6 +86,13 @@ func ConstructState(currRealizedPower abi.StoragePower) *State {
 	return st
 }
 
+// AdvanceEpoch updates the state to track the reward for the next epoch given the network's
+// realized power, as the reward actor does at the end of an epoch. The smoothed reward estimate is
+// left unchanged.
+func (st *State) AdvanceEpoch(currRealizedPower abi.StoragePower) {
+	st.updateToNextEpochWithReward(currRealizedPower)
+}
+
 // Takes in current realized power and updates internal state
 // Used for update of internal state during null rounds
 func (st *State) updateToNextEpoch(currRealizedPower abi.StoragePower) {
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"path/filepath"
+
 	"github.com/filecoin-project/go-state-types/builtin/v8/account"
 	"github.com/filecoin-project/go-state-types/builtin/v8/cron"
 	init_ "github.com/filecoin-project/go-state-types/builtin/v8/init"
@@ -13,26 +15,27 @@ import (
 	"github.com/filecoin-project/go-state-types/builtin/v8/system"
 	"github.com/filecoin-project/go-state-types/builtin/v8/util/smoothing"
 	"github.com/filecoin-project/go-state-types/builtin/v8/verifreg"
+	"github.com/filecoin-project/go-state-types/ipld/schemagen"
 	gen "github.com/whyrusleeping/cbor-gen"
 )
 
 func main() {
 	// Actors
-	if err := gen.WriteTupleEncodersToFile("./builtin/v8/system/cbor_gen.go", "system",
+	if err := writeTupleEncoders("./builtin/v8/system", "system",
 		// actor state
 		system.State{},
 	); err != nil {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v8/account/cbor_gen.go", "account",
+	if err := writeTupleEncoders("./builtin/v8/account", "account",
 		// actor state
 		account.State{},
 	); err != nil {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v8/init/cbor_gen.go", "init",
+	if err := writeTupleEncoders("./builtin/v8/init", "init",
 		// actor state
 		init_.State{},
 		// method params and returns
@@ -43,7 +46,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v8/cron/cbor_gen.go", "cron",
+	if err := writeTupleEncoders("./builtin/v8/cron", "cron",
 		// actor state
 		cron.State{},
 		cron.Entry{},
@@ -52,7 +55,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v8/reward/cbor_gen.go", "reward",
+	if err := writeTupleEncoders("./builtin/v8/reward", "reward",
 		// actor state
 		reward.State{},
 		// method params and returns
@@ -62,7 +65,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v8/multisig/cbor_gen.go", "multisig",
+	if err := writeTupleEncoders("./builtin/v8/multisig", "multisig",
 		// actor state
 		multisig.State{},
 		multisig.Transaction{},
@@ -82,7 +85,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v8/paych/cbor_gen.go", "paych",
+	if err := writeTupleEncoders("./builtin/v8/paych", "paych",
 		// actor state
 		paych.State{},
 		paych.LaneState{},
@@ -97,7 +100,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v8/power/cbor_gen.go", "power",
+	if err := writeTupleEncoders("./builtin/v8/power", "power",
 		// actors state
 		power.State{},
 		power.Claim{},
@@ -114,7 +117,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v8/market/cbor_gen.go", "market",
+	if err := writeTupleEncoders("./builtin/v8/market", "market",
 		// actor state
 		market.State{},
 		market.DealState{},
@@ -138,7 +141,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v8/miner/cbor_gen.go", "miner",
+	if err := writeTupleEncoders("./builtin/v8/miner", "miner",
 		// actor state
 		miner.State{},
 		miner.MinerInfo{},
@@ -191,7 +194,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v8/verifreg/cbor_gen.go", "verifreg",
+	if err := writeTupleEncoders("./builtin/v8/verifreg", "verifreg",
 		// actor state
 		verifreg.State{},
 
@@ -209,9 +212,18 @@ func main() {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v8/util/smoothing/cbor_gen.go", "smoothing",
+	if err := writeTupleEncoders("./builtin/v8/util/smoothing", "smoothing",
 		smoothing.FilterEstimate{},
 	); err != nil {
 		panic(err)
 	}
 }
+
+// writeTupleEncoders generates the CBOR tuple encoders of types from the package in dir, along with
+// the IPLD schema describing them.
+func writeTupleEncoders(dir, pkg string, types ...interface{}) error {
+	if err := gen.WriteTupleEncodersToFile(filepath.Join(dir, "cbor_gen.go"), pkg, types...); err != nil {
+		return err
+	}
+	return schemagen.WriteSchemaToDir(dir, pkg, types...)
+}
@@ -17,12 +17,14 @@ type StateSummary struct {
 // Checks internal invariants of verified registry state.
 func CheckStateInvariants(st *State, store adt.Store) (*StateSummary, *builtin.MessageAccumulator) {
 	acc := &builtin.MessageAccumulator{}
-	acc.Require(st.RootKey.Protocol() == addr.ID, "root key %v should have ID protocol", st.RootKey)
+	acc.RequireInvariant(builtin.InvariantVerifregRootKeyAddress, st.RootKey.Protocol() == addr.ID,
+		builtin.ViolationFields{"root_key": st.RootKey},
+		"root key %v should have ID protocol", st.RootKey)
 
 	// Check verifiers
 	allVerifiers := map[addr.Address]DataCap{}
 	if verifiers, err := adt.AsMap(store, st.Verifiers, builtin.DefaultHamtBitwidth); err != nil {
-		acc.Addf("error loading verifiers: %v", err)
+		acc.RequireInvariantNoError(builtin.InvariantVerifregStateLoadable, err, "error loading verifiers")
 	} else {
 		var vcap abi.StoragePower
 		err = verifiers.ForEach(&vcap, func(key string) error {
@@ -30,18 +32,22 @@ func CheckStateInvariants(st *State, store adt.Store) (*StateSummary, *builtin.M
 			if err != nil {
 				return err
 			}
-			acc.Require(verifier.Protocol() == addr.ID, "verifier %v should have ID protocol", verifier)
-			acc.Require(vcap.GreaterThanEqual(big.Zero()), "verifier %v cap %v is negative", verifier, vcap)
+			acc.RequireInvariant(builtin.InvariantVerifregVerifierAddress, verifier.Protocol() == addr.ID,
+				builtin.ViolationFields{"verifier": verifier},
+				"verifier %v should have ID protocol", verifier)
+			acc.RequireInvariant(builtin.InvariantVerifregVerifierCap, vcap.GreaterThanEqual(big.Zero()),
+				builtin.ViolationFields{"verifier": verifier, "actual": vcap},
+				"verifier %v cap %v is negative", verifier, vcap)
 			allVerifiers[verifier] = vcap.Copy()
 			return nil
 		})
-		acc.RequireNoError(err, "error iterating verifiers")
+		acc.RequireInvariantNoError(builtin.InvariantVerifregStateLoadable, err, "error iterating verifiers")
 	}
 
 	// Check clients
 	allClients := map[addr.Address]DataCap{}
 	if clients, err := adt.AsMap(store, st.VerifiedClients, builtin.DefaultHamtBitwidth); err != nil {
-		acc.Addf("error loading clients: %v", err)
+		acc.RequireInvariantNoError(builtin.InvariantVerifregStateLoadable, err, "error loading clients")
 	} else {
 		var ccap abi.StoragePower
 		err = clients.ForEach(&ccap, func(key string) error {
@@ -49,18 +55,24 @@ func CheckStateInvariants(st *State, store adt.Store) (*StateSummary, *builtin.M
 			if err != nil {
 				return err
 			}
-			acc.Require(client.Protocol() == addr.ID, "client %v should have ID protocol", client)
-			acc.Require(ccap.GreaterThanEqual(big.Zero()), "client %v cap %v is negative", client, ccap)
+			acc.RequireInvariant(builtin.InvariantVerifregClientAddress, client.Protocol() == addr.ID,
+				builtin.ViolationFields{"client": client},
+				"client %v should have ID protocol", client)
+			acc.RequireInvariant(builtin.InvariantVerifregClientCap, ccap.GreaterThanEqual(big.Zero()),
+				builtin.ViolationFields{"client": client, "actual": ccap},
+				"client %v cap %v is negative", client, ccap)
 			allClients[client] = ccap.Copy()
 			return nil
 		})
-		acc.RequireNoError(err, "error iterating clients")
+		acc.RequireInvariantNoError(builtin.InvariantVerifregStateLoadable, err, "error iterating clients")
 	}
 
 	// Check verifiers and clients are disjoint.
 	for v := range allVerifiers { //nolint:nomaprange
 		_, found := allClients[v]
-		acc.Require(!found, "verifier %v is also a client", v)
+		acc.RequireInvariant(builtin.InvariantVerifregVerifierClient, !found,
+			builtin.ViolationFields{"verifier": v},
+			"verifier %v is also a client", v)
 	}
 	// No need to iterate all clients; any overlap must have been one of all verifiers.
 
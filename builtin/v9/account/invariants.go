@@ -18,9 +18,10 @@ func CheckStateInvariants(st *State, idAddr address.Address) (*StateSummary, *bu
 	}
 
 	if id, err := address.IDFromAddress(idAddr); err != nil {
-		acc.Addf("error extracting actor ID from address: %v", err)
+		acc.RequireInvariantNoError(builtin.InvariantAccountIDAddress, err, "error extracting actor ID from address")
 	} else if id >= builtin.FirstNonSingletonActorId {
-		acc.Require(st.Address.Protocol() == address.BLS || st.Address.Protocol() == address.SECP256K1,
+		acc.RequireInvariant(builtin.InvariantAccountAddressProtocol, st.Address.Protocol() == address.BLS || st.Address.Protocol() == address.SECP256K1,
+			builtin.ViolationFields{"address": st.Address},
 			"actor address %v must be BLS or SECP256K1 protocol", st.Address)
 	}
 
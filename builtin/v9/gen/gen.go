@@ -1,6 +1,8 @@
 package main
 
 import (
+	"path/filepath"
+
 	"github.com/filecoin-project/go-state-types/builtin/v9/account"
 	"github.com/filecoin-project/go-state-types/builtin/v9/cron"
 	"github.com/filecoin-project/go-state-types/builtin/v9/datacap"
@@ -14,19 +16,20 @@ import (
 	"github.com/filecoin-project/go-state-types/builtin/v9/system"
 	"github.com/filecoin-project/go-state-types/builtin/v9/util/smoothing"
 	"github.com/filecoin-project/go-state-types/builtin/v9/verifreg"
+	"github.com/filecoin-project/go-state-types/ipld/schemagen"
 	gen "github.com/whyrusleeping/cbor-gen"
 )
 
 func main() {
 	// Actors
-	if err := gen.WriteTupleEncodersToFile("./builtin/v9/system/cbor_gen.go", "system",
+	if err := writeTupleEncoders("./builtin/v9/system", "system",
 		// actor state
 		system.State{},
 	); err != nil {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v9/account/cbor_gen.go", "account",
+	if err := writeTupleEncoders("./builtin/v9/account", "account",
 		// actor state
 		account.State{},
 		// method params and returns
@@ -35,7 +38,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v9/init/cbor_gen.go", "init",
+	if err := writeTupleEncoders("./builtin/v9/init", "init",
 		// actor state
 		init_.State{},
 		// method params and returns
@@ -46,7 +49,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v9/cron/cbor_gen.go", "cron",
+	if err := writeTupleEncoders("./builtin/v9/cron", "cron",
 		// actor state
 		cron.State{},
 		cron.Entry{},
@@ -55,7 +58,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v9/reward/cbor_gen.go", "reward",
+	if err := writeTupleEncoders("./builtin/v9/reward", "reward",
 		// actor state
 		reward.State{},
 		// method params and returns
@@ -65,7 +68,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v9/multisig/cbor_gen.go", "multisig",
+	if err := writeTupleEncoders("./builtin/v9/multisig", "multisig",
 		// actor state
 		multisig.State{},
 		multisig.Transaction{},
@@ -85,7 +88,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v9/paych/cbor_gen.go", "paych",
+	if err := writeTupleEncoders("./builtin/v9/paych", "paych",
 		// actor state
 		paych.State{},
 		paych.LaneState{},
@@ -100,7 +103,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v9/power/cbor_gen.go", "power",
+	if err := writeTupleEncoders("./builtin/v9/power", "power",
 		// actors state
 		power.State{},
 		power.Claim{},
@@ -117,7 +120,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v9/market/cbor_gen.go", "market",
+	if err := writeTupleEncoders("./builtin/v9/market", "market",
 		// actor state
 		market.State{},
 		market.DealState{},
@@ -144,7 +147,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v9/miner/cbor_gen.go", "miner",
+	if err := writeTupleEncoders("./builtin/v9/miner", "miner",
 		// actor state
 		miner.State{},
 		miner.MinerInfo{},
@@ -209,7 +212,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v9/verifreg/cbor_gen.go", "verifreg",
+	if err := writeTupleEncoders("./builtin/v9/verifreg", "verifreg",
 		// actor state
 		verifreg.State{},
 
@@ -247,7 +250,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v9/datacap/cbor_gen.go", "datacap",
+	if err := writeTupleEncoders("./builtin/v9/datacap", "datacap",
 		// actor state
 		datacap.State{},
 		datacap.TokenState{},
@@ -272,9 +275,18 @@ func main() {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./builtin/v9/util/smoothing/cbor_gen.go", "smoothing",
+	if err := writeTupleEncoders("./builtin/v9/util/smoothing", "smoothing",
 		smoothing.FilterEstimate{},
 	); err != nil {
 		panic(err)
 	}
 }
+
+// writeTupleEncoders generates the CBOR tuple encoders of types from the package in dir, along with
+// the IPLD schema describing them.
+func writeTupleEncoders(dir, pkg string, types ...interface{}) error {
+	if err := gen.WriteTupleEncodersToFile(filepath.Join(dir, "cbor_gen.go"), pkg, types...); err != nil {
+		return err
+	}
+	return schemagen.WriteSchemaToDir(dir, pkg, types...)
+}
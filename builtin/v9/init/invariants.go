@@ -18,8 +18,11 @@ type StateSummary struct {
 func CheckStateInvariants(st *State, store adt.Store) (*StateSummary, *builtin.MessageAccumulator) {
 	acc := &builtin.MessageAccumulator{}
 
-	acc.Require(len(st.NetworkName) > 0, "network name is empty")
-	acc.Require(st.NextID >= builtin.FirstNonSingletonActorId, "next id %d is too low", st.NextID)
+	acc.RequireInvariant(builtin.InvariantInitNetworkName, len(st.NetworkName) > 0, nil,
+		"network name is empty")
+	acc.RequireInvariant(builtin.InvariantInitNextID, st.NextID >= builtin.FirstNonSingletonActorId,
+		builtin.ViolationFields{"actual": st.NextID, "expected_min": builtin.FirstNonSingletonActorId},
+		"next id %d is too low", st.NextID)
 
 	initSummary := &StateSummary{
 		AddrIDs: nil,
@@ -28,7 +31,7 @@ func CheckStateInvariants(st *State, store adt.Store) (*StateSummary, *builtin.M
 
 	lut, err := adt.AsMap(store, st.AddressMap, builtin.DefaultHamtBitwidth)
 	if err != nil {
-		acc.Addf("error loading address map: %v", err)
+		acc.RequireInvariantNoError(builtin.InvariantInitStateLoadable, err, "error loading address map")
 		// Stop here, it's hard to make other useful checks.
 		return initSummary, acc
 	}
@@ -43,17 +46,25 @@ func CheckStateInvariants(st *State, store adt.Store) (*StateSummary, *builtin.M
 			return err
 		}
 
-		acc.Require(keyAddr.Protocol() != addr.ID, "key %v is an ID address", keyAddr)
-		acc.Require(keyAddr.Protocol() <= addr.BLS, "unknown address protocol for key %v", keyAddr)
-		acc.Require(actorId >= builtin.FirstNonSingletonActorId, "unexpected singleton ID value %v", actorId)
+		acc.RequireInvariant(builtin.InvariantInitKeyNotID, keyAddr.Protocol() != addr.ID,
+			builtin.ViolationFields{"key": keyAddr},
+			"key %v is an ID address", keyAddr)
+		acc.RequireInvariant(builtin.InvariantInitKeyProtocol, keyAddr.Protocol() <= addr.BLS,
+			builtin.ViolationFields{"key": keyAddr},
+			"unknown address protocol for key %v", keyAddr)
+		acc.RequireInvariant(builtin.InvariantInitMappedNonSingleton, actorId >= builtin.FirstNonSingletonActorId,
+			builtin.ViolationFields{"id": actorId},
+			"unexpected singleton ID value %v", actorId)
 
 		foundAddr, found := reverse[actorId]
-		acc.Require(!found, "duplicate mapping to ID %v: %v, %v", actorId, keyAddr, foundAddr)
+		acc.RequireInvariant(builtin.InvariantInitDuplicateMapping, !found,
+			builtin.ViolationFields{"id": actorId, "key": keyAddr, "existing_key": foundAddr},
+			"duplicate mapping to ID %v: %v, %v", actorId, keyAddr, foundAddr)
 		reverse[actorId] = keyAddr
 
 		initSummary.AddrIDs[keyAddr] = actorId
 		return nil
 	})
-	acc.RequireNoError(err, "error iterating address map")
+	acc.RequireInvariantNoError(builtin.InvariantInitStateLoadable, err, "error iterating address map")
 	return initSummary, acc
 }
@@ -13,6 +13,9 @@ import (
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
 	acrypto "github.com/filecoin-project/go-state-types/crypto"
+	"github.com/ipld/go-ipld-prime/datamodel"
+	"github.com/ipld/go-ipld-prime/node/basicnode"
+	"github.com/ipld/go-ipld-prime/node/bindnode"
 	mh "github.com/multiformats/go-multihash"
 	cbg "github.com/whyrusleeping/cbor-gen"
 	"golang.org/x/xerrors"
@@ -190,6 +193,46 @@ func (label *DealLabel) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// DealLabelBindnodeOption converts a DealLabel to and from an Any field
+// holding its string or bytes in a schema
+var DealLabelBindnodeOption = bindnode.TypedAnyConverter(&DealLabel{}, dealLabelFromNode, dealLabelToNode)
+
+func dealLabelFromNode(n datamodel.Node) (interface{}, error) {
+	var label DealLabel
+	switch n.Kind() {
+	case datamodel.Kind_String:
+		s, err := n.AsString()
+		if err != nil {
+			return nil, err
+		}
+		if !utf8.ValidString(s) {
+			return nil, xerrors.Errorf("label string not valid utf8")
+		}
+		label.bs = []byte(s)
+	case datamodel.Kind_Bytes:
+		b, err := n.AsBytes()
+		if err != nil {
+			return nil, err
+		}
+		label.bs = b
+		label.notString = true
+	default:
+		return nil, xerrors.Errorf("unexpected kind %s for DealLabel: only string or bytes expected", n.Kind())
+	}
+	return &label, nil
+}
+
+func dealLabelToNode(iface interface{}) (datamodel.Node, error) {
+	label, ok := iface.(*DealLabel)
+	if !ok {
+		return nil, xerrors.Errorf("expected *DealLabel value")
+	}
+	if label.IsString() {
+		return basicnode.NewString(string(label.bs)), nil
+	}
+	return basicnode.NewBytes(label.bs), nil
+}
+
 // Note: Deal Collateral is only released and returned to clients and miners
 // when the storage deal stops counting towards power. In the current iteration,
 // it will be released when the sector containing the storage deals expires,
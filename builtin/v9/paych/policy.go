@@ -1,7 +1,21 @@
 package paych
 
 import (
+	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/builtin"
 )
 
 const SettleDelay = builtin.EpochsInHour * 12
+
+// The network's settle delay. It defaults to SettleDelay and is set by a network profile.
+var networkSettleDelay = abi.ChainEpoch(SettleDelay)
+
+// NetworkSettleDelay returns the network's payment channel settle delay.
+func NetworkSettleDelay() abi.ChainEpoch {
+	return networkSettleDelay
+}
+
+// SetNetworkSettleDelay sets the network's payment channel settle delay.
+func SetNetworkSettleDelay(delay abi.ChainEpoch) {
+	networkSettleDelay = delay
+}
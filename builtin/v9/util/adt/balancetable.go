@@ -5,6 +5,7 @@ import (
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
 	cid "github.com/ipfs/go-cid"
+	"golang.org/x/xerrors"
 )
 
 // Bitwidth of balance table HAMTs, determined empirically from mutation
@@ -49,3 +50,80 @@ func (t *BalanceTable) Total() (abi.TokenAmount, error) {
 	})
 	return total, err
 }
+
+// Creates a new empty balance table.
+func MakeEmptyBalanceTable(s Store) (*BalanceTable, error) {
+	m, err := MakeEmptyMap(s, BalanceTableBitwidth)
+	if err != nil {
+		return nil, err
+	}
+	return (*BalanceTable)(m), nil
+}
+
+// Writes the table to the store and returns the root CID of the underlying HAMT.
+func (t *BalanceTable) Root() (cid.Cid, error) {
+	return (*Map)(t).Root()
+}
+
+// Adds an amount to a balance, requiring the resulting balance to be non-negative.
+// A balance reduced to zero is removed from the table.
+func (t *BalanceTable) Add(key addr.Address, value abi.TokenAmount) error {
+	prev, err := t.Get(key)
+	if err != nil {
+		return err
+	}
+	sum := big.Add(prev, value)
+	sign := sum.Sign()
+	if sign < 0 {
+		return xerrors.Errorf("adding %v to balance %v would give negative: %v", value, prev, sum)
+	} else if sign == 0 && !prev.IsZero() {
+		return (*Map)(t).Delete(abi.AddrKey(key))
+	} else if sign > 0 {
+		return (*Map)(t).Put(abi.AddrKey(key), &sum)
+	}
+	return nil
+}
+
+// Subtracts up to the specified amount from a balance, without reducing the balance below some minimum.
+// Returns the amount subtracted.
+func (t *BalanceTable) SubtractWithMinimum(key addr.Address, req abi.TokenAmount, floor abi.TokenAmount) (abi.TokenAmount, error) {
+	prev, err := t.Get(key)
+	if err != nil {
+		return big.Zero(), err
+	}
+
+	available := big.Max(big.Zero(), big.Sub(prev, floor))
+	sub := big.Min(available, req)
+	if sub.Sign() > 0 {
+		err = t.Add(key, sub.Neg())
+		if err != nil {
+			return big.Zero(), err
+		}
+	}
+	return sub, nil
+}
+
+// Subtracts the specified amount from a balance.
+// Returns an error if the balance is insufficient.
+func (t *BalanceTable) MustSubtract(key addr.Address, req abi.TokenAmount) error {
+	prev, err := t.Get(key)
+	if err != nil {
+		return err
+	}
+	if req.GreaterThan(prev) {
+		return xerrors.Errorf("couldn't subtract %v from balance %v of %v", req, prev, key)
+	}
+	return t.Add(key, req.Neg())
+}
+
+// Iterates over the non-zero balances in the table.
+func (t *BalanceTable) ForEach(cb func(key addr.Address, balance abi.TokenAmount) error) error {
+	var value abi.TokenAmount
+	return (*Map)(t).ForEach(&value, func(k string) error {
+		key, err := addr.NewFromBytes([]byte(k))
+		if err != nil {
+			return err
+		}
+		return cb(key, value)
+	})
+}
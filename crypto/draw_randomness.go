@@ -0,0 +1,140 @@
+package crypto
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	addr "github.com/filecoin-project/go-address"
+	"golang.org/x/crypto/blake2b"
+
+	"github.com/filecoin-project/go-state-types/abi"
+)
+
+// RandomnessSource is the chain randomness a domain draws from.
+type RandomnessSource int
+
+const (
+	// Randomness from the tickets of the chain's blocks.
+	RandomnessFromTickets RandomnessSource = iota
+	// Randomness from the drand beacon entries included in the chain.
+	RandomnessFromBeacon
+)
+
+func (s RandomnessSource) String() string {
+	switch s {
+	case RandomnessFromTickets:
+		return "tickets"
+	case RandomnessFromBeacon:
+		return "beacon"
+	default:
+		return fmt.Sprintf("RandomnessSource(%d)", int(s))
+	}
+}
+
+var domainSeparationTagNames = map[DomainSeparationTag]string{
+	DomainSeparationTag_TicketProduction:               "TicketProduction",
+	DomainSeparationTag_ElectionProofProduction:        "ElectionProofProduction",
+	DomainSeparationTag_WinningPoStChallengeSeed:       "WinningPoStChallengeSeed",
+	DomainSeparationTag_WindowedPoStChallengeSeed:      "WindowedPoStChallengeSeed",
+	DomainSeparationTag_SealRandomness:                 "SealRandomness",
+	DomainSeparationTag_InteractiveSealChallengeSeed:   "InteractiveSealChallengeSeed",
+	DomainSeparationTag_WindowedPoStDeadlineAssignment: "WindowedPoStDeadlineAssignment",
+	DomainSeparationTag_MarketDealCronSeed:             "MarketDealCronSeed",
+	DomainSeparationTag_PoStChainCommit:                "PoStChainCommit",
+}
+
+func (t DomainSeparationTag) String() string {
+	if name, ok := domainSeparationTagNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("DomainSeparationTag(%d)", int64(t))
+}
+
+// Source returns the randomness the domain draws from.
+// Seal randomness and Window PoSt chain commitments are drawn from tickets, so that they commit to a
+// particular chain; all other domains are drawn from the beacon.
+func (t DomainSeparationTag) Source() RandomnessSource {
+	switch t {
+	case DomainSeparationTag_SealRandomness, DomainSeparationTag_PoStChainCommit:
+		return RandomnessFromTickets
+	default:
+		return RandomnessFromBeacon
+	}
+}
+
+// DrawRandomnessFromBase draws randomness for a domain from a base value, being a ticket's VRF proof
+// or a beacon entry's signature.
+func DrawRandomnessFromBase(base []byte, tag DomainSeparationTag, round abi.ChainEpoch, entropy []byte) [32]byte {
+	return DrawRandomnessFromDigest(blake2b.Sum256(base), tag, round, entropy)
+}
+
+// DrawRandomnessFromDigest draws randomness for a domain from the digest of a base value, as provided
+// to actors by the virtual machine:
+//
+//	blake2b-256(int64_be(tag) || digest || int64_be(round) || entropy)
+func DrawRandomnessFromDigest(digest [32]byte, tag DomainSeparationTag, round abi.ChainEpoch, entropy []byte) [32]byte {
+	// blake2b.New256 only fails when given a key.
+	h, _ := blake2b.New256(nil)
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], uint64(tag))
+	_, _ = h.Write(buf[:])
+	_, _ = h.Write(digest[:])
+	binary.BigEndian.PutUint64(buf[:], uint64(round))
+	_, _ = h.Write(buf[:])
+	_, _ = h.Write(entropy)
+
+	var out [32]byte
+	copy(out[:], h.Sum(nil))
+	return out
+}
+
+// MinerEntropy returns the entropy with which randomness is drawn for a miner: the CBOR encoding
+// of its address.
+func MinerEntropy(miner addr.Address) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := miner.MarshalCBOR(&buf); err != nil {
+		return nil, fmt.Errorf("failed to marshal miner address: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func drawForMiner(digest [32]byte, tag DomainSeparationTag, round abi.ChainEpoch, miner addr.Address) ([]byte, error) {
+	entropy, err := MinerEntropy(miner)
+	if err != nil {
+		return nil, err
+	}
+	r := DrawRandomnessFromDigest(digest, tag, round, entropy)
+	return r[:], nil
+}
+
+// SealRandomness derives the ticket randomness a miner seals a sector with, drawn at the sector's
+// seal randomness epoch.
+func SealRandomness(ticketDigest [32]byte, miner addr.Address, sealRandEpoch abi.ChainEpoch) (abi.SealRandomness, error) {
+	return drawForMiner(ticketDigest, DomainSeparationTag_SealRandomness, sealRandEpoch, miner)
+}
+
+// InteractiveSealChallengeSeed derives the beacon randomness from which a sector's interactive
+// PoRep challenges are generated, drawn at the pre-commit epoch plus the challenge delay.
+func InteractiveSealChallengeSeed(beaconDigest [32]byte, miner addr.Address, seedEpoch abi.ChainEpoch) (abi.InteractiveSealRandomness, error) {
+	return drawForMiner(beaconDigest, DomainSeparationTag_InteractiveSealChallengeSeed, seedEpoch, miner)
+}
+
+// WindowPoStChallengeSeed derives the beacon randomness from which a deadline's Window PoSt
+// challenges are generated, drawn at the deadline's challenge epoch.
+func WindowPoStChallengeSeed(beaconDigest [32]byte, miner addr.Address, challengeEpoch abi.ChainEpoch) (abi.PoStRandomness, error) {
+	return drawForMiner(beaconDigest, DomainSeparationTag_WindowedPoStChallengeSeed, challengeEpoch, miner)
+}
+
+// WinningPoStChallengeSeed derives the beacon randomness from which a block's Winning PoSt
+// challenges are generated.
+func WinningPoStChallengeSeed(beaconDigest [32]byte, miner addr.Address, round abi.ChainEpoch) (abi.PoStRandomness, error) {
+	return drawForMiner(beaconDigest, DomainSeparationTag_WinningPoStChallengeSeed, round, miner)
+}
+
+// PoStChainCommit derives the ticket randomness a Window PoSt submission commits to, drawn at
+// the chain commit epoch without entropy.
+func PoStChainCommit(ticketDigest [32]byte, chainCommitEpoch abi.ChainEpoch) abi.Randomness {
+	r := DrawRandomnessFromDigest(ticketDigest, DomainSeparationTag_PoStChainCommit, chainCommitEpoch, nil)
+	return r[:]
+}
@@ -0,0 +1,58 @@
+package crypto_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/blake2b"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/crypto"
+)
+
+func TestDrawRandomness(t *testing.T) {
+	base := make([]byte, 32)
+	for i := range base {
+		base[i] = byte(i)
+	}
+	miner, err := addr.NewIDAddress(1000)
+	require.NoError(t, err)
+	entropy, err := crypto.MinerEntropy(miner)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x43, 0x00, 0xe8, 0x07}, entropy)
+
+	// Vectors computed independently of this implementation, for every tag at round 12345.
+	for tag, expected := range map[crypto.DomainSeparationTag]string{
+		crypto.DomainSeparationTag_TicketProduction:               "0032323a90a5a9a3ea39d306bc8c8945105e7c90a5ab2cf39ebbf8eff47f3859",
+		crypto.DomainSeparationTag_ElectionProofProduction:        "b795df87de3c57f6d430d180520c2cc43dd4be954143c296ef88bff033dd38ed",
+		crypto.DomainSeparationTag_WinningPoStChallengeSeed:       "450388f901a2414c73205ba2392e44223979229cac70a0e7689b5b8fac21f9e3",
+		crypto.DomainSeparationTag_WindowedPoStChallengeSeed:      "5561f8c3e4ffe459253c8df40d912d4e73d11dc26a6f211635c5e1cb17d9d7c1",
+		crypto.DomainSeparationTag_SealRandomness:                 "fe22b6a20d200582b135bf4e5cc3ef9e7169f356c3f3acc801171f9f0a36701c",
+		crypto.DomainSeparationTag_InteractiveSealChallengeSeed:   "587350f4deeca26223afc9db64eab6355e4ba1a9da683ff3e525ada7a9e6004f",
+		crypto.DomainSeparationTag_WindowedPoStDeadlineAssignment: "6e5dbef68296017a016f461bdbdb409031a13386d8623a7c5b4785f6ccc0d6ee",
+		crypto.DomainSeparationTag_MarketDealCronSeed:             "5f2cc4d61f20992201530348b6ab01001e08d38acef1337bfb4646b67ac94e85",
+		crypto.DomainSeparationTag_PoStChainCommit:                "6c4c4d3747a306f1a044cae48e046379898853f01342c082da76c2200e4c33e3",
+	} {
+		r := crypto.DrawRandomnessFromBase(base, tag, 12345, entropy)
+		assert.Equal(t, expected, hex.EncodeToString(r[:]), tag.String())
+	}
+
+	r := crypto.DrawRandomnessFromBase(nil, crypto.DomainSeparationTag_SealRandomness, -1, nil)
+	assert.Equal(t, "4eef19a4dd03307e40cb6cdc12944660a69fa5f424c23516018fff0f868ef174", hex.EncodeToString(r[:]))
+
+	digest := blake2b.Sum256(base)
+	seal, err := crypto.SealRandomness(digest, miner, 12345)
+	require.NoError(t, err)
+	assert.Equal(t, abi.SealRandomness(mustDecodeHex(t, "fe22b6a20d200582b135bf4e5cc3ef9e7169f356c3f3acc801171f9f0a36701c")), seal)
+	assert.Equal(t, crypto.RandomnessFromTickets, crypto.DomainSeparationTag_SealRandomness.Source())
+	assert.Equal(t, crypto.RandomnessFromBeacon, crypto.DomainSeparationTag_InteractiveSealChallengeSeed.Source())
+}
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return b
+}
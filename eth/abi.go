@@ -0,0 +1,371 @@
+package eth
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/filecoin-project/go-keccak"
+	"golang.org/x/xerrors"
+)
+
+// TypeKind is the kind of a Solidity ABI type.
+type TypeKind int
+
+const (
+	UintKind TypeKind = iota
+	IntKind
+	AddressKind
+	BoolKind
+	FixedBytesKind
+	BytesKind
+	StringKind
+	// A fixed length array, T[N].
+	ArrayKind
+	// A dynamic length array, T[].
+	SliceKind
+	TupleKind
+	// An external function reference: a 20 byte address followed by a 4 byte selector, encoded as
+	// bytes24.
+	FunctionKind
+)
+
+// Type is a Solidity ABI type.
+//
+// Values of each kind are represented in Go as:
+//   - uint<M>, int<M>: big.Int (int, int64 and uint64 are also accepted when encoding)
+//   - address: Address
+//   - bool: bool
+//   - bytes<M>, bytes, function: []byte
+//   - string: string
+//   - arrays and tuples: []interface{} of their elements
+type Type struct {
+	Kind TypeKind
+	// The width in bits of integers, in bytes of fixed size byte arrays and functions, or the length
+	// of arrays.
+	Size int
+	// The element type of arrays.
+	Elem *Type
+	// The fields of tuples.
+	Components []Argument
+}
+
+// Argument is a named input or output of a function or event.
+type Argument struct {
+	Name string
+	Type Type
+	// Whether an event argument is stored in a topic rather than the data.
+	Indexed bool
+}
+
+// Method is a function of a contract.
+type Method struct {
+	Name            string
+	Inputs          []Argument
+	Outputs         []Argument
+	StateMutability string
+}
+
+// Event is an event emitted by a contract.
+type Event struct {
+	Name   string
+	Inputs []Argument
+	// Anonymous events have no topic identifying them.
+	Anonymous bool
+}
+
+// ABI is the interface of a contract, as described by its JSON ABI.
+type ABI struct {
+	Constructor *Method
+	Methods     []*Method
+	Events      []*Event
+}
+
+type jsonArgument struct {
+	Name       string         `json:"name"`
+	Type       string         `json:"type"`
+	Indexed    bool           `json:"indexed"`
+	Components []jsonArgument `json:"components"`
+}
+
+type jsonEntry struct {
+	Type            string         `json:"type"`
+	Name            string         `json:"name"`
+	Inputs          []jsonArgument `json:"inputs"`
+	Outputs         []jsonArgument `json:"outputs"`
+	StateMutability string         `json:"stateMutability"`
+	Anonymous       bool           `json:"anonymous"`
+}
+
+// ParseABI reads a Solidity JSON ABI.
+// Errors, fallback and receive functions carry no calldata encoding and are skipped.
+func ParseABI(r io.Reader) (*ABI, error) {
+	var entries []jsonEntry
+	if err := json.NewDecoder(r).Decode(&entries); err != nil {
+		return nil, xerrors.Errorf("failed to decode ABI: %w", err)
+	}
+
+	out := &ABI{}
+	for _, e := range entries {
+		inputs, err := parseArguments(e.Inputs)
+		if err != nil {
+			return nil, xerrors.Errorf("%s %s inputs: %w", e.Type, e.Name, err)
+		}
+		switch e.Type {
+		case "function", "":
+			outputs, err := parseArguments(e.Outputs)
+			if err != nil {
+				return nil, xerrors.Errorf("function %s outputs: %w", e.Name, err)
+			}
+			out.Methods = append(out.Methods, &Method{Name: e.Name, Inputs: inputs, Outputs: outputs, StateMutability: e.StateMutability})
+		case "constructor":
+			out.Constructor = &Method{Inputs: inputs, StateMutability: e.StateMutability}
+		case "event":
+			indexed := 0
+			for _, in := range inputs {
+				if in.Indexed {
+					indexed++
+				}
+			}
+			if max := eventMaxTopics(e.Anonymous); indexed > max {
+				return nil, xerrors.Errorf("event %s has %d indexed arguments, more than %d", e.Name, indexed, max)
+			}
+			out.Events = append(out.Events, &Event{Name: e.Name, Inputs: inputs, Anonymous: e.Anonymous})
+		case "error", "fallback", "receive":
+		default:
+			return nil, xerrors.Errorf("unknown ABI entry type %q", e.Type)
+		}
+	}
+	return out, nil
+}
+
+// Events have up to 4 topics, the first identifying non-anonymous events.
+func eventMaxTopics(anonymous bool) int {
+	if anonymous {
+		return 4
+	}
+	return 3
+}
+
+func parseArguments(args []jsonArgument) ([]Argument, error) {
+	out := make([]Argument, len(args))
+	for i, a := range args {
+		t, err := parseType(a.Type, a.Components)
+		if err != nil {
+			return nil, xerrors.Errorf("argument %d (%s): %w", i, a.Name, err)
+		}
+		out[i] = Argument{Name: a.Name, Type: t, Indexed: a.Indexed}
+	}
+	return out, nil
+}
+
+// ParseType parses a Solidity type name, such as "uint256" or "bytes32[]". Tuples are described
+// by their components.
+func ParseType(name string, components ...Argument) (Type, error) {
+	if strings.HasPrefix(name, "tuple") {
+		if len(components) == 0 {
+			return Type{}, xerrors.Errorf("tuple type %s has no components", name)
+		}
+	}
+	return parseTypeWith(name, func() ([]Argument, error) { return components, nil })
+}
+
+func parseType(name string, components []jsonArgument) (Type, error) {
+	return parseTypeWith(name, func() ([]Argument, error) { return parseArguments(components) })
+}
+
+func parseTypeWith(name string, components func() ([]Argument, error)) (Type, error) {
+	if strings.HasSuffix(name, "]") {
+		open := strings.LastIndexByte(name, '[')
+		if open < 0 {
+			return Type{}, xerrors.Errorf("invalid type %q", name)
+		}
+		elem, err := parseTypeWith(name[:open], components)
+		if err != nil {
+			return Type{}, err
+		}
+		size := name[open+1 : len(name)-1]
+		if size == "" {
+			return Type{Kind: SliceKind, Elem: &elem}, nil
+		}
+		n, err := strconv.Atoi(size)
+		if err != nil || n <= 0 {
+			return Type{}, xerrors.Errorf("invalid array length in type %q", name)
+		}
+		return Type{Kind: ArrayKind, Size: n, Elem: &elem}, nil
+	}
+
+	switch {
+	case name == "address":
+		return Type{Kind: AddressKind}, nil
+	case name == "bool":
+		return Type{Kind: BoolKind}, nil
+	case name == "string":
+		return Type{Kind: StringKind}, nil
+	case name == "bytes":
+		return Type{Kind: BytesKind}, nil
+	// Function pointers are an address followed by a selector.
+	case name == "function":
+		return Type{Kind: FunctionKind, Size: 24}, nil
+	case name == "tuple":
+		args, err := components()
+		if err != nil {
+			return Type{}, err
+		}
+		return Type{Kind: TupleKind, Components: args}, nil
+	case strings.HasPrefix(name, "bytes"):
+		n, err := strconv.Atoi(name[len("bytes"):])
+		if err != nil || n < 1 || n > 32 {
+			return Type{}, xerrors.Errorf("invalid type %q", name)
+		}
+		return Type{Kind: FixedBytesKind, Size: n}, nil
+	case strings.HasPrefix(name, "uint"), strings.HasPrefix(name, "int"):
+		kind, bits := UintKind, strings.TrimPrefix(name, "uint")
+		if !strings.HasPrefix(name, "uint") {
+			kind, bits = IntKind, strings.TrimPrefix(name, "int")
+		}
+		if bits == "" {
+			return Type{Kind: kind, Size: 256}, nil
+		}
+		n, err := strconv.Atoi(bits)
+		if err != nil || n < 8 || n > 256 || n%8 != 0 {
+			return Type{}, xerrors.Errorf("invalid type %q", name)
+		}
+		return Type{Kind: kind, Size: n}, nil
+	default:
+		return Type{}, xerrors.Errorf("unsupported type %q", name)
+	}
+}
+
+// String returns the canonical name of the type, as used in signatures.
+func (t Type) String() string {
+	switch t.Kind {
+	case UintKind:
+		return "uint" + strconv.Itoa(t.Size)
+	case IntKind:
+		return "int" + strconv.Itoa(t.Size)
+	case AddressKind:
+		return "address"
+	case BoolKind:
+		return "bool"
+	case FixedBytesKind:
+		return "bytes" + strconv.Itoa(t.Size)
+	case BytesKind:
+		return "bytes"
+	case StringKind:
+		return "string"
+	case ArrayKind:
+		return t.Elem.String() + "[" + strconv.Itoa(t.Size) + "]"
+	case SliceKind:
+		return t.Elem.String() + "[]"
+	case TupleKind:
+		return "(" + typeList(t.Components) + ")"
+	case FunctionKind:
+		return "function"
+	default:
+		return "unknown"
+	}
+}
+
+func typeList(args []Argument) string {
+	names := make([]string, len(args))
+	for i, a := range args {
+		names[i] = a.Type.String()
+	}
+	return strings.Join(names, ",")
+}
+
+// Signature returns the canonical signature of the method, such as "transfer(address,uint256)".
+func (m *Method) Signature() string {
+	return m.Name + "(" + typeList(m.Inputs) + ")"
+}
+
+// Selector returns the 4 bytes prefixing calldata to the method: the start of the Keccak-256
+// digest of its signature.
+func (m *Method) Selector() [4]byte {
+	var out [4]byte
+	h := keccak256([]byte(m.Signature()))
+	copy(out[:], h[:])
+	return out
+}
+
+// Signature returns the canonical signature of the event, such as
+// "Transfer(address,address,uint256)".
+func (e *Event) Signature() string {
+	return e.Name + "(" + typeList(e.Inputs) + ")"
+}
+
+// Topic returns the first topic of logs of a non-anonymous event: the Keccak-256 digest of its
+// signature.
+func (e *Event) Topic() [32]byte {
+	return keccak256([]byte(e.Signature()))
+}
+
+// Method returns the method with a name, or with a signature to select among overloads.
+func (a *ABI) Method(name string) (*Method, error) {
+	var found *Method
+	for _, m := range a.Methods {
+		if m.Signature() == name {
+			return m, nil
+		}
+		if m.Name == name {
+			if found != nil {
+				return nil, xerrors.Errorf("method %s is overloaded, select it by signature", name)
+			}
+			found = m
+		}
+	}
+	if found == nil {
+		return nil, xerrors.Errorf("no method %s", name)
+	}
+	return found, nil
+}
+
+// MethodBySelector returns the method called by calldata with a selector.
+func (a *ABI) MethodBySelector(selector [4]byte) (*Method, error) {
+	for _, m := range a.Methods {
+		if m.Selector() == selector {
+			return m, nil
+		}
+	}
+	return nil, xerrors.Errorf("no method with selector %x", selector)
+}
+
+// Event returns the event with a name, or with a signature to select among overloads.
+func (a *ABI) Event(name string) (*Event, error) {
+	var found *Event
+	for _, e := range a.Events {
+		if e.Signature() == name {
+			return e, nil
+		}
+		if e.Name == name {
+			if found != nil {
+				return nil, xerrors.Errorf("event %s is overloaded, select it by signature", name)
+			}
+			found = e
+		}
+	}
+	if found == nil {
+		return nil, xerrors.Errorf("no event %s", name)
+	}
+	return found, nil
+}
+
+// EventByTopic returns the non-anonymous event identified by the first topic of a log.
+func (a *ABI) EventByTopic(topic [32]byte) (*Event, error) {
+	for _, e := range a.Events {
+		if !e.Anonymous && e.Topic() == topic {
+			return e, nil
+		}
+	}
+	return nil, xerrors.Errorf("no event with topic %x", topic)
+}
+
+func keccak256(data []byte) [32]byte {
+	h := keccak.NewLegacyKeccak256()
+	_, _ = h.Write(data)
+	var out [32]byte
+	copy(out[:], h.Sum(nil))
+	return out
+}
@@ -0,0 +1,394 @@
+package eth
+
+import (
+	"bytes"
+	"encoding/binary"
+	gobig "math/big"
+
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+)
+
+// The size of an ABI word.
+const wordSize = 32
+
+var two256 = new(gobig.Int).Lsh(gobig.NewInt(1), 256)
+
+// EncodeArgs encodes values of arguments as a tuple, as in calldata after the selector or in
+// return data.
+func EncodeArgs(args []Argument, values ...interface{}) ([]byte, error) {
+	if len(values) != len(args) {
+		return nil, xerrors.Errorf("expected %d values, got %d", len(args), len(values))
+	}
+	return encodeTuple(argTypes(args), values)
+}
+
+// DecodeArgs decodes values of arguments encoded as a tuple.
+func DecodeArgs(args []Argument, data []byte) ([]interface{}, error) {
+	return decodeTuple(argTypes(args), data)
+}
+
+// EncodeCall returns the calldata calling the method with values of its inputs.
+func (m *Method) EncodeCall(values ...interface{}) ([]byte, error) {
+	enc, err := EncodeArgs(m.Inputs, values...)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to encode call to %s: %w", m.Signature(), err)
+	}
+	selector := m.Selector()
+	return append(selector[:], enc...), nil
+}
+
+// DecodeCall decodes the values of the method's inputs from calldata.
+func (m *Method) DecodeCall(calldata []byte) ([]interface{}, error) {
+	selector := m.Selector()
+	if !bytes.HasPrefix(calldata, selector[:]) {
+		return nil, xerrors.Errorf("calldata is not a call to %s", m.Signature())
+	}
+	return DecodeArgs(m.Inputs, calldata[len(selector):])
+}
+
+// DecodeReturn decodes the values of the method's outputs from return data.
+func (m *Method) DecodeReturn(data []byte) ([]interface{}, error) {
+	out, err := DecodeArgs(m.Outputs, data)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to decode return of %s: %w", m.Signature(), err)
+	}
+	return out, nil
+}
+
+// InvokeParams returns the params of an EVM InvokeContract message calling the method: the
+// calldata as CBOR bytes.
+func (m *Method) InvokeParams(values ...interface{}) ([]byte, error) {
+	calldata, err := m.EncodeCall(values...)
+	if err != nil {
+		return nil, err
+	}
+	return cborBytes(calldata)
+}
+
+// DecodeInvokeReturn decodes the values of the method's outputs from the return of an EVM
+// InvokeContract message: the return data as CBOR bytes.
+func (m *Method) DecodeInvokeReturn(ret []byte) ([]interface{}, error) {
+	var data abi.CborBytes
+	if len(ret) > 0 {
+		if err := data.UnmarshalCBOR(bytes.NewReader(ret)); err != nil {
+			return nil, xerrors.Errorf("failed to decode return: %w", err)
+		}
+	}
+	return m.DecodeReturn(data)
+}
+
+// ConstructorParams returns the EAM CreateExternal params deploying a contract: its bytecode
+// followed by the encoded constructor inputs, as CBOR bytes.
+func (a *ABI) ConstructorParams(bytecode []byte, values ...interface{}) ([]byte, error) {
+	var inputs []Argument
+	if a.Constructor != nil {
+		inputs = a.Constructor.Inputs
+	}
+	enc, err := EncodeArgs(inputs, values...)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to encode constructor: %w", err)
+	}
+	return cborBytes(append(append([]byte{}, bytecode...), enc...))
+}
+
+func argTypes(args []Argument) []Type {
+	types := make([]Type, len(args))
+	for i, a := range args {
+		types[i] = a.Type
+	}
+	return types
+}
+
+// Whether values of a type are encoded out of line, behind an offset.
+func (t Type) dynamic() bool {
+	switch t.Kind {
+	case BytesKind, StringKind, SliceKind:
+		return true
+	case ArrayKind:
+		return t.Elem.dynamic()
+	case TupleKind:
+		for _, c := range t.Components {
+			if c.Type.dynamic() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// The size of the head of a value of a type within a tuple.
+func (t Type) headSize() int {
+	if t.dynamic() {
+		return wordSize
+	}
+	switch t.Kind {
+	case ArrayKind:
+		return t.Size * t.Elem.headSize()
+	case TupleKind:
+		size := 0
+		for _, c := range t.Components {
+			size += c.Type.headSize()
+		}
+		return size
+	default:
+		return wordSize
+	}
+}
+
+func repeat(t Type, n int) []Type {
+	types := make([]Type, n)
+	for i := range types {
+		types[i] = t
+	}
+	return types
+}
+
+func encodeTuple(types []Type, values []interface{}) ([]byte, error) {
+	headSize := 0
+	for _, t := range types {
+		headSize += t.headSize()
+	}
+	var head, tail []byte
+	for i, t := range types {
+		enc, err := encodeValue(t, values[i])
+		if err != nil {
+			return nil, xerrors.Errorf("%s value %d: %w", t, i, err)
+		}
+		if t.dynamic() {
+			head = append(head, uintWord(uint64(headSize+len(tail)))...)
+			tail = append(tail, enc...)
+		} else {
+			head = append(head, enc...)
+		}
+	}
+	return append(head, tail...), nil
+}
+
+func encodeValue(t Type, v interface{}) ([]byte, error) {
+	switch t.Kind {
+	case UintKind, IntKind:
+		return encodeInt(t, v)
+	case AddressKind:
+		a, ok := v.(Address)
+		if !ok {
+			return nil, xerrors.Errorf("expected Address, got %T", v)
+		}
+		return leftPad(a[:]), nil
+	case BoolKind:
+		b, ok := v.(bool)
+		if !ok {
+			return nil, xerrors.Errorf("expected bool, got %T", v)
+		}
+		if b {
+			return uintWord(1), nil
+		}
+		return uintWord(0), nil
+	case FixedBytesKind, FunctionKind:
+		b, ok := v.([]byte)
+		if !ok || len(b) != t.Size {
+			return nil, xerrors.Errorf("expected %d bytes, got %T of length %d", t.Size, v, len(b))
+		}
+		return rightPad(b), nil
+	case BytesKind, StringKind:
+		var b []byte
+		switch s := v.(type) {
+		case []byte:
+			b = s
+		case string:
+			b = []byte(s)
+		default:
+			return nil, xerrors.Errorf("expected []byte or string, got %T", v)
+		}
+		return append(uintWord(uint64(len(b))), rightPad(b)...), nil
+	case ArrayKind, SliceKind, TupleKind:
+		elems, ok := v.([]interface{})
+		if !ok {
+			return nil, xerrors.Errorf("expected []interface{}, got %T", v)
+		}
+		switch t.Kind {
+		case ArrayKind:
+			if len(elems) != t.Size {
+				return nil, xerrors.Errorf("expected %d elements, got %d", t.Size, len(elems))
+			}
+			return encodeTuple(repeat(*t.Elem, t.Size), elems)
+		case SliceKind:
+			enc, err := encodeTuple(repeat(*t.Elem, len(elems)), elems)
+			if err != nil {
+				return nil, err
+			}
+			return append(uintWord(uint64(len(elems))), enc...), nil
+		default:
+			if len(elems) != len(t.Components) {
+				return nil, xerrors.Errorf("expected %d fields, got %d", len(t.Components), len(elems))
+			}
+			return encodeTuple(argTypes(t.Components), elems)
+		}
+	default:
+		return nil, xerrors.Errorf("unsupported type %s", t)
+	}
+}
+
+func encodeInt(t Type, v interface{}) ([]byte, error) {
+	var n *gobig.Int
+	switch i := v.(type) {
+	case big.Int:
+		if i.Nil() {
+			return nil, xerrors.Errorf("nil integer")
+		}
+		n = i.Int
+	case int:
+		n = gobig.NewInt(int64(i))
+	case int64:
+		n = gobig.NewInt(i)
+	case uint64:
+		n = new(gobig.Int).SetUint64(i)
+	default:
+		return nil, xerrors.Errorf("expected an integer, got %T", v)
+	}
+	if !intFits(t, n) {
+		return nil, xerrors.Errorf("%s out of range of %s", n, t)
+	}
+	if n.Sign() < 0 {
+		n = new(gobig.Int).Add(n, two256)
+	}
+	return leftPad(n.Bytes()), nil
+}
+
+func intFits(t Type, n *gobig.Int) bool {
+	if t.Kind == UintKind {
+		return n.Sign() >= 0 && n.BitLen() <= t.Size
+	}
+	// Signed integers range from -2^(M-1) to 2^(M-1)-1.
+	if n.Sign() >= 0 {
+		return n.BitLen() < t.Size
+	}
+	return new(gobig.Int).Add(n, gobig.NewInt(1)).BitLen() < t.Size
+}
+
+func uintWord(v uint64) []byte {
+	out := make([]byte, wordSize)
+	binary.BigEndian.PutUint64(out[wordSize-8:], v)
+	return out
+}
+
+func leftPad(b []byte) []byte {
+	out := make([]byte, wordSize)
+	copy(out[wordSize-len(b):], b)
+	return out
+}
+
+func rightPad(b []byte) []byte {
+	out := make([]byte, (len(b)+wordSize-1)/wordSize*wordSize)
+	copy(out, b)
+	return out
+}
+
+func decodeTuple(types []Type, data []byte) ([]interface{}, error) {
+	out := make([]interface{}, len(types))
+	pos := 0
+	for i, t := range types {
+		size := t.headSize()
+		if pos+size > len(data) {
+			return nil, xerrors.Errorf("%s value %d exceeds the data", t, i)
+		}
+		enc := data[pos:]
+		if t.dynamic() {
+			offset, err := readLength(data[pos:], len(data))
+			if err != nil {
+				return nil, xerrors.Errorf("%s value %d offset: %w", t, i, err)
+			}
+			enc = data[offset:]
+		}
+		v, err := decodeValue(t, enc)
+		if err != nil {
+			return nil, xerrors.Errorf("%s value %d: %w", t, i, err)
+		}
+		out[i] = v
+		pos += size
+	}
+	return out, nil
+}
+
+// Decodes a value from the start of data.
+func decodeValue(t Type, data []byte) (interface{}, error) {
+	if len(data) < wordSize {
+		return nil, xerrors.Errorf("value exceeds the data")
+	}
+	word := data[:wordSize]
+	switch t.Kind {
+	case UintKind, IntKind:
+		n := new(gobig.Int).SetBytes(word)
+		if t.Kind == IntKind && word[0]&0x80 != 0 {
+			n.Sub(n, two256)
+		}
+		if !intFits(t, n) {
+			return nil, xerrors.Errorf("value out of range of %s", t)
+		}
+		return big.NewFromGo(n), nil
+	case AddressKind:
+		if !isZero(word[:wordSize-AddressLength]) {
+			return nil, xerrors.Errorf("address with dirty upper bytes")
+		}
+		var a Address
+		copy(a[:], word[wordSize-AddressLength:])
+		return a, nil
+	case BoolKind:
+		if !isZero(word[:wordSize-1]) || word[wordSize-1] > 1 {
+			return nil, xerrors.Errorf("invalid bool")
+		}
+		return word[wordSize-1] == 1, nil
+	case FixedBytesKind, FunctionKind:
+		if !isZero(word[t.Size:]) {
+			return nil, xerrors.Errorf("%s with dirty lower bytes", t)
+		}
+		return append([]byte{}, word[:t.Size]...), nil
+	case BytesKind, StringKind:
+		length, err := readLength(data, len(data)-wordSize)
+		if err != nil {
+			return nil, err
+		}
+		b := append([]byte{}, data[wordSize:wordSize+length]...)
+		if t.Kind == StringKind {
+			return string(b), nil
+		}
+		return b, nil
+	case ArrayKind:
+		return decodeTuple(repeat(*t.Elem, t.Size), data)
+	case SliceKind:
+		// Every element occupies at least a word, bounding the length by the data.
+		length, err := readLength(data, (len(data)-wordSize)/wordSize)
+		if err != nil {
+			return nil, err
+		}
+		return decodeTuple(repeat(*t.Elem, length), data[wordSize:])
+	case TupleKind:
+		return decodeTuple(argTypes(t.Components), data)
+	default:
+		return nil, xerrors.Errorf("unsupported type %s", t)
+	}
+}
+
+// Reads a length or offset word, which may not exceed a limit.
+func readLength(data []byte, limit int) (int, error) {
+	word := data[:wordSize]
+	if !isZero(word[:wordSize-8]) {
+		return 0, xerrors.Errorf("length overflows")
+	}
+	n := binary.BigEndian.Uint64(word[wordSize-8:])
+	if n > uint64(limit) {
+		return 0, xerrors.Errorf("length %d exceeds the data", n)
+	}
+	return int(n), nil
+}
+
+func isZero(b []byte) bool {
+	for _, c := range b {
+		if c != 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,173 @@
+package eth_test
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	keccak "github.com/filecoin-project/go-keccak"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin"
+	"github.com/filecoin-project/go-state-types/eth"
+)
+
+const testABI = `[
+	{"type": "function", "name": "f", "stateMutability": "nonpayable",
+	 "inputs": [{"name": "a", "type": "uint256"}, {"name": "b", "type": "uint32[]"}, {"name": "c", "type": "bytes10"}, {"name": "d", "type": "bytes"}],
+	 "outputs": []},
+	{"type": "function", "name": "transfer", "stateMutability": "nonpayable",
+	 "inputs": [{"name": "to", "type": "address"}, {"name": "amount", "type": "uint256"}],
+	 "outputs": [{"name": "", "type": "bool"}]},
+	{"type": "function", "name": "quote", "stateMutability": "view",
+	 "inputs": [{"name": "order", "type": "tuple", "components": [{"name": "delta", "type": "int64"}, {"name": "memo", "type": "string"}]}],
+	 "outputs": [{"name": "", "type": "int256[2]"}, {"name": "", "type": "string[]"}]},
+	{"type": "event", "name": "Transfer", "anonymous": false,
+	 "inputs": [{"name": "from", "type": "address", "indexed": true}, {"name": "to", "type": "address", "indexed": true}, {"name": "value", "type": "uint256", "indexed": false}]},
+	{"type": "error", "name": "Unauthorized", "inputs": []}
+]`
+
+func TestABICodec(t *testing.T) {
+	a, err := eth.ParseABI(strings.NewReader(testABI))
+	require.NoError(t, err)
+
+	// The example of the Solidity ABI specification.
+	f, err := a.Method("f")
+	require.NoError(t, err)
+	require.Equal(t, "f(uint256,uint32[],bytes10,bytes)", f.Signature())
+	calldata, err := f.EncodeCall(0x123, []interface{}{0x456, 0x789}, []byte("1234567890"), []byte("Hello, world!"))
+	require.NoError(t, err)
+	require.Equal(t, "8be65246"+
+		"0000000000000000000000000000000000000000000000000000000000000123"+
+		"0000000000000000000000000000000000000000000000000000000000000080"+
+		"3132333435363738393000000000000000000000000000000000000000000000"+
+		"00000000000000000000000000000000000000000000000000000000000000e0"+
+		"0000000000000000000000000000000000000000000000000000000000000002"+
+		"0000000000000000000000000000000000000000000000000000000000000456"+
+		"0000000000000000000000000000000000000000000000000000000000000789"+
+		"000000000000000000000000000000000000000000000000000000000000000d"+
+		"48656c6c6f2c20776f726c642100000000000000000000000000000000000000", hex.EncodeToString(calldata))
+	args, err := f.DecodeCall(calldata)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{
+		big.NewInt(0x123),
+		[]interface{}{big.NewInt(0x456), big.NewInt(0x789)},
+		[]byte("1234567890"),
+		[]byte("Hello, world!"),
+	}, args)
+
+	transfer, err := a.Method("transfer")
+	require.NoError(t, err)
+	require.Equal(t, [4]byte{0xa9, 0x05, 0x9c, 0xbb}, transfer.Selector())
+	_, err = transfer.EncodeCall(eth.NewMaskedIDAddress(1000), -1)
+	require.Error(t, err)
+	params, err := transfer.InvokeParams(eth.NewMaskedIDAddress(1000), 5)
+	require.NoError(t, err)
+	require.Equal(t, byte(0x58), params[0]) // CBOR byte string of 68 bytes.
+	require.Len(t, params, 2+4+64)
+
+	quote, err := a.Method("quote")
+	require.NoError(t, err)
+	require.Equal(t, "quote((int64,string))", quote.Signature())
+	input := []interface{}{[]interface{}{int64(-7), "memo"}}
+	calldata, err = quote.EncodeCall(input...)
+	require.NoError(t, err)
+	args, err = quote.DecodeCall(calldata)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{[]interface{}{big.NewInt(-7), "memo"}}, args)
+
+	ret, err := eth.EncodeArgs(quote.Outputs, []interface{}{big.NewInt(-1), 2}, []interface{}{"a", "bc"})
+	require.NoError(t, err)
+	out, err := quote.DecodeReturn(ret)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{
+		[]interface{}{big.NewInt(-1), big.NewInt(2)},
+		[]interface{}{"a", "bc"},
+	}, out)
+	_, err = quote.DecodeReturn(ret[:len(ret)-32])
+	require.Error(t, err)
+
+	_, err = a.Method("Unauthorized")
+	require.Error(t, err)
+}
+
+func TestDecodeActorEvent(t *testing.T) {
+	a, err := eth.ParseABI(strings.NewReader(testABI))
+	require.NoError(t, err)
+	transfer, err := a.Event("Transfer")
+	require.NoError(t, err)
+	topic := transfer.Topic()
+	require.Equal(t, "ddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef", hex.EncodeToString(topic[:]))
+
+	from, to := eth.NewMaskedIDAddress(1), eth.NewMaskedIDAddress(2)
+	var fromTopic, toTopic [32]byte
+	copy(fromTopic[12:], from[:])
+	copy(toTopic[12:], to[:])
+	data, err := eth.EncodeArgs(transfer.Inputs[2:], 1000)
+	require.NoError(t, err)
+	entries, err := eth.EventEntriesFromLog([][32]byte{topic, fromTopic, toTopic}, data)
+	require.NoError(t, err)
+	require.Equal(t, []string{"t1", "t2", "t3", "d"}, []string{entries[0].Key, entries[1].Key, entries[2].Key, entries[3].Key})
+
+	e, values, err := a.DecodeActorEvent(entries)
+	require.NoError(t, err)
+	require.Equal(t, transfer, e)
+	require.Equal(t, []interface{}{from, to, big.NewInt(1000)}, values)
+
+	_, _, err = a.DecodeActorEvent(entries[1:])
+	require.Error(t, err)
+}
+
+func TestNativeCall(t *testing.T) {
+	call, err := eth.NewNativeCall("Receive", big.NewInt(10), nil)
+	require.NoError(t, err)
+	require.Equal(t, builtin.MustGenerateFRCMethodNum("Receive"), call.Method)
+	require.Equal(t, uint64(eth.NativeCallCodecNone), call.Codec)
+	input, err := call.InputByID(1234)
+	require.NoError(t, err)
+	require.Len(t, input, 7*32)
+
+	output, err := eth.EncodeArgs([]eth.Argument{
+		{Type: mustType(t, "int256")}, {Type: mustType(t, "uint64")}, {Type: mustType(t, "bytes")},
+	}, -3, uint64(eth.NativeCallCodecCBOR), []byte{0x80})
+	require.NoError(t, err)
+	exitCode, codec, ret, err := eth.DecodeNativeCallOutput(output)
+	require.NoError(t, err)
+	require.Equal(t, int64(-3), exitCode)
+	require.Equal(t, uint64(eth.NativeCallCodecCBOR), codec)
+	require.Equal(t, []byte{0x80}, ret)
+}
+
+func mustType(t *testing.T, name string) eth.Type {
+	typ, err := eth.ParseType(name)
+	require.NoError(t, err)
+	return typ
+}
+
+func TestABIFunctionType(t *testing.T) {
+	a, err := eth.ParseABI(strings.NewReader(`[
+		{"type": "function", "name": "register", "stateMutability": "nonpayable",
+		 "inputs": [{"name": "callback", "type": "function"}], "outputs": []}
+	]`))
+	require.NoError(t, err)
+	register, err := a.Method("register")
+	require.NoError(t, err)
+	require.Equal(t, eth.FunctionKind, register.Inputs[0].Type.Kind)
+	require.Equal(t, "register(function)", register.Signature())
+	hasher := keccak.NewLegacyKeccak256()
+	hasher.Write([]byte("register(function)"))
+	var selector [4]byte
+	copy(selector[:], hasher.Sum(nil))
+	require.Equal(t, selector, register.Selector())
+
+	// A function is an address and a selector, left-aligned in a word.
+	callback := append(make([]byte, 20), 0xde, 0xad, 0xbe, 0xef)
+	callback[19] = 1
+	calldata, err := register.EncodeCall(callback)
+	require.NoError(t, err)
+	require.Equal(t, append(append(selector[:], callback...), make([]byte, 8)...), calldata)
+	decoded, err := register.DecodeCall(calldata)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{callback}, decoded)
+}
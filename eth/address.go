@@ -0,0 +1,136 @@
+// Package eth converts between Ethereum and Filecoin representations of addresses, transactions
+// and signatures, as used by the Ethereum Address Manager (EAM) and EVM actors.
+package eth
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"strings"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-keccak"
+	"github.com/multiformats/go-varint"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/builtin"
+)
+
+// AddressLength is the length in bytes of an Ethereum address.
+const AddressLength = 20
+
+// Address is a 20-byte Ethereum address.
+type Address [AddressLength]byte
+
+// The prefix of Ethereum addresses masking a Filecoin actor ID: 0xff followed by 11 zero bytes,
+// then the big-endian ID.
+var maskedIDPrefix = [AddressLength - 8]byte{0xff}
+
+// NewMaskedIDAddress returns the Ethereum address by which EVM contracts refer to the actor with
+// an ID.
+func NewMaskedIDAddress(id uint64) Address {
+	var a Address
+	copy(a[:], maskedIDPrefix[:])
+	binary.BigEndian.PutUint64(a[len(maskedIDPrefix):], id)
+	return a
+}
+
+// AddressFromPubKey returns the Ethereum address of an uncompressed secp256k1 public key:
+// the last 20 bytes of the Keccak-256 digest of the key without its 0x04 prefix.
+func AddressFromPubKey(pubkey []byte) (Address, error) {
+	if len(pubkey) != 65 || pubkey[0] != 0x04 {
+		return Address{}, xerrors.Errorf("expected a 65 byte uncompressed public key, got %d bytes", len(pubkey))
+	}
+	h := keccak.NewLegacyKeccak256()
+	_, _ = h.Write(pubkey[1:])
+	var a Address
+	copy(a[:], h.Sum(nil)[32-AddressLength:])
+	return a, nil
+}
+
+// AddressFromFilecoin returns the Ethereum address of a Filecoin address.
+// ID addresses map to masked ID addresses and f410 addresses to their Ethereum sub-address. Other
+// addresses have no Ethereum equivalent.
+func AddressFromFilecoin(a addr.Address) (Address, error) {
+	switch a.Protocol() {
+	case addr.ID:
+		id, err := addr.IDFromAddress(a)
+		if err != nil {
+			return Address{}, err
+		}
+		return NewMaskedIDAddress(id), nil
+	case addr.Delegated:
+		namespace, n, err := varint.FromUvarint(a.Payload())
+		if err != nil {
+			return Address{}, xerrors.Errorf("invalid delegated address %s: %w", a, err)
+		}
+		if namespace != builtin.EthereumAddressManagerActorID {
+			return Address{}, xerrors.Errorf("delegated address %s is not in the EAM namespace", a)
+		}
+		sub := a.Payload()[n:]
+		if len(sub) != AddressLength {
+			return Address{}, xerrors.Errorf("delegated address %s has a %d byte sub-address", a, len(sub))
+		}
+		var out Address
+		copy(out[:], sub)
+		if out.IsMaskedID() {
+			return Address{}, xerrors.Errorf("delegated address %s wraps a masked ID address", a)
+		}
+		return out, nil
+	default:
+		return Address{}, xerrors.Errorf("address %s has no Ethereum equivalent", a)
+	}
+}
+
+// ParseAddress parses a hex Ethereum address, with or without a 0x prefix.
+// Mixed-case addresses must carry a valid EIP-55 checksum.
+func ParseAddress(s string) (Address, error) {
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X")
+	if len(s) != 2*AddressLength {
+		return Address{}, xerrors.Errorf("expected %d hex digits, got %d", 2*AddressLength, len(s))
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return Address{}, xerrors.Errorf("invalid address: %w", err)
+	}
+	var a Address
+	copy(a[:], b)
+	if s != strings.ToLower(s) && s != strings.ToUpper(s) && a.String()[2:] != s {
+		return Address{}, xerrors.Errorf("invalid EIP-55 checksum")
+	}
+	return a, nil
+}
+
+// IsMaskedID returns whether the address masks an actor ID.
+func (a Address) IsMaskedID() bool {
+	return bytes.HasPrefix(a[:], maskedIDPrefix[:])
+}
+
+// ToFilecoin returns the Filecoin address of an Ethereum address: the ID address of a masked ID
+// address, otherwise the f410 address of the EAM namespace.
+func (a Address) ToFilecoin() (addr.Address, error) {
+	if a.IsMaskedID() {
+		return addr.NewIDAddress(binary.BigEndian.Uint64(a[len(maskedIDPrefix):]))
+	}
+	return addr.NewDelegatedAddress(builtin.EthereumAddressManagerActorID, a[:])
+}
+
+// String returns the EIP-55 checksummed hex encoding of the address.
+func (a Address) String() string {
+	lower := hex.EncodeToString(a[:])
+	h := keccak.NewLegacyKeccak256()
+	_, _ = h.Write([]byte(lower))
+	digest := h.Sum(nil)
+
+	out := []byte(lower)
+	for i, c := range out {
+		nibble := digest[i/2] >> 4
+		if i%2 == 1 {
+			nibble = digest[i/2] & 0xf
+		}
+		if c >= 'a' && nibble >= 8 {
+			out[i] = c - 'a' + 'A'
+		}
+	}
+	return "0x" + string(out)
+}
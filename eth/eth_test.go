@@ -0,0 +1,153 @@
+package eth
+
+import (
+	"encoding/hex"
+	"testing"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin"
+	"github.com/filecoin-project/go-state-types/crypto"
+)
+
+func mustHex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return b
+}
+
+func TestAddressConversions(t *testing.T) {
+	// EIP-55 examples.
+	for _, s := range []string{"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", "0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359"} {
+		a, err := ParseAddress(s)
+		require.NoError(t, err)
+		assert.Equal(t, s, a.String())
+	}
+	_, err := ParseAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD")
+	assert.Error(t, err)
+
+	// The address of the secp256k1 generator point, i.e. of private key 1.
+	a, err := AddressFromPubKey(mustHex(t, "0479be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8"))
+	require.NoError(t, err)
+	assert.Equal(t, "0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf", a.String())
+
+	f4, err := a.ToFilecoin()
+	require.NoError(t, err)
+	assert.Equal(t, addr.Delegated, f4.Protocol())
+	back, err := AddressFromFilecoin(f4)
+	require.NoError(t, err)
+	assert.Equal(t, a, back)
+
+	masked := NewMaskedIDAddress(1234)
+	assert.Equal(t, "ff000000000000000000000000000000000004d2", hex.EncodeToString(masked[:]))
+	id, err := masked.ToFilecoin()
+	require.NoError(t, err)
+	assert.Equal(t, "f01234", id.String())
+	back, err = AddressFromFilecoin(id)
+	require.NoError(t, err)
+	assert.Equal(t, masked, back)
+
+	other, err := addr.NewDelegatedAddress(32, a[:])
+	require.NoError(t, err)
+	_, err = AddressFromFilecoin(other)
+	assert.Error(t, err)
+}
+
+func TestLegacyEIP155Transaction(t *testing.T) {
+	// The example transaction from EIP-155.
+	raw := mustHex(t, "f86c098504a817c800825208943535353535353535353535353535353535353535880de0b6b3a76400008025a028ef61340bd939bc2195fe537567866003e1a15d3c71ff63e1590620aa636276a067cbe9d8997f761aecb703304b3800ccf555c9f3dc64214b297fb1966a3b6d83")
+	tx, err := ParseTransaction(raw)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), tx.ChainID)
+	assert.Equal(t, uint64(9), tx.Nonce)
+	assert.Equal(t, big.NewInt(20_000_000_000), tx.GasPrice)
+
+	payload, err := tx.SigningPayload()
+	require.NoError(t, err)
+	assert.Equal(t, "ec098504a817c800825208943535353535353535353535353535353535353535880de0b6b3a764000080018080", hex.EncodeToString(payload))
+	hash, err := tx.SigningHash()
+	require.NoError(t, err)
+	assert.Equal(t, "daf5a779ae972f972197303d7b574746c7ef83eadac0f2791ad23db92e4c8e53", hex.EncodeToString(hash[:]))
+
+	enc, err := tx.Encode()
+	require.NoError(t, err)
+	assert.Equal(t, raw, enc)
+
+	sig, err := tx.Signature()
+	require.NoError(t, err)
+	assert.Equal(t, crypto.SigTypeDelegated, sig.Type)
+	assert.Equal(t, byte(LegacyEIP155SignaturePrefix), sig.Data[0])
+	var decoded Transaction
+	decoded.Type, decoded.ChainID = TxTypeLegacy, 1
+	require.NoError(t, decoded.SetSignature(sig))
+	assert.Equal(t, tx.V, decoded.V)
+	assert.Equal(t, tx.R, decoded.R)
+	assert.Equal(t, tx.S, decoded.S)
+}
+
+func TestEIP1559Transaction(t *testing.T) {
+	to := NewMaskedIDAddress(1234)
+	tx := &Transaction{
+		Type:                 TxTypeEIP1559,
+		ChainID:              314,
+		Nonce:                3,
+		MaxPriorityFeePerGas: big.NewInt(100),
+		MaxFeePerGas:         big.NewInt(1_000_000),
+		GasLimit:             1_000_000,
+		To:                   &to,
+		Value:                big.NewInt(5),
+		Input:                []byte{0xde, 0xad, 0xbe, 0xef},
+		V:                    big.NewInt(1),
+		R:                    big.NewInt(7),
+		S:                    big.NewInt(8),
+	}
+	enc, err := tx.Encode()
+	require.NoError(t, err)
+	assert.Equal(t, byte(TxTypeEIP1559), enc[0])
+	decoded, err := ParseTransaction(enc)
+	require.NoError(t, err)
+	assert.Equal(t, tx, decoded)
+
+	sig, err := tx.Signature()
+	require.NoError(t, err)
+	assert.Len(t, sig.Data, EIP1559SignatureLength)
+	assert.Equal(t, byte(1), sig.Data[64])
+
+	// v is the y-parity, so only 0 and 1 are valid.
+	tx.V = big.Zero()
+	sig, err = tx.Signature()
+	require.NoError(t, err)
+	assert.Len(t, sig.Data, EIP1559SignatureLength)
+	assert.Equal(t, byte(0), sig.Data[64])
+	for _, v := range []big.Int{{}, big.NewInt(2), big.NewInt(27), big.NewInt(257)} {
+		tx.V = v
+		_, err = tx.Signature()
+		assert.Error(t, err, v.String())
+	}
+	tx.V = big.NewInt(1)
+
+	sender, err := ParseAddress("0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf")
+	require.NoError(t, err)
+	msg, err := tx.ToFilecoinMessage(sender)
+	require.NoError(t, err)
+	assert.Equal(t, "f01234", msg.To.String())
+	assert.Equal(t, builtin.MethodsEVM.InvokeContract, msg.Method)
+	assert.Equal(t, []byte{0x44, 0xde, 0xad, 0xbe, 0xef}, msg.Params)
+	assert.Equal(t, abi.NewTokenAmount(1_000_000), msg.GasFeeCap)
+	assert.Equal(t, abi.NewTokenAmount(100), msg.GasPremium)
+	assert.Equal(t, addr.Delegated, msg.From.Protocol())
+
+	// Contract creation goes through the EAM.
+	tx.To = nil
+	msg, err = tx.ToFilecoinMessage(sender)
+	require.NoError(t, err)
+	assert.Equal(t, builtin.EthereumAddressManagerActorAddr, msg.To)
+	assert.Equal(t, builtin.MethodsEAM.CreateExternal, msg.Method)
+
+	_, err = ParseTransaction(append([]byte{0x01}, enc[1:]...))
+	assert.Error(t, err)
+}
@@ -0,0 +1,165 @@
+package eth
+
+import (
+	"strconv"
+
+	"golang.org/x/xerrors"
+)
+
+// The codec of the values of EVM actor event entries: raw bytes.
+const EventEntryCodecRaw = 0x55
+
+// Flags of event entries, selecting which of their keys and values are indexed by the node.
+const (
+	EventFlagIndexedKey   = 0x01
+	EventFlagIndexedValue = 0x02
+	EventFlagIndexedAll   = EventFlagIndexedKey | EventFlagIndexedValue
+)
+
+// EventEntry is an entry of an actor event.
+// The EVM actor emits each log as an event with entries keyed "t1" to "t4" for its topics and "d"
+// for its data.
+type EventEntry struct {
+	Flags uint8
+	Key   string
+	Codec uint64
+	Value []byte
+}
+
+// EventLogFromEntries returns the topics and data of the EVM log carried by actor event entries.
+func EventLogFromEntries(entries []EventEntry) ([][32]byte, []byte, error) {
+	var topics [4]*[32]byte
+	var data []byte
+	for _, e := range entries {
+		if e.Codec != EventEntryCodecRaw {
+			return nil, nil, xerrors.Errorf("event entry %s has codec 0x%x, expected raw", e.Key, e.Codec)
+		}
+		if e.Key == "d" {
+			data = e.Value
+			continue
+		}
+		if len(e.Key) != 2 || e.Key[0] != 't' {
+			return nil, nil, xerrors.Errorf("unexpected event entry %s", e.Key)
+		}
+		i, err := strconv.Atoi(e.Key[1:])
+		if err != nil || i < 1 || i > len(topics) {
+			return nil, nil, xerrors.Errorf("unexpected event entry %s", e.Key)
+		}
+		if topics[i-1] != nil {
+			return nil, nil, xerrors.Errorf("duplicate event entry %s", e.Key)
+		}
+		if len(e.Value) != 32 {
+			return nil, nil, xerrors.Errorf("event topic %s has %d bytes", e.Key, len(e.Value))
+		}
+		var topic [32]byte
+		copy(topic[:], e.Value)
+		topics[i-1] = &topic
+	}
+
+	var out [][32]byte
+	for i, t := range topics {
+		if t == nil {
+			for _, rest := range topics[i:] {
+				if rest != nil {
+					return nil, nil, xerrors.Errorf("event topic t%d missing", i+1)
+				}
+			}
+			break
+		}
+		out = append(out, *t)
+	}
+	return out, data, nil
+}
+
+// EventEntriesFromLog returns the actor event entries the EVM actor emits for a log.
+func EventEntriesFromLog(topics [][32]byte, data []byte) ([]EventEntry, error) {
+	if len(topics) > 4 {
+		return nil, xerrors.Errorf("log has %d topics, more than 4", len(topics))
+	}
+	entries := make([]EventEntry, 0, len(topics)+1)
+	for i := range topics {
+		entries = append(entries, EventEntry{
+			Flags: EventFlagIndexedAll,
+			Key:   "t" + strconv.Itoa(i+1),
+			Codec: EventEntryCodecRaw,
+			Value: append([]byte{}, topics[i][:]...),
+		})
+	}
+	if len(data) > 0 {
+		entries = append(entries, EventEntry{
+			Flags: EventFlagIndexedAll,
+			Key:   "d",
+			Codec: EventEntryCodecRaw,
+			Value: data,
+		})
+	}
+	return entries, nil
+}
+
+// DecodeLog decodes the values of the event's inputs from the topics and data of a log.
+// Indexed inputs of dynamic types, whose topic holds the Keccak-256 digest of their encoding, are
+// returned as that [32]byte digest.
+func (e *Event) DecodeLog(topics [][32]byte, data []byte) ([]interface{}, error) {
+	if !e.Anonymous {
+		if len(topics) == 0 || topics[0] != e.Topic() {
+			return nil, xerrors.Errorf("log is not a %s event", e.Signature())
+		}
+		topics = topics[1:]
+	}
+
+	var unindexed []Argument
+	indexed := 0
+	for _, in := range e.Inputs {
+		if in.Indexed {
+			indexed++
+		} else {
+			unindexed = append(unindexed, in)
+		}
+	}
+	if len(topics) != indexed {
+		return nil, xerrors.Errorf("%s event has %d indexed inputs, log has %d topics", e.Signature(), indexed, len(topics))
+	}
+	values, err := DecodeArgs(unindexed, data)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to decode %s event data: %w", e.Signature(), err)
+	}
+
+	out := make([]interface{}, len(e.Inputs))
+	for i, in := range e.Inputs {
+		if !in.Indexed {
+			out[i], values = values[0], values[1:]
+			continue
+		}
+		topic := topics[0]
+		topics = topics[1:]
+		if in.Type.dynamic() || in.Type.Kind == ArrayKind || in.Type.Kind == TupleKind {
+			out[i] = topic
+			continue
+		}
+		if out[i], err = decodeValue(in.Type, topic[:]); err != nil {
+			return nil, xerrors.Errorf("failed to decode %s event input %s: %w", e.Signature(), in.Name, err)
+		}
+	}
+	return out, nil
+}
+
+// DecodeActorEvent decodes an EVM log emitted as an actor event, identifying its event by the
+// first topic.
+func (a *ABI) DecodeActorEvent(entries []EventEntry) (*Event, []interface{}, error) {
+	topics, data, err := EventLogFromEntries(entries)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(topics) == 0 {
+		return nil, nil, xerrors.Errorf("cannot identify an event without topics")
+	}
+	e, err := a.EventByTopic(topics[0])
+	if err != nil {
+		return nil, nil, err
+	}
+	values, err := e.DecodeLog(topics, data)
+	if err != nil {
+		return nil, nil, err
+	}
+	return e, values, nil
+}
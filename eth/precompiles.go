@@ -0,0 +1,107 @@
+package eth
+
+import (
+	addr "github.com/filecoin-project/go-address"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin"
+)
+
+// The Filecoin precompiles by which contracts call native actors.
+var (
+	// CallActorPrecompile calls an actor by its Filecoin address.
+	CallActorPrecompile = Address{0xfe, 19: 0x03}
+	// CallActorIDPrecompile calls an actor by its ID.
+	CallActorIDPrecompile = Address{0xfe, 19: 0x05}
+)
+
+// Codecs of the params and return values of native calls.
+const (
+	NativeCallCodecNone    = 0
+	NativeCallCodecCBOR    = 0x51
+	NativeCallCodecDAGCBOR = 0x71
+)
+
+// The flag making a native call read-only.
+const nativeCallFlagReadOnly = 1
+
+var (
+	callActorInputs   = mustArguments("uint64", "uint256", "uint64", "uint64", "bytes", "bytes")
+	callActorIDInputs = mustArguments("uint64", "uint256", "uint64", "uint64", "bytes", "uint64")
+	callActorOutputs  = mustArguments("int256", "uint64", "bytes")
+)
+
+// NativeCall is a call from a contract to a native actor through a call actor precompile.
+type NativeCall struct {
+	Method abi.MethodNum
+	Value  abi.TokenAmount
+	// Read-only calls may not modify state.
+	ReadOnly bool
+	// NativeCallCodecNone for empty params.
+	Codec  uint64
+	Params []byte
+}
+
+// NewNativeCall returns a call to the exported method of a native actor with a name, numbered
+// according to FRC-42, with CBOR params.
+func NewNativeCall(method string, value abi.TokenAmount, params []byte) (*NativeCall, error) {
+	num, err := builtin.GenerateFRCMethodNum(method)
+	if err != nil {
+		return nil, err
+	}
+	codec := uint64(NativeCallCodecCBOR)
+	if len(params) == 0 {
+		codec = NativeCallCodecNone
+	}
+	return &NativeCall{Method: num, Value: value, Codec: codec, Params: params}, nil
+}
+
+// Input returns the input to CallActorPrecompile calling an actor by address.
+func (c *NativeCall) Input(to addr.Address) ([]byte, error) {
+	return EncodeArgs(callActorInputs, c.fields(to.Bytes())...)
+}
+
+// InputByID returns the input to CallActorIDPrecompile calling an actor by ID.
+func (c *NativeCall) InputByID(to abi.ActorID) ([]byte, error) {
+	return EncodeArgs(callActorIDInputs, c.fields(uint64(to))...)
+}
+
+func (c *NativeCall) fields(to interface{}) []interface{} {
+	var flags uint64
+	if c.ReadOnly {
+		flags |= nativeCallFlagReadOnly
+	}
+	value := c.Value
+	if value.Nil() {
+		value = big.Zero()
+	}
+	return []interface{}{uint64(c.Method), value, flags, c.Codec, c.Params, to}
+}
+
+// DecodeNativeCallOutput decodes the output of a call actor precompile: the exit code of the
+// call, and the codec and bytes of its return value.
+func DecodeNativeCallOutput(output []byte) (int64, uint64, []byte, error) {
+	values, err := DecodeArgs(callActorOutputs, output)
+	if err != nil {
+		return 0, 0, nil, xerrors.Errorf("failed to decode native call output: %w", err)
+	}
+	exitCode, codec := values[0].(big.Int), values[1].(big.Int)
+	if !exitCode.Int.IsInt64() {
+		return 0, 0, nil, xerrors.Errorf("exit code %s out of range", exitCode)
+	}
+	return exitCode.Int64(), codec.Uint64(), values[2].([]byte), nil
+}
+
+func mustArguments(types ...string) []Argument {
+	args := make([]Argument, len(types))
+	for i, name := range types {
+		t, err := ParseType(name)
+		if err != nil {
+			panic(err)
+		}
+		args[i] = Argument{Type: t}
+	}
+	return args
+}
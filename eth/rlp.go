@@ -0,0 +1,169 @@
+package eth
+
+import (
+	"encoding/binary"
+
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/big"
+)
+
+// The maximum depth of nested RLP lists accepted when decoding.
+const maxRLPDepth = 8
+
+// encodeRLP encodes an RLP item: a byte string ([]byte) or a list of items ([]interface{}).
+func encodeRLP(item interface{}) ([]byte, error) {
+	switch v := item.(type) {
+	case []byte:
+		if len(v) == 1 && v[0] < 0x80 {
+			return []byte{v[0]}, nil
+		}
+		return append(rlpHeader(0x80, len(v)), v...), nil
+	case []interface{}:
+		var payload []byte
+		for _, elem := range v {
+			enc, err := encodeRLP(elem)
+			if err != nil {
+				return nil, err
+			}
+			payload = append(payload, enc...)
+		}
+		return append(rlpHeader(0xc0, len(payload)), payload...), nil
+	default:
+		return nil, xerrors.Errorf("cannot RLP encode %T", item)
+	}
+}
+
+func rlpHeader(offset byte, length int) []byte {
+	if length <= 55 {
+		return []byte{offset + byte(length)}
+	}
+	lenBytes := uint64Bytes(uint64(length))
+	return append([]byte{offset + 55 + byte(len(lenBytes))}, lenBytes...)
+}
+
+// decodeRLP decodes a single RLP item, which must span all of the data.
+func decodeRLP(data []byte) (interface{}, error) {
+	item, n, err := decodeRLPItem(data, 0)
+	if err != nil {
+		return nil, err
+	}
+	if n != len(data) {
+		return nil, xerrors.Errorf("%d trailing bytes after RLP item", len(data)-n)
+	}
+	return item, nil
+}
+
+func decodeRLPItem(data []byte, depth int) (interface{}, int, error) {
+	if len(data) == 0 {
+		return nil, 0, xerrors.Errorf("unexpected end of RLP data")
+	}
+	prefix := data[0]
+	switch {
+	case prefix < 0x80:
+		return []byte{prefix}, 1, nil
+	case prefix < 0xc0:
+		start, length, err := rlpLength(data, 0x80)
+		if err != nil {
+			return nil, 0, err
+		}
+		str := data[start : start+length]
+		if length == 1 && str[0] < 0x80 {
+			return nil, 0, xerrors.Errorf("non-canonical RLP encoding of single byte")
+		}
+		return append([]byte{}, str...), start + length, nil
+	default:
+		if depth >= maxRLPDepth {
+			return nil, 0, xerrors.Errorf("RLP lists nested deeper than %d", maxRLPDepth)
+		}
+		start, length, err := rlpLength(data, 0xc0)
+		if err != nil {
+			return nil, 0, err
+		}
+		payload := data[start : start+length]
+		list := []interface{}{}
+		for len(payload) > 0 {
+			item, n, err := decodeRLPItem(payload, depth+1)
+			if err != nil {
+				return nil, 0, err
+			}
+			list = append(list, item)
+			payload = payload[n:]
+		}
+		return list, start + length, nil
+	}
+}
+
+// Returns the offset and length of the payload of an item with a header at the given offset.
+func rlpLength(data []byte, offset byte) (int, int, error) {
+	prefix := data[0]
+	if prefix <= offset+55 {
+		length := int(prefix - offset)
+		if len(data) < 1+length {
+			return 0, 0, xerrors.Errorf("RLP item of %d bytes exceeds the data", length)
+		}
+		return 1, length, nil
+	}
+
+	lenLen := int(prefix - offset - 55)
+	if len(data) < 1+lenLen {
+		return 0, 0, xerrors.Errorf("RLP length of %d bytes exceeds the data", lenLen)
+	}
+	if data[1] == 0 {
+		return 0, 0, xerrors.Errorf("non-canonical RLP length with leading zeros")
+	}
+	length, err := parseUint64(data[1 : 1+lenLen])
+	if err != nil {
+		return 0, 0, err
+	}
+	if length <= 55 {
+		return 0, 0, xerrors.Errorf("non-canonical RLP long encoding of %d bytes", length)
+	}
+	if length > uint64(len(data)-1-lenLen) {
+		return 0, 0, xerrors.Errorf("RLP item of %d bytes exceeds the data", length)
+	}
+	return 1 + lenLen, int(length), nil
+}
+
+// Returns the minimal big-endian encoding of an integer, empty for zero.
+func uint64Bytes(v uint64) []byte {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], v)
+	i := 0
+	for i < len(buf) && buf[i] == 0 {
+		i++
+	}
+	return append([]byte{}, buf[i:]...)
+}
+
+func bigIntBytes(v big.Int) ([]byte, error) {
+	if v.Nil() || v.IsZero() {
+		return []byte{}, nil
+	}
+	if v.Sign() < 0 {
+		return nil, xerrors.Errorf("cannot RLP encode negative integer %s", v)
+	}
+	return v.Int.Bytes(), nil
+}
+
+func parseUint64(b []byte) (uint64, error) {
+	if len(b) > 8 {
+		return 0, xerrors.Errorf("integer of %d bytes overflows uint64", len(b))
+	}
+	if len(b) > 0 && b[0] == 0 {
+		return 0, xerrors.Errorf("non-canonical integer with leading zeros")
+	}
+	var buf [8]byte
+	copy(buf[8-len(b):], b)
+	return binary.BigEndian.Uint64(buf[:]), nil
+}
+
+func parseBigInt(b []byte) (big.Int, error) {
+	if len(b) > 32 {
+		return big.Int{}, xerrors.Errorf("integer of %d bytes overflows uint256", len(b))
+	}
+	if len(b) > 0 && b[0] == 0 {
+		return big.Int{}, xerrors.Errorf("non-canonical integer with leading zeros")
+	}
+	return big.PositiveFromUnsignedBytes(b), nil
+}
@@ -0,0 +1,471 @@
+package eth
+
+import (
+	"bytes"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-keccak"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin"
+	"github.com/filecoin-project/go-state-types/crypto"
+)
+
+// Ethereum transaction types accepted by Filecoin.
+const (
+	TxTypeLegacy  = 0x00
+	TxTypeEIP1559 = 0x02
+)
+
+// Markers prefixed to the delegated signatures of legacy transactions, distinguishing them from
+// EIP-1559 signatures.
+const (
+	LegacyHomesteadSignaturePrefix = 0x01
+	LegacyEIP155SignaturePrefix    = 0x02
+)
+
+// The length of an EIP-1559 signature: r, s and the y-parity v.
+const EIP1559SignatureLength = 65
+
+// Transaction is a signed Ethereum transaction, either an EIP-1559 dynamic fee transaction or a
+// legacy transaction, with (EIP-155) or without (homestead) replay protection.
+// Access lists are not supported.
+type Transaction struct {
+	Type byte
+	// Zero for homestead transactions.
+	ChainID uint64
+	Nonce   uint64
+	// Legacy transactions pay a gas price, EIP-1559 transactions a priority fee up to a fee cap.
+	GasPrice             big.Int
+	MaxPriorityFeePerGas big.Int
+	MaxFeePerGas         big.Int
+	GasLimit             uint64
+	// Nil for contract creation.
+	To    *Address
+	Value big.Int
+	Input []byte
+
+	V big.Int
+	R big.Int
+	S big.Int
+}
+
+// Message is the Filecoin message a transaction maps onto.
+type Message struct {
+	Version    uint64
+	To         addr.Address
+	From       addr.Address
+	Nonce      uint64
+	Value      abi.TokenAmount
+	GasLimit   int64
+	GasFeeCap  abi.TokenAmount
+	GasPremium abi.TokenAmount
+	Method     abi.MethodNum
+	Params     []byte
+}
+
+// ParseTransaction decodes a signed transaction from its RLP encoding.
+func ParseTransaction(data []byte) (*Transaction, error) {
+	if len(data) == 0 {
+		return nil, xerrors.Errorf("empty transaction")
+	}
+	switch {
+	case data[0] == TxTypeEIP1559:
+		return parseEIP1559(data[1:])
+	case data[0] >= 0xc0:
+		return parseLegacy(data)
+	default:
+		return nil, xerrors.Errorf("unsupported transaction type %d", data[0])
+	}
+}
+
+func parseEIP1559(data []byte) (*Transaction, error) {
+	fields, err := decodeFields(data, 12)
+	if err != nil {
+		return nil, xerrors.Errorf("invalid EIP-1559 transaction: %w", err)
+	}
+	if accessList, ok := fields[8].([]interface{}); !ok || len(accessList) != 0 {
+		return nil, xerrors.Errorf("access lists are not supported")
+	}
+	tx := &Transaction{Type: TxTypeEIP1559}
+	if err := parseInto(fields,
+		uintField(&tx.ChainID), uintField(&tx.Nonce), bigField(&tx.MaxPriorityFeePerGas), bigField(&tx.MaxFeePerGas),
+		uintField(&tx.GasLimit), toField(&tx.To), bigField(&tx.Value), bytesField(&tx.Input), skipField,
+		bigField(&tx.V), bigField(&tx.R), bigField(&tx.S),
+	); err != nil {
+		return nil, xerrors.Errorf("invalid EIP-1559 transaction: %w", err)
+	}
+	if tx.V.GreaterThan(big.NewInt(1)) {
+		return nil, xerrors.Errorf("invalid EIP-1559 signature y-parity %s", tx.V)
+	}
+	return tx, nil
+}
+
+func parseLegacy(data []byte) (*Transaction, error) {
+	fields, err := decodeFields(data, 9)
+	if err != nil {
+		return nil, xerrors.Errorf("invalid legacy transaction: %w", err)
+	}
+	tx := &Transaction{Type: TxTypeLegacy}
+	if err := parseInto(fields,
+		uintField(&tx.Nonce), bigField(&tx.GasPrice), uintField(&tx.GasLimit), toField(&tx.To),
+		bigField(&tx.Value), bytesField(&tx.Input), bigField(&tx.V), bigField(&tx.R), bigField(&tx.S),
+	); err != nil {
+		return nil, xerrors.Errorf("invalid legacy transaction: %w", err)
+	}
+
+	// Homestead signatures have v of 27 or 28, EIP-155 ones v = 2 * chain ID + 35 or 36.
+	switch {
+	case tx.V.Equals(big.NewInt(27)) || tx.V.Equals(big.NewInt(28)):
+		tx.ChainID = 0
+	case tx.V.GreaterThanEqual(big.NewInt(35)) && tx.V.Int.IsUint64():
+		tx.ChainID = (tx.V.Uint64() - 35) / 2
+	default:
+		return nil, xerrors.Errorf("invalid legacy signature v %s", tx.V)
+	}
+	return tx, nil
+}
+
+// Encode returns the RLP encoding of the signed transaction.
+func (tx *Transaction) Encode() ([]byte, error) {
+	fields, err := tx.fields(true)
+	if err != nil {
+		return nil, err
+	}
+	enc, err := encodeRLP(fields)
+	if err != nil {
+		return nil, err
+	}
+	if tx.Type == TxTypeEIP1559 {
+		return append([]byte{TxTypeEIP1559}, enc...), nil
+	}
+	return enc, nil
+}
+
+// SigningPayload returns the encoding of the transaction that is hashed and signed by the sender.
+func (tx *Transaction) SigningPayload() ([]byte, error) {
+	fields, err := tx.fields(false)
+	if err != nil {
+		return nil, err
+	}
+	enc, err := encodeRLP(fields)
+	if err != nil {
+		return nil, err
+	}
+	if tx.Type == TxTypeEIP1559 {
+		return append([]byte{TxTypeEIP1559}, enc...), nil
+	}
+	return enc, nil
+}
+
+// SigningHash returns the Keccak-256 digest of the signing payload, from which the sender's
+// public key is recovered.
+func (tx *Transaction) SigningHash() ([32]byte, error) {
+	payload, err := tx.SigningPayload()
+	if err != nil {
+		return [32]byte{}, err
+	}
+	h := keccak.NewLegacyKeccak256()
+	_, _ = h.Write(payload)
+	var out [32]byte
+	copy(out[:], h.Sum(nil))
+	return out, nil
+}
+
+func (tx *Transaction) fields(signed bool) ([]interface{}, error) {
+	var to []byte
+	if tx.To != nil {
+		to = tx.To[:]
+	}
+	var fields []interface{}
+	var ints []big.Int
+	switch tx.Type {
+	case TxTypeEIP1559:
+		fields = []interface{}{
+			uint64Bytes(tx.ChainID), uint64Bytes(tx.Nonce), nil, nil,
+			uint64Bytes(tx.GasLimit), to, nil, tx.Input, []interface{}{},
+		}
+		ints = []big.Int{tx.MaxPriorityFeePerGas, tx.MaxFeePerGas, tx.Value}
+		if err := setBigFields(fields, []int{2, 3, 6}, ints); err != nil {
+			return nil, err
+		}
+		if signed {
+			sig, err := bigFields(tx.V, tx.R, tx.S)
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, sig...)
+		}
+	case TxTypeLegacy:
+		fields = []interface{}{uint64Bytes(tx.Nonce), nil, uint64Bytes(tx.GasLimit), to, nil, tx.Input}
+		if err := setBigFields(fields, []int{1, 4}, []big.Int{tx.GasPrice, tx.Value}); err != nil {
+			return nil, err
+		}
+		switch {
+		case signed:
+			sig, err := bigFields(tx.V, tx.R, tx.S)
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, sig...)
+		case tx.ChainID != 0:
+			// EIP-155 signs over the chain ID in place of the signature.
+			fields = append(fields, uint64Bytes(tx.ChainID), []byte{}, []byte{})
+		}
+	default:
+		return nil, xerrors.Errorf("unsupported transaction type %d", tx.Type)
+	}
+	for i, f := range fields {
+		if b, ok := f.([]byte); ok && b == nil {
+			fields[i] = []byte{}
+		}
+	}
+	return fields, nil
+}
+
+// Signature returns the transaction's signature as a Filecoin delegated signature.
+// EIP-1559 signatures are r || s || v. Legacy signatures are prefixed with a marker byte and carry
+// the full v, which encodes the chain ID.
+func (tx *Transaction) Signature() (*crypto.Signature, error) {
+	r, err := padTo32(tx.R)
+	if err != nil {
+		return nil, xerrors.Errorf("invalid signature r: %w", err)
+	}
+	s, err := padTo32(tx.S)
+	if err != nil {
+		return nil, xerrors.Errorf("invalid signature s: %w", err)
+	}
+	if tx.V.Nil() {
+		return nil, xerrors.Errorf("transaction has no signature v")
+	}
+	v, err := bigIntBytes(tx.V)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []byte
+	switch {
+	case tx.Type == TxTypeEIP1559:
+		// v is the y-parity of the signature, encoded as a single byte even when zero.
+		if len(v) > 1 || (len(v) == 1 && v[0] > 1) {
+			return nil, xerrors.Errorf("invalid EIP-1559 signature v %s, expected 0 or 1", tx.V)
+		}
+		data = append(append(r, s...), append(make([]byte, 1-len(v)), v...)...)
+	case tx.ChainID == 0:
+		data = append(append(append([]byte{LegacyHomesteadSignaturePrefix}, r...), s...), v...)
+	default:
+		data = append(append(append([]byte{LegacyEIP155SignaturePrefix}, r...), s...), v...)
+	}
+	return &crypto.Signature{Type: crypto.SigTypeDelegated, Data: data}, nil
+}
+
+// SetSignature sets the transaction's v, r and s from a delegated signature, as produced by Signature.
+func (tx *Transaction) SetSignature(sig *crypto.Signature) error {
+	if sig.Type != crypto.SigTypeDelegated {
+		return xerrors.Errorf("expected a delegated signature, got type %d", sig.Type)
+	}
+	data := sig.Data
+	if tx.Type == TxTypeLegacy {
+		if len(data) < 2 {
+			return xerrors.Errorf("legacy signature too short")
+		}
+		expected := byte(LegacyHomesteadSignaturePrefix)
+		if tx.ChainID != 0 {
+			expected = LegacyEIP155SignaturePrefix
+		}
+		if data[0] != expected {
+			return xerrors.Errorf("legacy signature has marker %d, expected %d", data[0], expected)
+		}
+		data = data[1:]
+	} else if len(data) != EIP1559SignatureLength {
+		return xerrors.Errorf("expected a %d byte signature, got %d", EIP1559SignatureLength, len(data))
+	}
+	if len(data) <= 64 {
+		return xerrors.Errorf("signature too short")
+	}
+	tx.R = big.PositiveFromUnsignedBytes(data[:32])
+	tx.S = big.PositiveFromUnsignedBytes(data[32:64])
+	tx.V = big.PositiveFromUnsignedBytes(data[64:])
+	return nil
+}
+
+// ToFilecoinMessage returns the Filecoin message executing the transaction on behalf of a sender.
+// Contract creations call the EAM's CreateExternal method with the init code, other transactions
+// call InvokeContract on the recipient with the input data. Parameters are CBOR byte strings.
+func (tx *Transaction) ToFilecoinMessage(from Address) (*Message, error) {
+	sender, err := from.ToFilecoin()
+	if err != nil {
+		return nil, err
+	}
+	if tx.GasLimit > uint64(1<<63-1) {
+		return nil, xerrors.Errorf("gas limit %d overflows", tx.GasLimit)
+	}
+
+	msg := &Message{
+		From:     sender,
+		Nonce:    tx.Nonce,
+		Value:    tx.Value.Copy(),
+		GasLimit: int64(tx.GasLimit),
+	}
+	if tx.Type == TxTypeEIP1559 {
+		msg.GasFeeCap = tx.MaxFeePerGas.Copy()
+		msg.GasPremium = tx.MaxPriorityFeePerGas.Copy()
+	} else {
+		msg.GasFeeCap = tx.GasPrice.Copy()
+		msg.GasPremium = tx.GasPrice.Copy()
+	}
+	if msg.Value.Nil() {
+		msg.Value = big.Zero()
+	}
+
+	if tx.To == nil {
+		msg.To = builtin.EthereumAddressManagerActorAddr
+		msg.Method = builtin.MethodsEAM.CreateExternal
+		if msg.Params, err = cborBytes(tx.Input); err != nil {
+			return nil, err
+		}
+		return msg, nil
+	}
+
+	if msg.To, err = tx.To.ToFilecoin(); err != nil {
+		return nil, err
+	}
+	msg.Method = builtin.MethodsEVM.InvokeContract
+	if len(tx.Input) > 0 {
+		if msg.Params, err = cborBytes(tx.Input); err != nil {
+			return nil, err
+		}
+	}
+	return msg, nil
+}
+
+func cborBytes(b []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	params := abi.CborBytes(b)
+	if err := params.MarshalCBOR(&buf); err != nil {
+		return nil, xerrors.Errorf("failed to encode params: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func padTo32(v big.Int) ([]byte, error) {
+	b, err := bigIntBytes(v)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) > 32 {
+		return nil, xerrors.Errorf("value exceeds 32 bytes")
+	}
+	return append(make([]byte, 32-len(b)), b...), nil
+}
+
+func bigFields(ints ...big.Int) ([]interface{}, error) {
+	out := make([]interface{}, len(ints))
+	for i, v := range ints {
+		b, err := bigIntBytes(v)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = b
+	}
+	return out, nil
+}
+
+func setBigFields(fields []interface{}, idx []int, ints []big.Int) error {
+	values, err := bigFields(ints...)
+	if err != nil {
+		return err
+	}
+	for i, f := range idx {
+		fields[f] = values[i]
+	}
+	return nil
+}
+
+// Decodes an RLP list of a number of fields.
+func decodeFields(data []byte, count int) ([]interface{}, error) {
+	item, err := decodeRLP(data)
+	if err != nil {
+		return nil, err
+	}
+	fields, ok := item.([]interface{})
+	if !ok {
+		return nil, xerrors.Errorf("expected an RLP list")
+	}
+	if len(fields) != count {
+		return nil, xerrors.Errorf("expected %d fields, got %d", count, len(fields))
+	}
+	return fields, nil
+}
+
+type fieldParser func(item interface{}) error
+
+func parseInto(fields []interface{}, parsers ...fieldParser) error {
+	for i, parse := range parsers {
+		if err := parse(fields[i]); err != nil {
+			return xerrors.Errorf("field %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func bytesField(out *[]byte) fieldParser {
+	return func(item interface{}) error {
+		b, ok := item.([]byte)
+		if !ok {
+			return xerrors.Errorf("expected a byte string")
+		}
+		*out = b
+		return nil
+	}
+}
+
+func uintField(out *uint64) fieldParser {
+	return func(item interface{}) error {
+		var b []byte
+		if err := bytesField(&b)(item); err != nil {
+			return err
+		}
+		v, err := parseUint64(b)
+		*out = v
+		return err
+	}
+}
+
+func bigField(out *big.Int) fieldParser {
+	return func(item interface{}) error {
+		var b []byte
+		if err := bytesField(&b)(item); err != nil {
+			return err
+		}
+		v, err := parseBigInt(b)
+		*out = v
+		return err
+	}
+}
+
+func toField(out **Address) fieldParser {
+	return func(item interface{}) error {
+		var b []byte
+		if err := bytesField(&b)(item); err != nil {
+			return err
+		}
+		switch len(b) {
+		case 0:
+			*out = nil
+		case AddressLength:
+			var a Address
+			copy(a[:], b)
+			*out = &a
+		default:
+			return xerrors.Errorf("expected a %d byte address, got %d bytes", AddressLength, len(b))
+		}
+		return nil
+	}
+}
+
+func skipField(interface{}) error {
+	return nil
+}
@@ -0,0 +1,109 @@
+package exitcode
+
+import (
+	"math"
+
+	"github.com/filecoin-project/go-state-types/actors"
+)
+
+// Actor-specific exit codes of the builtin actors.
+// Actors not listed here (e.g. the verified registry) only return system and common exit codes.
+const (
+	// The miner actor's balance no longer covers its locked funds, pre-commit deposits and
+	// initial pledge.
+	ErrBalanceInvariantBroken = ExitCode(1000)
+	// The miner actor failed to send a sector content notification, from actors version 13.
+	ErrNotificationSendFailed = ExitCode(1001)
+	// The receiver of a sector content notification aborted, from actors version 13.
+	ErrNotificationReceiverAborted = ExitCode(1002)
+	// The receiver of a sector content notification returned an invalid response, from actors
+	// version 13.
+	ErrNotificationResponseInvalid = ExitCode(1003)
+	// The receiver of a sector content notification rejected it, from actors version 13.
+	ErrNotificationRejected = ExitCode(1004)
+
+	// The market actor was asked to activate a deal past its start epoch, from actors version 13.
+	ErrDealExpired = ExitCode(32)
+
+	// The power actor has too many pre-committed sectors awaiting proof verification this epoch.
+	ErrTooManyProveCommits = FirstActorSpecificExitCode
+
+	// A payment channel voucher was submitted after the channel settled.
+	ErrChannelStateUpdateAfterSettled = FirstActorSpecificExitCode
+)
+
+// Exit codes of the EVM actor, from actors version 10.
+const (
+	// The EVM contract reverted; the return value holds the revert data.
+	ErrEVMContractReverted = FirstActorSpecificExitCode + 1 + iota
+	// The EVM contract executed the INVALID instruction.
+	ErrEVMContractInvalidInstruction
+	// The EVM contract executed an undefined instruction.
+	ErrEVMContractUndefinedInstruction
+	// The EVM contract popped from an empty stack.
+	ErrEVMContractStackUnderflow
+	// The EVM contract exceeded the maximum stack depth.
+	ErrEVMContractStackOverflow
+	// The EVM contract accessed memory out of bounds.
+	ErrEVMContractIllegalMemoryAccess
+	// The EVM contract jumped to an invalid jump destination.
+	ErrEVMContractBadJumpdest
+	// The EVM contract failed to self-destruct.
+	ErrEVMContractSelfdestructFailed
+)
+
+// Builtin actors manifest keys, as defined by the manifest package.
+const (
+	datacapActor  = "datacap"
+	evmActor      = "evm"
+	marketActor   = "storagemarket"
+	minerActor    = "storageminer"
+	paychActor    = "paymentchannel"
+	powerActor    = "storagepower"
+	latestVersion = actors.Version(math.MaxInt32)
+)
+
+func init() {
+	Register(minerActor, actors.Version0, latestVersion, ErrBalanceInvariantBroken, "ErrBalanceInvariantBroken",
+		"the miner's balance no longer covers its locked funds, pre-commit deposits and initial pledge")
+	Register(minerActor, actors.Version0, latestVersion, ErrInsufficientFunds, "ErrInsufficientFunds",
+		"the miner's available balance does not cover the required deposit, pledge or fee")
+	Register(minerActor, actors.Version13, latestVersion, ErrNotificationSendFailed, "ErrNotificationSendFailed",
+		"sending a sector content notification failed")
+	Register(minerActor, actors.Version13, latestVersion, ErrNotificationReceiverAborted, "ErrNotificationReceiverAborted",
+		"the receiver of a sector content notification aborted")
+	Register(minerActor, actors.Version13, latestVersion, ErrNotificationResponseInvalid, "ErrNotificationResponseInvalid",
+		"the receiver of a sector content notification returned an invalid response")
+	Register(minerActor, actors.Version13, latestVersion, ErrNotificationRejected, "ErrNotificationRejected",
+		"the receiver of a sector content notification rejected it")
+
+	Register(powerActor, actors.Version0, latestVersion, ErrTooManyProveCommits, "ErrTooManyProveCommits",
+		"too many sector proofs are awaiting verification this epoch")
+
+	Register(paychActor, actors.Version0, latestVersion, ErrChannelStateUpdateAfterSettled, "ErrChannelStateUpdateAfterSettled",
+		"the payment channel state cannot be updated after the channel settled")
+
+	Register(marketActor, actors.Version0, latestVersion, ErrInsufficientFunds, "ErrInsufficientFunds",
+		"the client's or provider's available escrow balance does not cover the deal payment or collateral")
+	Register(marketActor, actors.Version13, latestVersion, ErrDealExpired, "ErrDealExpired",
+		"the deal's start epoch passed before it was activated")
+
+	Register(datacapActor, actors.Version9, latestVersion, ErrInsufficientFunds, "ErrInsufficientFunds",
+		"the DataCap balance or allowance is insufficient")
+
+	for _, r := range []struct {
+		code              ExitCode
+		name, description string
+	}{
+		{ErrEVMContractReverted, "ErrEVMContractReverted", "the contract reverted"},
+		{ErrEVMContractInvalidInstruction, "ErrEVMContractInvalidInstruction", "the contract executed the INVALID instruction"},
+		{ErrEVMContractUndefinedInstruction, "ErrEVMContractUndefinedInstruction", "the contract executed an undefined instruction"},
+		{ErrEVMContractStackUnderflow, "ErrEVMContractStackUnderflow", "the contract popped from an empty stack"},
+		{ErrEVMContractStackOverflow, "ErrEVMContractStackOverflow", "the contract exceeded the maximum stack depth"},
+		{ErrEVMContractIllegalMemoryAccess, "ErrEVMContractIllegalMemoryAccess", "the contract accessed memory out of bounds"},
+		{ErrEVMContractBadJumpdest, "ErrEVMContractBadJumpdest", "the contract jumped to an invalid jump destination"},
+		{ErrEVMContractSelfdestructFailed, "ErrEVMContractSelfdestructFailed", "the contract failed to self-destruct"},
+	} {
+		Register(evmActor, actors.Version10, latestVersion, r.code, r.name, r.description)
+	}
+}
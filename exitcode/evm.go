@@ -0,0 +1,101 @@
+package exitcode
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+
+	cbg "github.com/whyrusleeping/cbor-gen"
+	"golang.org/x/xerrors"
+)
+
+var (
+	// Selector of Solidity's Error(string), raised by require() and revert("reason").
+	evmErrorSelector = []byte{0x08, 0xc3, 0x79, 0xa0}
+	// Selector of Solidity's Panic(uint256), raised by failed assertions and runtime checks.
+	evmPanicSelector = []byte{0x4e, 0x48, 0x7b, 0x71}
+)
+
+// Solidity panic codes, carried by Panic(uint256) revert data.
+var evmPanicCodes = map[uint64]string{
+	0x00: "generic compiler inserted panic",
+	0x01: "assertion failed",
+	0x11: "arithmetic overflow or underflow",
+	0x12: "division or modulo by zero",
+	0x21: "conversion to an invalid enum value",
+	0x22: "incorrectly encoded storage byte array",
+	0x31: "pop on an empty array",
+	0x32: "array index out of bounds",
+	0x41: "too much memory allocated",
+	0x51: "call to a zero-initialized internal function",
+}
+
+// DescribeEVMRevertReturn describes the return value of a message that failed with
+// ErrEVMContractReverted, which is the contract's revert data encoded as a CBOR byte string.
+func DescribeEVMRevertReturn(ret []byte) (string, error) {
+	if len(ret) == 0 {
+		return DescribeEVMRevert(nil), nil
+	}
+	data, err := cbg.ReadByteArray(bytes.NewReader(ret), uint64(len(ret)))
+	if err != nil {
+		return "", xerrors.Errorf("failed to decode revert data: %w", err)
+	}
+	return DescribeEVMRevert(data), nil
+}
+
+// DescribeEVMRevert describes the revert data of a reverted EVM contract call, decoding Solidity's
+// Error(string) reasons and Panic(uint256) codes.
+// Other revert data is described by its custom error selector.
+func DescribeEVMRevert(data []byte) string {
+	if len(data) == 0 {
+		return "reverted without data"
+	}
+	if len(data) < 4 {
+		return fmt.Sprintf("reverted with data 0x%x", data)
+	}
+
+	selector, args := data[:4], data[4:]
+	switch {
+	case bytes.Equal(selector, evmErrorSelector):
+		if reason, ok := decodeABIString(args); ok {
+			return fmt.Sprintf("reverted: %s", reason)
+		}
+	case bytes.Equal(selector, evmPanicSelector):
+		if code, ok := decodeABIUint64(args); ok {
+			desc, known := evmPanicCodes[code]
+			if !known {
+				desc = "unknown panic code"
+			}
+			return fmt.Sprintf("panic: %s (0x%02x)", desc, code)
+		}
+	}
+	return fmt.Sprintf("reverted with custom error 0x%s", hex.EncodeToString(selector))
+}
+
+// Decodes an ABI-encoded uint256 that fits in a uint64.
+func decodeABIUint64(word []byte) (uint64, bool) {
+	if len(word) < 32 {
+		return 0, false
+	}
+	for _, b := range word[:24] {
+		if b != 0 {
+			return 0, false
+		}
+	}
+	return binary.BigEndian.Uint64(word[24:32]), true
+}
+
+// Decodes an ABI-encoded string argument from the head of a tuple.
+func decodeABIString(args []byte) (string, bool) {
+	offset, ok := decodeABIUint64(args)
+	if !ok || offset > uint64(len(args)) {
+		return "", false
+	}
+	length, ok := decodeABIUint64(args[offset:])
+	if !ok || length > uint64(len(args))-offset-32 {
+		return "", false
+	}
+	start := offset + 32
+	return string(args[start : start+length]), true
+}
@@ -33,3 +33,31 @@ var names = map[ExitCode]string{
 	ErrReadOnly:          "ErrReadOnly",
 	ErrNotPayable:        "ErrNotPayable",
 }
+
+var descriptions = map[ExitCode]string{
+	Ok: "the message executed successfully",
+
+	// System errors
+	SysErrSenderInvalid:      "the message sender is not valid as a message sender",
+	SysErrSenderStateInvalid: "the message sender is not in a state to send the message",
+	SysErrIllegalInstruction: "the message receiver trapped",
+	SysErrInvalidReceiver:    "the message receiver is not valid and cannot be implicitly created",
+	SysErrInsufficientFunds:  "the message sender has insufficient balance for the value being sent",
+	SysErrOutOfGas:           "message execution used more gas than the gas limit",
+	SysErrIllegalExitCode:    "the actor attempted to exit with a reserved exit code",
+	SysErrFatal:              "something unexpected happened in the system",
+	SysErrMissingReturn:      "the actor returned a block handle that doesn't exist",
+
+	// Common errors
+	ErrIllegalArgument:   "a method parameter is invalid",
+	ErrNotFound:          "a requested resource does not exist",
+	ErrForbidden:         "the action is disallowed",
+	ErrInsufficientFunds: "a balance of funds is insufficient",
+	ErrIllegalState:      "the actor's internal state is invalid",
+	ErrSerialization:     "de/serialization failed within actor code",
+	ErrUnhandledMessage:  "the actor cannot handle this message",
+	ErrUnspecified:       "the actor failed with an unspecified error",
+	ErrAssertionFailed:   "the actor failed a user-level assertion",
+	ErrReadOnly:          "the actor cannot perform the requested operation in read-only mode",
+	ErrNotPayable:        "the method cannot handle a transfer of value",
+}
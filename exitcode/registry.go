@@ -0,0 +1,110 @@
+package exitcode
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/filecoin-project/go-state-types/actors"
+)
+
+// Info describes the meaning of an exit code.
+type Info struct {
+	Code ExitCode
+	// Symbolic name, e.g. "ErrIllegalArgument".
+	Name        string
+	Description string
+}
+
+func (i Info) String() string {
+	if i.Name == "" {
+		return i.Code.String()
+	}
+	return fmt.Sprintf("%s(%d): %s", i.Name, i.Code, i.Description)
+}
+
+// An actor-specific interpretation of an exit code, for a range of actors versions.
+type registration struct {
+	from, to actors.Version
+	info     Info
+}
+
+type registryKey struct {
+	actor string
+	code  ExitCode
+}
+
+var (
+	registryLk sync.RWMutex
+	registry   = map[registryKey][]registration{}
+)
+
+// Register records the meaning of an exit code when returned by an actor, identified by its
+// builtin actors manifest key (e.g. "storageminer"), for actors versions from..to inclusive.
+// A registration may also refine the description of a common exit code for that actor.
+func Register(actor string, from, to actors.Version, code ExitCode, name, description string) {
+	registryLk.Lock()
+	defer registryLk.Unlock()
+
+	key := registryKey{actor: actor, code: code}
+	registry[key] = append(registry[key], registration{
+		from: from,
+		to:   to,
+		info: Info{Code: code, Name: name, Description: description},
+	})
+}
+
+// Lookup describes an exit code returned by an actor at an actors version.
+// Codes without an actor-specific registration are described as system or common codes.
+// Returns false if the code is unknown.
+func Lookup(actor string, version actors.Version, code ExitCode) (Info, bool) {
+	registryLk.RLock()
+	defer registryLk.RUnlock()
+
+	for _, r := range registry[registryKey{actor: actor, code: code}] {
+		if version >= r.from && version <= r.to {
+			return r.info, true
+		}
+	}
+	name, ok := names[code]
+	if !ok {
+		return Info{Code: code}, false
+	}
+	return Info{Code: code, Name: name, Description: descriptions[code]}, true
+}
+
+// WithActor annotates an error with the actor that produced it, so that Describe can interpret
+// actor-specific exit codes. Unwrap extracts the same exit code from the result as from err.
+func WithActor(err error, actor string, version actors.Version) error {
+	if err == nil {
+		return nil
+	}
+	return &actorError{actor: actor, version: version, cause: err}
+}
+
+// Describe extracts an exit code from an error like Unwrap, and describes it in the context of the
+// actor most recently recorded by WithActor, if any.
+func Describe(err error, defaultExitCode ExitCode) Info {
+	code := Unwrap(err, defaultExitCode)
+	var ae *actorError
+	if errors.As(err, &ae) {
+		info, _ := Lookup(ae.actor, ae.version, code)
+		return info
+	}
+	info, _ := Lookup("", 0, code)
+	return info
+}
+
+type actorError struct {
+	actor   string
+	version actors.Version
+	cause   error
+}
+
+func (e *actorError) Error() string {
+	return fmt.Sprintf("%s actor (v%d): %s", e.actor, e.version, e.cause)
+}
+
+func (e *actorError) Unwrap() error {
+	return e.cause
+}
@@ -0,0 +1,84 @@
+package exitcode_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/actors"
+	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/filecoin-project/go-state-types/manifest"
+)
+
+func TestLookup(t *testing.T) {
+	info, ok := exitcode.Lookup(manifest.EvmKey, actors.Version19, 33)
+	require.True(t, ok)
+	assert.Equal(t, exitcode.ErrEVMContractReverted, info.Code)
+	assert.Equal(t, "ErrEVMContractReverted", info.Name)
+
+	// The EVM actor doesn't exist before actors version 10.
+	info, ok = exitcode.Lookup(manifest.EvmKey, actors.Version9, 33)
+	assert.False(t, ok)
+	assert.Equal(t, "33", info.String())
+
+	info, ok = exitcode.Lookup(manifest.PaychKey, actors.Version19, 32)
+	require.True(t, ok)
+	assert.Equal(t, "ErrChannelStateUpdateAfterSettled", info.Name)
+
+	info, ok = exitcode.Lookup(manifest.MinerKey, actors.Version19, 1004)
+	require.True(t, ok)
+	assert.Equal(t, exitcode.ErrNotificationRejected, info.Code)
+	_, ok = exitcode.Lookup(manifest.MinerKey, actors.Version12, 1004)
+	assert.False(t, ok)
+	info, ok = exitcode.Lookup(manifest.MarketKey, actors.Version13, 32)
+	require.True(t, ok)
+	assert.Equal(t, "ErrDealExpired", info.Name)
+	_, ok = exitcode.Lookup(manifest.MarketKey, actors.Version12, 32)
+	assert.False(t, ok)
+
+	// Common codes fall back to their generic description.
+	info, ok = exitcode.Lookup(manifest.VerifregKey, actors.Version19, exitcode.ErrForbidden)
+	require.True(t, ok)
+	assert.Equal(t, "ErrForbidden(18): the action is disallowed", info.String())
+}
+
+func TestDescribe(t *testing.T) {
+	err := exitcode.ExitCode(33).Wrapf("contract reverted")
+	err = exitcode.WithActor(err, manifest.EvmKey, actors.Version19)
+	err = xerrors.Errorf("send failed: %w", err)
+
+	assert.Equal(t, exitcode.ErrEVMContractReverted, exitcode.Unwrap(err, exitcode.Ok))
+	assert.Equal(t, "ErrEVMContractReverted", exitcode.Describe(err, exitcode.Ok).Name)
+	assert.Equal(t, "ErrIllegalState", exitcode.Describe(xerrors.New("no code"), exitcode.ErrIllegalState).Name)
+	assert.Nil(t, exitcode.WithActor(nil, manifest.EvmKey, actors.Version19))
+}
+
+func TestDescribeEVMRevert(t *testing.T) {
+	mustHex := func(s string) []byte {
+		b, err := hex.DecodeString(s)
+		require.NoError(t, err)
+		return b
+	}
+
+	// Error("Not enough Ether provided.")
+	errorData := mustHex("08c379a0" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"000000000000000000000000000000000000000000000000000000000000001a" +
+		"4e6f7420656e6f7567682045746865722070726f76696465642e000000000000")
+	assert.Equal(t, "reverted: Not enough Ether provided.", exitcode.DescribeEVMRevert(errorData))
+
+	// Panic(0x11)
+	panicData := mustHex("4e487b71" + "0000000000000000000000000000000000000000000000000000000000000011")
+	assert.Equal(t, "panic: arithmetic overflow or underflow (0x11)", exitcode.DescribeEVMRevert(panicData))
+
+	assert.Equal(t, "reverted with custom error 0xdeadbeef", exitcode.DescribeEVMRevert(mustHex("deadbeef")))
+	assert.Equal(t, "reverted without data", exitcode.DescribeEVMRevert(nil))
+
+	// The message return value wraps the revert data in a CBOR byte string.
+	desc, err := exitcode.DescribeEVMRevertReturn(append([]byte{0x58, byte(len(panicData))}, panicData...))
+	require.NoError(t, err)
+	assert.Equal(t, "panic: arithmetic overflow or underflow (0x11)", desc)
+}
@@ -0,0 +1,349 @@
+// Package export streams tables of actor state, such as sectors and deals, to CSV or JSON Lines for
+// analytics.
+//
+// The columns of each table are the same for every supported actors version. Fields that a version
+// does not have, such as the daily fee of sectors before actors version 16, are absent (nil).
+package export
+
+import (
+	"context"
+	"reflect"
+	"sync"
+
+	"github.com/ipfs/go-cid"
+	cbg "github.com/whyrusleeping/cbor-gen"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/actors"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin"
+	"github.com/filecoin-project/go-state-types/builtin/v8/util/adt"
+	"github.com/filecoin-project/go-state-types/cbor"
+	"github.com/filecoin-project/go-state-types/manifest"
+)
+
+// Bit widths of the exported collections, which are the same in every actors version.
+const (
+	sectorsAmtBitwidth   = 5
+	proposalsAmtBitwidth = 5
+	statesAmtBitwidth    = 6
+)
+
+// Options configures an export.
+type Options struct {
+	// Actors version of the state tree.
+	Version actors.Version
+	// Actor code CIDs by manifest key, as expected by CheckStateInvariants.
+	ActorCodes map[string]cid.Cid
+	// Epoch at which the locked balances of multisigs are computed.
+	Epoch abi.ChainEpoch
+	// Number of actors exported concurrently, each by its own goroutine, so the store must support
+	// concurrent reads. With the default of one, actors are exported in the order of the state tree
+	// and each table's rows in a stable order; otherwise the rows of different actors interleave.
+	Parallelism int
+}
+
+// Export writes the rows of each table with a writer from the state tree with the given root.
+// Writers are called by one goroutine at a time and flushed once all rows are written.
+func Export(ctx context.Context, store adt.Store, root cid.Cid, opts Options, writers map[Table]Writer) error {
+	types, ok := versions[opts.Version]
+	if !ok {
+		return xerrors.Errorf("unsupported actors version %d", opts.Version)
+	}
+	for t := range writers {
+		if _, ok := schemas[t]; !ok {
+			return xerrors.Errorf("unknown table %q", t)
+		}
+	}
+	tree, err := builtin.LoadTree(store, root)
+	if err != nil {
+		return xerrors.Errorf("failed to load state tree: %w", err)
+	}
+
+	e := &exporter{store: store, opts: opts, types: types, writers: writers}
+	parallelism := opts.Parallelism
+	if parallelism < 1 {
+		parallelism = 1
+	}
+	g, gctx := errgroup.WithContext(ctx)
+	queue := make(chan actor)
+	for i := 0; i < parallelism; i++ {
+		g.Go(func() error {
+			for a := range queue {
+				if err := e.exportActor(a); err != nil {
+					return xerrors.Errorf("failed to export actor %s: %w", a.addr, err)
+				}
+			}
+			return nil
+		})
+	}
+	g.Go(func() error {
+		defer close(queue)
+		return forEachActor(tree, types.treeV5, func(a actor) error {
+			select {
+			case queue <- a:
+				return nil
+			case <-gctx.Done():
+				return gctx.Err()
+			}
+		})
+	})
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	for _, t := range Tables {
+		if w, ok := writers[t]; ok {
+			if err := w.Flush(); err != nil {
+				return xerrors.Errorf("failed to flush %s: %w", t, err)
+			}
+		}
+	}
+	return nil
+}
+
+// actor is the part of an actor's entry in the state tree that is common to its versions.
+type actor struct {
+	addr    address.Address
+	code    cid.Cid
+	head    cid.Cid
+	balance abi.TokenAmount
+}
+
+func forEachActor(tree *builtin.ActorTree, v5 bool, fn func(actor) error) error {
+	if v5 {
+		return tree.ForEachV5(func(addr address.Address, a *builtin.ActorV5) error {
+			return fn(actor{addr: addr, code: a.Code, head: a.Head, balance: a.Balance})
+		})
+	}
+	return tree.ForEachV4(func(addr address.Address, a *builtin.ActorV4) error {
+		return fn(actor{addr: addr, code: a.Code, head: a.Head, balance: a.Balance})
+	})
+}
+
+type exporter struct {
+	store   adt.Store
+	opts    Options
+	types   stateTypes
+	writers map[Table]Writer
+
+	lk sync.Mutex
+}
+
+func (e *exporter) wants(tables ...Table) bool {
+	for _, t := range tables {
+		if _, ok := e.writers[t]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// emit writes a row to its table's writer, if any.
+func (e *exporter) emit(t Table, keys []interface{}, srcs ...interface{}) error {
+	w, ok := e.writers[t]
+	if !ok {
+		return nil
+	}
+	r, err := row(t, keys, srcs...)
+	if err != nil {
+		return err
+	}
+	e.lk.Lock()
+	defer e.lk.Unlock()
+	return w.Write(r)
+}
+
+func (e *exporter) exportActor(a actor) error {
+	switch a.code {
+	case e.opts.ActorCodes[manifest.MinerKey]:
+		if e.wants(Sectors, MinerInfo) {
+			return e.exportMiner(a)
+		}
+	case e.opts.ActorCodes[manifest.MarketKey]:
+		if e.wants(Deals) {
+			return e.exportMarket(a)
+		}
+	case e.opts.ActorCodes[manifest.PowerKey]:
+		if e.wants(PowerClaims) {
+			return e.exportPower(a)
+		}
+	case e.opts.ActorCodes[manifest.VerifregKey]:
+		if e.wants(Claims, Allocations) && e.types.verifregState != nil {
+			return e.exportVerifreg(a)
+		}
+	case e.opts.ActorCodes[manifest.MultisigKey]:
+		if e.wants(MultisigVesting) {
+			return e.exportMultisig(a)
+		}
+	}
+	return nil
+}
+
+// load decodes the object with a CID as a value of a state type, returning a pointer to it.
+func (e *exporter) load(c cid.Cid, typ reflect.Type) (interface{}, error) {
+	out := reflect.New(typ).Interface()
+	if err := e.store.Get(e.store.Context(), c, out); err != nil {
+		return nil, xerrors.Errorf("failed to load %s: %w", typ, err)
+	}
+	return out, nil
+}
+
+// cidField returns a CID field of a state object.
+func cidField(obj interface{}, name string) cid.Cid {
+	c, _ := reflect.ValueOf(obj).Elem().FieldByName(name).Interface().(cid.Cid)
+	return c
+}
+
+// newValue returns a pointer to a new value of a state type, for collections to decode into.
+func newValue(typ reflect.Type) (interface{}, cbor.Unmarshaler) {
+	v := reflect.New(typ).Interface()
+	return v, v.(cbor.Unmarshaler)
+}
+
+func (e *exporter) exportMiner(a actor) error {
+	st, err := e.load(a.head, e.types.minerState)
+	if err != nil {
+		return err
+	}
+	keys := []interface{}{a.addr.String()}
+	if e.wants(MinerInfo) {
+		info, err := e.load(cidField(st, "Info"), e.types.minerInfo)
+		if err != nil {
+			return err
+		}
+		if err := e.emit(MinerInfo, keys, info); err != nil {
+			return err
+		}
+	}
+	if !e.wants(Sectors) {
+		return nil
+	}
+	sectors, err := adt.AsArray(e.store, cidField(st, "Sectors"), sectorsAmtBitwidth)
+	if err != nil {
+		return xerrors.Errorf("failed to load sectors: %w", err)
+	}
+	sector, out := newValue(e.types.sectorInfo)
+	return sectors.ForEach(out, func(int64) error {
+		return e.emit(Sectors, keys, sector)
+	})
+}
+
+func (e *exporter) exportMarket(a actor) error {
+	st, err := e.load(a.head, e.types.marketState)
+	if err != nil {
+		return err
+	}
+	proposals, err := adt.AsArray(e.store, cidField(st, "Proposals"), proposalsAmtBitwidth)
+	if err != nil {
+		return xerrors.Errorf("failed to load deal proposals: %w", err)
+	}
+	states, err := adt.AsArray(e.store, cidField(st, "States"), statesAmtBitwidth)
+	if err != nil {
+		return xerrors.Errorf("failed to load deal states: %w", err)
+	}
+	proposal, out := newValue(e.types.dealProposal)
+	return proposals.ForEach(out, func(id int64) error {
+		dealState, stateOut := newValue(e.types.dealState)
+		found, err := states.Get(uint64(id), stateOut)
+		if err != nil {
+			return xerrors.Errorf("failed to load state of deal %d: %w", id, err)
+		}
+		if !found {
+			dealState = nil
+		}
+		return e.emit(Deals, []interface{}{id}, proposal, dealState)
+	})
+}
+
+func (e *exporter) exportPower(a actor) error {
+	st, err := e.load(a.head, e.types.powerState)
+	if err != nil {
+		return err
+	}
+	claims, err := adt.AsMap(e.store, cidField(st, "Claims"), builtin.DefaultHamtBitwidth)
+	if err != nil {
+		return xerrors.Errorf("failed to load power claims: %w", err)
+	}
+	claim, out := newValue(e.types.powerClaim)
+	return claims.ForEach(out, func(k string) error {
+		miner, err := address.NewFromBytes([]byte(k))
+		if err != nil {
+			return err
+		}
+		return e.emit(PowerClaims, []interface{}{miner.String()}, claim)
+	})
+}
+
+func (e *exporter) exportVerifreg(a actor) error {
+	st, err := e.load(a.head, e.types.verifregState)
+	if err != nil {
+		return err
+	}
+	for _, nested := range []struct {
+		table Table
+		field string
+		typ   reflect.Type
+	}{
+		{Claims, "Claims", e.types.claim},
+		{Allocations, "Allocations", e.types.allocation},
+	} {
+		if !e.wants(nested.table) {
+			continue
+		}
+		if err := e.forEachNested(cidField(st, nested.field), nested.typ, func(id uint64, v interface{}) error {
+			return e.emit(nested.table, []interface{}{int64(id)}, v)
+		}); err != nil {
+			return xerrors.Errorf("failed to export %s: %w", nested.table, err)
+		}
+	}
+	return nil
+}
+
+// forEachNested iterates over a HAMT of HAMTs keyed by actor ID and then by claim or allocation ID.
+func (e *exporter) forEachNested(root cid.Cid, typ reflect.Type, fn func(id uint64, v interface{}) error) error {
+	outer, err := adt.AsMap(e.store, root, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		return err
+	}
+	var innerRoot cbg.CborCid
+	return outer.ForEach(&innerRoot, func(string) error {
+		inner, err := adt.AsMap(e.store, cid.Cid(innerRoot), builtin.DefaultHamtBitwidth)
+		if err != nil {
+			return err
+		}
+		v, out := newValue(typ)
+		return inner.ForEach(out, func(k string) error {
+			id, err := abi.ParseUIntKey(k)
+			if err != nil {
+				return err
+			}
+			return fn(id, v)
+		})
+	})
+}
+
+// amountLocked is implemented by the multisig state of every actors version.
+type amountLocked interface {
+	AmountLocked(elapsedEpoch abi.ChainEpoch) abi.TokenAmount
+}
+
+func (e *exporter) exportMultisig(a actor) error {
+	st, err := e.load(a.head, e.types.multisigState)
+	if err != nil {
+		return err
+	}
+	startEpoch, _ := reflect.ValueOf(st).Elem().FieldByName("StartEpoch").Interface().(abi.ChainEpoch)
+	locked := st.(amountLocked).AmountLocked(e.opts.Epoch - startEpoch)
+	return e.emit(MultisigVesting, []interface{}{a.addr.String(), bigString(a.balance), bigString(locked)}, st)
+}
+
+func bigString(v big.Int) interface{} {
+	if v.Int == nil {
+		return nil
+	}
+	return v.String()
+}
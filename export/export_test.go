@@ -0,0 +1,147 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"sort"
+	"testing"
+
+	cbor "github.com/ipfs/go-ipld-cbor"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	miner8 "github.com/filecoin-project/go-state-types/builtin/v8/miner"
+	"github.com/filecoin-project/go-state-types/builtin/v8/util/adt"
+	"github.com/filecoin-project/go-state-types/test_util"
+	"github.com/filecoin-project/go-state-types/test_util/synth"
+)
+
+func TestExport(t *testing.T) {
+	ctx := context.Background()
+	store := cbor.NewCborStore(test_util.NewSyncBlockStoreInMemory())
+	cfg := synth.DefaultConfig()
+	cfg.SectorsPerMiner = 50
+	res, err := synth.Generate(ctx, store, cfg)
+	require.NoError(t, err)
+
+	export := func(parallelism int) map[Table]*bytes.Buffer {
+		out := make(map[Table]*bytes.Buffer)
+		writers := make(map[Table]Writer)
+		for _, table := range Tables {
+			out[table] = new(bytes.Buffer)
+			writers[table] = NewCSVWriter(out[table], table)
+		}
+		require.NoError(t, Export(ctx, adt.WrapStore(ctx, store), res.StateRoot, Options{
+			Version:     cfg.ActorsVersion,
+			ActorCodes:  res.ActorCodes,
+			Epoch:       cfg.PriorEpoch,
+			Parallelism: parallelism,
+		}, writers))
+		return out
+	}
+
+	serial := export(1)
+	expected := map[Table]int{
+		Sectors:         cfg.Miners * cfg.SectorsPerMiner,
+		Deals:           cfg.Miners * cfg.DealsPerMiner,
+		Claims:          cfg.Miners * cfg.ClaimsPerMiner,
+		Allocations:     cfg.VerifiedClients * cfg.AllocationsPerClient,
+		MinerInfo:       cfg.Miners,
+		PowerClaims:     cfg.Miners,
+		MultisigVesting: cfg.Multisigs,
+	}
+	records := make(map[Table][][]string)
+	for table, n := range expected {
+		rs, err := csv.NewReader(serial[table]).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, rs, n+1, table)
+		for i, c := range Columns(table) {
+			require.Equal(t, c.Name, rs[0][i])
+		}
+		records[table] = rs[1:]
+	}
+	for _, r := range records[Sectors] {
+		require.NotEmpty(t, r[column(Sectors, "miner")])
+		require.NotEmpty(t, r[column(Sectors, "daily_fee")])
+		// Fields of earlier versions only
+		require.Empty(t, r[column(Sectors, "replaced_sector_age")])
+		require.Empty(t, r[column(Sectors, "simple_qa_power")])
+	}
+	for _, r := range records[Deals] {
+		require.NotEmpty(t, r[column(Deals, "sector_number")])
+	}
+
+	// Exporting in parallel yields the same rows in another order.
+	for table, buf := range export(4) {
+		rs, err := csv.NewReader(buf).ReadAll()
+		require.NoError(t, err)
+		sortRecords(rs[1:])
+		sortRecords(records[table])
+		require.Equal(t, records[table], rs[1:], table)
+	}
+
+	var jsonl bytes.Buffer
+	require.NoError(t, Export(ctx, adt.WrapStore(ctx, store), res.StateRoot, Options{
+		Version:    cfg.ActorsVersion,
+		ActorCodes: res.ActorCodes,
+	}, map[Table]Writer{PowerClaims: NewJSONLinesWriter(&jsonl, PowerClaims)}))
+	lines := 0
+	for scanner := bufio.NewScanner(&jsonl); scanner.Scan(); lines++ {
+		var claim map[string]interface{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &claim))
+		require.Len(t, claim, len(Columns(PowerClaims)))
+		require.IsType(t, "", claim["raw_byte_power"])
+		require.IsType(t, float64(0), claim["window_post_proof_type"])
+	}
+	require.Equal(t, cfg.Miners, lines)
+
+	err = Export(ctx, adt.WrapStore(ctx, store), res.StateRoot, Options{Version: 7}, nil)
+	require.ErrorContains(t, err, "unsupported actors version")
+}
+
+func TestRowAcrossVersions(t *testing.T) {
+	r, err := row(Sectors, []interface{}{"f01000"}, &miner8.SectorOnChainInfo{
+		SectorNumber:      7,
+		DealWeight:        big.NewInt(3),
+		ExpectedDayReward: big.NewInt(5),
+		ReplacedSectorAge: abi.ChainEpoch(11),
+	})
+	require.NoError(t, err)
+	values := make(map[string]interface{})
+	for i, c := range Columns(Sectors) {
+		values[c.Name] = r[i]
+	}
+	require.Equal(t, "f01000", values["miner"])
+	require.Equal(t, int64(7), values["sector_number"])
+	require.Equal(t, "3", values["deal_weight"])
+	require.Equal(t, "5", values["expected_day_reward"])
+	require.Equal(t, int64(11), values["replaced_sector_age"])
+	require.Nil(t, values["initial_pledge"], "unset token amount")
+	require.Nil(t, values["sealed_cid"], "undefined CID")
+	require.Nil(t, values["sector_key_cid"], "nil pointer")
+	require.Nil(t, values["daily_fee"], "field added after v8")
+}
+
+func sortRecords(rs [][]string) {
+	sort.Slice(rs, func(i, j int) bool {
+		for k := range rs[i] {
+			if rs[i][k] != rs[j][k] {
+				return rs[i][k] < rs[j][k]
+			}
+		}
+		return false
+	})
+}
+
+func column(t Table, name string) int {
+	for i, c := range Columns(t) {
+		if c.Name == name {
+			return i
+		}
+	}
+	panic("no column " + name)
+}
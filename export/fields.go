@@ -0,0 +1,126 @@
+package export
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/ipfs/go-cid"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/big"
+)
+
+// dealLabel is implemented by the DealLabel of every actors version.
+type dealLabel interface {
+	IsString() bool
+	ToString() (string, error)
+	ToBytes() ([]byte, error)
+}
+
+// fieldValue returns the value of a column from the field at a dot-separated path in a state
+// object. The value is nil if the object or a pointer on the path is nil, or if the object's type
+// does not have the field, as in actors versions before or after those having it.
+func fieldValue(v reflect.Value, path string, kind Kind) (interface{}, error) {
+	for _, name := range strings.Split(path, ".") {
+		v = deref(v)
+		if !v.IsValid() {
+			return nil, nil
+		}
+		if v.Kind() != reflect.Struct {
+			return nil, xerrors.Errorf("field %s of non-struct %s", name, v.Type())
+		}
+		if v = v.FieldByName(name); !v.IsValid() {
+			return nil, nil
+		}
+	}
+	return value(v, kind)
+}
+
+func deref(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// value converts a field to a value of a column's kind.
+func value(v reflect.Value, kind Kind) (interface{}, error) {
+	if v = deref(v); !v.IsValid() {
+		return nil, nil
+	}
+	switch x := v.Interface().(type) {
+	case big.Int:
+		if x.Int == nil {
+			return nil, nil
+		}
+		if kind == KindBigInt {
+			return x.String(), nil
+		}
+	case cid.Cid:
+		if !x.Defined() {
+			return nil, nil
+		}
+		if kind == KindString {
+			return x.String(), nil
+		}
+	case address.Address:
+		if x == address.Undef {
+			return nil, nil
+		}
+		if kind == KindString {
+			return x.String(), nil
+		}
+	case dealLabel:
+		// A label is either a string or bytes, presented in one column or the other.
+		switch {
+		case kind == KindString && x.IsString():
+			return x.ToString()
+		case kind == KindBytes && !x.IsString():
+			return x.ToBytes()
+		case kind == KindString || kind == KindBytes:
+			return nil, nil
+		}
+	default:
+		switch {
+		case kind == KindInt && v.CanInt():
+			return v.Int(), nil
+		case kind == KindInt && v.CanUint():
+			return int64(v.Uint()), nil
+		case kind == KindBool && v.Kind() == reflect.Bool:
+			return v.Bool(), nil
+		case kind == KindString && v.Kind() == reflect.String:
+			return v.String(), nil
+		case kind == KindBytes && v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8:
+			return v.Bytes(), nil
+		case kind == KindList && v.Kind() == reflect.Slice:
+			list := make([]string, v.Len())
+			for i := range list {
+				elem, err := value(v.Index(i), KindString)
+				if err != nil {
+					return nil, err
+				}
+				s, _ := elem.(string)
+				list[i] = s
+			}
+			return list, nil
+		}
+	}
+	return nil, xerrors.Errorf("cannot present %s as %s", v.Type(), kind)
+}
+
+var kindNames = map[Kind]string{
+	KindInt:    "int",
+	KindBigInt: "bigint",
+	KindString: "string",
+	KindBool:   "bool",
+	KindBytes:  "bytes",
+	KindList:   "list",
+}
+
+func (k Kind) String() string {
+	return kindNames[k]
+}
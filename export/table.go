@@ -0,0 +1,190 @@
+package export
+
+import (
+	"reflect"
+
+	"golang.org/x/xerrors"
+)
+
+// Table is a kind of row extracted from the state of some actors.
+type Table string
+
+const (
+	// Sectors of storage miners, one row per SectorOnChainInfo.
+	Sectors Table = "sectors"
+	// Market deals, one row per DealProposal joined with its DealState, if any.
+	Deals Table = "deals"
+	// Verified registry claims, from actors version 9.
+	Claims Table = "claims"
+	// Verified registry allocations, from actors version 9.
+	Allocations Table = "allocations"
+	// Storage miner info, one row per miner.
+	MinerInfo Table = "miner_info"
+	// Storage power claims, one row per miner.
+	PowerClaims Table = "power_claims"
+	// Multisig linear vesting, one row per multisig.
+	MultisigVesting Table = "multisig_vesting"
+)
+
+// Tables lists every table, in a fixed order.
+var Tables = []Table{Sectors, Deals, Claims, Allocations, MinerInfo, PowerClaims, MultisigVesting}
+
+// Kind is the type of the values in a column.
+type Kind int
+
+const (
+	KindInt    Kind = iota // int64
+	KindBigInt             // string, the decimal value of a token amount or other big integer
+	KindString             // string, including addresses and CIDs
+	KindBool               // bool
+	KindBytes              // []byte
+	KindList               // []string
+)
+
+// Column is a column of a table. Its values have the column's Kind, or are nil where the state has no
+// value, including for fields that the state does not have in an actors version.
+type Column struct {
+	Name string
+	Kind Kind
+
+	key  bool   // taken from the keys of the row rather than from its state
+	src  int    // index of the state object holding the field
+	path string // field path in the state object, dot-separated
+}
+
+// Row is a row of a table, holding a value for each column.
+type Row []interface{}
+
+func key(name string, kind Kind) Column {
+	return Column{Name: name, Kind: kind, key: true}
+}
+
+func field(name string, kind Kind, path string) Column {
+	return Column{Name: name, Kind: kind, path: path}
+}
+
+func fieldOf(src int, name string, kind Kind, path string) Column {
+	return Column{Name: name, Kind: kind, src: src, path: path}
+}
+
+var schemas = map[Table][]Column{
+	Sectors: {
+		key("miner", KindString),
+		field("sector_number", KindInt, "SectorNumber"),
+		field("seal_proof", KindInt, "SealProof"),
+		field("sealed_cid", KindString, "SealedCID"),
+		field("activation", KindInt, "Activation"),
+		field("expiration", KindInt, "Expiration"),
+		field("deal_weight", KindBigInt, "DealWeight"),
+		field("verified_deal_weight", KindBigInt, "VerifiedDealWeight"),
+		field("initial_pledge", KindBigInt, "InitialPledge"),
+		field("expected_day_reward", KindBigInt, "ExpectedDayReward"),
+		field("expected_storage_pledge", KindBigInt, "ExpectedStoragePledge"),
+		field("replaced_sector_age", KindInt, "ReplacedSectorAge"),
+		field("power_base_epoch", KindInt, "PowerBaseEpoch"),
+		field("replaced_day_reward", KindBigInt, "ReplacedDayReward"),
+		field("sector_key_cid", KindString, "SectorKeyCID"),
+		field("simple_qa_power", KindBool, "SimpleQAPower"),
+		field("flags", KindInt, "Flags"),
+		field("daily_fee", KindBigInt, "DailyFee"),
+	},
+	Deals: {
+		key("deal_id", KindInt),
+		fieldOf(0, "piece_cid", KindString, "PieceCID"),
+		fieldOf(0, "piece_size", KindInt, "PieceSize"),
+		fieldOf(0, "verified_deal", KindBool, "VerifiedDeal"),
+		fieldOf(0, "client", KindString, "Client"),
+		fieldOf(0, "provider", KindString, "Provider"),
+		fieldOf(0, "label", KindString, "Label"),
+		fieldOf(0, "label_bytes", KindBytes, "Label"),
+		fieldOf(0, "start_epoch", KindInt, "StartEpoch"),
+		fieldOf(0, "end_epoch", KindInt, "EndEpoch"),
+		fieldOf(0, "storage_price_per_epoch", KindBigInt, "StoragePricePerEpoch"),
+		fieldOf(0, "provider_collateral", KindBigInt, "ProviderCollateral"),
+		fieldOf(0, "client_collateral", KindBigInt, "ClientCollateral"),
+		fieldOf(1, "sector_number", KindInt, "SectorNumber"),
+		fieldOf(1, "sector_start_epoch", KindInt, "SectorStartEpoch"),
+		fieldOf(1, "last_updated_epoch", KindInt, "LastUpdatedEpoch"),
+		fieldOf(1, "slash_epoch", KindInt, "SlashEpoch"),
+		fieldOf(1, "verified_claim", KindInt, "VerifiedClaim"),
+	},
+	Claims: {
+		key("claim_id", KindInt),
+		field("provider", KindInt, "Provider"),
+		field("client", KindInt, "Client"),
+		field("data", KindString, "Data"),
+		field("size", KindInt, "Size"),
+		field("term_min", KindInt, "TermMin"),
+		field("term_max", KindInt, "TermMax"),
+		field("term_start", KindInt, "TermStart"),
+		field("sector", KindInt, "Sector"),
+	},
+	Allocations: {
+		key("allocation_id", KindInt),
+		field("client", KindInt, "Client"),
+		field("provider", KindInt, "Provider"),
+		field("data", KindString, "Data"),
+		field("size", KindInt, "Size"),
+		field("term_min", KindInt, "TermMin"),
+		field("term_max", KindInt, "TermMax"),
+		field("expiration", KindInt, "Expiration"),
+	},
+	MinerInfo: {
+		key("miner", KindString),
+		field("owner", KindString, "Owner"),
+		field("worker", KindString, "Worker"),
+		field("control_addresses", KindList, "ControlAddresses"),
+		field("pending_worker_key", KindString, "PendingWorkerKey.NewWorker"),
+		field("pending_worker_key_effective_at", KindInt, "PendingWorkerKey.EffectiveAt"),
+		field("peer_id", KindBytes, "PeerId"),
+		field("window_post_proof_type", KindInt, "WindowPoStProofType"),
+		field("sector_size", KindInt, "SectorSize"),
+		field("window_post_partition_sectors", KindInt, "WindowPoStPartitionSectors"),
+		field("consensus_fault_elapsed", KindInt, "ConsensusFaultElapsed"),
+		field("pending_owner_address", KindString, "PendingOwnerAddress"),
+		field("beneficiary", KindString, "Beneficiary"),
+		field("beneficiary_quota", KindBigInt, "BeneficiaryTerm.Quota"),
+		field("beneficiary_used_quota", KindBigInt, "BeneficiaryTerm.UsedQuota"),
+		field("beneficiary_expiration", KindInt, "BeneficiaryTerm.Expiration"),
+	},
+	PowerClaims: {
+		key("miner", KindString),
+		field("window_post_proof_type", KindInt, "WindowPoStProofType"),
+		field("raw_byte_power", KindBigInt, "RawBytePower"),
+		field("quality_adj_power", KindBigInt, "QualityAdjPower"),
+	},
+	MultisigVesting: {
+		key("multisig", KindString),
+		key("balance", KindBigInt),
+		key("locked", KindBigInt),
+		field("signers", KindList, "Signers"),
+		field("num_approvals_threshold", KindInt, "NumApprovalsThreshold"),
+		field("initial_balance", KindBigInt, "InitialBalance"),
+		field("start_epoch", KindInt, "StartEpoch"),
+		field("unlock_duration", KindInt, "UnlockDuration"),
+	},
+}
+
+// Columns returns the columns of a table, which are the same for every actors version.
+func Columns(t Table) []Column {
+	return schemas[t]
+}
+
+// row builds a row of a table from its keys, in the order of its key columns, and the state objects
+// holding its fields, which may be nil.
+func row(t Table, keys []interface{}, srcs ...interface{}) (Row, error) {
+	columns := schemas[t]
+	out := make(Row, len(columns))
+	for i, c := range columns {
+		if c.key {
+			out[i], keys = keys[0], keys[1:]
+			continue
+		}
+		v, err := fieldValue(reflect.ValueOf(srcs[c.src]), c.path, c.Kind)
+		if err != nil {
+			return nil, xerrors.Errorf("%s column %s: %w", t, c.Name, err)
+		}
+		out[i] = v
+	}
+	return out, nil
+}
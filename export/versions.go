@@ -0,0 +1,269 @@
+package export
+
+import (
+	"reflect"
+
+	"github.com/filecoin-project/go-state-types/actors"
+	market10 "github.com/filecoin-project/go-state-types/builtin/v10/market"
+	miner10 "github.com/filecoin-project/go-state-types/builtin/v10/miner"
+	multisig10 "github.com/filecoin-project/go-state-types/builtin/v10/multisig"
+	power10 "github.com/filecoin-project/go-state-types/builtin/v10/power"
+	verifreg10 "github.com/filecoin-project/go-state-types/builtin/v10/verifreg"
+	market11 "github.com/filecoin-project/go-state-types/builtin/v11/market"
+	miner11 "github.com/filecoin-project/go-state-types/builtin/v11/miner"
+	multisig11 "github.com/filecoin-project/go-state-types/builtin/v11/multisig"
+	power11 "github.com/filecoin-project/go-state-types/builtin/v11/power"
+	verifreg11 "github.com/filecoin-project/go-state-types/builtin/v11/verifreg"
+	market12 "github.com/filecoin-project/go-state-types/builtin/v12/market"
+	miner12 "github.com/filecoin-project/go-state-types/builtin/v12/miner"
+	multisig12 "github.com/filecoin-project/go-state-types/builtin/v12/multisig"
+	power12 "github.com/filecoin-project/go-state-types/builtin/v12/power"
+	verifreg12 "github.com/filecoin-project/go-state-types/builtin/v12/verifreg"
+	market13 "github.com/filecoin-project/go-state-types/builtin/v13/market"
+	miner13 "github.com/filecoin-project/go-state-types/builtin/v13/miner"
+	multisig13 "github.com/filecoin-project/go-state-types/builtin/v13/multisig"
+	power13 "github.com/filecoin-project/go-state-types/builtin/v13/power"
+	verifreg13 "github.com/filecoin-project/go-state-types/builtin/v13/verifreg"
+	market14 "github.com/filecoin-project/go-state-types/builtin/v14/market"
+	miner14 "github.com/filecoin-project/go-state-types/builtin/v14/miner"
+	multisig14 "github.com/filecoin-project/go-state-types/builtin/v14/multisig"
+	power14 "github.com/filecoin-project/go-state-types/builtin/v14/power"
+	verifreg14 "github.com/filecoin-project/go-state-types/builtin/v14/verifreg"
+	market15 "github.com/filecoin-project/go-state-types/builtin/v15/market"
+	miner15 "github.com/filecoin-project/go-state-types/builtin/v15/miner"
+	multisig15 "github.com/filecoin-project/go-state-types/builtin/v15/multisig"
+	power15 "github.com/filecoin-project/go-state-types/builtin/v15/power"
+	verifreg15 "github.com/filecoin-project/go-state-types/builtin/v15/verifreg"
+	market16 "github.com/filecoin-project/go-state-types/builtin/v16/market"
+	miner16 "github.com/filecoin-project/go-state-types/builtin/v16/miner"
+	multisig16 "github.com/filecoin-project/go-state-types/builtin/v16/multisig"
+	power16 "github.com/filecoin-project/go-state-types/builtin/v16/power"
+	verifreg16 "github.com/filecoin-project/go-state-types/builtin/v16/verifreg"
+	market17 "github.com/filecoin-project/go-state-types/builtin/v17/market"
+	miner17 "github.com/filecoin-project/go-state-types/builtin/v17/miner"
+	multisig17 "github.com/filecoin-project/go-state-types/builtin/v17/multisig"
+	power17 "github.com/filecoin-project/go-state-types/builtin/v17/power"
+	verifreg17 "github.com/filecoin-project/go-state-types/builtin/v17/verifreg"
+	market18 "github.com/filecoin-project/go-state-types/builtin/v18/market"
+	miner18 "github.com/filecoin-project/go-state-types/builtin/v18/miner"
+	multisig18 "github.com/filecoin-project/go-state-types/builtin/v18/multisig"
+	power18 "github.com/filecoin-project/go-state-types/builtin/v18/power"
+	verifreg18 "github.com/filecoin-project/go-state-types/builtin/v18/verifreg"
+	market19 "github.com/filecoin-project/go-state-types/builtin/v19/market"
+	miner19 "github.com/filecoin-project/go-state-types/builtin/v19/miner"
+	multisig19 "github.com/filecoin-project/go-state-types/builtin/v19/multisig"
+	power19 "github.com/filecoin-project/go-state-types/builtin/v19/power"
+	verifreg19 "github.com/filecoin-project/go-state-types/builtin/v19/verifreg"
+	market8 "github.com/filecoin-project/go-state-types/builtin/v8/market"
+	miner8 "github.com/filecoin-project/go-state-types/builtin/v8/miner"
+	multisig8 "github.com/filecoin-project/go-state-types/builtin/v8/multisig"
+	power8 "github.com/filecoin-project/go-state-types/builtin/v8/power"
+	market9 "github.com/filecoin-project/go-state-types/builtin/v9/market"
+	miner9 "github.com/filecoin-project/go-state-types/builtin/v9/miner"
+	multisig9 "github.com/filecoin-project/go-state-types/builtin/v9/multisig"
+	power9 "github.com/filecoin-project/go-state-types/builtin/v9/power"
+	verifreg9 "github.com/filecoin-project/go-state-types/builtin/v9/verifreg"
+)
+
+// stateTypes are the types of the exported state in an actors version. The tables are built from
+// their fields by name, so that the same columns cover every version.
+type stateTypes struct {
+	// Whether the state tree holds ActorV5 rather than ActorV4, from actors version 10.
+	treeV5 bool
+
+	minerState   reflect.Type
+	minerInfo    reflect.Type
+	sectorInfo   reflect.Type
+	marketState  reflect.Type
+	dealProposal reflect.Type
+	dealState    reflect.Type
+	powerState   reflect.Type
+	powerClaim   reflect.Type
+	// The verified registry holds claims and allocations from actors version 9, nil before.
+	verifregState reflect.Type
+	claim         reflect.Type
+	allocation    reflect.Type
+	multisigState reflect.Type
+}
+
+func typeOf(ptr interface{}) reflect.Type {
+	return reflect.TypeOf(ptr).Elem()
+}
+
+var versions = map[actors.Version]stateTypes{
+	actors.Version8: {
+		minerState:    typeOf((*miner8.State)(nil)),
+		minerInfo:     typeOf((*miner8.MinerInfo)(nil)),
+		sectorInfo:    typeOf((*miner8.SectorOnChainInfo)(nil)),
+		marketState:   typeOf((*market8.State)(nil)),
+		dealProposal:  typeOf((*market8.DealProposal)(nil)),
+		dealState:     typeOf((*market8.DealState)(nil)),
+		powerState:    typeOf((*power8.State)(nil)),
+		powerClaim:    typeOf((*power8.Claim)(nil)),
+		multisigState: typeOf((*multisig8.State)(nil)),
+	},
+	actors.Version9: {
+		minerState:    typeOf((*miner9.State)(nil)),
+		minerInfo:     typeOf((*miner9.MinerInfo)(nil)),
+		sectorInfo:    typeOf((*miner9.SectorOnChainInfo)(nil)),
+		marketState:   typeOf((*market9.State)(nil)),
+		dealProposal:  typeOf((*market9.DealProposal)(nil)),
+		dealState:     typeOf((*market9.DealState)(nil)),
+		powerState:    typeOf((*power9.State)(nil)),
+		powerClaim:    typeOf((*power9.Claim)(nil)),
+		verifregState: typeOf((*verifreg9.State)(nil)),
+		claim:         typeOf((*verifreg9.Claim)(nil)),
+		allocation:    typeOf((*verifreg9.Allocation)(nil)),
+		multisigState: typeOf((*multisig9.State)(nil)),
+	},
+	actors.Version10: {
+		treeV5:        true,
+		minerState:    typeOf((*miner10.State)(nil)),
+		minerInfo:     typeOf((*miner10.MinerInfo)(nil)),
+		sectorInfo:    typeOf((*miner10.SectorOnChainInfo)(nil)),
+		marketState:   typeOf((*market10.State)(nil)),
+		dealProposal:  typeOf((*market10.DealProposal)(nil)),
+		dealState:     typeOf((*market10.DealState)(nil)),
+		powerState:    typeOf((*power10.State)(nil)),
+		powerClaim:    typeOf((*power10.Claim)(nil)),
+		verifregState: typeOf((*verifreg10.State)(nil)),
+		claim:         typeOf((*verifreg10.Claim)(nil)),
+		allocation:    typeOf((*verifreg10.Allocation)(nil)),
+		multisigState: typeOf((*multisig10.State)(nil)),
+	},
+	actors.Version11: {
+		treeV5:        true,
+		minerState:    typeOf((*miner11.State)(nil)),
+		minerInfo:     typeOf((*miner11.MinerInfo)(nil)),
+		sectorInfo:    typeOf((*miner11.SectorOnChainInfo)(nil)),
+		marketState:   typeOf((*market11.State)(nil)),
+		dealProposal:  typeOf((*market11.DealProposal)(nil)),
+		dealState:     typeOf((*market11.DealState)(nil)),
+		powerState:    typeOf((*power11.State)(nil)),
+		powerClaim:    typeOf((*power11.Claim)(nil)),
+		verifregState: typeOf((*verifreg11.State)(nil)),
+		claim:         typeOf((*verifreg11.Claim)(nil)),
+		allocation:    typeOf((*verifreg11.Allocation)(nil)),
+		multisigState: typeOf((*multisig11.State)(nil)),
+	},
+	actors.Version12: {
+		treeV5:        true,
+		minerState:    typeOf((*miner12.State)(nil)),
+		minerInfo:     typeOf((*miner12.MinerInfo)(nil)),
+		sectorInfo:    typeOf((*miner12.SectorOnChainInfo)(nil)),
+		marketState:   typeOf((*market12.State)(nil)),
+		dealProposal:  typeOf((*market12.DealProposal)(nil)),
+		dealState:     typeOf((*market12.DealState)(nil)),
+		powerState:    typeOf((*power12.State)(nil)),
+		powerClaim:    typeOf((*power12.Claim)(nil)),
+		verifregState: typeOf((*verifreg12.State)(nil)),
+		claim:         typeOf((*verifreg12.Claim)(nil)),
+		allocation:    typeOf((*verifreg12.Allocation)(nil)),
+		multisigState: typeOf((*multisig12.State)(nil)),
+	},
+	actors.Version13: {
+		treeV5:        true,
+		minerState:    typeOf((*miner13.State)(nil)),
+		minerInfo:     typeOf((*miner13.MinerInfo)(nil)),
+		sectorInfo:    typeOf((*miner13.SectorOnChainInfo)(nil)),
+		marketState:   typeOf((*market13.State)(nil)),
+		dealProposal:  typeOf((*market13.DealProposal)(nil)),
+		dealState:     typeOf((*market13.DealState)(nil)),
+		powerState:    typeOf((*power13.State)(nil)),
+		powerClaim:    typeOf((*power13.Claim)(nil)),
+		verifregState: typeOf((*verifreg13.State)(nil)),
+		claim:         typeOf((*verifreg13.Claim)(nil)),
+		allocation:    typeOf((*verifreg13.Allocation)(nil)),
+		multisigState: typeOf((*multisig13.State)(nil)),
+	},
+	actors.Version14: {
+		treeV5:        true,
+		minerState:    typeOf((*miner14.State)(nil)),
+		minerInfo:     typeOf((*miner14.MinerInfo)(nil)),
+		sectorInfo:    typeOf((*miner14.SectorOnChainInfo)(nil)),
+		marketState:   typeOf((*market14.State)(nil)),
+		dealProposal:  typeOf((*market14.DealProposal)(nil)),
+		dealState:     typeOf((*market14.DealState)(nil)),
+		powerState:    typeOf((*power14.State)(nil)),
+		powerClaim:    typeOf((*power14.Claim)(nil)),
+		verifregState: typeOf((*verifreg14.State)(nil)),
+		claim:         typeOf((*verifreg14.Claim)(nil)),
+		allocation:    typeOf((*verifreg14.Allocation)(nil)),
+		multisigState: typeOf((*multisig14.State)(nil)),
+	},
+	actors.Version15: {
+		treeV5:        true,
+		minerState:    typeOf((*miner15.State)(nil)),
+		minerInfo:     typeOf((*miner15.MinerInfo)(nil)),
+		sectorInfo:    typeOf((*miner15.SectorOnChainInfo)(nil)),
+		marketState:   typeOf((*market15.State)(nil)),
+		dealProposal:  typeOf((*market15.DealProposal)(nil)),
+		dealState:     typeOf((*market15.DealState)(nil)),
+		powerState:    typeOf((*power15.State)(nil)),
+		powerClaim:    typeOf((*power15.Claim)(nil)),
+		verifregState: typeOf((*verifreg15.State)(nil)),
+		claim:         typeOf((*verifreg15.Claim)(nil)),
+		allocation:    typeOf((*verifreg15.Allocation)(nil)),
+		multisigState: typeOf((*multisig15.State)(nil)),
+	},
+	actors.Version16: {
+		treeV5:        true,
+		minerState:    typeOf((*miner16.State)(nil)),
+		minerInfo:     typeOf((*miner16.MinerInfo)(nil)),
+		sectorInfo:    typeOf((*miner16.SectorOnChainInfo)(nil)),
+		marketState:   typeOf((*market16.State)(nil)),
+		dealProposal:  typeOf((*market16.DealProposal)(nil)),
+		dealState:     typeOf((*market16.DealState)(nil)),
+		powerState:    typeOf((*power16.State)(nil)),
+		powerClaim:    typeOf((*power16.Claim)(nil)),
+		verifregState: typeOf((*verifreg16.State)(nil)),
+		claim:         typeOf((*verifreg16.Claim)(nil)),
+		allocation:    typeOf((*verifreg16.Allocation)(nil)),
+		multisigState: typeOf((*multisig16.State)(nil)),
+	},
+	actors.Version17: {
+		treeV5:        true,
+		minerState:    typeOf((*miner17.State)(nil)),
+		minerInfo:     typeOf((*miner17.MinerInfo)(nil)),
+		sectorInfo:    typeOf((*miner17.SectorOnChainInfo)(nil)),
+		marketState:   typeOf((*market17.State)(nil)),
+		dealProposal:  typeOf((*market17.DealProposal)(nil)),
+		dealState:     typeOf((*market17.DealState)(nil)),
+		powerState:    typeOf((*power17.State)(nil)),
+		powerClaim:    typeOf((*power17.Claim)(nil)),
+		verifregState: typeOf((*verifreg17.State)(nil)),
+		claim:         typeOf((*verifreg17.Claim)(nil)),
+		allocation:    typeOf((*verifreg17.Allocation)(nil)),
+		multisigState: typeOf((*multisig17.State)(nil)),
+	},
+	actors.Version18: {
+		treeV5:        true,
+		minerState:    typeOf((*miner18.State)(nil)),
+		minerInfo:     typeOf((*miner18.MinerInfo)(nil)),
+		sectorInfo:    typeOf((*miner18.SectorOnChainInfo)(nil)),
+		marketState:   typeOf((*market18.State)(nil)),
+		dealProposal:  typeOf((*market18.DealProposal)(nil)),
+		dealState:     typeOf((*market18.DealState)(nil)),
+		powerState:    typeOf((*power18.State)(nil)),
+		powerClaim:    typeOf((*power18.Claim)(nil)),
+		verifregState: typeOf((*verifreg18.State)(nil)),
+		claim:         typeOf((*verifreg18.Claim)(nil)),
+		allocation:    typeOf((*verifreg18.Allocation)(nil)),
+		multisigState: typeOf((*multisig18.State)(nil)),
+	},
+	actors.Version19: {
+		treeV5:        true,
+		minerState:    typeOf((*miner19.State)(nil)),
+		minerInfo:     typeOf((*miner19.MinerInfo)(nil)),
+		sectorInfo:    typeOf((*miner19.SectorOnChainInfo)(nil)),
+		marketState:   typeOf((*market19.State)(nil)),
+		dealProposal:  typeOf((*market19.DealProposal)(nil)),
+		dealState:     typeOf((*market19.DealState)(nil)),
+		powerState:    typeOf((*power19.State)(nil)),
+		powerClaim:    typeOf((*power19.Claim)(nil)),
+		verifregState: typeOf((*verifreg19.State)(nil)),
+		claim:         typeOf((*verifreg19.Claim)(nil)),
+		allocation:    typeOf((*verifreg19.Allocation)(nil)),
+		multisigState: typeOf((*multisig19.State)(nil)),
+	},
+}
@@ -0,0 +1,125 @@
+package export
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// Writer receives the rows of a table.
+type Writer interface {
+	Write(row Row) error
+	// Flush writes any buffered rows to the underlying writer.
+	Flush() error
+}
+
+// CSVWriter writes rows as CSV, after a header of the column names.
+// Absent values are empty, bytes are base64-encoded and lists are joined with semicolons.
+type CSVWriter struct {
+	w       *csv.Writer
+	columns []Column
+	header  bool
+}
+
+var _ Writer = (*CSVWriter)(nil)
+
+func NewCSVWriter(w io.Writer, t Table) *CSVWriter {
+	return &CSVWriter{w: csv.NewWriter(w), columns: Columns(t)}
+}
+
+func (cw *CSVWriter) writeHeader() error {
+	if cw.header {
+		return nil
+	}
+	cw.header = true
+	names := make([]string, len(cw.columns))
+	for i, c := range cw.columns {
+		names[i] = c.Name
+	}
+	return cw.w.Write(names)
+}
+
+func (cw *CSVWriter) Write(row Row) error {
+	if len(row) != len(cw.columns) {
+		return xerrors.Errorf("row of %d values for %d columns", len(row), len(cw.columns))
+	}
+	if err := cw.writeHeader(); err != nil {
+		return err
+	}
+	record := make([]string, len(row))
+	for i, v := range row {
+		switch v := v.(type) {
+		case nil:
+		case int64:
+			record[i] = strconv.FormatInt(v, 10)
+		case string:
+			record[i] = v
+		case bool:
+			record[i] = strconv.FormatBool(v)
+		case []byte:
+			record[i] = base64.StdEncoding.EncodeToString(v)
+		case []string:
+			record[i] = strings.Join(v, ";")
+		default:
+			return xerrors.Errorf("unexpected value %T in column %s", v, cw.columns[i].Name)
+		}
+	}
+	return cw.w.Write(record)
+}
+
+// Flush writes the header if no row has been written, so that an empty table has one, and any
+// buffered rows.
+func (cw *CSVWriter) Flush() error {
+	if err := cw.writeHeader(); err != nil {
+		return err
+	}
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+// JSONLinesWriter writes each row as a JSON object on its own line, with the columns in order.
+// Absent values are null and bytes are base64-encoded strings.
+type JSONLinesWriter struct {
+	w       *bufio.Writer
+	columns []Column
+}
+
+var _ Writer = (*JSONLinesWriter)(nil)
+
+func NewJSONLinesWriter(w io.Writer, t Table) *JSONLinesWriter {
+	return &JSONLinesWriter{w: bufio.NewWriter(w), columns: Columns(t)}
+}
+
+func (jw *JSONLinesWriter) Write(row Row) error {
+	if len(row) != len(jw.columns) {
+		return xerrors.Errorf("row of %d values for %d columns", len(row), len(jw.columns))
+	}
+	line := []byte{'{'}
+	for i, v := range row {
+		if i > 0 {
+			line = append(line, ',')
+		}
+		name, err := json.Marshal(jw.columns[i].Name)
+		if err != nil {
+			return err
+		}
+		value, err := json.Marshal(v)
+		if err != nil {
+			return xerrors.Errorf("failed to encode column %s: %w", jw.columns[i].Name, err)
+		}
+		line = append(append(append(line, name...), ':'), value...)
+	}
+	line = append(line, '}', '\n')
+	_, err := jw.w.Write(line)
+	return err
+}
+
+func (jw *JSONLinesWriter) Flush() error {
+	return jw.w.Flush()
+}
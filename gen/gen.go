@@ -20,6 +20,7 @@ func main() {
 	if err := gen.WriteTupleEncodersToFile("./builtin/cbor_gen.go", "builtin",
 		builtin.ActorV4{},
 		builtin.ActorV5{},
+		builtin.StateRoot{},
 	); err != nil {
 		panic(err)
 	}
@@ -0,0 +1,328 @@
+/*
+Package adl provides go-ipld-prime Advanced Data Layouts for the HAMTs and AMTs of actor state.
+
+A HAMT is viewed as a single map node and an AMT as a single map node keyed by decimal indexes,
+which are sparse. The nodes of the structures are loaded lazily through a LinkSystem, so views can
+be walked with selectors and paths such as Sectors/1234 from a miner state.
+*/
+package adl
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+
+	"github.com/ipfs/go-cid"
+	_ "github.com/ipld/go-ipld-prime/codec/dagcbor" // state is encoded with dag-cbor
+	"github.com/ipld/go-ipld-prime/datamodel"
+	"github.com/ipld/go-ipld-prime/linking"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+	"github.com/ipld/go-ipld-prime/node/basicnode"
+	"github.com/ipld/go-ipld-prime/schema"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/builtin"
+)
+
+// Names under which RegisterReifiers registers the views, for selectors to interpret nodes as them.
+const (
+	HAMTReifierName = "filecoin-hamt"
+	AMTReifierName  = "filecoin-amt"
+)
+
+type config struct {
+	bitWidth int
+	keys     KeyEncoding
+	values   datamodel.NodePrototype
+}
+
+// Option configures a view.
+type Option func(*config)
+
+// BitWidth sets the bit width of a HAMT, builtin.DefaultHamtBitwidth by default.
+// The bit width of an AMT is read from its root.
+func BitWidth(bitWidth int) Option {
+	return func(c *config) {
+		c.bitWidth = bitWidth
+	}
+}
+
+// Keys sets how the keys of a HAMT are presented, RawKeys by default.
+func Keys(keys KeyEncoding) Option {
+	return func(c *config) {
+		c.keys = keys
+	}
+}
+
+// Values sets the prototype of the values, such as the bindnode prototype of an actor type from its
+// package's IpldSchema. By default values are basic nodes of their dag-cbor data.
+func Values(proto datamodel.NodePrototype) Option {
+	return func(c *config) {
+		c.values = proto
+	}
+}
+
+func newConfig(opts []Option) *config {
+	c := &config{bitWidth: builtin.DefaultHamtBitwidth, keys: RawKeys}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// value returns a value of a structure under the configured prototype.
+func (c *config) value(n datamodel.Node) (datamodel.Node, error) {
+	if c.values == nil {
+		return n, nil
+	}
+	proto := c.values
+	if typed, ok := proto.(schema.TypedPrototype); ok {
+		// The data is that of the representation of a typed value.
+		proto = typed.Representation()
+	}
+	nb := proto.NewBuilder()
+	if err := datamodel.Copy(n, nb); err != nil {
+		return nil, fmt.Errorf("failed to build value: %w", err)
+	}
+	return nb.Build(), nil
+}
+
+// KeyEncoding converts between the keys of a HAMT and the strings keying its view.
+type KeyEncoding struct {
+	Encode func(key []byte) (string, error)
+	Decode func(s string) ([]byte, error)
+}
+
+var (
+	// RawKeys presents keys as their bytes.
+	RawKeys = KeyEncoding{
+		Encode: func(key []byte) (string, error) { return string(key), nil },
+		Decode: func(s string) ([]byte, error) { return []byte(s), nil },
+	}
+	// IntKeys presents the keys of abi.IntKey as decimal integers.
+	IntKeys = KeyEncoding{
+		Encode: func(key []byte) (string, error) {
+			i, n := binary.Varint(key)
+			if n != len(key) {
+				return "", fmt.Errorf("invalid varint key %x", key)
+			}
+			return strconv.FormatInt(i, 10), nil
+		},
+		Decode: func(s string) ([]byte, error) {
+			i, err := strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			return binary.AppendVarint(nil, i), nil
+		},
+	}
+	// UintKeys presents the keys of abi.UIntKey as decimal integers.
+	UintKeys = KeyEncoding{
+		Encode: func(key []byte) (string, error) {
+			i, n := binary.Uvarint(key)
+			if n != len(key) {
+				return "", fmt.Errorf("invalid uvarint key %x", key)
+			}
+			return strconv.FormatUint(i, 10), nil
+		},
+		Decode: func(s string) ([]byte, error) {
+			i, err := strconv.ParseUint(s, 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			return binary.AppendUvarint(nil, i), nil
+		},
+	}
+	// AddressKeys presents the keys of abi.AddrKey as addresses, e.g. f01234.
+	AddressKeys = KeyEncoding{
+		Encode: func(key []byte) (string, error) {
+			a, err := address.NewFromBytes(key)
+			if err != nil {
+				return "", err
+			}
+			return a.String(), nil
+		},
+		Decode: func(s string) ([]byte, error) {
+			a, err := address.NewFromString(s)
+			if err != nil {
+				return nil, err
+			}
+			return a.Bytes(), nil
+		},
+	}
+)
+
+// HAMTReifier returns a reifier viewing a HAMT root node as the HAMT.
+func HAMTReifier(opts ...Option) linking.NodeReifier {
+	cfg := newConfig(opts)
+	return func(lnkCtx linking.LinkContext, n datamodel.Node, lsys *linking.LinkSystem) (datamodel.Node, error) {
+		return newHAMT(lnkCtx.Ctx, lsys, cfg, n)
+	}
+}
+
+// AMTReifier returns a reifier viewing an AMT root node as the AMT.
+func AMTReifier(opts ...Option) linking.NodeReifier {
+	cfg := newConfig(opts)
+	return func(lnkCtx linking.LinkContext, n datamodel.Node, lsys *linking.LinkSystem) (datamodel.Node, error) {
+		return newAMT(lnkCtx.Ctx, lsys, cfg, n)
+	}
+}
+
+// RegisterReifiers registers reifiers for HAMTs of the default bit width and raw keys and for AMTs
+// with a LinkSystem, under HAMTReifierName and AMTReifierName.
+func RegisterReifiers(lsys *linking.LinkSystem) {
+	if lsys.KnownReifiers == nil {
+		lsys.KnownReifiers = make(map[string]linking.NodeReifier)
+	}
+	lsys.KnownReifiers[HAMTReifierName] = HAMTReifier()
+	lsys.KnownReifiers[AMTReifierName] = AMTReifier()
+}
+
+// FieldReifier returns a reifier applying the reifier for the field through whose link a node is
+// loaded, such as AMTReifier for the Sectors of a miner state, and leaving other nodes as they are.
+func FieldReifier(byField map[string]linking.NodeReifier) linking.NodeReifier {
+	return func(lnkCtx linking.LinkContext, n datamodel.Node, lsys *linking.LinkSystem) (datamodel.Node, error) {
+		if reify, ok := byField[fieldName(lnkCtx)]; ok {
+			return reify(lnkCtx, n, lsys)
+		}
+		return n, nil
+	}
+}
+
+// fieldName returns the name of the field holding a link being loaded, if known.
+// Walks end the LinkPath with the field but Focus ends it before, so a LinkPath not ending with the
+// field is followed by looking for the link among the fields of the parent node.
+func fieldName(lnkCtx linking.LinkContext) string {
+	var last string
+	if lnkCtx.LinkPath.Len() > 0 {
+		last = lnkCtx.LinkPath.Last().String()
+	}
+	parent := lnkCtx.ParentNode
+	if parent == nil || lnkCtx.LinkNode == nil || parent.Kind() != datamodel.Kind_Map {
+		return last
+	}
+	lnk, err := lnkCtx.LinkNode.AsLink()
+	if err != nil {
+		return last
+	}
+	sameLink := func(n datamodel.Node) bool {
+		other, err := n.AsLink()
+		return err == nil && other.Binary() == lnk.Binary()
+	}
+	if v, err := parent.LookupByString(last); last != "" && err == nil && sameLink(v) {
+		return last
+	}
+	for it := parent.MapIterator(); !it.Done(); {
+		k, v, err := it.Next()
+		if err != nil {
+			break
+		}
+		if sameLink(v) {
+			if name, err := k.AsString(); err == nil {
+				return name
+			}
+		}
+	}
+	return last
+}
+
+// LoadHAMT loads a view of the HAMT with the given root.
+func LoadHAMT(ctx context.Context, lsys *linking.LinkSystem, root cid.Cid, opts ...Option) (datamodel.Node, error) {
+	n, err := fill(ctx, lsys, cidlink.Link{Cid: root})
+	if err != nil {
+		return nil, err
+	}
+	return newHAMT(ctx, lsys, newConfig(opts), n)
+}
+
+// LoadAMT loads a view of the AMT with the given root.
+func LoadAMT(ctx context.Context, lsys *linking.LinkSystem, root cid.Cid, opts ...Option) (datamodel.Node, error) {
+	n, err := fill(ctx, lsys, cidlink.Link{Cid: root})
+	if err != nil {
+		return nil, err
+	}
+	return newAMT(ctx, lsys, newConfig(opts), n)
+}
+
+// fill loads a node of a structure. Unlike Load, it does not apply the LinkSystem's reifier, which
+// is for the structure's root.
+func fill(ctx context.Context, lsys *linking.LinkSystem, lnk datamodel.Link) (datamodel.Node, error) {
+	nb := basicnode.Prototype.Any.NewBuilder()
+	if err := lsys.Fill(linking.LinkContext{Ctx: ctx}, lnk, nb); err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", lnk, err)
+	}
+	return nb.Build(), nil
+}
+
+// tuple returns the fields of a node encoded as a tuple of the given length.
+func tuple(n datamodel.Node, length int) ([]datamodel.Node, error) {
+	if n.Kind() != datamodel.Kind_List || n.Length() != int64(length) {
+		return nil, fmt.Errorf("expected a tuple of %d fields, got %s", length, n.Kind())
+	}
+	fields := make([]datamodel.Node, length)
+	for i := range fields {
+		var err error
+		if fields[i], err = n.LookupByIndex(int64(i)); err != nil {
+			return nil, err
+		}
+	}
+	return fields, nil
+}
+
+// mapView implements the parts of datamodel.Node common to the views, which are maps.
+type mapView struct {
+	name string
+}
+
+func (v mapView) Kind() datamodel.Kind {
+	return datamodel.Kind_Map
+}
+
+func (v mapView) wrongKind(method string, kinds datamodel.KindSet) error {
+	return datamodel.ErrWrongKind{TypeName: v.name, MethodName: method, AppropriateKind: kinds, ActualKind: datamodel.Kind_Map}
+}
+
+func (v mapView) LookupByIndex(int64) (datamodel.Node, error) {
+	return nil, v.wrongKind("LookupByIndex", datamodel.KindSet_JustList)
+}
+
+func (v mapView) ListIterator() datamodel.ListIterator {
+	return nil
+}
+
+func (v mapView) IsAbsent() bool {
+	return false
+}
+
+func (v mapView) IsNull() bool {
+	return false
+}
+
+func (v mapView) AsBool() (bool, error) {
+	return false, v.wrongKind("AsBool", datamodel.KindSet_JustBool)
+}
+
+func (v mapView) AsInt() (int64, error) {
+	return 0, v.wrongKind("AsInt", datamodel.KindSet_JustInt)
+}
+
+func (v mapView) AsFloat() (float64, error) {
+	return 0, v.wrongKind("AsFloat", datamodel.KindSet_JustFloat)
+}
+
+func (v mapView) AsString() (string, error) {
+	return "", v.wrongKind("AsString", datamodel.KindSet_JustString)
+}
+
+func (v mapView) AsBytes() ([]byte, error) {
+	return nil, v.wrongKind("AsBytes", datamodel.KindSet_JustBytes)
+}
+
+func (v mapView) AsLink() (datamodel.Link, error) {
+	return nil, v.wrongKind("AsLink", datamodel.KindSet_JustLink)
+}
+
+func (v mapView) Prototype() datamodel.NodePrototype {
+	return basicnode.Prototype.Map
+}
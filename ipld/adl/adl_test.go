@@ -0,0 +1,210 @@
+package adl_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strconv"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	"github.com/ipld/go-ipld-prime/datamodel"
+	"github.com/ipld/go-ipld-prime/linking"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+	"github.com/ipld/go-ipld-prime/node/basicnode"
+	"github.com/ipld/go-ipld-prime/traversal"
+	"github.com/ipld/go-ipld-prime/traversal/selector/builder"
+	mh "github.com/multiformats/go-multihash"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin"
+	"github.com/filecoin-project/go-state-types/builtin/v19/miner"
+	"github.com/filecoin-project/go-state-types/builtin/v19/util/adt"
+	"github.com/filecoin-project/go-state-types/ipld/adl"
+	"github.com/filecoin-project/go-state-types/test_util"
+)
+
+func newStores() (adt.Store, *linking.LinkSystem) {
+	bs := test_util.NewBlockStoreInMemory()
+	lsys := cidlink.DefaultLinkSystem()
+	lsys.StorageReadOpener = func(lnkCtx linking.LinkContext, lnk datamodel.Link) (io.Reader, error) {
+		b, err := bs.Get(lnkCtx.Ctx, lnk.(cidlink.Link).Cid)
+		if err != nil {
+			return nil, err
+		}
+		return bytes.NewReader(b.RawData()), nil
+	}
+	return adt.WrapStore(context.Background(), cbor.NewCborStore(bs)), &lsys
+}
+
+func TestHAMT(t *testing.T) {
+	ctx := context.Background()
+	store, lsys := newStores()
+
+	m, err := adt.MakeEmptyMap(store, builtin.DefaultHamtBitwidth)
+	require.NoError(t, err)
+	const entries = 500
+	for i := int64(-entries / 2); i < entries/2; i++ {
+		val := abi.CborString(strconv.FormatInt(i, 10))
+		require.NoError(t, m.Put(abi.IntKey(i), &val))
+	}
+	root, err := m.Root()
+	require.NoError(t, err)
+
+	node, err := adl.LoadHAMT(ctx, lsys, root, adl.Keys(adl.IntKeys))
+	require.NoError(t, err)
+	require.Equal(t, datamodel.Kind_Map, node.Kind())
+	require.EqualValues(t, entries, node.Length())
+
+	v, err := node.LookupByString("-17")
+	require.NoError(t, err)
+	s, err := v.AsString()
+	require.NoError(t, err)
+	require.Equal(t, "-17", s)
+	_, err = node.LookupByString("1000")
+	require.ErrorAs(t, err, &datamodel.ErrNotExists{})
+
+	// Iteration follows the HAMT's own order.
+	var expected []string
+	var val abi.CborString
+	require.NoError(t, m.ForEach(&val, func(string) error {
+		expected = append(expected, string(val))
+		return nil
+	}))
+	var keys []string
+	for it := node.MapIterator(); !it.Done(); {
+		k, v, err := it.Next()
+		require.NoError(t, err)
+		key, err := k.AsString()
+		require.NoError(t, err)
+		s, err := v.AsString()
+		require.NoError(t, err)
+		require.Equal(t, key, s)
+		keys = append(keys, key)
+	}
+	require.Equal(t, expected, keys)
+}
+
+func TestAMT(t *testing.T) {
+	ctx := context.Background()
+	store, lsys := newStores()
+
+	a, err := adt.MakeEmptyArray(store, miner.SectorsAmtBitwidth)
+	require.NoError(t, err)
+	indexes := []uint64{0, 1, 31, 1234, 100000}
+	for _, i := range indexes {
+		val := abi.CborString(strconv.FormatUint(i, 10))
+		require.NoError(t, a.Set(i, &val))
+	}
+	root, err := a.Root()
+	require.NoError(t, err)
+
+	node, err := adl.LoadAMT(ctx, lsys, root)
+	require.NoError(t, err)
+	require.EqualValues(t, len(indexes), node.Length())
+
+	var keys []string
+	for it := node.MapIterator(); !it.Done(); {
+		k, v, err := it.Next()
+		require.NoError(t, err)
+		key, err := k.AsString()
+		require.NoError(t, err)
+		s, err := v.AsString()
+		require.NoError(t, err)
+		require.Equal(t, key, s)
+		keys = append(keys, key)
+	}
+	require.Equal(t, []string{"0", "1", "31", "1234", "100000"}, keys)
+
+	for _, missing := range []string{"2", "1235", "99999999999"} {
+		_, err = node.LookupByString(missing)
+		require.ErrorAs(t, err, &datamodel.ErrNotExists{})
+	}
+
+	// Selectors interpret the root as the AMT through the registered reifiers.
+	adl.RegisterReifiers(lsys)
+	substrate, err := lsys.Load(linking.LinkContext{Ctx: ctx}, cidlink.Link{Cid: root}, basicnode.Prototype.Any)
+	require.NoError(t, err)
+	ssb := builder.NewSelectorSpecBuilder(basicnode.Prototype.Any)
+	sel, err := ssb.ExploreInterpretAs(adl.AMTReifierName, ssb.ExploreAll(ssb.Matcher())).Selector()
+	require.NoError(t, err)
+	var matched []string
+	progress := traversal.Progress{Cfg: &traversal.Config{Ctx: ctx, LinkSystem: *lsys}}
+	require.NoError(t, progress.WalkMatching(substrate, sel, func(p traversal.Progress, n datamodel.Node) error {
+		matched = append(matched, p.Path.String())
+		return nil
+	}))
+	require.Equal(t, keys, matched)
+}
+
+func TestTraverseMinerSectors(t *testing.T) {
+	ctx := context.Background()
+	store, lsys := newStores()
+
+	h, err := mh.Sum([]byte("placeholder"), mh.SHA2_256, -1)
+	require.NoError(t, err)
+	placeholder := cid.NewCidV1(cid.DagCBOR, h)
+
+	sectors, err := adt.MakeEmptyArray(store, miner.SectorsAmtBitwidth)
+	require.NoError(t, err)
+	for _, n := range []abi.SectorNumber{7, 1234} {
+		require.NoError(t, sectors.Set(uint64(n), &miner.SectorOnChainInfo{
+			SectorNumber:       n,
+			SealedCID:          placeholder,
+			DealWeight:         big.Zero(),
+			VerifiedDealWeight: big.Zero(),
+			InitialPledge:      big.NewInt(int64(n)),
+			DailyFee:           big.Zero(),
+		}))
+	}
+	sectorsRoot, err := sectors.Root()
+	require.NoError(t, err)
+	stRoot, err := store.Put(ctx, &miner.State{
+		Info:                       placeholder,
+		PreCommitDeposits:          big.Zero(),
+		LockedFunds:                big.Zero(),
+		FeeDebt:                    big.Zero(),
+		InitialPledge:              big.Zero(),
+		PreCommittedSectors:        placeholder,
+		PreCommittedSectorsCleanUp: placeholder,
+		AllocatedSectors:           placeholder,
+		Sectors:                    sectorsRoot,
+		Deadlines:                  placeholder,
+	})
+	require.NoError(t, err)
+
+	stProto, err := miner.IpldSchema.Prototype((*miner.State)(nil))
+	require.NoError(t, err)
+	sectorProto, err := miner.IpldSchema.Prototype((*miner.SectorOnChainInfo)(nil))
+	require.NoError(t, err)
+	lsys.NodeReifier = adl.FieldReifier(map[string]linking.NodeReifier{
+		"Sectors": adl.AMTReifier(adl.Values(sectorProto)),
+	})
+
+	st, err := lsys.Load(linking.LinkContext{Ctx: ctx}, cidlink.Link{Cid: stRoot}, stProto.Representation())
+	require.NoError(t, err)
+	progress := traversal.Progress{Cfg: &traversal.Config{
+		Ctx:        ctx,
+		LinkSystem: *lsys,
+		LinkTargetNodePrototypeChooser: func(datamodel.Link, linking.LinkContext) (datamodel.NodePrototype, error) {
+			return basicnode.Prototype.Any, nil
+		},
+	}}
+	var found bool
+	require.NoError(t, progress.Focus(st, datamodel.ParsePath("Sectors/1234/InitialPledge"), func(_ traversal.Progress, n datamodel.Node) error {
+		b, err := n.AsBytes()
+		require.NoError(t, err)
+		pledge, err := big.FromBytes(b)
+		require.NoError(t, err)
+		require.EqualValues(t, 1234, pledge.Int64())
+		found = true
+		return nil
+	}))
+	require.True(t, found)
+
+	_, err = adl.LoadAMT(ctx, lsys, cid.Undef)
+	require.Error(t, err)
+}
@@ -0,0 +1,265 @@
+package adl
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/ipld/go-ipld-prime/datamodel"
+	"github.com/ipld/go-ipld-prime/linking"
+	"github.com/ipld/go-ipld-prime/node/basicnode"
+)
+
+// amtNode is a node of an AMT: a bitmap of the occupied slots and, for the occupied slots, either
+// links to child nodes or, at height 0, values.
+type amtNode struct {
+	bmap   []byte
+	links  datamodel.Node
+	values datamodel.Node
+}
+
+func decodeAMTNode(n datamodel.Node) (*amtNode, error) {
+	fields, err := tuple(n, 3)
+	if err != nil {
+		return nil, fmt.Errorf("invalid AMT node: %w", err)
+	}
+	bmap, err := fields[0].AsBytes()
+	if err != nil {
+		return nil, fmt.Errorf("invalid AMT bitmap: %w", err)
+	}
+	if fields[1].Kind() != datamodel.Kind_List || fields[2].Kind() != datamodel.Kind_List {
+		return nil, fmt.Errorf("invalid AMT node: expected lists of links and values")
+	}
+	return &amtNode{bmap: bmap, links: fields[1], values: fields[2]}, nil
+}
+
+func (n *amtNode) isSet(slot uint64) bool {
+	return slot/8 < uint64(len(n.bmap)) && n.bmap[slot/8]&(1<<(slot%8)) != 0
+}
+
+// position returns the position among the links or values of an occupied slot.
+func (n *amtNode) position(slot uint64) int64 {
+	var pos int64
+	for i := uint64(0); i < slot; i++ {
+		if n.isSet(i) {
+			pos++
+		}
+	}
+	return pos
+}
+
+// AMT is a view of an AMT as a map from its indexes, in decimal, to its values.
+type AMT struct {
+	mapView
+	ctx      context.Context
+	lsys     *linking.LinkSystem
+	cfg      *config
+	root     datamodel.Node
+	bitWidth uint64
+	height   uint64
+	count    int64
+	node     *amtNode
+}
+
+var _ datamodel.Node = (*AMT)(nil)
+
+func newAMT(ctx context.Context, lsys *linking.LinkSystem, cfg *config, root datamodel.Node) (*AMT, error) {
+	fields, err := tuple(root, 4)
+	if err != nil {
+		return nil, fmt.Errorf("invalid AMT root: %w", err)
+	}
+	var header [3]int64
+	for i := range header {
+		if header[i], err = fields[i].AsInt(); err != nil || header[i] < 0 {
+			return nil, fmt.Errorf("invalid AMT root: field %d is not a non-negative integer", i)
+		}
+	}
+	if header[0] < 1 || header[0] > 18 {
+		return nil, fmt.Errorf("invalid AMT bit width %d", header[0])
+	}
+	node, err := decodeAMTNode(fields[3])
+	if err != nil {
+		return nil, err
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return &AMT{
+		mapView:  mapView{name: "AMT"},
+		ctx:      ctx,
+		lsys:     lsys,
+		cfg:      cfg,
+		root:     root,
+		bitWidth: uint64(header[0]),
+		height:   uint64(header[1]),
+		count:    header[2],
+		node:     node,
+	}, nil
+}
+
+// Substrate returns the root node of the AMT.
+func (a *AMT) Substrate() datamodel.Node {
+	return a.root
+}
+
+// nodesForHeight returns the number of indexes under each slot of a node at a height.
+func (a *AMT) nodesForHeight(height uint64) uint64 {
+	if a.bitWidth*height >= 64 {
+		return math.MaxUint64
+	}
+	return 1 << (a.bitWidth * height)
+}
+
+// Find returns the value at an index, or nil if the AMT has none.
+func (a *AMT) Find(i uint64) (datamodel.Node, error) {
+	if capacity := a.nodesForHeight(a.height + 1); capacity != math.MaxUint64 && i >= capacity {
+		return nil, nil
+	}
+	node := a.node
+	for height := a.height; height > 0; height-- {
+		nfh := a.nodesForHeight(height)
+		slot := i / nfh
+		if !node.isSet(slot) {
+			return nil, nil
+		}
+		child, err := a.child(node, slot)
+		if err != nil {
+			return nil, err
+		}
+		node, i = child, i%nfh
+	}
+	if !node.isSet(i) {
+		return nil, nil
+	}
+	v, err := node.values.LookupByIndex(node.position(i))
+	if err != nil {
+		return nil, fmt.Errorf("invalid AMT node: %w", err)
+	}
+	return a.cfg.value(v)
+}
+
+func (a *AMT) child(node *amtNode, slot uint64) (*amtNode, error) {
+	pointer, err := node.links.LookupByIndex(node.position(slot))
+	if err != nil {
+		return nil, fmt.Errorf("invalid AMT node: %w", err)
+	}
+	lnk, err := pointer.AsLink()
+	if err != nil {
+		return nil, fmt.Errorf("invalid AMT link: %w", err)
+	}
+	n, err := fill(a.ctx, a.lsys, lnk)
+	if err != nil {
+		return nil, err
+	}
+	return decodeAMTNode(n)
+}
+
+func (a *AMT) LookupByString(s string) (datamodel.Node, error) {
+	i, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid AMT index %q: %w", s, err)
+	}
+	v, err := a.Find(i)
+	if err != nil {
+		return nil, err
+	}
+	if v == nil {
+		return nil, datamodel.ErrNotExists{Segment: datamodel.PathSegmentOfString(s)}
+	}
+	return v, nil
+}
+
+func (a *AMT) LookupByNode(key datamodel.Node) (datamodel.Node, error) {
+	s, err := key.AsString()
+	if err != nil {
+		return nil, err
+	}
+	return a.LookupByString(s)
+}
+
+func (a *AMT) LookupBySegment(seg datamodel.PathSegment) (datamodel.Node, error) {
+	return a.LookupByString(seg.String())
+}
+
+// MapIterator iterates over the entries in ascending order of index, loading nodes as it reaches
+// them.
+func (a *AMT) MapIterator() datamodel.MapIterator {
+	return &amtIterator{a: a, stack: []amtFrame{{node: a.node, height: a.height}}}
+}
+
+// Length returns the number of entries recorded in the root of the AMT.
+func (a *AMT) Length() int64 {
+	return a.count
+}
+
+type amtFrame struct {
+	node   *amtNode
+	height uint64
+	offset uint64 // the first index under the node
+	slot   uint64 // the next slot to visit
+}
+
+// amtIterator walks an AMT depth first. It keeps the next entry ready, so Done is accurate.
+type amtIterator struct {
+	a     *AMT
+	stack []amtFrame
+	index uint64
+	next  datamodel.Node
+	err   error
+}
+
+// advance readies the next entry, if any.
+func (it *amtIterator) advance() {
+	width := uint64(1) << it.a.bitWidth
+	for it.next == nil && it.err == nil && len(it.stack) > 0 {
+		top := &it.stack[len(it.stack)-1]
+		for top.slot < width && !top.node.isSet(top.slot) {
+			top.slot++
+		}
+		if top.slot >= width {
+			it.stack = it.stack[:len(it.stack)-1]
+			continue
+		}
+		slot := top.slot
+		top.slot++
+		if top.height == 0 {
+			v, err := top.node.values.LookupByIndex(top.node.position(slot))
+			if err != nil {
+				it.err = fmt.Errorf("invalid AMT node: %w", err)
+				return
+			}
+			it.index, it.next = top.offset+slot, v
+			return
+		}
+		child, err := it.a.child(top.node, slot)
+		if err != nil {
+			it.err = err
+			return
+		}
+		nfh := it.a.nodesForHeight(top.height)
+		it.stack = append(it.stack, amtFrame{node: child, height: top.height - 1, offset: top.offset + slot*nfh})
+	}
+}
+
+func (it *amtIterator) Next() (datamodel.Node, datamodel.Node, error) {
+	it.advance()
+	if it.err != nil {
+		return nil, nil, it.err
+	}
+	if it.next == nil {
+		return nil, nil, datamodel.ErrIteratorOverread{}
+	}
+	v := it.next
+	it.next = nil
+	value, err := it.a.cfg.value(v)
+	if err != nil {
+		return nil, nil, err
+	}
+	return basicnode.NewString(strconv.FormatUint(it.index, 10)), value, nil
+}
+
+func (it *amtIterator) Done() bool {
+	it.advance()
+	return it.next == nil && it.err == nil
+}
@@ -0,0 +1,286 @@
+package adl
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+
+	"github.com/ipld/go-ipld-prime/datamodel"
+	"github.com/ipld/go-ipld-prime/linking"
+	"github.com/ipld/go-ipld-prime/node/basicnode"
+)
+
+// hamtNode is a node of a HAMT: a bitfield of the occupied slots and a pointer for each, either a
+// link to a child node or a bucket of [key, value] pairs.
+type hamtNode struct {
+	bitfield *big.Int
+	pointers datamodel.Node
+}
+
+func decodeHAMTNode(n datamodel.Node) (*hamtNode, error) {
+	fields, err := tuple(n, 2)
+	if err != nil {
+		return nil, fmt.Errorf("invalid HAMT node: %w", err)
+	}
+	bf, err := fields[0].AsBytes()
+	if err != nil {
+		return nil, fmt.Errorf("invalid HAMT bitfield: %w", err)
+	}
+	if fields[1].Kind() != datamodel.Kind_List {
+		return nil, fmt.Errorf("invalid HAMT pointers: expected a list, got %s", fields[1].Kind())
+	}
+	return &hamtNode{bitfield: new(big.Int).SetBytes(bf), pointers: fields[1]}, nil
+}
+
+// HAMT is a view of a HAMT as a map from its keys, as presented by the configured KeyEncoding, to its
+// values.
+type HAMT struct {
+	mapView
+	ctx    context.Context
+	lsys   *linking.LinkSystem
+	cfg    *config
+	root   datamodel.Node
+	node   *hamtNode
+	length int64 // -1 until counted
+}
+
+var _ datamodel.Node = (*HAMT)(nil)
+
+func newHAMT(ctx context.Context, lsys *linking.LinkSystem, cfg *config, root datamodel.Node) (*HAMT, error) {
+	if cfg.bitWidth < 1 || cfg.bitWidth > 8 {
+		return nil, fmt.Errorf("invalid HAMT bit width %d", cfg.bitWidth)
+	}
+	node, err := decodeHAMTNode(root)
+	if err != nil {
+		return nil, err
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return &HAMT{mapView: mapView{name: "HAMT"}, ctx: ctx, lsys: lsys, cfg: cfg, root: root, node: node, length: -1}, nil
+}
+
+// Substrate returns the root node of the HAMT.
+func (h *HAMT) Substrate() datamodel.Node {
+	return h.root
+}
+
+// Find returns the value under a raw key, or nil if the HAMT has none.
+func (h *HAMT) Find(key []byte) (datamodel.Node, error) {
+	digest := sha256.Sum256(key)
+	node := h.node
+	for depth := 0; ; depth++ {
+		if (depth+1)*h.cfg.bitWidth > len(digest)*8 {
+			return nil, fmt.Errorf("HAMT is deeper than its hash")
+		}
+		idx := hashBits(digest[:], depth*h.cfg.bitWidth, h.cfg.bitWidth)
+		if node.bitfield.Bit(idx) == 0 {
+			return nil, nil
+		}
+		pointer, err := node.pointers.LookupByIndex(int64(popCountBelow(node.bitfield, idx)))
+		if err != nil {
+			return nil, fmt.Errorf("invalid HAMT node: %w", err)
+		}
+		if pointer.Kind() == datamodel.Kind_Link {
+			if node, err = h.child(pointer); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		var found datamodel.Node
+		err = forEachKV(pointer, func(k []byte, v datamodel.Node) error {
+			if string(k) == string(key) {
+				found = v
+			}
+			return nil
+		})
+		if err != nil || found == nil {
+			return nil, err
+		}
+		return h.cfg.value(found)
+	}
+}
+
+func (h *HAMT) child(pointer datamodel.Node) (*hamtNode, error) {
+	lnk, err := pointer.AsLink()
+	if err != nil {
+		return nil, err
+	}
+	n, err := fill(h.ctx, h.lsys, lnk)
+	if err != nil {
+		return nil, err
+	}
+	return decodeHAMTNode(n)
+}
+
+func (h *HAMT) LookupByString(s string) (datamodel.Node, error) {
+	key, err := h.cfg.keys.Decode(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid HAMT key %q: %w", s, err)
+	}
+	v, err := h.Find(key)
+	if err != nil {
+		return nil, err
+	}
+	if v == nil {
+		return nil, datamodel.ErrNotExists{Segment: datamodel.PathSegmentOfString(s)}
+	}
+	return v, nil
+}
+
+func (h *HAMT) LookupByNode(key datamodel.Node) (datamodel.Node, error) {
+	s, err := key.AsString()
+	if err != nil {
+		return nil, err
+	}
+	return h.LookupByString(s)
+}
+
+func (h *HAMT) LookupBySegment(seg datamodel.PathSegment) (datamodel.Node, error) {
+	return h.LookupByString(seg.String())
+}
+
+// MapIterator iterates over the entries in the order of the HAMT, loading nodes as it reaches them.
+func (h *HAMT) MapIterator() datamodel.MapIterator {
+	return &hamtIterator{h: h, stack: []hamtFrame{{pointers: h.node.pointers}}}
+}
+
+// Length counts the entries of the HAMT, which loads all its nodes, or returns -1 if it cannot.
+func (h *HAMT) Length() int64 {
+	if h.length >= 0 {
+		return h.length
+	}
+	var count int64
+	for it := h.MapIterator(); !it.Done(); count++ {
+		if _, _, err := it.Next(); err != nil {
+			return -1
+		}
+	}
+	h.length = count
+	return count
+}
+
+type hamtFrame struct {
+	pointers datamodel.Node
+	pointer  int64
+	bucket   []hamtKV // entries of the current bucket not yet returned
+}
+
+type hamtKV struct {
+	key   []byte
+	value datamodel.Node
+}
+
+// hamtIterator walks a HAMT depth first. It keeps the next entry ready, so Done is accurate.
+type hamtIterator struct {
+	h     *HAMT
+	stack []hamtFrame
+	next  *hamtKV
+	err   error
+}
+
+// advance readies the next entry, if any.
+func (it *hamtIterator) advance() {
+	for it.next == nil && it.err == nil && len(it.stack) > 0 {
+		top := &it.stack[len(it.stack)-1]
+		if len(top.bucket) > 0 {
+			it.next = &top.bucket[0]
+			top.bucket = top.bucket[1:]
+			return
+		}
+		if top.pointer >= top.pointers.Length() {
+			it.stack = it.stack[:len(it.stack)-1]
+			continue
+		}
+		pointer, err := top.pointers.LookupByIndex(top.pointer)
+		top.pointer++
+		if err != nil {
+			it.err = err
+			return
+		}
+		if pointer.Kind() == datamodel.Kind_Link {
+			child, err := it.h.child(pointer)
+			if err != nil {
+				it.err = err
+				return
+			}
+			it.stack = append(it.stack, hamtFrame{pointers: child.pointers})
+			continue
+		}
+		it.err = forEachKV(pointer, func(k []byte, v datamodel.Node) error {
+			top.bucket = append(top.bucket, hamtKV{key: k, value: v})
+			return nil
+		})
+	}
+}
+
+func (it *hamtIterator) Next() (datamodel.Node, datamodel.Node, error) {
+	it.advance()
+	if it.err != nil {
+		return nil, nil, it.err
+	}
+	if it.next == nil {
+		return nil, nil, datamodel.ErrIteratorOverread{}
+	}
+	kv := it.next
+	it.next = nil
+	key, err := it.h.cfg.keys.Encode(kv.key)
+	if err != nil {
+		return nil, nil, err
+	}
+	value, err := it.h.cfg.value(kv.value)
+	if err != nil {
+		return nil, nil, err
+	}
+	return basicnode.NewString(key), value, nil
+}
+
+func (it *hamtIterator) Done() bool {
+	it.advance()
+	return it.next == nil && it.err == nil
+}
+
+// forEachKV calls f with the entries of a bucket.
+func forEachKV(bucket datamodel.Node, f func(key []byte, value datamodel.Node) error) error {
+	if bucket.Kind() != datamodel.Kind_List {
+		return fmt.Errorf("invalid HAMT pointer: expected a link or a list, got %s", bucket.Kind())
+	}
+	for it := bucket.ListIterator(); !it.Done(); {
+		_, kv, err := it.Next()
+		if err != nil {
+			return err
+		}
+		fields, err := tuple(kv, 2)
+		if err != nil {
+			return fmt.Errorf("invalid HAMT entry: %w", err)
+		}
+		key, err := fields[0].AsBytes()
+		if err != nil {
+			return fmt.Errorf("invalid HAMT key: %w", err)
+		}
+		if err := f(key, fields[1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// hashBits returns the width bits of a digest starting at bit offset, most significant first.
+func hashBits(digest []byte, offset, width int) int {
+	out := 0
+	for i := offset; i < offset+width; i++ {
+		out = out<<1 | int(digest[i/8]>>(7-i%8)&1)
+	}
+	return out
+}
+
+// popCountBelow returns the number of set bits in a bitfield below idx, the position of the pointer
+// for slot idx.
+func popCountBelow(bitfield *big.Int, idx int) int {
+	count := 0
+	for i := 0; i < idx; i++ {
+		count += int(bitfield.Bit(i))
+	}
+	return count
+}
@@ -7,11 +7,19 @@ interface using the bindnode package.
 package ipld
 
 import (
+	"bytes"
 	"fmt"
+	"reflect"
 
+	"github.com/ipfs/go-cid"
+	"github.com/ipld/go-ipld-prime/datamodel"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+	"github.com/ipld/go-ipld-prime/node/basicnode"
 	"github.com/ipld/go-ipld-prime/node/bindnode"
+	cbg "github.com/whyrusleeping/cbor-gen"
 
 	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-bitfield"
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
 	"github.com/filecoin-project/go-state-types/crypto"
@@ -35,6 +43,102 @@ var AddressBindnodeOption = bindnode.TypedBytesConverter(&address.Address{}, add
 // Bytes field in a schema
 var SignatureBindnodeOption = bindnode.TypedBytesConverter(&crypto.Signature{}, signatureFromBytes, signatureToBytes)
 
+// BitFieldBindnodeOption converts a BitField type to and from a Bytes field
+// holding its RLE+ encoding in a schema
+var BitFieldBindnodeOption = bindnode.TypedBytesConverter(&bitfield.BitField{}, bitFieldFromBytes, bitFieldToBytes)
+
+// CborCidBindnodeOption converts a cbor-gen CborCid type to and from a Link
+// field in a schema
+var CborCidBindnodeOption = bindnode.TypedLinkConverter(new(cbg.CborCid), cborCidFromLink, cborCidToLink)
+
+// ByteArrayBindnodeOption converts a byte array type, given by a pointer to
+// it, to and from a Bytes field of the same length in a schema
+func ByteArrayBindnodeOption(ptrVal interface{}) bindnode.Option {
+	typ := reflect.TypeOf(ptrVal).Elem()
+	from := func(b []byte) (interface{}, error) {
+		if len(b) != typ.Len() {
+			return nil, fmt.Errorf("expected %d bytes, got %d", typ.Len(), len(b))
+		}
+		arr := reflect.New(typ)
+		reflect.Copy(arr.Elem(), reflect.ValueOf(b))
+		return arr.Interface(), nil
+	}
+	to := func(iface interface{}) ([]byte, error) {
+		arr := reflect.ValueOf(iface)
+		if arr.Type() != reflect.PointerTo(typ) {
+			return nil, fmt.Errorf("expected *%s value", typ)
+		}
+		b := make([]byte, typ.Len())
+		reflect.Copy(reflect.ValueOf(b), arr.Elem())
+		return b, nil
+	}
+	return bindnode.TypedBytesConverter(ptrVal, from, to)
+}
+
+// CidArrayBindnodeOption converts a CID array type, given by a pointer to it,
+// to and from an Any field holding a list of links of the same length in a
+// schema
+func CidArrayBindnodeOption(ptrVal interface{}) bindnode.Option {
+	typ := reflect.TypeOf(ptrVal).Elem()
+	from := func(n datamodel.Node) (interface{}, error) {
+		if n.Kind() != datamodel.Kind_List || n.Length() != int64(typ.Len()) {
+			return nil, fmt.Errorf("expected a list of %d links", typ.Len())
+		}
+		arr := reflect.New(typ)
+		for it := n.ListIterator(); !it.Done(); {
+			i, v, err := it.Next()
+			if err != nil {
+				return nil, err
+			}
+			l, err := v.AsLink()
+			if err != nil {
+				return nil, err
+			}
+			cl, ok := l.(cidlink.Link)
+			if !ok {
+				return nil, fmt.Errorf("expected a CID link, got %T", l)
+			}
+			arr.Elem().Index(int(i)).Set(reflect.ValueOf(cl.Cid))
+		}
+		return arr.Interface(), nil
+	}
+	to := func(iface interface{}) (datamodel.Node, error) {
+		arr := reflect.ValueOf(iface)
+		if arr.Type() != reflect.PointerTo(typ) {
+			return nil, fmt.Errorf("expected *%s value", typ)
+		}
+		nb := basicnode.Prototype.List.NewBuilder()
+		la, err := nb.BeginList(int64(typ.Len()))
+		if err != nil {
+			return nil, err
+		}
+		for i := 0; i < typ.Len(); i++ {
+			c := arr.Elem().Index(i).Interface().(cid.Cid)
+			if err := la.AssembleValue().AssignLink(cidlink.Link{Cid: c}); err != nil {
+				return nil, err
+			}
+		}
+		if err := la.Finish(); err != nil {
+			return nil, err
+		}
+		return nb.Build(), nil
+	}
+	return bindnode.TypedAnyConverter(ptrVal, from, to)
+}
+
+func cborCidFromLink(c cid.Cid) (interface{}, error) {
+	cc := cbg.CborCid(c)
+	return &cc, nil
+}
+
+func cborCidToLink(iface interface{}) (cid.Cid, error) {
+	cc, ok := iface.(*cbg.CborCid)
+	if !ok {
+		return cid.Undef, fmt.Errorf("expected *CborCid value")
+	}
+	return cid.Cid(*cc), nil
+}
+
 func tokenAmountFromBytes(b []byte) (interface{}, error) {
 	return bigIntFromBytes(b)
 }
@@ -102,3 +206,24 @@ func signatureToBytes(iface interface{}) ([]byte, error) {
 	ba := append([]byte{byte(s.Type)}, s.Data...)
 	return ba, nil
 }
+
+func bitFieldFromBytes(b []byte) (interface{}, error) {
+	bf, err := bitfield.NewFromBytes(b)
+	if err != nil {
+		return nil, err
+	}
+	return &bf, nil
+}
+
+func bitFieldToBytes(iface interface{}) ([]byte, error) {
+	bf, ok := iface.(*bitfield.BitField)
+	if !ok {
+		return nil, fmt.Errorf("expected *BitField value")
+	}
+	// Encode as cbor-gen does, which keeps the bytes of an unmodified bitfield
+	var buf bytes.Buffer
+	if err := bf.MarshalCBOR(&buf); err != nil {
+		return nil, err
+	}
+	return cbg.ReadByteArray(&buf, bitfield.MaxEncodedSize)
+}
@@ -0,0 +1,198 @@
+// Package ipldtest checks that types encode identically with cbor-gen and with bindnode under the
+// schemas generated by ipld/schemagen.
+package ipldtest
+
+import (
+	"bytes"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	prime "github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/codec/dagcbor"
+	"github.com/ipld/go-ipld-prime/node/bindnode"
+	mh "github.com/multiformats/go-multihash"
+	"github.com/stretchr/testify/require"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-bitfield"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/crypto"
+	"github.com/filecoin-project/go-state-types/ipld"
+)
+
+// Values of each type checked.
+const samples = 8
+
+// RoundTrip checks, for random values of each type pointed to by ptrTypes, that bindnode decodes
+// the cbor-gen encoding of a value to a value with the same encoding, and encodes it back to the
+// same bytes.
+func RoundTrip(t *testing.T, s *ipld.Schema, ptrTypes ...interface{}) {
+	for _, ptrType := range ptrTypes {
+		typ := reflect.TypeOf(ptrType).Elem()
+		t.Run(typ.Name(), func(t *testing.T) {
+			proto, err := s.Prototype(ptrType)
+			require.NoError(t, err)
+			rnd := rand.New(rand.NewSource(int64(len(typ.Name()))))
+			for i := 0; i < samples; i++ {
+				val := reflect.New(typ)
+				fill(rnd, val.Elem(), 0)
+
+				var encoded bytes.Buffer
+				require.NoError(t, val.Interface().(cbg.CBORMarshaler).MarshalCBOR(&encoded))
+				node, err := prime.DecodeUsingPrototype(encoded.Bytes(), dagcbor.Decode, proto)
+				require.NoError(t, err, "bindnode should decode %x", encoded.Bytes())
+
+				// Compare encodings, as cbor-gen and bindnode may decode empty lists differently.
+				// The schema binds the field of a transparent type, which is what cbor-gen encodes.
+				decoded := reflect.New(typ)
+				if typ.Kind() == reflect.Struct && typ.NumField() == 1 && typ.Field(0).Tag.Get("cborgen") == "transparent" {
+					decoded.Elem().Field(0).Set(reflect.ValueOf(bindnode.Unwrap(node)).Elem())
+				} else {
+					decoded = reflect.ValueOf(bindnode.Unwrap(node))
+				}
+				var decodedEncoded bytes.Buffer
+				require.NoError(t, decoded.Interface().(cbg.CBORMarshaler).MarshalCBOR(&decodedEncoded))
+				require.Equal(t, encoded.Bytes(), decodedEncoded.Bytes(), "bindnode should decode %x to an equal value", encoded.Bytes())
+
+				reencoded, err := prime.Encode(node, dagcbor.Encode)
+				require.NoError(t, err)
+				require.Equal(t, encoded.Bytes(), reencoded)
+			}
+		})
+	}
+}
+
+var (
+	bigIntType    = reflect.TypeOf(big.Int{})
+	addressType   = reflect.TypeOf(address.Address{})
+	signatureType = reflect.TypeOf(crypto.Signature{})
+	bitFieldType  = reflect.TypeOf(bitfield.BitField{})
+	cidType       = reflect.TypeOf(cid.Cid{})
+	cborCidType   = reflect.TypeOf(cbg.CborCid{})
+)
+
+// fill sets v to a random value. Containers are left empty past a few levels of nesting.
+func fill(rnd *rand.Rand, v reflect.Value, depth int) {
+	switch v.Type() {
+	case bigIntType:
+		v.Set(reflect.ValueOf(big.NewInt(rnd.Int63() - rnd.Int63())))
+		return
+	case addressType:
+		a, err := address.NewIDAddress(uint64(rnd.Int63()))
+		if err != nil {
+			panic(err)
+		}
+		v.Set(reflect.ValueOf(a))
+		return
+	case signatureType:
+		sig := crypto.Signature{Type: crypto.SigTypeBLS, Data: randBytes(rnd, 96)}
+		if rnd.Intn(2) == 0 {
+			sig = crypto.Signature{Type: crypto.SigTypeSecp256k1, Data: randBytes(rnd, 65)}
+		}
+		v.Set(reflect.ValueOf(sig))
+		return
+	case bitFieldType:
+		var bits []uint64
+		for i := rnd.Intn(4); i > 0; i-- {
+			bits = append(bits, uint64(rnd.Intn(1000)))
+		}
+		v.Set(reflect.ValueOf(bitfield.NewFromSet(bits)))
+		return
+	case cidType:
+		h, err := mh.Sum(randBytes(rnd, 8), mh.SHA2_256, -1)
+		if err != nil {
+			panic(err)
+		}
+		v.Set(reflect.ValueOf(cid.NewCidV1(cid.DagCBOR, h)))
+		return
+	case cborCidType:
+		fill(rnd, v.Addr().Convert(reflect.TypeOf(&cid.Cid{})).Elem(), depth)
+		return
+	}
+	if u, ok := v.Addr().Interface().(cbg.CBORUnmarshaler); ok && hasUnexported(v.Type()) {
+		// A type with a hand-written encoding that hides its fields, such as a deal label, is filled
+		// by decoding a string or bytes.
+		major, payload := byte(cbg.MajTextString), []byte(randString(rnd))
+		if rnd.Intn(2) == 0 {
+			major, payload = cbg.MajByteString, randBytes(rnd, rnd.Intn(16))
+		}
+		var buf bytes.Buffer
+		if err := cbg.WriteMajorTypeHeader(&buf, major, uint64(len(payload))); err != nil {
+			panic(err)
+		}
+		buf.Write(payload)
+		if err := u.UnmarshalCBOR(&buf); err != nil {
+			panic(err)
+		}
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		v.SetBool(rnd.Intn(2) == 0)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(rnd.Int63() >> rnd.Intn(64) * int64(1-2*rnd.Intn(2)))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(rnd.Uint64() >> rnd.Intn(64))
+	case reflect.String:
+		v.SetString(randString(rnd))
+	case reflect.Ptr:
+		if depth < 3 && rnd.Intn(3) > 0 {
+			v.Set(reflect.New(v.Type().Elem()))
+			fill(rnd, v.Elem(), depth+1)
+		}
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			v.SetBytes(randBytes(rnd, rnd.Intn(16)))
+			return
+		}
+		n := 0
+		if depth < 3 {
+			n = rnd.Intn(3)
+		}
+		v.Set(reflect.MakeSlice(v.Type(), n, n))
+		for i := 0; i < n; i++ {
+			fill(rnd, v.Index(i), depth+1)
+		}
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			fill(rnd, v.Index(i), depth+1)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			fill(rnd, v.Field(i), depth+1)
+		}
+	default:
+		panic("cannot fill " + v.Type().String())
+	}
+}
+
+func hasUnexported(typ reflect.Type) bool {
+	if typ.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < typ.NumField(); i++ {
+		if !typ.Field(i).IsExported() {
+			return true
+		}
+	}
+	return false
+}
+
+func randBytes(rnd *rand.Rand, n int) []byte {
+	b := make([]byte, n)
+	rnd.Read(b)
+	return b
+}
+
+func randString(rnd *rand.Rand) string {
+	const letters = "abcdefghijklmnopqrstuvwxyz0123456789"
+	b := make([]byte, rnd.Intn(12))
+	for i := range b {
+		b[i] = letters[rnd.Intn(len(letters))]
+	}
+	return string(b)
+}
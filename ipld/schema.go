@@ -0,0 +1,136 @@
+package ipld
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	prime "github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/node/bindnode"
+	"github.com/ipld/go-ipld-prime/schema"
+)
+
+// DefaultBindnodeOptions are the converters for the common Filecoin data types that the schemas of
+// the actor packages represent as Bytes or Links.
+var DefaultBindnodeOptions = []bindnode.Option{
+	BigIntBindnodeOption,
+	AddressBindnodeOption,
+	SignatureBindnodeOption,
+	BitFieldBindnodeOption,
+	CborCidBindnodeOption,
+}
+
+// Schema is the IPLD schema of the CBOR encodings of the types in a Go package, as generated by
+// ipld/schemagen. It binds the package's types, and those of other packages they contain, to
+// go-ipld-prime nodes with bindnode.
+//
+// The schema names a type of its own package after the Go type, and a type of another package after
+// the Go type prefixed with the capitalized package name, e.g. SmoothingFilterEstimate.
+type Schema struct {
+	pkgPath string
+	dsl     []byte
+	options []bindnode.Option
+
+	once sync.Once
+	ts   *schema.TypeSystem
+	err  error
+}
+
+// NewSchema returns the schema for a package from its DSL, to be bound with the default options and
+// any options for the package's own converted types. The DSL is only parsed when first needed.
+func NewSchema(pkgPath string, dsl []byte, options ...bindnode.Option) *Schema {
+	return &Schema{
+		pkgPath: pkgPath,
+		dsl:     dsl,
+		options: append(append([]bindnode.Option{}, DefaultBindnodeOptions...), options...),
+	}
+}
+
+// TypeSystem returns the loaded schema.
+func (s *Schema) TypeSystem() (*schema.TypeSystem, error) {
+	s.once.Do(func() {
+		s.ts, s.err = prime.LoadSchemaBytes(s.dsl)
+		if s.err != nil {
+			s.err = fmt.Errorf("failed to load schema of %s: %w", s.pkgPath, s.err)
+		}
+	})
+	return s.ts, s.err
+}
+
+// TypeName returns the name under which the schema of a package declares a Go type.
+func TypeName(pkgPath string, typ reflect.Type) schema.TypeName {
+	if typ.PkgPath() == pkgPath {
+		return typ.Name()
+	}
+	pkg, _, _ := strings.Cut(typ.String(), ".")
+	return strings.ToUpper(pkg[:1]) + pkg[1:] + typ.Name()
+}
+
+// Type returns the schema type of a Go type.
+func (s *Schema) Type(typ reflect.Type) (schema.Type, error) {
+	ts, err := s.TypeSystem()
+	if err != nil {
+		return nil, err
+	}
+	name := TypeName(s.pkgPath, typ)
+	schemaType := ts.TypeByName(name)
+	if schemaType == nil {
+		return nil, fmt.Errorf("schema of %s has no type %s for %s", s.pkgPath, name, typ)
+	}
+	return schemaType, nil
+}
+
+// Prototype returns a prototype building nodes of the type pointed to by ptrType, which may be nil,
+// e.g. (*miner.State)(nil).
+// The Go value of a type with a transparent encoding is its single field, so a node of such a type
+// unwraps to a pointer to that field's type.
+func (s *Schema) Prototype(ptrType interface{}) (proto schema.TypedPrototype, err error) {
+	typ := reflect.TypeOf(ptrType)
+	if typ == nil || typ.Kind() != reflect.Ptr {
+		return nil, fmt.Errorf("expected a pointer type, got %T", ptrType)
+	}
+	schemaType, err := s.Type(typ.Elem())
+	if err != nil {
+		return nil, err
+	}
+	if field, ok := transparentField(typ.Elem()); ok {
+		ptrType = reflect.Zero(reflect.PointerTo(field.Type)).Interface()
+	}
+	defer recoverBindnode(&err)
+	return bindnode.Prototype(ptrType, schemaType, s.options...), nil
+}
+
+// Wrap returns a node backed by the Go value ptrVal points to.
+func (s *Schema) Wrap(ptrVal interface{}) (node schema.TypedNode, err error) {
+	val := reflect.ValueOf(ptrVal)
+	if val.Kind() != reflect.Ptr || val.IsNil() {
+		return nil, fmt.Errorf("expected a non-nil pointer, got %T", ptrVal)
+	}
+	schemaType, err := s.Type(val.Type().Elem())
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := transparentField(val.Type().Elem()); ok {
+		ptrVal = val.Elem().Field(0).Addr().Interface()
+	}
+	defer recoverBindnode(&err)
+	return bindnode.Wrap(ptrVal, schemaType, s.options...), nil
+}
+
+// transparentField returns the single field of a struct that cbor-gen encodes as that field alone.
+func transparentField(typ reflect.Type) (reflect.StructField, bool) {
+	if typ.Kind() != reflect.Struct || typ.NumField() != 1 {
+		return reflect.StructField{}, false
+	}
+	field := typ.Field(0)
+	return field, field.Tag.Get("cborgen") == "transparent"
+}
+
+// recoverBindnode turns the panic with which bindnode reports a Go type incompatible with its schema
+// type into an error.
+func recoverBindnode(err *error) {
+	if r := recover(); r != nil {
+		*err = fmt.Errorf("%v", r)
+	}
+}
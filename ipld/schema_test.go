@@ -0,0 +1,63 @@
+package ipld_test
+
+import (
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	"github.com/ipld/go-ipld-prime/datamodel"
+	"github.com/ipld/go-ipld-prime/traversal"
+	mh "github.com/multiformats/go-multihash"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin/v19/miner"
+	"github.com/filecoin-project/go-state-types/builtin/v19/power"
+)
+
+func TestSchemaTraversal(t *testing.T) {
+	h, err := mh.Sum([]byte("info"), mh.SHA2_256, -1)
+	require.NoError(t, err)
+	c := cid.NewCidV1(cid.DagCBOR, h)
+
+	st := &miner.State{
+		Info:                       c,
+		PreCommitDeposits:          big.Zero(),
+		LockedFunds:                big.Zero(),
+		FeeDebt:                    big.Zero(),
+		InitialPledge:              big.NewInt(7),
+		PreCommittedSectors:        c,
+		PreCommittedSectorsCleanUp: c,
+		AllocatedSectors:           c,
+		Sectors:                    c,
+		Deadlines:                  c,
+	}
+	node, err := miner.IpldSchema.Wrap(st)
+	require.NoError(t, err)
+	links, err := traversal.SelectLinks(node)
+	require.NoError(t, err)
+	require.Len(t, links, 6)
+
+	info := &miner.MinerInfo{
+		Owner:            address.TestAddress,
+		Worker:           address.TestAddress,
+		ControlAddresses: []address.Address{address.TestAddress, address.TestAddress2},
+	}
+	node, err = miner.IpldSchema.Wrap(info)
+	require.NoError(t, err)
+	require.NoError(t, traversal.Focus(node, datamodel.ParsePath("ControlAddresses/1"), func(_ traversal.Progress, n datamodel.Node) error {
+		b, err := n.AsBytes()
+		require.NoError(t, err)
+		require.Equal(t, address.TestAddress2.Bytes(), b)
+		return nil
+	}))
+
+	// A transparent type is bound through its field.
+	node, err = miner.IpldSchema.Wrap(&miner.VestingFundsTail{Funds: []miner.VestingFund{{Epoch: 1, Amount: big.NewInt(2)}}})
+	require.NoError(t, err)
+	require.Equal(t, datamodel.Kind_List, node.Kind())
+	require.EqualValues(t, 1, node.Length())
+
+	_, err = miner.IpldSchema.Prototype((*power.State)(nil))
+	require.Error(t, err)
+}
@@ -0,0 +1,321 @@
+/*
+Package schemagen generates IPLD schemas for types with cbor-gen tuple encodings.
+
+For each package it writes the schema DSL, a Go file embedding it as the package's IpldSchema, and a
+test checking that every type round-trips between cbor-gen and bindnode.
+*/
+package schemagen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/ipfs/go-cid"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-bitfield"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/crypto"
+	"github.com/filecoin-project/go-state-types/ipld"
+)
+
+const (
+	SchemaFile = "schema.ipldsch"
+	GoFile     = "ipld_gen.go"
+	TestFile   = "ipld_gen_test.go"
+)
+
+const header = "Code generated by github.com/filecoin-project/go-state-types/ipld/schemagen. DO NOT EDIT."
+
+// Types with hand-written encodings that ipld.DefaultBindnodeOptions convert, or that bindnode binds
+// natively, and the schema types representing them.
+var primitiveTypes = map[reflect.Type]string{
+	reflect.TypeOf(big.Int{}):           "Bytes",
+	reflect.TypeOf(address.Address{}):   "Bytes",
+	reflect.TypeOf(crypto.Signature{}):  "Bytes",
+	reflect.TypeOf(bitfield.BitField{}): "Bytes",
+	reflect.TypeOf(cid.Cid{}):           "Link",
+	reflect.TypeOf(cbg.CborCid{}):       "Link",
+}
+
+// customType is a type with a hand-written encoding in an actors version, declared by name in the
+// schema and bound with a converter exported by its package.
+type customType struct {
+	doc       string
+	kind      string
+	converter string
+}
+
+var customTypes = map[string]customType{
+	"market.DealLabel": {
+		doc:       "A DealLabel is either a string or bytes.",
+		kind:      "any",
+		converter: "DealLabelBindnodeOption",
+	},
+}
+
+// WriteSchemaToDir writes the schema of types from the package in dir, which must all be of that
+// package and have cbor-gen tuple encodings, along with its Go binding and round-trip test.
+func WriteSchemaToDir(dir, pkg string, types ...interface{}) error {
+	if len(types) == 0 {
+		return fmt.Errorf("no types for package %s", pkg)
+	}
+	g := &generator{
+		pkgPath: reflect.TypeOf(types[0]).PkgPath(),
+		names:   make(map[reflect.Type]string),
+		taken:   make(map[string]reflect.Type),
+		imports: make(map[string]string),
+	}
+	var local []reflect.Type
+	for _, v := range types {
+		typ := reflect.TypeOf(v)
+		if typ.PkgPath() != g.pkgPath {
+			return fmt.Errorf("type %s is not in package %s", typ, g.pkgPath)
+		}
+		if _, err := g.declare(typ); err != nil {
+			return err
+		}
+		local = append(local, typ)
+	}
+
+	var dsl bytes.Buffer
+	fmt.Fprintf(&dsl, "# %s\n", header)
+	for i := 0; i < len(g.decls); i++ { // declaring types may declare more
+		decl, err := g.declaration(g.decls[i])
+		if err != nil {
+			return fmt.Errorf("failed to describe %s: %w", g.decls[i], err)
+		}
+		fmt.Fprintf(&dsl, "\n%s\n", decl)
+	}
+	if err := os.WriteFile(filepath.Join(dir, SchemaFile), dsl.Bytes(), 0644); err != nil {
+		return err
+	}
+	if err := writeGoFile(filepath.Join(dir, GoFile), g.binding(pkg)); err != nil {
+		return err
+	}
+	return writeGoFile(filepath.Join(dir, TestFile), g.test(pkg, local))
+}
+
+type generator struct {
+	pkgPath    string
+	names      map[reflect.Type]string
+	taken      map[string]reflect.Type
+	decls      []reflect.Type
+	converters []string
+	imports    map[string]string // package path to name, for the converters
+}
+
+// declare returns the schema name of a type, adding it to the declarations. Structs and custom
+// types are declared when referenced, other types only when given.
+func (g *generator) declare(typ reflect.Type) (string, error) {
+	if name, ok := g.names[typ]; ok {
+		return name, nil
+	}
+	name := ipld.TypeName(g.pkgPath, typ)
+	if other, ok := g.taken[name]; ok {
+		return "", fmt.Errorf("types %s and %s would both be named %s", other, typ, name)
+	}
+	g.names[typ] = name
+	g.taken[name] = typ
+	g.decls = append(g.decls, typ)
+	if custom, ok := customTypes[typ.String()]; ok {
+		if typ.PkgPath() == g.pkgPath {
+			g.convert(custom.converter)
+		} else {
+			qualifier, _, _ := strings.Cut(typ.String(), ".")
+			g.imports[typ.PkgPath()] = qualifier
+			g.convert(qualifier + "." + custom.converter)
+		}
+	}
+	return name, nil
+}
+
+// convert adds a bindnode option to the package's schema binding.
+func (g *generator) convert(option string) {
+	for _, c := range g.converters {
+		if c == option {
+			return
+		}
+	}
+	g.converters = append(g.converters, option)
+}
+
+// declaration returns the schema declaration of a declared type.
+func (g *generator) declaration(typ reflect.Type) (string, error) {
+	name := g.names[typ]
+	if custom, ok := customTypes[typ.String()]; ok {
+		return fmt.Sprintf("# %s\ntype %s %s", custom.doc, name, custom.kind), nil
+	}
+	if typ.Kind() != reflect.Struct {
+		expr, err := g.kindExpr(typ)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("type %s %s", name, expr), nil
+	}
+
+	if typ.NumField() == 1 && typ.Field(0).Tag.Get("cborgen") == "transparent" {
+		expr, err := g.expr(typ.Field(0).Type)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("# Encoded as its %s field alone\ntype %s %s", typ.Field(0).Name, name, expr), nil
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "type %s struct {\n", name)
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			return "", fmt.Errorf("field %s is not exported", field.Name)
+		}
+		expr, err := g.expr(field.Type)
+		if err != nil {
+			return "", fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		var notes []string
+		if note := g.note(field.Type); note != "" {
+			notes = append(notes, note)
+		}
+		switch tag := field.Tag.Get("cborgen"); tag {
+		case "":
+		case "optional":
+			// cbor-gen always encodes the field but also decodes tuples without it
+			notes = append(notes, "optional when decoded by cbor-gen")
+		default:
+			return "", fmt.Errorf("field %s has unsupported cborgen tag %q", field.Name, tag)
+		}
+		fmt.Fprintf(&b, "\t%s %s", field.Name, expr)
+		if len(notes) > 0 {
+			fmt.Fprintf(&b, " # %s", strings.Join(notes, "; "))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("} representation tuple")
+	return b.String(), nil
+}
+
+// expr returns the schema type expression for a Go type.
+func (g *generator) expr(typ reflect.Type) (string, error) {
+	if name, ok := g.names[typ]; ok {
+		return name, nil
+	}
+	if kind, ok := primitiveTypes[typ]; ok {
+		return kind, nil
+	}
+	if _, ok := customTypes[typ.String()]; ok {
+		return g.declare(typ)
+	}
+	return g.kindExpr(typ)
+}
+
+// kindExpr returns the schema type expression for the kind of a Go type.
+func (g *generator) kindExpr(typ reflect.Type) (string, error) {
+	switch typ.Kind() {
+	case reflect.Ptr:
+		elem, err := g.expr(typ.Elem())
+		if err != nil {
+			return "", err
+		}
+		return "nullable " + elem, nil
+	case reflect.Bool:
+		return "Bool", nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "Int", nil
+	case reflect.String:
+		return "String", nil
+	case reflect.Slice:
+		if typ.Elem().Kind() == reflect.Uint8 {
+			return "Bytes", nil
+		}
+		elem, err := g.expr(typ.Elem())
+		if err != nil {
+			return "", err
+		}
+		return "[" + elem + "]", nil
+	case reflect.Array:
+		// bindnode has no arrays, so they are converted
+		switch typ.Elem() {
+		case reflect.TypeOf(byte(0)):
+			g.convert(fmt.Sprintf("ipld.ByteArrayBindnodeOption(new(%s))", typ))
+			return "Bytes", nil
+		case reflect.TypeOf(cid.Cid{}):
+			g.imports["github.com/ipfs/go-cid"] = "cid"
+			g.convert(fmt.Sprintf("ipld.CidArrayBindnodeOption(new(%s))", typ))
+			return "Any", nil
+		}
+		return "", fmt.Errorf("unsupported array type %s", typ)
+	case reflect.Struct:
+		return g.declare(typ)
+	default:
+		return "", fmt.Errorf("unsupported type %s", typ)
+	}
+}
+
+// note returns the Go type behind a schema type expression, where the expression does not name it.
+func (g *generator) note(typ reflect.Type) string {
+	base := typ
+	for base.Kind() == reflect.Ptr || (base.Kind() == reflect.Slice && base.Elem().Kind() != reflect.Uint8) {
+		base = base.Elem()
+	}
+	if _, ok := g.names[base]; ok || (base.PkgPath() == "" && base.Kind() != reflect.Array) {
+		return ""
+	}
+	return strings.ReplaceAll(typ.String(), "*", "")
+}
+
+// binding returns the Go file embedding the schema.
+func (g *generator) binding(pkg string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "// %s\n\npackage %s\n\nimport (\n\t_ \"embed\"\n\n", header, pkg)
+	fmt.Fprintf(&b, "\t\"github.com/filecoin-project/go-state-types/ipld\"\n")
+	paths := make([]string, 0, len(g.imports))
+	for path := range g.imports {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		if name := g.imports[path]; name != filepath.Base(path) {
+			fmt.Fprintf(&b, "\t%s %q\n", name, path)
+		} else {
+			fmt.Fprintf(&b, "\t%q\n", path)
+		}
+	}
+	fmt.Fprintf(&b, ")\n\n//go:embed %s\nvar ipldSchemaDSL []byte\n\n", SchemaFile)
+	fmt.Fprintf(&b, "// IpldSchema describes the CBOR encodings of the types in this package.\n")
+	fmt.Fprintf(&b, "var IpldSchema = ipld.NewSchema(%q, ipldSchemaDSL", g.pkgPath)
+	for _, converter := range g.converters {
+		fmt.Fprintf(&b, ", %s", converter)
+	}
+	b.WriteString(")\n")
+	return b.String()
+}
+
+// test returns the Go file testing that the types of the package round-trip.
+func (g *generator) test(pkg string, types []reflect.Type) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "// %s\n\npackage %s\n\nimport (\n\t\"testing\"\n\n", header, pkg)
+	fmt.Fprintf(&b, "\t\"github.com/filecoin-project/go-state-types/ipld/ipldtest\"\n)\n\n")
+	fmt.Fprintf(&b, "func TestIpldSchemaRoundTrip(t *testing.T) {\n\tipldtest.RoundTrip(t, IpldSchema,\n")
+	for _, typ := range types {
+		fmt.Fprintf(&b, "\t\t(*%s)(nil),\n", typ.Name())
+	}
+	b.WriteString("\t)\n}\n")
+	return b.String()
+}
+
+func writeGoFile(fname, src string) error {
+	formatted, err := format.Source([]byte(src))
+	if err != nil {
+		return fmt.Errorf("failed to format %s: %w", fname, err)
+	}
+	return os.WriteFile(fname, formatted, 0644)
+}
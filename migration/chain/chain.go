@@ -0,0 +1,200 @@
+// Package chain drives a state tree through several consecutive actors version migrations.
+//
+// Each builtin/vN/migration package only knows how to upgrade a state tree from version N-1 to N,
+// and their entry points differ slightly (v9 has its own Config, Logger and MigrationCache types,
+// v15 takes the FIP-0081 power ramp parameters). This package hides those differences behind the
+// shared migration.Config, migration.Logger and migration.MigrationCache abstractions.
+package chain
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/actors"
+	migration10 "github.com/filecoin-project/go-state-types/builtin/v10/migration"
+	migration11 "github.com/filecoin-project/go-state-types/builtin/v11/migration"
+	migration12 "github.com/filecoin-project/go-state-types/builtin/v12/migration"
+	migration13 "github.com/filecoin-project/go-state-types/builtin/v13/migration"
+	migration14 "github.com/filecoin-project/go-state-types/builtin/v14/migration"
+	migration15 "github.com/filecoin-project/go-state-types/builtin/v15/migration"
+	migration16 "github.com/filecoin-project/go-state-types/builtin/v16/migration"
+	migration17 "github.com/filecoin-project/go-state-types/builtin/v17/migration"
+	migration18 "github.com/filecoin-project/go-state-types/builtin/v18/migration"
+	migration19 "github.com/filecoin-project/go-state-types/builtin/v19/migration"
+	migration9 "github.com/filecoin-project/go-state-types/builtin/v9/migration"
+	"github.com/filecoin-project/go-state-types/migration"
+	"github.com/filecoin-project/go-state-types/rt"
+
+	"github.com/ipfs/go-cid"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	"golang.org/x/xerrors"
+)
+
+// MinVersion is the oldest actors version a chained migration can start from.
+const MinVersion = actors.Version8
+
+// MaxVersion is the newest actors version a chained migration can reach.
+const MaxVersion = actors.Version19
+
+// Step describes a single hop of a chained migration, upgrading the state tree to Version.
+type Step struct {
+	// Actors version the state tree is migrated to by this step.
+	Version actors.Version
+	// CID of the builtin actors manifest for Version.
+	Manifest cid.Cid
+	// The epoch at which this step is considered to run.
+	// Zero (the default) uses the UpgradeEpoch of the shared migration.Config.
+	UpgradeEpoch abi.ChainEpoch
+
+	// FIP-0081 power ramp parameters, only used (and required) by the step to actors version 15.
+	PowerRampStartEpoch     int64
+	PowerRampDurationEpochs uint64
+}
+
+// migrateFunc is the common shape of a single version migration once adapted.
+type migrateFunc func(ctx context.Context, store cbor.IpldStore, step Step, actorsRootIn cid.Cid, priorEpoch abi.ChainEpoch, cfg migration.Config, log migration.Logger, cache migration.MigrationCache) (cid.Cid, error)
+
+func stepFunc(v actors.Version) (migrateFunc, bool) {
+	switch v {
+	case actors.Version9:
+		return migrateV9, true
+	case actors.Version10:
+		return adapt(migration10.MigrateStateTree), true
+	case actors.Version11:
+		return adapt(migration11.MigrateStateTree), true
+	case actors.Version12:
+		return adapt(migration12.MigrateStateTree), true
+	case actors.Version13:
+		return adapt(migration13.MigrateStateTree), true
+	case actors.Version14:
+		return adapt(migration14.MigrateStateTree), true
+	case actors.Version15:
+		return migrateV15, true
+	case actors.Version16:
+		return adapt(migration16.MigrateStateTree), true
+	case actors.Version17:
+		return adapt(migration17.MigrateStateTree), true
+	case actors.Version18:
+		return adapt(migration18.MigrateStateTree), true
+	case actors.Version19:
+		return adapt(migration19.MigrateStateTree), true
+	default:
+		return nil, false
+	}
+}
+
+func adapt(f func(context.Context, cbor.IpldStore, cid.Cid, cid.Cid, abi.ChainEpoch, migration.Config, migration.Logger, migration.MigrationCache) (cid.Cid, error)) migrateFunc {
+	return func(ctx context.Context, store cbor.IpldStore, step Step, actorsRootIn cid.Cid, priorEpoch abi.ChainEpoch, cfg migration.Config, log migration.Logger, cache migration.MigrationCache) (cid.Cid, error) {
+		return f(ctx, store, step.Manifest, actorsRootIn, priorEpoch, cfg, log, cache)
+	}
+}
+
+func migrateV9(ctx context.Context, store cbor.IpldStore, step Step, actorsRootIn cid.Cid, priorEpoch abi.ChainEpoch, cfg migration.Config, log migration.Logger, cache migration.MigrationCache) (cid.Cid, error) {
+	cfg9 := migration9.Config{
+		MaxWorkers:        cfg.MaxWorkers,
+		JobQueueSize:      cfg.JobQueueSize,
+		ResultQueueSize:   cfg.ResultQueueSize,
+		ProgressLogPeriod: cfg.ProgressLogPeriod,
+	}
+	return migration9.MigrateStateTree(ctx, store, step.Manifest, actorsRootIn, priorEpoch, cfg9, log, cache)
+}
+
+func migrateV15(ctx context.Context, store cbor.IpldStore, step Step, actorsRootIn cid.Cid, priorEpoch abi.ChainEpoch, cfg migration.Config, log migration.Logger, cache migration.MigrationCache) (cid.Cid, error) {
+	return migration15.MigrateStateTree(ctx, store, step.Manifest, actorsRootIn, priorEpoch, step.PowerRampStartEpoch, step.PowerRampDurationEpochs, cfg, log, cache)
+}
+
+// ValidateSteps checks that steps form a gap-free sequence of supported migrations starting
+// from actors version from, each with the parameters its migration needs.
+func ValidateSteps(from actors.Version, steps []Step) error {
+	if from < MinVersion || from > MaxVersion {
+		return xerrors.Errorf("unsupported starting actors version %d", from)
+	}
+	if len(steps) == 0 {
+		return xerrors.Errorf("no migration steps given")
+	}
+
+	prev := from
+	for i, step := range steps {
+		if step.Version != prev+1 {
+			return xerrors.Errorf("step %d migrates to actors version %d, expected %d", i, step.Version, prev+1)
+		}
+		if _, ok := stepFunc(step.Version); !ok {
+			return xerrors.Errorf("step %d: no migration to actors version %d", i, step.Version)
+		}
+		if !step.Manifest.Defined() {
+			return xerrors.Errorf("step %d: undefined manifest for actors version %d", i, step.Version)
+		}
+		if step.Version == actors.Version15 {
+			if step.PowerRampStartEpoch <= 0 {
+				return xerrors.Errorf("step %d: power ramp start epoch %d must be positive", i, step.PowerRampStartEpoch)
+			}
+			if step.PowerRampDurationEpochs == 0 {
+				return xerrors.Errorf("step %d: power ramp duration must be non-zero", i)
+			}
+		} else if step.PowerRampStartEpoch != 0 || step.PowerRampDurationEpochs != 0 {
+			return xerrors.Errorf("step %d: power ramp parameters are only used by the migration to actors version 15, not %d", i, step.Version)
+		}
+		prev = step.Version
+	}
+	return nil
+}
+
+// Migrate migrates the state tree at actorsRootIn, which must be at actors version from, through
+// every step in order and returns the resulting state root.
+// The store must support concurrent writes (even if the configured worker count is 1).
+//
+// A single cache may be shared by all steps: keys are namespaced per target version so that
+// entries recorded by one step are never observed by another.
+func Migrate(ctx context.Context, store cbor.IpldStore, actorsRootIn cid.Cid, from actors.Version, priorEpoch abi.ChainEpoch, steps []Step, cfg migration.Config, log migration.Logger, cache migration.MigrationCache) (cid.Cid, error) {
+	if err := ValidateSteps(from, steps); err != nil {
+		return cid.Undef, err
+	}
+
+	root := actorsRootIn
+	for _, step := range steps {
+		f, _ := stepFunc(step.Version)
+
+		stepCfg := cfg
+		if step.UpgradeEpoch != 0 {
+			stepCfg.UpgradeEpoch = step.UpgradeEpoch
+		}
+
+		startTime := time.Now()
+		log.Log(rt.INFO, "Migrating state tree %s to actors version %d", root, step.Version)
+		newRoot, err := f(ctx, store, step, root, priorEpoch, stepCfg, log, versionedCache{version: step.Version, inner: cache})
+		if err != nil {
+			return cid.Undef, xerrors.Errorf("migrating to actors version %d: %w", step.Version, err)
+		}
+		log.Log(rt.INFO, "Migrated to actors version %d, new state tree %s after %v", step.Version, newRoot, time.Since(startTime).Round(100*time.Millisecond))
+		root = newRoot
+	}
+
+	return root, nil
+}
+
+// versionedCache prefixes every key with the target actors version so that a single
+// MigrationCache can safely back several migrations.
+type versionedCache struct {
+	version actors.Version
+	inner   migration.MigrationCache
+}
+
+var _ migration.MigrationCache = versionedCache{}
+
+func (c versionedCache) key(k string) string {
+	return fmt.Sprintf("v%d-%s", c.version, k)
+}
+
+func (c versionedCache) Write(key string, newCid cid.Cid) error {
+	return c.inner.Write(c.key(key), newCid)
+}
+
+func (c versionedCache) Read(key string) (bool, cid.Cid, error) {
+	return c.inner.Read(c.key(key))
+}
+
+func (c versionedCache) Load(key string, loadFunc func() (cid.Cid, error)) (cid.Cid, error) {
+	return c.inner.Load(c.key(key), loadFunc)
+}
@@ -0,0 +1,164 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/actors"
+	"github.com/filecoin-project/go-state-types/builtin"
+	v16 "github.com/filecoin-project/go-state-types/builtin/v16"
+	v16power "github.com/filecoin-project/go-state-types/builtin/v16/power"
+	v19 "github.com/filecoin-project/go-state-types/builtin/v19"
+	"github.com/filecoin-project/go-state-types/builtin/v19/util/adt"
+	"github.com/filecoin-project/go-state-types/manifest"
+	"github.com/filecoin-project/go-state-types/migration"
+	"github.com/filecoin-project/go-state-types/rt"
+	"github.com/filecoin-project/go-state-types/test_util"
+	"github.com/filecoin-project/go-state-types/test_util/synth"
+	"github.com/ipfs/go-cid"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	"github.com/multiformats/go-multihash"
+	"github.com/stretchr/testify/require"
+)
+
+func testManifest(t *testing.T, name string) cid.Cid {
+	c, err := cid.V1Builder{Codec: cid.DagCBOR, MhType: multihash.IDENTITY}.Sum([]byte(name))
+	require.NoError(t, err)
+	return c
+}
+
+func TestValidateSteps(t *testing.T) {
+	m := testManifest(t, "manifest")
+
+	require.NoError(t, ValidateSteps(actors.Version8, []Step{
+		{Version: actors.Version9, Manifest: m},
+		{Version: actors.Version10, Manifest: m},
+	}))
+	require.NoError(t, ValidateSteps(actors.Version18, []Step{{Version: actors.Version19, Manifest: m}}))
+
+	require.Error(t, ValidateSteps(actors.Version8, nil), "no steps")
+	require.Error(t, ValidateSteps(actors.Version7, []Step{{Version: actors.Version8, Manifest: m}}), "unsupported start")
+	require.Error(t, ValidateSteps(actors.Version19, []Step{{Version: actors.Version19 + 1, Manifest: m}}), "unsupported target")
+	require.Error(t, ValidateSteps(actors.Version8, []Step{
+		{Version: actors.Version9, Manifest: m},
+		{Version: actors.Version11, Manifest: m},
+	}), "gap")
+	require.Error(t, ValidateSteps(actors.Version8, []Step{{Version: actors.Version9}}), "undefined manifest")
+
+	// The power ramp parameters are required by the step to version 15, and only by it.
+	ramp := Step{Version: actors.Version15, Manifest: m, PowerRampStartEpoch: 100, PowerRampDurationEpochs: 200}
+	require.NoError(t, ValidateSteps(actors.Version14, []Step{ramp}))
+	noStart := ramp
+	noStart.PowerRampStartEpoch = 0
+	require.Error(t, ValidateSteps(actors.Version14, []Step{noStart}), "ramp start epoch")
+	negativeStart := ramp
+	negativeStart.PowerRampStartEpoch = -1
+	require.Error(t, ValidateSteps(actors.Version14, []Step{negativeStart}), "negative ramp start epoch")
+	noDuration := ramp
+	noDuration.PowerRampDurationEpochs = 0
+	require.Error(t, ValidateSteps(actors.Version14, []Step{noDuration}), "ramp duration")
+	require.Error(t, ValidateSteps(actors.Version14, []Step{ramp, {Version: actors.Version16, Manifest: m, PowerRampDurationEpochs: 200}}), "ramp on another step")
+}
+
+type testLogger struct {
+	tb testing.TB
+}
+
+func (l testLogger) Log(_ rt.LogLevel, msg string, args ...interface{}) {
+	l.tb.Logf(msg, args...)
+}
+
+func TestMigrateSyntheticState(t *testing.T) {
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		from, to actors.Version
+		check    func(*builtin.ActorTree, abi.ChainEpoch, map[string]cid.Cid) (*builtin.MessageAccumulator, error)
+	}{
+		{from: actors.Version14, to: actors.Version16, check: v16.CheckStateInvariants},
+		{from: actors.Version17, to: actors.Version19, check: v19.CheckStateInvariants},
+	} {
+		t.Run(fmt.Sprintf("v%d to v%d", tc.from, tc.to), func(t *testing.T) {
+			store := cbor.NewCborStore(test_util.NewSyncBlockStoreInMemory())
+			cfg := synth.DefaultConfig()
+			cfg.ActorsVersion = tc.from
+			cfg.SectorsPerMiner = 50
+			res, err := synth.Generate(ctx, store, cfg)
+			require.NoError(t, err)
+
+			var steps []Step
+			var codes map[string]cid.Cid
+			for v := tc.from + 1; v <= tc.to; v++ {
+				manifestCid, err := synth.MakeManifest(ctx, store, v)
+				require.NoError(t, err)
+				step := Step{Version: v, Manifest: manifestCid}
+				if v == actors.Version15 {
+					step.PowerRampStartEpoch = int64(cfg.PriorEpoch) + 1
+					step.PowerRampDurationEpochs = 365 * uint64(builtin.EpochsInDay)
+				}
+				steps = append(steps, step)
+				codes = manifestCodes(t, ctx, store, step.Manifest, v)
+			}
+
+			migrationCfg := migration.Config{MaxWorkers: 4, UpgradeEpoch: cfg.PriorEpoch + 1}
+			root, err := Migrate(ctx, store, res.StateRoot, tc.from, cfg.PriorEpoch, steps, migrationCfg, testLogger{t}, migration.NewMemMigrationCache())
+			require.NoError(t, err)
+
+			tree, err := builtin.LoadTree(adt.WrapStore(ctx, store), root)
+			require.NoError(t, err)
+			acc, err := tc.check(tree, cfg.PriorEpoch, codes)
+			require.NoError(t, err)
+			require.True(t, acc.IsEmpty(), acc.Messages())
+
+			if tc.to == actors.Version16 {
+				requirePowerRamp(t, ctx, tree, steps[actors.Version15-tc.from-1])
+			}
+		})
+	}
+}
+
+func manifestCodes(t *testing.T, ctx context.Context, store cbor.IpldStore, manifestCid cid.Cid, v actors.Version) map[string]cid.Cid {
+	adtStore := adt.WrapStore(ctx, store)
+	var m manifest.Manifest
+	require.NoError(t, adtStore.Get(ctx, manifestCid, &m))
+	require.NoError(t, m.Load(ctx, adtStore))
+	codes := make(map[string]cid.Cid)
+	for _, name := range manifest.GetBuiltinActorsKeys(v) {
+		c, ok := m.Get(name)
+		require.True(t, ok, name)
+		codes[name] = c
+	}
+	return codes
+}
+
+// requirePowerRamp checks that the power actor of a version 16 tree carries the ramp parameters of
+// the step to version 15.
+func requirePowerRamp(t *testing.T, ctx context.Context, tree *builtin.ActorTree, step Step) {
+	act, found, err := tree.GetActorV5(builtin.StoragePowerActorAddr)
+	require.NoError(t, err)
+	require.True(t, found)
+	var st v16power.State
+	require.NoError(t, tree.Store.Get(ctx, act.Head, &st))
+	require.Equal(t, step.PowerRampStartEpoch, st.RampStartEpoch)
+	require.Equal(t, step.PowerRampDurationEpochs, st.RampDurationEpochs)
+}
+
+func TestVersionedCacheIsolatesSteps(t *testing.T) {
+	inner := migration.NewMemMigrationCache()
+	c9 := versionedCache{version: actors.Version9, inner: inner}
+	c10 := versionedCache{version: actors.Version10, inner: inner}
+
+	v := testManifest(t, "value")
+	require.NoError(t, c9.Write("key", v))
+
+	found, got, err := c9.Read("key")
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, v, got)
+
+	found, _, err = c10.Read("key")
+	require.NoError(t, err)
+	require.False(t, found)
+}
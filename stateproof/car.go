@@ -0,0 +1,138 @@
+package stateproof
+
+import (
+	"context"
+	"io"
+
+	block "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	ipldcbor "github.com/ipfs/go-ipld-cbor"
+	cbg "github.com/whyrusleeping/cbor-gen"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/builtin"
+	"github.com/filecoin-project/go-state-types/builtin/v8/system"
+	"github.com/filecoin-project/go-state-types/manifest"
+	"github.com/filecoin-project/go-state-types/store"
+)
+
+// WriteCAR writes the proof as a CAR with the state root as its root.
+func (p *Proof) WriteCAR(ctx context.Context, w io.Writer) error {
+	cw, err := store.NewCARWriter(ctx, nil, w, []cid.Cid{p.Root})
+	if err != nil {
+		return err
+	}
+	for _, b := range p.Blocks {
+		if err := cw.Put(b); err != nil {
+			return err
+		}
+	}
+	return cw.Flush()
+}
+
+// ReadProof reads a proof written by WriteCAR. Its root is taken from the CAR, so Verify checks it
+// against a trusted root.
+func ReadProof(ctx context.Context, r io.Reader) (*Proof, error) {
+	var blocks collector
+	roots, err := store.ImportCAR(ctx, r, &blocks)
+	if err != nil {
+		return nil, err
+	}
+	if len(roots) != 1 {
+		return nil, xerrors.Errorf("expected a proof with one root, got %d", len(roots))
+	}
+	return &Proof{Root: roots[0], Blocks: blocks}, nil
+}
+
+// ExportActor writes a CAR with the header state root as its root, holding the blocks on the path to an
+// actor and the DAG of its state, so that the actor's state can be loaded from the CAR through the
+// state tree. Options may exclude parts of the state, such as the bytecode of an EVM actor.
+func ExportActor(ctx context.Context, bs ipldcbor.IpldBlockstore, w io.Writer, root cid.Cid, actor address.Address, opts ...store.CAROption) error {
+	proof, res, err := Prove(ctx, bs, root, Path{Actor: actor})
+	if err != nil {
+		return err
+	}
+	if !res.Found {
+		return xerrors.Errorf("actor %s not found", actor)
+	}
+	cw, err := store.NewCARWriter(ctx, bs, w, []cid.Cid{root}, opts...)
+	if err != nil {
+		return err
+	}
+	// The proof ends with the actor's state, written by the walk unless excluded.
+	for _, b := range proof.Blocks {
+		if !b.Cid().Equals(res.Actor.Head) {
+			if err := cw.Put(b); err != nil {
+				return err
+			}
+		}
+	}
+	if err := cw.Walk(res.Actor.Head); err != nil {
+		return err
+	}
+	return cw.Flush()
+}
+
+// ExportState writes a CAR of the state tree with the given header state root, without the code of
+// the built-in actors, which the state links to but is not state.
+func ExportState(ctx context.Context, bs ipldcbor.IpldBlockstore, w io.Writer, root cid.Cid, opts ...store.CAROption) error {
+	codes, err := ActorCodes(ctx, bs, root)
+	if err != nil {
+		return err
+	}
+	return store.ExportCAR(ctx, bs, w, []cid.Cid{root}, append([]store.CAROption{store.ExcludeCids(codes...)}, opts...)...)
+}
+
+// ActorCodes returns the code CIDs of the built-in actors of the state tree with the given header
+// state root, from the manifest held by the system actor.
+func ActorCodes(ctx context.Context, bs ipldcbor.IpldBlockstore, root cid.Cid) ([]cid.Cid, error) {
+	s := store.WrapBlockStore(ctx, bs)
+	tree, err := loadStateTree(s, root)
+	if err != nil {
+		return nil, err
+	}
+	var entry cbg.Deferred
+	found, err := tree.Map.Get(abi.AddrKey(builtin.SystemActorAddr), &entry)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to look system actor up: %w", err)
+	}
+	if !found {
+		return nil, xerrors.Errorf("system actor not found")
+	}
+	act, err := decodeActor(entry.Raw)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to decode system actor: %w", err)
+	}
+	var st system.State
+	if err := s.Get(ctx, act.Head, &st); err != nil {
+		return nil, xerrors.Errorf("failed to load system actor state: %w", err)
+	}
+	var data manifest.ManifestData
+	if err := s.Get(ctx, st.BuiltinActors, &data); err != nil {
+		return nil, xerrors.Errorf("failed to load manifest: %w", err)
+	}
+	codes := make([]cid.Cid, 0, len(data.Entries))
+	for _, e := range data.Entries {
+		codes = append(codes, e.Code)
+	}
+	return codes, nil
+}
+
+// collector collects the blocks put into it.
+type collector []block.Block
+
+func (c *collector) Get(_ context.Context, k cid.Cid) (block.Block, error) {
+	for _, b := range *c {
+		if b.Cid().Equals(k) {
+			return b, nil
+		}
+	}
+	return nil, xerrors.Errorf("block %s not found", k)
+}
+
+func (c *collector) Put(_ context.Context, b block.Block) error {
+	*c = append(*c, b)
+	return nil
+}
@@ -0,0 +1,148 @@
+package stateproof_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	block "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	mh "github.com/multiformats/go-multihash"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/actors"
+	"github.com/filecoin-project/go-state-types/builtin"
+	v19 "github.com/filecoin-project/go-state-types/builtin/v19"
+	"github.com/filecoin-project/go-state-types/builtin/v19/evm"
+	"github.com/filecoin-project/go-state-types/builtin/v19/util/adt"
+	"github.com/filecoin-project/go-state-types/manifest"
+	"github.com/filecoin-project/go-state-types/stateproof"
+	"github.com/filecoin-project/go-state-types/store"
+	"github.com/filecoin-project/go-state-types/test_util"
+	"github.com/filecoin-project/go-state-types/test_util/synth"
+)
+
+func TestExportActor(t *testing.T) {
+	ctx := context.Background()
+	bs := test_util.NewSyncBlockStoreInMemory()
+	res, err := synth.Generate(ctx, cbor.NewCborStore(bs), synth.DefaultConfig())
+	require.NoError(t, err)
+	s := adt.WrapStore(ctx, cbor.NewCborStore(bs))
+	root := headerRoot(ctx, t, cbor.NewCborStore(bs), res.StateRoot)
+
+	tree, err := builtin.LoadTree(s, res.StateRoot)
+	require.NoError(t, err)
+	var evmAddr address.Address
+	require.NoError(t, tree.ForEachV5(func(addr address.Address, act *builtin.ActorV5) error {
+		if act.Code == res.ActorCodes[manifest.EvmKey] && evmAddr == address.Undef {
+			evmAddr = addr
+		}
+		return nil
+	}))
+	act, found, err := tree.GetActorV5(evmAddr)
+	require.NoError(t, err)
+	require.True(t, found)
+	var expected evm.State
+	require.NoError(t, s.Get(ctx, act.Head, &expected))
+
+	// Bytecode is a raw block, written along with the actor's state unless excluded.
+	require.Equal(t, uint64(cid.Raw), expected.Bytecode.Prefix().Codec)
+	var car bytes.Buffer
+	require.NoError(t, stateproof.ExportActor(ctx, bs, &car, root, evmAddr))
+	ros, err := store.NewReadOnlyStore(bytes.NewReader(car.Bytes()))
+	require.NoError(t, err)
+	require.True(t, ros.Has(expected.Bytecode))
+	bytecode, err := expected.GetBytecode(store.WrapBlockStore(ctx, ros))
+	require.NoError(t, err)
+	require.NotEmpty(t, bytecode)
+
+	// The actor's state loads from the CAR through the state tree, without the excluded bytecode.
+	car.Reset()
+	require.NoError(t, stateproof.ExportActor(ctx, bs, &car, root, evmAddr, store.ExcludeCids(expected.Bytecode)))
+	ros, err = store.NewReadOnlyStore(bytes.NewReader(car.Bytes()))
+	require.NoError(t, err)
+	exported := store.WrapBlockStore(ctx, ros)
+	var sr builtin.StateRoot
+	require.NoError(t, exported.Get(ctx, root, &sr))
+	require.Equal(t, res.StateRoot, sr.Actors)
+	tree, err = builtin.LoadTree(exported, sr.Actors)
+	require.NoError(t, err)
+	exportedAct, found, err := tree.GetActorV5(evmAddr)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, act, exportedAct)
+	var st evm.State
+	require.NoError(t, exported.Get(ctx, exportedAct.Head, &st))
+	require.Equal(t, expected, st)
+	require.True(t, ros.Has(st.ContractState))
+	require.False(t, ros.Has(expected.Bytecode))
+
+	// A proof survives a round trip through a CAR.
+	path := stateproof.Path{Actor: evmAddr}
+	proof, _, err := stateproof.Prove(ctx, bs, root, path)
+	require.NoError(t, err)
+	car.Reset()
+	require.NoError(t, proof.WriteCAR(ctx, &car))
+	read, err := stateproof.ReadProof(ctx, &car)
+	require.NoError(t, err)
+	require.Equal(t, proof.Root, read.Root)
+	verified, err := stateproof.Verify(ctx, root, read, path)
+	require.NoError(t, err)
+	require.True(t, verified.Found)
+	require.Equal(t, act.Head, verified.Actor.Head)
+}
+
+func TestExportState(t *testing.T) {
+	ctx := context.Background()
+	bs := test_util.NewSyncBlockStoreInMemory()
+	ipld := cbor.NewCborStore(bs)
+
+	// A manifest of actor code held in the blockstore, as on a node.
+	var data manifest.ManifestData
+	for _, name := range manifest.GetBuiltinActorsKeys(actors.Version19) {
+		code := []byte("wasm module of the " + name + " actor")
+		c, err := cid.V1Builder{Codec: cid.Raw, MhType: mh.BLAKE2B_MIN + 31}.Sum(code)
+		require.NoError(t, err)
+		b, err := block.NewBlockWithCid(code, c)
+		require.NoError(t, err)
+		require.NoError(t, bs.Put(ctx, b))
+		data.Entries = append(data.Entries, manifest.ManifestEntry{Name: name, Code: c})
+	}
+	dataCid, err := ipld.Put(ctx, &data)
+	require.NoError(t, err)
+	cfg := synth.DefaultConfig()
+	cfg.SectorsPerMiner = 50
+	cfg.Manifest, err = ipld.Put(ctx, &manifest.Manifest{Version: 1, Data: dataCid})
+	require.NoError(t, err)
+	res, err := synth.Generate(ctx, ipld, cfg)
+	require.NoError(t, err)
+	root := headerRoot(ctx, t, ipld, res.StateRoot)
+
+	codes, err := stateproof.ActorCodes(ctx, bs, root)
+	require.NoError(t, err)
+	require.Len(t, codes, len(res.ActorCodes))
+
+	var car bytes.Buffer
+	require.NoError(t, stateproof.ExportState(ctx, bs, &car, root))
+	ros, err := store.NewReadOnlyStore(bytes.NewReader(car.Bytes()))
+	require.NoError(t, err)
+	for _, c := range codes {
+		require.False(t, ros.Has(c))
+	}
+	tree, err := builtin.LoadTree(store.WrapBlockStore(ctx, ros), res.StateRoot)
+	require.NoError(t, err)
+	acc, err := v19.CheckStateInvariants(tree, cfg.PriorEpoch, res.ActorCodes)
+	require.NoError(t, err)
+	require.True(t, acc.IsEmpty(), acc.Messages())
+
+	// Exported as a plain DAG, the state carries the code it links to.
+	car.Reset()
+	require.NoError(t, store.ExportCAR(ctx, bs, &car, []cid.Cid{res.StateRoot}))
+	ros, err = store.NewReadOnlyStore(bytes.NewReader(car.Bytes()))
+	require.NoError(t, err)
+	for _, c := range codes {
+		require.True(t, ros.Has(c))
+	}
+}
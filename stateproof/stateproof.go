@@ -0,0 +1,275 @@
+// Package stateproof proves and verifies values in a state tree, such as a sector of a miner or a
+// verified registry claim, with the blocks on the path to them from the state root.
+//
+// A proof is the set of blocks read while looking a path up in the state, so verifying it needs no
+// other store: the lookup is repeated over the proof's blocks, each checked against its CID, from a
+// state root the verifier trusts. The state root is the one taken from a block header, the CID of
+// the builtin.StateRoot holding the version of the state tree and the root of its actors, so a
+// proof starts with that block. A proof that a path is absent is made and verified in the same way.
+package stateproof
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+
+	block "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	ipldcbor "github.com/ipfs/go-ipld-cbor"
+	cbg "github.com/whyrusleeping/cbor-gen"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/builtin"
+	"github.com/filecoin-project/go-state-types/builtin/v8/util/adt"
+	"github.com/filecoin-project/go-state-types/cbor"
+	"github.com/filecoin-project/go-state-types/store"
+)
+
+// Step is a lookup in a HAMT or AMT.
+type Step struct {
+	hamt     bool
+	bitWidth int
+	key      abi.Keyer
+	index    uint64
+}
+
+// HAMTKey looks a key up in a HAMT of the given bit width.
+func HAMTKey(bitWidth int, key abi.Keyer) Step {
+	return Step{hamt: true, bitWidth: bitWidth, key: key}
+}
+
+// AMTIndex looks an index up in an AMT of the given bit width.
+func AMTIndex(bitWidth int, index uint64) Step {
+	return Step{bitWidth: bitWidth, index: index}
+}
+
+// Path locates a value in a state tree: an actor, a field of its state holding the root of a HAMT
+// or AMT, and the lookups leading from that root to the value. The value of each lookup but the
+// last is the root of the next collection, as in the verified registry's HAMTs of claims by
+// provider.
+//
+// A path without a field locates the actor's state.
+type Path struct {
+	// ID address of the actor.
+	Actor address.Address
+	// Pointer to the actor's state type in the actors version of the state, e.g. (*miner.State)(nil).
+	State interface{}
+	// Name of the field of State holding the root of the first collection.
+	Field string
+	Steps []Step
+}
+
+// Proof holds the blocks proving a path in the state tree with the given root.
+type Proof struct {
+	Root   cid.Cid
+	Blocks []block.Block
+}
+
+// Result is the outcome of looking a path up.
+type Result struct {
+	// Whether the path is present. A proof of a missing path proves its absence.
+	Found bool
+	// The actor, if present. The delegated address is nil in state trees before actors version 10.
+	Actor *builtin.ActorV5
+	// The raw value at the path, if present.
+	Value *cbg.Deferred
+}
+
+// Decode decodes the value at a path that was found.
+func (r *Result) Decode(out cbor.Unmarshaler) error {
+	if !r.Found {
+		return xerrors.Errorf("path not found")
+	}
+	return out.UnmarshalCBOR(bytes.NewReader(r.Value.Raw))
+}
+
+// Prove looks a path up in the state tree with the given header state root, returning the blocks read
+// as the proof along with the result.
+func Prove(ctx context.Context, bs ipldcbor.IpldBlockstore, root cid.Cid, path Path) (*Proof, *Result, error) {
+	rec := &recorder{bs: bs, seen: make(map[cid.Cid]struct{})}
+	res, err := resolve(store.WrapBlockStore(ctx, rec), root, path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &Proof{Root: root, Blocks: rec.blocks}, res, nil
+}
+
+// Verify looks a path up in the blocks of a proof from a trusted header state root, failing if the
+// proof is for another root, any block does not match its CID or a block on the path is missing.
+func Verify(ctx context.Context, root cid.Cid, proof *Proof, path Path) (*Result, error) {
+	if !proof.Root.Equals(root) {
+		return nil, xerrors.Errorf("proof is for state root %s, not %s", proof.Root, root)
+	}
+	bs := make(proofBlocks, len(proof.Blocks))
+	for _, b := range proof.Blocks {
+		c, err := b.Cid().Prefix().Sum(b.RawData())
+		if err != nil {
+			return nil, xerrors.Errorf("failed to hash block %s: %w", b.Cid(), err)
+		}
+		if !c.Equals(b.Cid()) {
+			return nil, xerrors.Errorf("block data does not match CID %s", b.Cid())
+		}
+		bs[b.Cid()] = b
+	}
+	return resolve(store.WrapBlockStore(ctx, bs), root, path)
+}
+
+func resolve(s adt.Store, root cid.Cid, path Path) (*Result, error) {
+	if path.Actor.Protocol() != address.ID {
+		return nil, xerrors.Errorf("non-ID address %v invalid as actor key", path.Actor)
+	}
+	tree, err := loadStateTree(s, root)
+	if err != nil {
+		return nil, err
+	}
+	var entry cbg.Deferred
+	found, err := tree.Map.Get(abi.AddrKey(path.Actor), &entry)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to look actor %s up: %w", path.Actor, err)
+	}
+	if !found {
+		return &Result{}, nil
+	}
+	act, err := decodeActor(entry.Raw)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to decode actor %s: %w", path.Actor, err)
+	}
+
+	var head cbg.Deferred
+	if err := s.Get(s.Context(), act.Head, &head); err != nil {
+		return nil, xerrors.Errorf("failed to load state of actor %s: %w", path.Actor, err)
+	}
+	if path.Field == "" {
+		return &Result{Found: true, Actor: act, Value: &head}, nil
+	}
+
+	next, err := stateField(head.Raw, path.State, path.Field)
+	if err != nil {
+		return nil, err
+	}
+	var value cbg.Deferred
+	for i, step := range path.Steps {
+		if i > 0 {
+			var c cbg.CborCid
+			if err := c.UnmarshalCBOR(bytes.NewReader(value.Raw)); err != nil {
+				return nil, xerrors.Errorf("value of step %d is not a collection root: %w", i-1, err)
+			}
+			next = cid.Cid(c)
+		}
+		if found, err = step.lookup(s, next, &value); err != nil {
+			return nil, xerrors.Errorf("step %d: %w", i, err)
+		}
+		if !found {
+			return &Result{Actor: act}, nil
+		}
+	}
+	return &Result{Found: true, Actor: act, Value: &value}, nil
+}
+
+// loadStateTree loads the actors of the state tree with the given header state root.
+func loadStateTree(s adt.Store, root cid.Cid) (*builtin.ActorTree, error) {
+	var sr builtin.StateRoot
+	if err := s.Get(s.Context(), root, &sr); err != nil {
+		return nil, xerrors.Errorf("failed to load state root %s: %w", root, err)
+	}
+	tree, err := builtin.LoadTree(s, sr.Actors)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to load state tree: %w", err)
+	}
+	return tree, nil
+}
+
+func (step Step) lookup(s adt.Store, root cid.Cid, out *cbg.Deferred) (bool, error) {
+	if step.hamt {
+		m, err := adt.AsMap(s, root, step.bitWidth)
+		if err != nil {
+			return false, xerrors.Errorf("failed to load HAMT: %w", err)
+		}
+		return m.Get(step.key, out)
+	}
+	a, err := adt.AsArray(s, root, step.bitWidth)
+	if err != nil {
+		return false, xerrors.Errorf("failed to load AMT: %w", err)
+	}
+	return a.Get(step.index, out)
+}
+
+// decodeActor decodes an entry of the state tree, which is an ActorV5 from actors version 10 and an
+// ActorV4 before.
+func decodeActor(raw []byte) (*builtin.ActorV5, error) {
+	var v5 builtin.ActorV5
+	if err := v5.UnmarshalCBOR(bytes.NewReader(raw)); err == nil {
+		return &v5, nil
+	}
+	var v4 builtin.ActorV4
+	if err := v4.UnmarshalCBOR(bytes.NewReader(raw)); err != nil {
+		return nil, err
+	}
+	return &builtin.ActorV5{Code: v4.Code, Head: v4.Head, CallSeqNum: v4.CallSeqNum, Balance: v4.Balance}, nil
+}
+
+// stateField decodes an actor's state as the type pointed to by ptrType and returns the CID in one
+// of its fields.
+func stateField(raw []byte, ptrType interface{}, name string) (cid.Cid, error) {
+	typ := reflect.TypeOf(ptrType)
+	if typ == nil || typ.Kind() != reflect.Ptr {
+		return cid.Undef, xerrors.Errorf("expected a pointer to a state type, got %T", ptrType)
+	}
+	st := reflect.New(typ.Elem())
+	u, ok := st.Interface().(cbor.Unmarshaler)
+	if !ok {
+		return cid.Undef, xerrors.Errorf("state type %s cannot be decoded", typ.Elem())
+	}
+	if err := u.UnmarshalCBOR(bytes.NewReader(raw)); err != nil {
+		return cid.Undef, xerrors.Errorf("failed to decode state as %s: %w", typ.Elem(), err)
+	}
+	field := st.Elem().FieldByName(name)
+	if !field.IsValid() {
+		return cid.Undef, xerrors.Errorf("state type %s has no field %s", typ.Elem(), name)
+	}
+	c, ok := field.Interface().(cid.Cid)
+	if !ok {
+		return cid.Undef, xerrors.Errorf("field %s of %s is not a CID", name, typ.Elem())
+	}
+	return c, nil
+}
+
+// recorder records the blocks read from a blockstore, in the order first read.
+type recorder struct {
+	bs     ipldcbor.IpldBlockstore
+	seen   map[cid.Cid]struct{}
+	blocks []block.Block
+}
+
+func (r *recorder) Get(ctx context.Context, c cid.Cid) (block.Block, error) {
+	b, err := r.bs.Get(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := r.seen[c]; !ok {
+		r.seen[c] = struct{}{}
+		r.blocks = append(r.blocks, b)
+	}
+	return b, nil
+}
+
+func (r *recorder) Put(context.Context, block.Block) error {
+	return xerrors.Errorf("proving does not write blocks")
+}
+
+// proofBlocks is a read-only blockstore of the blocks of a proof.
+type proofBlocks map[cid.Cid]block.Block
+
+func (p proofBlocks) Get(_ context.Context, c cid.Cid) (block.Block, error) {
+	b, ok := p[c]
+	if !ok {
+		return nil, xerrors.Errorf("proof is missing block %s", c)
+	}
+	return b, nil
+}
+
+func (p proofBlocks) Put(context.Context, block.Block) error {
+	return xerrors.Errorf("proof blocks are read-only")
+}
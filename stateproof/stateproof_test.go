@@ -0,0 +1,158 @@
+package stateproof_test
+
+import (
+	"context"
+	"testing"
+
+	block "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	"github.com/stretchr/testify/require"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/builtin"
+	"github.com/filecoin-project/go-state-types/builtin/v19/miner"
+	"github.com/filecoin-project/go-state-types/builtin/v19/util/adt"
+	"github.com/filecoin-project/go-state-types/builtin/v19/verifreg"
+	"github.com/filecoin-project/go-state-types/manifest"
+	"github.com/filecoin-project/go-state-types/stateproof"
+	"github.com/filecoin-project/go-state-types/test_util"
+	"github.com/filecoin-project/go-state-types/test_util/synth"
+)
+
+func TestProveAndVerify(t *testing.T) {
+	ctx := context.Background()
+	bs := test_util.NewSyncBlockStoreInMemory()
+	cfg := synth.DefaultConfig()
+	res, err := synth.Generate(ctx, cbor.NewCborStore(bs), cfg)
+	require.NoError(t, err)
+	store := adt.WrapStore(ctx, cbor.NewCborStore(bs))
+	root := headerRoot(ctx, t, cbor.NewCborStore(bs), res.StateRoot)
+
+	// Pick a miner and one of its sectors.
+	tree, err := builtin.LoadTree(store, res.StateRoot)
+	require.NoError(t, err)
+	var minerAddr address.Address
+	require.NoError(t, tree.ForEachV5(func(addr address.Address, act *builtin.ActorV5) error {
+		if act.Code == res.ActorCodes[manifest.MinerKey] && minerAddr == address.Undef {
+			minerAddr = addr
+		}
+		return nil
+	}))
+	act, found, err := tree.GetActorV5(minerAddr)
+	require.NoError(t, err)
+	require.True(t, found)
+	var st miner.State
+	require.NoError(t, store.Get(ctx, act.Head, &st))
+	sectors, err := miner.LoadSectors(store, st.Sectors)
+	require.NoError(t, err)
+	var expected miner.SectorOnChainInfo
+	var sectorNumber abi.SectorNumber
+	require.NoError(t, sectors.ForEach(&expected, func(i int64) error {
+		sectorNumber = abi.SectorNumber(i)
+		return nil
+	}))
+	require.NotZero(t, sectorNumber)
+
+	sectorPath := func(n abi.SectorNumber) stateproof.Path {
+		return stateproof.Path{
+			Actor: minerAddr,
+			State: (*miner.State)(nil),
+			Field: "Sectors",
+			Steps: []stateproof.Step{stateproof.AMTIndex(miner.SectorsAmtBitwidth, uint64(n))},
+		}
+	}
+	proof, proved, err := stateproof.Prove(ctx, bs, root, sectorPath(sectorNumber))
+	require.NoError(t, err)
+	require.True(t, proved.Found)
+	require.Equal(t, root, proof.Blocks[0].Cid())
+
+	verified, err := stateproof.Verify(ctx, root, proof, sectorPath(sectorNumber))
+	require.NoError(t, err)
+	require.True(t, verified.Found)
+	require.Equal(t, act.Head, verified.Actor.Head)
+	var info miner.SectorOnChainInfo
+	require.NoError(t, verified.Decode(&info))
+	require.Equal(t, expected, info)
+
+	// The absence of a sector is proven too.
+	absent, _, err := stateproof.Prove(ctx, bs, root, sectorPath(1<<40))
+	require.NoError(t, err)
+	verified, err = stateproof.Verify(ctx, root, absent, sectorPath(1<<40))
+	require.NoError(t, err)
+	require.False(t, verified.Found)
+	require.NotNil(t, verified.Actor)
+
+	// A proof for another state root is rejected, however consistent its blocks.
+	otherCfg := synth.DefaultConfig()
+	otherCfg.Seed = cfg.Seed + 1
+	other, err := synth.Generate(ctx, cbor.NewCborStore(bs), otherCfg)
+	require.NoError(t, err)
+	require.NotEqual(t, res.StateRoot, other.StateRoot)
+	otherRoot := headerRoot(ctx, t, cbor.NewCborStore(bs), other.StateRoot)
+	forgedProof, _, err := stateproof.Prove(ctx, bs, otherRoot, sectorPath(sectorNumber))
+	require.NoError(t, err)
+	_, err = stateproof.Verify(ctx, root, forgedProof, sectorPath(sectorNumber))
+	require.ErrorContains(t, err, "proof is for state root")
+	forgedProof.Root = root
+	_, err = stateproof.Verify(ctx, root, forgedProof, sectorPath(sectorNumber))
+	require.ErrorContains(t, err, "missing block")
+
+	// The root of the actors is not a header state root.
+	_, _, err = stateproof.Prove(ctx, bs, res.StateRoot, sectorPath(sectorNumber))
+	require.ErrorContains(t, err, "failed to load state root")
+
+	// A proof fails without a block on the path or with altered data.
+	missing := &stateproof.Proof{Root: proof.Root, Blocks: proof.Blocks[:len(proof.Blocks)-1]}
+	_, err = stateproof.Verify(ctx, root, missing, sectorPath(sectorNumber))
+	require.ErrorContains(t, err, "missing block")
+	last := proof.Blocks[len(proof.Blocks)-1]
+	altered := append([]byte{}, last.RawData()...)
+	altered[len(altered)-1] ^= 1
+	forged, err := block.NewBlockWithCid(altered, last.Cid())
+	require.NoError(t, err)
+	tampered := &stateproof.Proof{Root: proof.Root, Blocks: append(append([]block.Block{}, proof.Blocks[:len(proof.Blocks)-1]...), forged)}
+	_, err = stateproof.Verify(ctx, root, tampered, sectorPath(sectorNumber))
+	require.ErrorContains(t, err, "does not match")
+
+	// A claim is found through the verified registry's HAMT of claims by provider.
+	act, found, err = tree.GetActorV5(builtin.VerifiedRegistryActorAddr)
+	require.NoError(t, err)
+	require.True(t, found)
+	var vst verifreg.State
+	require.NoError(t, store.Get(ctx, act.Head, &vst))
+	claims, err := vst.LoadClaimsToMap(store, minerAddr)
+	require.NoError(t, err)
+	require.NotEmpty(t, claims)
+	for id, claim := range claims {
+		path := stateproof.Path{
+			Actor: builtin.VerifiedRegistryActorAddr,
+			State: (*verifreg.State)(nil),
+			Field: "Claims",
+			Steps: []stateproof.Step{
+				stateproof.HAMTKey(builtin.DefaultHamtBitwidth, abi.IdAddrKey(minerAddr)),
+				stateproof.HAMTKey(builtin.DefaultHamtBitwidth, abi.UIntKey(uint64(id))),
+			},
+		}
+		proof, _, err := stateproof.Prove(ctx, bs, root, path)
+		require.NoError(t, err)
+		verified, err := stateproof.Verify(ctx, root, proof, path)
+		require.NoError(t, err)
+		var decoded verifreg.Claim
+		require.NoError(t, verified.Decode(&decoded))
+		require.Equal(t, claim, decoded)
+		break
+	}
+}
+
+// headerRoot writes the state root a block header references for the given root of actors: the
+// wrapper of the state tree's version, its actors and an empty state info.
+func headerRoot(ctx context.Context, t *testing.T, ipld cbor.IpldStore, actors cid.Cid) cid.Cid {
+	info, err := ipld.Put(ctx, &cbg.Deferred{Raw: []byte{0x80}})
+	require.NoError(t, err)
+	root, err := ipld.Put(ctx, &builtin.StateRoot{Version: 5, Actors: actors, Info: info})
+	require.NoError(t, err)
+	return root
+}
@@ -0,0 +1,192 @@
+package store
+
+import (
+	"container/list"
+	"context"
+	"reflect"
+	"sync"
+
+	block "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	ipldcbor "github.com/ipfs/go-ipld-cbor"
+	cbg "github.com/whyrusleeping/cbor-gen"
+)
+
+// The wrappers here compose by nesting, blockstores first, e.g. for a metered store caching blocks
+// and decoded state:
+//
+//	bs := NewCachedBlockstore(NewMeteredBlockstore(raw, blockMetrics), 256<<20)
+//	s := NewMeteredStore(NewDecodeCache(WrapBlockStore(ctx, bs), 100_000), storeMetrics)
+//
+// As every actors version's adt.Store has the methods of Store, the result backs the ADTs and state
+// of any version.
+
+// CacheStats describes the use of a cache.
+type CacheStats struct {
+	Hits   uint64
+	Misses uint64
+	// Number of entries held.
+	Entries int
+	// Total size of the entries held, in the unit of the cache's capacity.
+	Size int
+}
+
+// lru holds values up to a total size, evicting the least recently used first.
+type lru[K comparable, V any] struct {
+	lk      sync.Mutex
+	maxSize int
+	size    int
+	order   *list.List
+	items   map[K]*list.Element
+	hits    uint64
+	misses  uint64
+}
+
+type lruEntry[K comparable, V any] struct {
+	key   K
+	value V
+	size  int
+}
+
+func newLRU[K comparable, V any](maxSize int) *lru[K, V] {
+	return &lru[K, V]{maxSize: maxSize, order: list.New(), items: make(map[K]*list.Element)}
+}
+
+func (c *lru[K, V]) get(key K) (V, bool) {
+	c.lk.Lock()
+	defer c.lk.Unlock()
+	e, ok := c.items[key]
+	if !ok {
+		c.misses++
+		var zero V
+		return zero, false
+	}
+	c.hits++
+	c.order.MoveToFront(e)
+	return e.Value.(*lruEntry[K, V]).value, true
+}
+
+func (c *lru[K, V]) add(key K, value V, size int) {
+	if size > c.maxSize {
+		return
+	}
+	c.lk.Lock()
+	defer c.lk.Unlock()
+	if e, ok := c.items[key]; ok {
+		c.order.MoveToFront(e)
+		return
+	}
+	c.items[key] = c.order.PushFront(&lruEntry[K, V]{key: key, value: value, size: size})
+	c.size += size
+	for c.size > c.maxSize {
+		oldest := c.order.Remove(c.order.Back()).(*lruEntry[K, V])
+		delete(c.items, oldest.key)
+		c.size -= oldest.size
+	}
+}
+
+func (c *lru[K, V]) stats() CacheStats {
+	c.lk.Lock()
+	defer c.lk.Unlock()
+	return CacheStats{Hits: c.hits, Misses: c.misses, Entries: len(c.items), Size: c.size}
+}
+
+// CachedBlockstore keeps the most recently used blocks of a blockstore in memory, up to a total size
+// of block data. Blocks written through it are cached too, as they are often read back soon after.
+type CachedBlockstore struct {
+	bs    ipldcbor.IpldBlockstore
+	cache *lru[cid.Cid, block.Block]
+}
+
+var _ ipldcbor.IpldBlockstore = (*CachedBlockstore)(nil)
+
+// NewCachedBlockstore caches up to maxBytes of block data from bs.
+func NewCachedBlockstore(bs ipldcbor.IpldBlockstore, maxBytes int) *CachedBlockstore {
+	return &CachedBlockstore{bs: bs, cache: newLRU[cid.Cid, block.Block](maxBytes)}
+}
+
+func (c *CachedBlockstore) Get(ctx context.Context, k cid.Cid) (block.Block, error) {
+	if b, ok := c.cache.get(k); ok {
+		return b, nil
+	}
+	b, err := c.bs.Get(ctx, k)
+	if err != nil {
+		return nil, err
+	}
+	c.cache.add(k, b, len(b.RawData()))
+	return b, nil
+}
+
+func (c *CachedBlockstore) Put(ctx context.Context, b block.Block) error {
+	if err := c.bs.Put(ctx, b); err != nil {
+		return err
+	}
+	c.cache.add(b.Cid(), b, len(b.RawData()))
+	return nil
+}
+
+// Stats describes the use of the cache, with sizes in bytes.
+func (c *CachedBlockstore) Stats() CacheStats {
+	return c.cache.stats()
+}
+
+// DecodeCache keeps the most recently read values of a store in decoded form, by CID and type, so
+// that reading the same object again, such as the root of a HAMT loaded by many lookups, skips
+// decoding it.
+//
+// Values are deep-copied into and out of the cache, so that callers may modify what they read, as
+// actors and the HAMT and AMT do. Only cbor-gen types that can be copied so are cached: those built
+// of basic values, slices, arrays, maps, pointers and structs with exported fields, along with
+// big integers, bitfields, CIDs and addresses. Other types, such as HAMT nodes, are read through.
+type DecodeCache struct {
+	s     Store
+	cache *lru[decodeKey, reflect.Value]
+}
+
+type decodeKey struct {
+	c   cid.Cid
+	typ reflect.Type
+}
+
+var _ Store = (*DecodeCache)(nil)
+
+// NewDecodeCache caches up to the given number of values decoded from s.
+func NewDecodeCache(s Store, entries int) *DecodeCache {
+	return &DecodeCache{s: s, cache: newLRU[decodeKey, reflect.Value](entries)}
+}
+
+func (d *DecodeCache) Context() context.Context {
+	return d.s.Context()
+}
+
+func (d *DecodeCache) Get(ctx context.Context, c cid.Cid, out interface{}) error {
+	if _, ok := out.(cbg.CBORUnmarshaler); !ok {
+		return d.s.Get(ctx, c, out)
+	}
+	dst := reflect.ValueOf(out)
+	if dst.Kind() != reflect.Ptr || dst.IsNil() || !copyable(dst.Type().Elem()) {
+		return d.s.Get(ctx, c, out)
+	}
+	key := decodeKey{c: c, typ: dst.Type()}
+	if v, ok := d.cache.get(key); ok {
+		return deepCopy(dst.Elem(), v)
+	}
+	if err := d.s.Get(ctx, c, out); err != nil {
+		return err
+	}
+	v := reflect.New(dst.Type().Elem()).Elem()
+	if err := deepCopy(v, dst.Elem()); err != nil {
+		return err
+	}
+	d.cache.add(key, v, 1)
+	return nil
+}
+
+func (d *DecodeCache) Put(ctx context.Context, v interface{}) (cid.Cid, error) {
+	return d.s.Put(ctx, v)
+}
+
+// Stats describes the use of the cache, with sizes in entries.
+func (d *DecodeCache) Stats() CacheStats {
+	return d.cache.stats()
+}
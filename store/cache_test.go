@@ -0,0 +1,177 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cbor "github.com/ipfs/go-ipld-cbor"
+	mh "github.com/multiformats/go-multihash"
+	"github.com/stretchr/testify/require"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/builtin"
+	v19 "github.com/filecoin-project/go-state-types/builtin/v19"
+	"github.com/filecoin-project/go-state-types/builtin/v19/miner"
+	"github.com/filecoin-project/go-state-types/builtin/v19/power"
+	"github.com/filecoin-project/go-state-types/builtin/v19/util/adt"
+	"github.com/filecoin-project/go-state-types/store"
+	"github.com/filecoin-project/go-state-types/test_util"
+	"github.com/filecoin-project/go-state-types/test_util/synth"
+)
+
+func TestCachedStore(t *testing.T) {
+	ctx := context.Background()
+	raw := test_util.NewSyncBlockStoreInMemory()
+	cfg := synth.DefaultConfig()
+	cfg.SectorsPerMiner = 50
+	res, err := synth.Generate(ctx, cbor.NewCborStore(raw), cfg)
+	require.NoError(t, err)
+
+	var blockMetrics, storeMetrics store.Metrics
+	blocks := store.NewCachedBlockstore(store.NewMeteredBlockstore(raw, &blockMetrics), 64<<20)
+	decoded := store.NewDecodeCache(store.WrapBlockStore(ctx, blocks), 100_000)
+	recording := store.NewRecordingStore(decoded)
+	s := store.NewMeteredStore(recording, &storeMetrics)
+
+	check := func() {
+		tree, err := builtin.LoadTree(s, res.StateRoot)
+		require.NoError(t, err)
+		acc, err := v19.CheckStateInvariants(tree, cfg.PriorEpoch, res.ActorCodes)
+		require.NoError(t, err)
+		require.True(t, acc.IsEmpty(), acc.Messages())
+	}
+	check()
+	firstReads := blockMetrics.Snapshot().Reads
+	require.NotZero(t, firstReads.Count)
+	require.NotZero(t, firstReads.Bytes)
+	read := recording.Read()
+	require.NotEmpty(t, read)
+	for _, c := range read {
+		_, err := raw.Get(ctx, c)
+		require.NoError(t, err)
+	}
+
+	// A second pass is served from the caches.
+	check()
+	require.Equal(t, firstReads.Count, blockMetrics.Snapshot().Reads.Count)
+	require.NotZero(t, decoded.Stats().Hits)
+	require.Equal(t, read, recording.Read())
+	reads := storeMetrics.Snapshot().Reads
+	require.NotZero(t, reads.Count)
+	require.Zero(t, reads.Errors)
+	var histogram uint64
+	for _, n := range reads.Latency {
+		histogram += n
+	}
+	require.Equal(t, reads.Count, histogram)
+	require.LessOrEqual(t, reads.LatencyQuantile(0.5), reads.LatencyQuantile(0.99))
+
+	// Cached values are copies: changing one does not change the next read.
+	tree, err := builtin.LoadTree(s, res.StateRoot)
+	require.NoError(t, err)
+	act, found, err := tree.GetActorV5(builtin.StoragePowerActorAddr)
+	require.NoError(t, err)
+	require.True(t, found)
+	var st, again power.State
+	require.NoError(t, s.Get(ctx, act.Head, &st))
+	expected := st.MinerCount
+	st.MinerCount++
+	require.NoError(t, s.Get(ctx, act.Head, &again))
+	require.Equal(t, expected, again.MinerCount)
+
+	// Writes are recorded and counted.
+	c, err := s.Put(ctx, &st)
+	require.NoError(t, err)
+	require.Contains(t, recording.Written(), c)
+	require.Equal(t, uint64(1), storeMetrics.Snapshot().Writes.Count)
+	recording.Reset()
+	require.Empty(t, recording.Read())
+	require.Empty(t, recording.Written())
+}
+
+func TestDecodeCacheCopies(t *testing.T) {
+	ctx := context.Background()
+	s := store.NewDecodeCache(store.WrapBlockStore(ctx, test_util.NewSyncBlockStoreInMemory()), 1000)
+
+	// Updating a HAMT loaded through the cache leaves other loads of its root intact.
+	m, err := adt.MakeEmptyMap(s, builtin.DefaultHamtBitwidth)
+	require.NoError(t, err)
+	for i := int64(0); i < 100; i++ {
+		v := cbg.CborInt(i)
+		require.NoError(t, m.Put(abi.IntKey(i), &v))
+	}
+	root, err := m.Root()
+	require.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		loaded, err := adt.AsMap(s, root, builtin.DefaultHamtBitwidth)
+		require.NoError(t, err)
+		v := cbg.CborInt(-1)
+		require.NoError(t, loaded.Put(abi.IntKey(1000+int64(i)), &v))
+		_, err = loaded.Root()
+		require.NoError(t, err)
+	}
+	loaded, err := adt.AsMap(s, root, builtin.DefaultHamtBitwidth)
+	require.NoError(t, err)
+	var v cbg.CborInt
+	count := 0
+	require.NoError(t, loaded.ForEach(&v, func(string) error {
+		count++
+		return nil
+	}))
+	require.Equal(t, 100, count)
+
+	// So does modifying the big integers and bitfields of a state in place.
+	dl, err := miner.ConstructDeadline(s)
+	require.NoError(t, err)
+	dl.FaultyPower = miner.NewPowerPair(abi.NewStoragePower(10), abi.NewStoragePower(20))
+	c, err := s.Put(ctx, dl)
+	require.NoError(t, err)
+	var modified, read miner.Deadline
+	require.NoError(t, s.Get(ctx, c, &modified))
+	modified.PartitionsPoSted.Set(3)
+	modified.FaultyPower.Raw.Int.SetInt64(7)
+	require.NoError(t, s.Get(ctx, c, &read))
+	posted, err := read.PartitionsPoSted.IsSet(3)
+	require.NoError(t, err)
+	require.False(t, posted)
+	require.Equal(t, abi.NewStoragePower(10), read.FaultyPower.Raw)
+	require.NotZero(t, s.Stats().Hits)
+}
+
+func TestCachedBlockstoreEviction(t *testing.T) {
+	ctx := context.Background()
+	raw := test_util.NewSyncBlockStoreInMemory()
+	var metrics store.Metrics
+	blocks := store.NewCachedBlockstore(store.NewMeteredBlockstore(raw, &metrics), 100)
+
+	// Each block is over a third of the cache, so it holds two.
+	value := func(i byte) *cbor.Node {
+		n, err := cbor.WrapObject(map[string]interface{}{"v": string(make([]byte, 38)) + string(i)}, mh.SHA2_256, -1)
+		require.NoError(t, err)
+		return n
+	}
+	var blocksPut []*cbor.Node
+	for i := byte(0); i < 3; i++ {
+		n := value(i)
+		require.NoError(t, blocks.Put(ctx, n))
+		blocksPut = append(blocksPut, n)
+	}
+	stats := blocks.Stats()
+	require.Equal(t, 2, stats.Entries)
+	require.Equal(t, 2*len(blocksPut[0].RawData()), stats.Size)
+
+	// The oldest block was evicted and is read through.
+	_, err := blocks.Get(ctx, blocksPut[2].Cid())
+	require.NoError(t, err)
+	require.Zero(t, metrics.Snapshot().Reads.Count)
+	_, err = blocks.Get(ctx, blocksPut[0].Cid())
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), metrics.Snapshot().Reads.Count)
+	require.Equal(t, uint64(1), blocks.Stats().Hits)
+	require.Equal(t, uint64(1), blocks.Stats().Misses)
+
+	require.Equal(t, time.Microsecond, store.LatencyBound(0))
+	require.Equal(t, 2*time.Microsecond, store.LatencyBound(1))
+}
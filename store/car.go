@@ -0,0 +1,392 @@
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+
+	block "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	ipldcbor "github.com/ipfs/go-ipld-cbor"
+	mh "github.com/multiformats/go-multihash"
+	cbg "github.com/whyrusleeping/cbor-gen"
+	"golang.org/x/xerrors"
+)
+
+// CARs here are version 1 CAR files: a dag-cbor header listing the roots, followed by each block as
+// a varint length and its CID and data.
+
+// Sections larger than this are rejected when reading a CAR, rather than allocated.
+const maxCARSection = 32 << 20
+
+// CAROption configures the export of a CAR.
+type CAROption func(*carConfig)
+
+type carConfig struct {
+	exclude []func(cid.Cid) bool
+}
+
+// ExcludeCids leaves blocks out of an export, along with the blocks only reachable through them,
+// e.g. the bytecode of an EVM actor.
+func ExcludeCids(cids ...cid.Cid) CAROption {
+	set := make(map[cid.Cid]struct{}, len(cids))
+	for _, c := range cids {
+		set[c] = struct{}{}
+	}
+	return ExcludeFunc(func(c cid.Cid) bool {
+		_, ok := set[c]
+		return ok
+	})
+}
+
+// ExcludeFunc leaves the blocks for which a function returns true out of an export, along with the
+// blocks only reachable through them.
+func ExcludeFunc(exclude func(cid.Cid) bool) CAROption {
+	return func(cfg *carConfig) {
+		cfg.exclude = append(cfg.exclude, exclude)
+	}
+}
+
+// ExportCAR writes a CAR of the DAGs with the given roots, as written by CARWriter.Walk.
+func ExportCAR(ctx context.Context, bs ipldcbor.IpldBlockstore, w io.Writer, roots []cid.Cid, opts ...CAROption) error {
+	cw, err := NewCARWriter(ctx, bs, w, roots, opts...)
+	if err != nil {
+		return err
+	}
+	for _, root := range roots {
+		if err := cw.Walk(root); err != nil {
+			return err
+		}
+	}
+	return cw.Flush()
+}
+
+// CARWriter writes a CAR from blocks and DAGs in a blockstore, each block once.
+type CARWriter struct {
+	ctx  context.Context
+	bs   ipldcbor.IpldBlockstore
+	w    *bufio.Writer
+	cfg  carConfig
+	seen map[cid.Cid]struct{}
+}
+
+// NewCARWriter writes the header of a CAR with the given roots, ready for its blocks.
+func NewCARWriter(ctx context.Context, bs ipldcbor.IpldBlockstore, w io.Writer, roots []cid.Cid, opts ...CAROption) (*CARWriter, error) {
+	cw := &CARWriter{ctx: ctx, bs: bs, w: bufio.NewWriter(w), seen: make(map[cid.Cid]struct{})}
+	for _, opt := range opts {
+		opt(&cw.cfg)
+	}
+	var header bytes.Buffer
+	if err := writeCARHeader(&header, roots); err != nil {
+		return nil, err
+	}
+	if err := cw.section(header.Bytes()); err != nil {
+		return nil, err
+	}
+	return cw, nil
+}
+
+func (cw *CARWriter) excluded(c cid.Cid) bool {
+	for _, exclude := range cw.cfg.exclude {
+		if exclude(c) {
+			return true
+		}
+	}
+	return false
+}
+
+// Walk writes the blocks of the DAG with a root not written yet, in depth-first order of the links,
+// so that the same DAG always yields the same CAR.
+// Links to dag-cbor blocks are followed, and raw blocks, such as EVM bytecode, are written as leaves.
+// Identity CIDs, whose data is inline, are not written. Actor code is raw too, and is written unless
+// excluded; stateproof.ExportState excludes it from state trees.
+func (cw *CARWriter) Walk(root cid.Cid) error {
+	stack := []cid.Cid{root}
+	for len(stack) > 0 {
+		c := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if _, ok := cw.seen[c]; ok || c.Prefix().MhType == mh.IDENTITY || cw.excluded(c) {
+			continue
+		}
+		b, err := cw.bs.Get(cw.ctx, c)
+		if err != nil {
+			return xerrors.Errorf("failed to get block %s: %w", c, err)
+		}
+		if err := cw.Put(b); err != nil {
+			return err
+		}
+		if c.Prefix().Codec == cid.Raw {
+			continue
+		}
+		var links []cid.Cid
+		if err := cbg.ScanForLinks(bytes.NewReader(b.RawData()), func(link cid.Cid) {
+			if codec := link.Prefix().Codec; codec == cid.DagCBOR || codec == cid.Raw {
+				links = append(links, link)
+			}
+		}); err != nil {
+			return xerrors.Errorf("failed to scan block %s for links: %w", c, err)
+		}
+		// Push in reverse so that links are visited in order.
+		for i := len(links) - 1; i >= 0; i-- {
+			stack = append(stack, links[i])
+		}
+	}
+	return nil
+}
+
+// Put writes a block, unless it has been written already. Exclusions do not apply.
+func (cw *CARWriter) Put(b block.Block) error {
+	if _, ok := cw.seen[b.Cid()]; ok {
+		return nil
+	}
+	cw.seen[b.Cid()] = struct{}{}
+	return cw.section(b.Cid().Bytes(), b.RawData())
+}
+
+// Flush writes any buffered data to the underlying writer.
+func (cw *CARWriter) Flush() error {
+	return cw.w.Flush()
+}
+
+func (cw *CARWriter) section(parts ...[]byte) error {
+	n := 0
+	for _, p := range parts {
+		n += len(p)
+	}
+	if _, err := cw.w.Write(binary.AppendUvarint(nil, uint64(n))); err != nil {
+		return err
+	}
+	for _, p := range parts {
+		if _, err := cw.w.Write(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeCARHeader(w io.Writer, roots []cid.Cid) error {
+	cw := cbg.NewCborWriter(w)
+	if err := cw.WriteMajorTypeHeader(cbg.MajMap, 2); err != nil {
+		return err
+	}
+	if err := writeCARHeaderKey(cw, "roots"); err != nil {
+		return err
+	}
+	if err := cw.WriteMajorTypeHeader(cbg.MajArray, uint64(len(roots))); err != nil {
+		return err
+	}
+	for _, root := range roots {
+		if err := cbg.WriteCid(cw, root); err != nil {
+			return err
+		}
+	}
+	if err := writeCARHeaderKey(cw, "version"); err != nil {
+		return err
+	}
+	return cw.WriteMajorTypeHeader(cbg.MajUnsignedInt, 1)
+}
+
+func writeCARHeaderKey(cw *cbg.CborWriter, key string) error {
+	if err := cw.WriteMajorTypeHeader(cbg.MajTextString, uint64(len(key))); err != nil {
+		return err
+	}
+	_, err := cw.WriteString(key)
+	return err
+}
+
+func readCARHeader(r io.Reader) ([]cid.Cid, error) {
+	cr := cbg.NewCborReader(r)
+	maj, n, err := cr.ReadHeader()
+	if err != nil {
+		return nil, err
+	}
+	if maj != cbg.MajMap {
+		return nil, xerrors.Errorf("CAR header is not a map")
+	}
+	var roots []cid.Cid
+	var version uint64
+	for i := uint64(0); i < n; i++ {
+		key, err := cbg.ReadStringWithMax(cr, 16)
+		if err != nil {
+			return nil, err
+		}
+		switch key {
+		case "roots":
+			maj, count, err := cr.ReadHeader()
+			if err != nil {
+				return nil, err
+			}
+			if maj != cbg.MajArray || count > cbg.MaxLength {
+				return nil, xerrors.Errorf("CAR roots are not a list")
+			}
+			for j := uint64(0); j < count; j++ {
+				root, err := cbg.ReadCid(cr)
+				if err != nil {
+					return nil, xerrors.Errorf("invalid CAR root: %w", err)
+				}
+				roots = append(roots, root)
+			}
+		case "version":
+			maj, v, err := cr.ReadHeader()
+			if err != nil {
+				return nil, err
+			}
+			if maj != cbg.MajUnsignedInt {
+				return nil, xerrors.Errorf("CAR version is not an integer")
+			}
+			version = v
+		default:
+			return nil, xerrors.Errorf("unexpected CAR header field %q", key)
+		}
+	}
+	if version != 1 {
+		return nil, xerrors.Errorf("unsupported CAR version %d", version)
+	}
+	return roots, nil
+}
+
+// carReader reads the sections of a CAR.
+type carReader struct {
+	r      *bufio.Reader
+	offset int64
+}
+
+// next returns the next section and its offset, or io.EOF after the last.
+func (cr *carReader) next() ([]byte, int64, error) {
+	n, err := binary.ReadUvarint(cr.r)
+	if err != nil {
+		if err == io.EOF {
+			return nil, 0, io.EOF
+		}
+		return nil, 0, xerrors.Errorf("invalid CAR section length: %w", err)
+	}
+	if n > maxCARSection {
+		return nil, 0, xerrors.Errorf("CAR section of %d bytes is too large", n)
+	}
+	section := make([]byte, n)
+	if _, err := io.ReadFull(cr.r, section); err != nil {
+		return nil, 0, xerrors.Errorf("truncated CAR section: %w", err)
+	}
+	offset := cr.offset + int64(len(binary.AppendUvarint(nil, n)))
+	cr.offset = offset + int64(n)
+	return section, offset, nil
+}
+
+// readCARBlock parses a section holding a block and checks that the data matches the CID.
+func readCARBlock(section []byte) (block.Block, error) {
+	n, c, err := cid.CidFromBytes(section)
+	if err != nil {
+		return nil, xerrors.Errorf("invalid CID in CAR section: %w", err)
+	}
+	data := section[n:]
+	sum, err := c.Prefix().Sum(data)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to hash block %s: %w", c, err)
+	}
+	if !sum.Equals(c) {
+		return nil, xerrors.Errorf("block data does not match CID %s", c)
+	}
+	return block.NewBlockWithCid(data, c)
+}
+
+// ImportCAR reads the blocks of a CAR into a blockstore as they are read, checking each against its
+// CID, and returns the roots of the CAR.
+func ImportCAR(ctx context.Context, r io.Reader, bs ipldcbor.IpldBlockstore) ([]cid.Cid, error) {
+	cr := &carReader{r: bufio.NewReader(r)}
+	header, _, err := cr.next()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to read CAR header: %w", err)
+	}
+	roots, err := readCARHeader(bytes.NewReader(header))
+	if err != nil {
+		return nil, xerrors.Errorf("invalid CAR header: %w", err)
+	}
+	for {
+		section, _, err := cr.next()
+		if err == io.EOF {
+			return roots, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		b, err := readCARBlock(section)
+		if err != nil {
+			return nil, err
+		}
+		if err := bs.Put(ctx, b); err != nil {
+			return nil, xerrors.Errorf("failed to put block %s: %w", b.Cid(), err)
+		}
+	}
+}
+
+// ReadOnlyStore is a blockstore backed by a CAR file, which it indexes when opened and then reads
+// blocks from as they are requested.
+type ReadOnlyStore struct {
+	r     io.ReaderAt
+	roots []cid.Cid
+	index map[cid.Cid]carSection
+}
+
+type carSection struct {
+	offset int64
+	length int
+}
+
+var _ ipldcbor.IpldBlockstore = (*ReadOnlyStore)(nil)
+
+// NewReadOnlyStore indexes the CAR read by r, which must remain readable while the store is used.
+func NewReadOnlyStore(r io.ReaderAt) (*ReadOnlyStore, error) {
+	cr := &carReader{r: bufio.NewReader(io.NewSectionReader(r, 0, 1<<62))}
+	header, _, err := cr.next()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to read CAR header: %w", err)
+	}
+	roots, err := readCARHeader(bytes.NewReader(header))
+	if err != nil {
+		return nil, xerrors.Errorf("invalid CAR header: %w", err)
+	}
+	s := &ReadOnlyStore{r: r, roots: roots, index: make(map[cid.Cid]carSection)}
+	for {
+		section, offset, err := cr.next()
+		if err == io.EOF {
+			return s, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		_, c, err := cid.CidFromBytes(section)
+		if err != nil {
+			return nil, xerrors.Errorf("invalid CID in CAR section: %w", err)
+		}
+		s.index[c] = carSection{offset: offset, length: len(section)}
+	}
+}
+
+// Roots returns the roots of the CAR.
+func (s *ReadOnlyStore) Roots() []cid.Cid {
+	return s.roots
+}
+
+// Has reports whether the CAR holds a block.
+func (s *ReadOnlyStore) Has(c cid.Cid) bool {
+	_, ok := s.index[c]
+	return ok
+}
+
+func (s *ReadOnlyStore) Get(_ context.Context, c cid.Cid) (block.Block, error) {
+	sec, ok := s.index[c]
+	if !ok {
+		return nil, xerrors.Errorf("block %s not found in CAR", c)
+	}
+	section := make([]byte, sec.length)
+	if _, err := s.r.ReadAt(section, sec.offset); err != nil {
+		return nil, xerrors.Errorf("failed to read block %s: %w", c, err)
+	}
+	return readCARBlock(section)
+}
+
+func (s *ReadOnlyStore) Put(context.Context, block.Block) error {
+	return xerrors.Errorf("CAR store is read-only")
+}
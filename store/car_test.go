@@ -0,0 +1,72 @@
+package store_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/builtin"
+	v19 "github.com/filecoin-project/go-state-types/builtin/v19"
+	"github.com/filecoin-project/go-state-types/store"
+	"github.com/filecoin-project/go-state-types/test_util"
+	"github.com/filecoin-project/go-state-types/test_util/synth"
+)
+
+func TestCARRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	bs := test_util.NewSyncBlockStoreInMemory()
+	cfg := synth.DefaultConfig()
+	cfg.SectorsPerMiner = 50
+	res, err := synth.Generate(ctx, cbor.NewCborStore(bs), cfg)
+	require.NoError(t, err)
+	roots := []cid.Cid{res.StateRoot}
+
+	var car bytes.Buffer
+	require.NoError(t, store.ExportCAR(ctx, bs, &car, roots))
+	var again bytes.Buffer
+	require.NoError(t, store.ExportCAR(ctx, bs, &again, roots))
+	require.Equal(t, car.Bytes(), again.Bytes(), "exports are reproducible")
+
+	// The imported state is complete.
+	imported := test_util.NewSyncBlockStoreInMemory()
+	importedRoots, err := store.ImportCAR(ctx, bytes.NewReader(car.Bytes()), imported)
+	require.NoError(t, err)
+	require.Equal(t, roots, importedRoots)
+	tree, err := builtin.LoadTree(store.WrapBlockStore(ctx, imported), res.StateRoot)
+	require.NoError(t, err)
+	acc, err := v19.CheckStateInvariants(tree, cfg.PriorEpoch, res.ActorCodes)
+	require.NoError(t, err)
+	require.True(t, acc.IsEmpty(), acc.Messages())
+
+	// So is the state read from the CAR in place.
+	ros, err := store.NewReadOnlyStore(bytes.NewReader(car.Bytes()))
+	require.NoError(t, err)
+	require.Equal(t, roots, ros.Roots())
+	tree, err = builtin.LoadTree(store.WrapBlockStore(ctx, ros), res.StateRoot)
+	require.NoError(t, err)
+	acc, err = v19.CheckStateInvariants(tree, cfg.PriorEpoch, res.ActorCodes)
+	require.NoError(t, err)
+	require.True(t, acc.IsEmpty(), acc.Messages())
+	block, err := ros.Get(ctx, res.StateRoot)
+	require.NoError(t, err)
+	require.Error(t, ros.Put(ctx, block))
+
+	// Excluding the root of the state tree leaves an empty CAR.
+	var excluded bytes.Buffer
+	require.NoError(t, store.ExportCAR(ctx, bs, &excluded, roots, store.ExcludeCids(res.StateRoot)))
+	ros, err = store.NewReadOnlyStore(bytes.NewReader(excluded.Bytes()))
+	require.NoError(t, err)
+	require.False(t, ros.Has(res.StateRoot))
+
+	// Altered data is rejected.
+	corrupt := append([]byte{}, car.Bytes()...)
+	corrupt[len(corrupt)-1] ^= 1
+	_, err = store.ImportCAR(ctx, bytes.NewReader(corrupt), test_util.NewSyncBlockStoreInMemory())
+	require.ErrorContains(t, err, "does not match")
+	_, err = store.ImportCAR(ctx, bytes.NewReader(car.Bytes()[:car.Len()-1]), test_util.NewSyncBlockStoreInMemory())
+	require.ErrorContains(t, err, "truncated")
+}
@@ -0,0 +1,168 @@
+package store
+
+import (
+	"math/big"
+	"reflect"
+	"sync"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-bitfield"
+	"github.com/ipfs/go-cid"
+)
+
+var (
+	bigIntType   = reflect.TypeOf(big.Int{})
+	bitFieldType = reflect.TypeOf(bitfield.BitField{})
+	// Types with unexported fields whose values are never modified in place.
+	immutableTypes = map[reflect.Type]bool{
+		reflect.TypeOf(cid.Cid{}):         true,
+		reflect.TypeOf(address.Address{}): true,
+	}
+)
+
+// copyableTypes memoizes copyable.
+var copyableTypes sync.Map
+
+// copyable reports whether deepCopy can copy values of a type.
+func copyable(t reflect.Type) bool {
+	if ok, found := copyableTypes.Load(t); found {
+		return ok.(bool)
+	}
+	ok := isCopyable(t, make(map[reflect.Type]bool))
+	copyableTypes.Store(t, ok)
+	return ok
+}
+
+func isCopyable(t reflect.Type, visiting map[reflect.Type]bool) bool {
+	if t == bigIntType || t == bitFieldType || immutableTypes[t] {
+		return true
+	}
+	// A recursive type is copyable if the rest of it is.
+	if visiting[t] {
+		return true
+	}
+	visiting[t] = true
+	if plain(t) {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		return isCopyable(t.Elem(), visiting)
+	case reflect.Map:
+		return isCopyable(t.Key(), visiting) && isCopyable(t.Elem(), visiting)
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() || !isCopyable(f.Type, visiting) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// plain reports whether values of a type are copied by assignment.
+func plain(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128, reflect.String:
+		return true
+	case reflect.Array:
+		return plain(t.Elem())
+	default:
+		return immutableTypes[t]
+	}
+}
+
+// deepCopy sets dst, which must be settable, to a copy of src sharing no memory that may be modified
+// in place. The type must be copyable.
+func deepCopy(dst, src reflect.Value) error {
+	t := src.Type()
+	switch {
+	case t == bigIntType:
+		if !src.CanAddr() {
+			// Map values are not addressable.
+			v := reflect.New(t).Elem()
+			v.Set(src)
+			src = v
+		}
+		dst.Addr().Interface().(*big.Int).Set(src.Addr().Interface().(*big.Int))
+		return nil
+	case t == bitFieldType:
+		bf, err := src.Interface().(bitfield.BitField).Copy()
+		if err != nil {
+			return err
+		}
+		dst.Set(reflect.ValueOf(bf))
+		return nil
+	case immutableTypes[t]:
+		dst.Set(src)
+		return nil
+	}
+	switch t.Kind() {
+	case reflect.Ptr:
+		if src.IsNil() {
+			dst.Set(reflect.Zero(t))
+			return nil
+		}
+		v := reflect.New(t.Elem())
+		if err := deepCopy(v.Elem(), src.Elem()); err != nil {
+			return err
+		}
+		dst.Set(v)
+	case reflect.Slice:
+		if src.IsNil() {
+			dst.Set(reflect.Zero(t))
+			return nil
+		}
+		v := reflect.MakeSlice(t, src.Len(), src.Len())
+		if plain(t.Elem()) {
+			reflect.Copy(v, src)
+			dst.Set(v)
+			return nil
+		}
+		for i := 0; i < src.Len(); i++ {
+			if err := deepCopy(v.Index(i), src.Index(i)); err != nil {
+				return err
+			}
+		}
+		dst.Set(v)
+	case reflect.Array:
+		for i := 0; i < src.Len(); i++ {
+			if err := deepCopy(dst.Index(i), src.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		if src.IsNil() {
+			dst.Set(reflect.Zero(t))
+			return nil
+		}
+		v := reflect.MakeMapWithSize(t, src.Len())
+		iter := src.MapRange()
+		for iter.Next() {
+			k := reflect.New(t.Key()).Elem()
+			if err := deepCopy(k, iter.Key()); err != nil {
+				return err
+			}
+			e := reflect.New(t.Elem()).Elem()
+			if err := deepCopy(e, iter.Value()); err != nil {
+				return err
+			}
+			v.SetMapIndex(k, e)
+		}
+		dst.Set(v)
+	case reflect.Struct:
+		for i := 0; i < src.NumField(); i++ {
+			if err := deepCopy(dst.Field(i), src.Field(i)); err != nil {
+				return err
+			}
+		}
+	default:
+		dst.Set(src)
+	}
+	return nil
+}
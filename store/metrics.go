@@ -0,0 +1,247 @@
+package store
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	block "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	ipldcbor "github.com/ipfs/go-ipld-cbor"
+)
+
+// Latency histograms have buckets with upper bounds doubling from one microsecond, and a last bucket
+// for anything longer.
+const (
+	firstLatencyBound = time.Microsecond
+	latencyBounds     = 24
+)
+
+// Metrics counts the reads and writes of a store or blockstore, and the time they take. Metrics may
+// be shared by several wrappers to count their operations together.
+type Metrics struct {
+	reads, writes opMetrics
+}
+
+type opMetrics struct {
+	count   atomic.Uint64
+	errors  atomic.Uint64
+	bytes   atomic.Uint64
+	total   atomic.Int64
+	buckets [latencyBounds + 1]atomic.Uint64
+}
+
+func (m *opMetrics) observe(start time.Time, size int, err error) {
+	elapsed := time.Since(start)
+	m.count.Add(1)
+	if err != nil {
+		m.errors.Add(1)
+	}
+	m.bytes.Add(uint64(size))
+	m.total.Add(int64(elapsed))
+	i := 0
+	for bound := firstLatencyBound; i < latencyBounds && elapsed > bound; bound *= 2 {
+		i++
+	}
+	m.buckets[i].Add(1)
+}
+
+func (m *opMetrics) snapshot() OpStats {
+	s := OpStats{
+		Count:        m.count.Load(),
+		Errors:       m.errors.Load(),
+		Bytes:        m.bytes.Load(),
+		TotalLatency: time.Duration(m.total.Load()),
+		Latency:      make([]uint64, latencyBounds+1),
+	}
+	for i := range m.buckets {
+		s.Latency[i] = m.buckets[i].Load()
+	}
+	return s
+}
+
+// MetricsSnapshot holds the values of Metrics at one time.
+type MetricsSnapshot struct {
+	Reads  OpStats
+	Writes OpStats
+}
+
+// OpStats describes operations of one kind.
+type OpStats struct {
+	Count  uint64
+	Errors uint64
+	// Size of the blocks read or written, counted by blockstores only.
+	Bytes        uint64
+	TotalLatency time.Duration
+	// Histogram of latencies, counting the operations taking up to LatencyBound(i) in bucket i.
+	Latency []uint64
+}
+
+// LatencyBound returns the upper bound of a bucket of a latency histogram, which is unbounded for the
+// last bucket.
+func LatencyBound(i int) time.Duration {
+	if i >= latencyBounds {
+		return time.Duration(1<<63 - 1)
+	}
+	return firstLatencyBound << i
+}
+
+// LatencyQuantile returns the upper bound of the bucket holding the given quantile of latencies,
+// e.g. 0.99 for the 99th percentile, or zero if there were no operations.
+func (s OpStats) LatencyQuantile(q float64) time.Duration {
+	var total uint64
+	for _, n := range s.Latency {
+		total += n
+	}
+	if total == 0 {
+		return 0
+	}
+	rank := uint64(q * float64(total))
+	var seen uint64
+	for i, n := range s.Latency {
+		seen += n
+		if seen > rank {
+			return LatencyBound(i)
+		}
+	}
+	return LatencyBound(len(s.Latency) - 1)
+}
+
+// Snapshot returns the current values of the metrics.
+func (m *Metrics) Snapshot() MetricsSnapshot {
+	return MetricsSnapshot{Reads: m.reads.snapshot(), Writes: m.writes.snapshot()}
+}
+
+// NewMeteredBlockstore records the reads and writes of bs in m.
+func NewMeteredBlockstore(bs ipldcbor.IpldBlockstore, m *Metrics) ipldcbor.IpldBlockstore {
+	return &meteredBlockstore{bs: bs, m: m}
+}
+
+type meteredBlockstore struct {
+	bs ipldcbor.IpldBlockstore
+	m  *Metrics
+}
+
+func (mb *meteredBlockstore) Get(ctx context.Context, c cid.Cid) (block.Block, error) {
+	start := time.Now()
+	b, err := mb.bs.Get(ctx, c)
+	size := 0
+	if err == nil {
+		size = len(b.RawData())
+	}
+	mb.m.reads.observe(start, size, err)
+	return b, err
+}
+
+func (mb *meteredBlockstore) Put(ctx context.Context, b block.Block) error {
+	start := time.Now()
+	err := mb.bs.Put(ctx, b)
+	mb.m.writes.observe(start, len(b.RawData()), err)
+	return err
+}
+
+// NewMeteredStore records the reads and writes of s in m, including the time taken to decode and
+// encode values.
+func NewMeteredStore(s Store, m *Metrics) Store {
+	return &meteredStore{s: s, m: m}
+}
+
+type meteredStore struct {
+	s Store
+	m *Metrics
+}
+
+func (ms *meteredStore) Context() context.Context {
+	return ms.s.Context()
+}
+
+func (ms *meteredStore) Get(ctx context.Context, c cid.Cid, out interface{}) error {
+	start := time.Now()
+	err := ms.s.Get(ctx, c, out)
+	ms.m.reads.observe(start, 0, err)
+	return err
+}
+
+func (ms *meteredStore) Put(ctx context.Context, v interface{}) (cid.Cid, error) {
+	start := time.Now()
+	c, err := ms.s.Put(ctx, v)
+	ms.m.writes.observe(start, 0, err)
+	return c, err
+}
+
+// RecordingStore records the CIDs read and written through a store, each once in the order first
+// read or written, e.g. to find the state an indexer or invariant check depends on.
+type RecordingStore struct {
+	s       Store
+	lk      sync.Mutex
+	read    recorded
+	written recorded
+}
+
+type recorded struct {
+	seen map[cid.Cid]struct{}
+	cids []cid.Cid
+}
+
+var _ Store = (*RecordingStore)(nil)
+
+// NewRecordingStore records the CIDs accessed through s.
+func NewRecordingStore(s Store) *RecordingStore {
+	return &RecordingStore{s: s}
+}
+
+func (r *RecordingStore) Context() context.Context {
+	return r.s.Context()
+}
+
+func (r *RecordingStore) Get(ctx context.Context, c cid.Cid, out interface{}) error {
+	if err := r.s.Get(ctx, c, out); err != nil {
+		return err
+	}
+	r.record(c, &r.read)
+	return nil
+}
+
+func (r *RecordingStore) Put(ctx context.Context, v interface{}) (cid.Cid, error) {
+	c, err := r.s.Put(ctx, v)
+	if err != nil {
+		return cid.Undef, err
+	}
+	r.record(c, &r.written)
+	return c, nil
+}
+
+func (r *RecordingStore) record(c cid.Cid, to *recorded) {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+	if to.seen == nil {
+		to.seen = make(map[cid.Cid]struct{})
+	}
+	if _, ok := to.seen[c]; !ok {
+		to.seen[c] = struct{}{}
+		to.cids = append(to.cids, c)
+	}
+}
+
+// Read returns the CIDs read.
+func (r *RecordingStore) Read() []cid.Cid {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+	return append([]cid.Cid(nil), r.read.cids...)
+}
+
+// Written returns the CIDs written.
+func (r *RecordingStore) Written() []cid.Cid {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+	return append([]cid.Cid(nil), r.written.cids...)
+}
+
+// Reset forgets the CIDs recorded so far.
+func (r *RecordingStore) Reset() {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+	r.read = recorded{}
+	r.written = recorded{}
+}
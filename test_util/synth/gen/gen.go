@@ -0,0 +1,59 @@
+// Command gen writes the per-version state builders of the synth package from the templates in
+// this directory:
+//
+//	go run ./test_util/synth/gen/gen.go
+package main
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"text/template"
+)
+
+const (
+	outputDir    = "./test_util/synth"
+	firstVersion = 8
+	lastVersion  = 19
+)
+
+const header = "// Code generated by github.com/filecoin-project/go-state-types/test_util/synth/gen. DO NOT EDIT.\n\n"
+
+//go:embed builder.go.tmpl
+var builderTemplate string
+
+//go:embed miner.go.tmpl
+var minerTemplate string
+
+// The generated files, by name pattern, and their templates.
+var files = map[string]*template.Template{
+	"v%d.go":       template.Must(template.New("builder").Parse(builderTemplate)),
+	"v%d_miner.go": template.Must(template.New("miner").Parse(minerTemplate)),
+}
+
+func main() {
+	for v := firstVersion; v <= lastVersion; v++ {
+		for name, tmpl := range files { // nolint:nomaprange
+			out, err := generate(tmpl, v)
+			if err != nil {
+				panic(fmt.Errorf("%s: %w", fmt.Sprintf(name, v), err))
+			}
+			if err := os.WriteFile(filepath.Join(outputDir, fmt.Sprintf(name, v)), out, 0644); err != nil {
+				panic(err)
+			}
+		}
+	}
+}
+
+// Renders a template for an actors version, whose number is available to the template as .V.
+func generate(tmpl *template.Template, version int) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(header)
+	if err := tmpl.Execute(&buf, struct{ V int }{version}); err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// The checked-in builders must match the templates; run `make gen` after editing either.
+func TestGeneratedUpToDate(t *testing.T) {
+	for v := firstVersion; v <= lastVersion; v++ {
+		for name, tmpl := range files { // nolint:nomaprange
+			file := fmt.Sprintf(name, v)
+			t.Run(file, func(t *testing.T) {
+				expected, err := generate(tmpl, v)
+				require.NoError(t, err)
+				actual, err := os.ReadFile(filepath.Join("..", file))
+				require.NoError(t, err)
+				require.Equal(t, string(expected), string(actual))
+			})
+		}
+	}
+}
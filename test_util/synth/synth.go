@@ -0,0 +1,307 @@
+// Package synth generates synthetic, deterministic state trees for tests and benchmarks.
+//
+// The generated states are internally consistent: they pass the CheckStateInvariants function of
+// the targeted actors version, so they can stand in for a network snapshot when exercising
+// migrations, invariant checks or state indexers.
+//
+// The state of each actors version is built by the vN.go and vN_miner.go files, which are generated
+// from the templates in the gen directory by `make gen`.
+package synth
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+
+	"github.com/ipfs/go-cid"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	mh "github.com/multiformats/go-multihash"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/actors"
+	"github.com/filecoin-project/go-state-types/builtin/v8/util/adt"
+	"github.com/filecoin-project/go-state-types/manifest"
+)
+
+// Config parameterizes a generated state tree.
+// Every value is deterministic given the Seed.
+type Config struct {
+	// Actors version of the generated state, from 8 to 19.
+	ActorsVersion actors.Version
+	// Seed for the pseudo-random source; the same config always yields the same state root.
+	Seed int64
+	// Name recorded in the init actor.
+	NetworkName string
+	// The epoch at which the generated state is considered final, i.e. the priorEpoch to pass to
+	// CheckStateInvariants.
+	PriorEpoch abi.ChainEpoch
+	// Builtin actors manifest to use for actor code CIDs.
+	// cid.Undef (the default) generates a synthetic manifest with identity-hashed code CIDs.
+	Manifest cid.Cid
+
+	// Seal proof used for every miner's sectors.
+	SealProof abi.RegisteredSealProof
+	// Number of storage miners.
+	Miners int
+	// Number of proven sectors per miner, spread over all deadlines and their partitions.
+	SectorsPerMiner int
+	// Number of each miner's sectors holding a single (unverified) market deal.
+	DealsPerMiner int
+	// Number of each miner's sectors holding a single verified piece claimed in the verified registry.
+	// Before actors version 13 the piece is also a verified market deal, and before version 9 it is
+	// only a verified market deal.
+	ClaimsPerMiner int
+
+	// Number of plain storage clients with market escrow.
+	Clients int
+	// Number of verified clients holding DataCap.
+	VerifiedClients int
+	// Number of pending verified registry allocations made by each verified client.
+	// Allocations require actors version 9 or later.
+	AllocationsPerClient int
+	// Number of verifiers in the verified registry.
+	Verifiers int
+
+	// Number of multisig actors with a linear vesting schedule.
+	Multisigs int
+	// Number of EVM contract actors, which require actors version 10 or later.
+	EVMActors int
+	// Number of additional plain account actors.
+	Accounts int
+}
+
+// DefaultConfig returns a small configuration of the latest actors version, suitable for unit tests.
+func DefaultConfig() Config {
+	return Config{
+		ActorsVersion:        actors.Version19,
+		Seed:                 1,
+		NetworkName:          "synthnet",
+		PriorEpoch:           4_500_000,
+		SealProof:            abi.RegisteredSealProof_StackedDrg32GiBV1_1,
+		Miners:               4,
+		SectorsPerMiner:      200,
+		DealsPerMiner:        20,
+		ClaimsPerMiner:       20,
+		Clients:              4,
+		VerifiedClients:      2,
+		AllocationsPerClient: 3,
+		Verifiers:            2,
+		Multisigs:            3,
+		EVMActors:            3,
+		Accounts:             10,
+	}
+}
+
+// Result describes a generated state tree.
+type Result struct {
+	// Root of the generated actors tree.
+	StateRoot cid.Cid
+	// Manifest recorded in the system actor.
+	Manifest cid.Cid
+	// Actor code CIDs by manifest key, as expected by CheckStateInvariants.
+	ActorCodes map[string]cid.Cid
+}
+
+// Generate writes a synthetic state tree described by cfg into store.
+// The store must support writes; the resulting blocks are everything needed to load the state.
+// EVM bytecode is written as a raw block, as on chain, so generating EVM actors needs a store that,
+// like cbor.BasicIpldStore, honours the CID codec of the values put.
+func Generate(ctx context.Context, store cbor.IpldStore, cfg Config) (*Result, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, xerrors.Errorf("invalid config: %w", err)
+	}
+
+	manifestCid, m, err := loadOrMakeManifest(ctx, store, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	switch cfg.ActorsVersion {
+	case actors.Version8:
+		return generateV8(ctx, store, cfg, manifestCid, m)
+	case actors.Version9:
+		return generateV9(ctx, store, cfg, manifestCid, m)
+	case actors.Version10:
+		return generateV10(ctx, store, cfg, manifestCid, m)
+	case actors.Version11:
+		return generateV11(ctx, store, cfg, manifestCid, m)
+	case actors.Version12:
+		return generateV12(ctx, store, cfg, manifestCid, m)
+	case actors.Version13:
+		return generateV13(ctx, store, cfg, manifestCid, m)
+	case actors.Version14:
+		return generateV14(ctx, store, cfg, manifestCid, m)
+	case actors.Version15:
+		return generateV15(ctx, store, cfg, manifestCid, m)
+	case actors.Version16:
+		return generateV16(ctx, store, cfg, manifestCid, m)
+	case actors.Version17:
+		return generateV17(ctx, store, cfg, manifestCid, m)
+	case actors.Version18:
+		return generateV18(ctx, store, cfg, manifestCid, m)
+	case actors.Version19:
+		return generateV19(ctx, store, cfg, manifestCid, m)
+	default:
+		return nil, xerrors.Errorf("unsupported actors version %d", cfg.ActorsVersion)
+	}
+}
+
+func (cfg *Config) validate() error {
+	if cfg.PriorEpoch <= 0 {
+		return xerrors.Errorf("prior epoch %d must be positive", cfg.PriorEpoch)
+	}
+	if cfg.Miners < 0 || cfg.SectorsPerMiner < 0 || cfg.DealsPerMiner < 0 || cfg.ClaimsPerMiner < 0 ||
+		cfg.Clients < 0 || cfg.VerifiedClients < 0 || cfg.AllocationsPerClient < 0 || cfg.Verifiers < 0 ||
+		cfg.Multisigs < 0 || cfg.EVMActors < 0 || cfg.Accounts < 0 {
+		return xerrors.Errorf("actor and item counts must not be negative")
+	}
+	if cfg.DealsPerMiner+cfg.ClaimsPerMiner > cfg.SectorsPerMiner {
+		return xerrors.Errorf("%d deals and %d claims do not fit in %d sectors", cfg.DealsPerMiner, cfg.ClaimsPerMiner, cfg.SectorsPerMiner)
+	}
+	if cfg.DealsPerMiner > 0 && cfg.Clients == 0 {
+		return xerrors.Errorf("deals require at least one client")
+	}
+	if cfg.ClaimsPerMiner > 0 && cfg.VerifiedClients == 0 {
+		return xerrors.Errorf("claims require at least one verified client")
+	}
+	if cfg.EVMActors > 0 && cfg.ActorsVersion < actors.Version10 {
+		return xerrors.Errorf("EVM actors require actors version 10 or later")
+	}
+	if cfg.AllocationsPerClient > 0 && cfg.ActorsVersion < actors.Version9 {
+		return xerrors.Errorf("verified registry allocations require actors version 9 or later")
+	}
+	if cfg.AllocationsPerClient > 0 && cfg.Miners == 0 {
+		return xerrors.Errorf("allocations require at least one miner")
+	}
+	return nil
+}
+
+func loadOrMakeManifest(ctx context.Context, store cbor.IpldStore, cfg Config) (cid.Cid, *manifest.Manifest, error) {
+	adtStore := adt.WrapStore(ctx, store)
+
+	manifestCid := cfg.Manifest
+	if !manifestCid.Defined() {
+		var err error
+		if manifestCid, err = MakeManifest(ctx, store, cfg.ActorsVersion); err != nil {
+			return cid.Undef, nil, err
+		}
+	}
+
+	var m manifest.Manifest
+	if err := adtStore.Get(ctx, manifestCid, &m); err != nil {
+		return cid.Undef, nil, xerrors.Errorf("error reading actor manifest: %w", err)
+	}
+	if err := m.Load(ctx, adtStore); err != nil {
+		return cid.Undef, nil, xerrors.Errorf("error loading actor manifest: %w", err)
+	}
+	return manifestCid, &m, nil
+}
+
+// MakeManifest stores a synthetic manifest for the given actors version, with identity-hashed code
+// CIDs of the form "fil/<version>/<actor key>".
+func MakeManifest(ctx context.Context, store cbor.IpldStore, av actors.Version) (cid.Cid, error) {
+	builder := cid.V1Builder{Codec: cid.Raw, MhType: mh.IDENTITY}
+
+	var data manifest.ManifestData
+	for _, name := range manifest.GetBuiltinActorsKeys(av) {
+		codeCid, err := builder.Sum([]byte(fmt.Sprintf("fil/%d/%s", av, name)))
+		if err != nil {
+			return cid.Undef, xerrors.Errorf("failed to make code cid for %s: %w", name, err)
+		}
+		data.Entries = append(data.Entries, manifest.ManifestEntry{Name: name, Code: codeCid})
+	}
+
+	dataCid, err := store.Put(ctx, &data)
+	if err != nil {
+		return cid.Undef, xerrors.Errorf("failed to store manifest data: %w", err)
+	}
+	manifestCid, err := store.Put(ctx, &manifest.Manifest{Version: 1, Data: dataCid})
+	if err != nil {
+		return cid.Undef, xerrors.Errorf("failed to store manifest: %w", err)
+	}
+	return manifestCid, nil
+}
+
+func actorCodes(m *manifest.Manifest, av actors.Version) (map[string]cid.Cid, error) {
+	codes := make(map[string]cid.Cid)
+	for _, name := range manifest.GetBuiltinActorsKeys(av) {
+		c, ok := m.Get(name)
+		if !ok {
+			return nil, xerrors.Errorf("code cid for %s actor not found in manifest", name)
+		}
+		codes[name] = c
+	}
+	return codes, nil
+}
+
+// putRaw stores data as a raw block, as the EVM actor stores bytecode.
+func putRaw(ctx context.Context, store cbor.IpldStore, data []byte) (cid.Cid, error) {
+	c, err := cid.V1Builder{Codec: cid.Raw, MhType: mh.BLAKE2B_MIN + 31}.Sum(data)
+	if err != nil {
+		return cid.Undef, err
+	}
+	put, err := store.Put(ctx, &rawBlock{data: data, cid: c})
+	if err != nil {
+		return cid.Undef, err
+	}
+	if put != c {
+		return cid.Undef, xerrors.Errorf("store %T wrote raw block %v as %v", store, c, put)
+	}
+	return c, nil
+}
+
+// A block written verbatim by an IpldStore.
+// Stores such as cbor.BasicIpldStore use the codec of the CID a value provides, and write the bytes
+// output by its MarshalCBOR method as they are.
+type rawBlock struct {
+	data []byte
+	cid  cid.Cid
+}
+
+func (b *rawBlock) Cid() cid.Cid {
+	return b.cid
+}
+
+func (b *rawBlock) MarshalCBOR(w io.Writer) error {
+	_, err := w.Write(b.data)
+	return err
+}
+
+// randBytes returns n pseudo-random bytes.
+func randBytes(rnd *rand.Rand, n int) []byte {
+	b := make([]byte, n)
+	_, _ = rnd.Read(b)
+	return b
+}
+
+// randEpoch returns a pseudo-random epoch in [0, n), or zero if n is not positive.
+func randEpoch(rnd *rand.Rand, n abi.ChainEpoch) abi.ChainEpoch {
+	if n <= 0 {
+		return 0
+	}
+	return abi.ChainEpoch(rnd.Int63n(int64(n)))
+}
+
+// pieceCID returns a pseudo-random unsealed piece commitment.
+func pieceCID(rnd *rand.Rand) (cid.Cid, error) {
+	digest := randBytes(rnd, 32)
+	digest[31] &= 0x3f // Fr32 padding
+	h, err := mh.Encode(digest, mh.SHA2_256_TRUNC254_PADDED)
+	if err != nil {
+		return cid.Undef, err
+	}
+	return cid.NewCidV1(cid.FilCommitmentUnsealed, h), nil
+}
+
+// sealedCID returns a pseudo-random sealed sector commitment.
+func sealedCID(rnd *rand.Rand) (cid.Cid, error) {
+	digest := randBytes(rnd, 32)
+	digest[31] &= 0x3f
+	h, err := mh.Encode(digest, mh.POSEIDON_BLS12_381_A1_FC1)
+	if err != nil {
+		return cid.Undef, err
+	}
+	return cid.NewCidV1(cid.FilCommitmentSealed, h), nil
+}
@@ -0,0 +1,133 @@
+package synth
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/actors"
+	"github.com/filecoin-project/go-state-types/builtin"
+	v10 "github.com/filecoin-project/go-state-types/builtin/v10"
+	v11 "github.com/filecoin-project/go-state-types/builtin/v11"
+	v12 "github.com/filecoin-project/go-state-types/builtin/v12"
+	v13 "github.com/filecoin-project/go-state-types/builtin/v13"
+	v14 "github.com/filecoin-project/go-state-types/builtin/v14"
+	v15 "github.com/filecoin-project/go-state-types/builtin/v15"
+	v16 "github.com/filecoin-project/go-state-types/builtin/v16"
+	v17 "github.com/filecoin-project/go-state-types/builtin/v17"
+	v18 "github.com/filecoin-project/go-state-types/builtin/v18"
+	v19 "github.com/filecoin-project/go-state-types/builtin/v19"
+	"github.com/filecoin-project/go-state-types/builtin/v19/util/adt"
+	v8 "github.com/filecoin-project/go-state-types/builtin/v8"
+	v9 "github.com/filecoin-project/go-state-types/builtin/v9"
+	"github.com/filecoin-project/go-state-types/test_util"
+)
+
+func TestGenerateV19PassesInvariants(t *testing.T) {
+	ctx := context.Background()
+
+	small := DefaultConfig()
+	small.SealProof = abi.RegisteredSealProof_StackedDrg8MiBV1_1 // two sectors per partition
+	small.SectorsPerMiner = 150
+	noAllocations := DefaultConfig()
+	noAllocations.AllocationsPerClient = 0
+
+	for name, cfg := range map[string]Config{"default": DefaultConfig(), "small sectors": small, "no allocations": noAllocations} {
+		t.Run(name, func(t *testing.T) {
+			store := cbor.NewCborStore(test_util.NewSyncBlockStoreInMemory())
+			res, err := Generate(ctx, store, cfg)
+			require.NoError(t, err)
+
+			tree, err := builtin.LoadTree(adt.WrapStore(ctx, store), res.StateRoot)
+			require.NoError(t, err)
+			acc, err := v19.CheckStateInvariants(tree, cfg.PriorEpoch, res.ActorCodes)
+			require.NoError(t, err)
+			require.True(t, acc.IsEmpty(), acc.Messages())
+		})
+	}
+}
+
+func TestGenerateOlderVersionsPassInvariants(t *testing.T) {
+	ctx := context.Background()
+
+	checks := map[actors.Version]func(*builtin.ActorTree, abi.ChainEpoch, map[string]cid.Cid) (*builtin.MessageAccumulator, error){
+		actors.Version8:  v8.CheckStateInvariants,
+		actors.Version9:  v9.CheckStateInvariants,
+		actors.Version10: v10.CheckStateInvariants,
+		actors.Version11: v11.CheckStateInvariants,
+		actors.Version12: v12.CheckStateInvariants,
+		actors.Version13: v13.CheckStateInvariants,
+		actors.Version14: v14.CheckStateInvariants,
+		actors.Version15: v15.CheckStateInvariants,
+		actors.Version16: v16.CheckStateInvariants,
+		actors.Version17: v17.CheckStateInvariants,
+		actors.Version18: v18.CheckStateInvariants,
+	}
+	for av, check := range checks {
+		t.Run(fmt.Sprintf("v%d", av), func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.ActorsVersion = av
+			cfg.SectorsPerMiner = 100
+			if av < actors.Version10 {
+				cfg.EVMActors = 0
+			}
+			if av < actors.Version9 {
+				cfg.AllocationsPerClient = 0
+			}
+			store := cbor.NewCborStore(test_util.NewSyncBlockStoreInMemory())
+			res, err := Generate(ctx, store, cfg)
+			require.NoError(t, err)
+
+			tree, err := builtin.LoadTree(adt.WrapStore(ctx, store), res.StateRoot)
+			require.NoError(t, err)
+			acc, err := check(tree, cfg.PriorEpoch, res.ActorCodes)
+			require.NoError(t, err)
+			require.True(t, acc.IsEmpty(), acc.Messages())
+		})
+	}
+}
+
+func TestGenerateIsDeterministic(t *testing.T) {
+	ctx := context.Background()
+	cfg := DefaultConfig()
+	cfg.SectorsPerMiner = 50
+
+	gen := func(cfg Config) *Result {
+		res, err := Generate(ctx, cbor.NewCborStore(test_util.NewSyncBlockStoreInMemory()), cfg)
+		require.NoError(t, err)
+		return res
+	}
+
+	first := gen(cfg)
+	require.Equal(t, first.StateRoot, gen(cfg).StateRoot)
+
+	cfg.Seed++
+	require.NotEqual(t, first.StateRoot, gen(cfg).StateRoot)
+}
+
+func TestGenerateRejectsInvalidConfig(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.DealsPerMiner = cfg.SectorsPerMiner + 1
+	_, err := Generate(context.Background(), cbor.NewCborStore(test_util.NewSyncBlockStoreInMemory()), cfg)
+	require.Error(t, err)
+
+	cfg = DefaultConfig()
+	cfg.ActorsVersion = actors.Version7
+	_, err = Generate(context.Background(), cbor.NewCborStore(test_util.NewSyncBlockStoreInMemory()), cfg)
+	require.Error(t, err)
+
+	// Actors that do not exist in the requested version.
+	cfg = DefaultConfig()
+	cfg.ActorsVersion = actors.Version9
+	_, err = Generate(context.Background(), cbor.NewCborStore(test_util.NewSyncBlockStoreInMemory()), cfg)
+	require.ErrorContains(t, err, "EVM actors")
+	cfg.EVMActors = 0
+	cfg.ActorsVersion = actors.Version8
+	_, err = Generate(context.Background(), cbor.NewCborStore(test_util.NewSyncBlockStoreInMemory()), cfg)
+	require.ErrorContains(t, err, "allocations")
+}